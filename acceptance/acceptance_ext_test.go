@@ -1,6 +1,7 @@
 package acceptance
 
 import (
+	"context"
 	"net/http"
 	"os"
 	"testing"
@@ -45,7 +46,7 @@ func TestIntegrations(t *testing.T) {
 		},
 	}
 
-	createMsg, err := client_ext.Integrations.Create(&integration)
+	createMsg, err := client_ext.Integrations.Create(context.Background(), &integration)
 	assert.NoError(t, err)
 	assert.NotNil(t, createMsg)
 	assert.NotEmpty(t, createMsg)
@@ -53,12 +54,12 @@ func TestIntegrations(t *testing.T) {
 
 	integrationID := createMsg.ID
 
-	listMsg, err := client_ext.Integrations.List()
+	listMsg, err := client_ext.Integrations.List(context.Background())
 	assert.NoError(t, err)
 	assert.NotNil(t, listMsg)
 	assert.NotEmpty(t, listMsg)
 
-	getMsg, err := client_ext.Integrations.Read(integrationID)
+	getMsg, err := client_ext.Integrations.Read(context.Background(), integrationID)
 	assert.NoError(t, err)
 	assert.NotNil(t, getMsg)
 	assert.NotEmpty(t, getMsg)
@@ -69,17 +70,17 @@ func TestIntegrations(t *testing.T) {
 	integration.UserData.Name = "wlwu-tets-update"
 	integration.UserData.URL = "http://www.example1.com"
 
-	updateMsg, err := client_ext.Integrations.Update(integrationID, &integration)
+	updateMsg, err := client_ext.Integrations.Update(context.Background(), integrationID, &integration)
 	assert.NoError(t, err)
 	assert.NotNil(t, updateMsg)
 	assert.True(t, updateMsg.Status)
 
-	delMsg, err := client_ext.Integrations.Delete(integrationID)
+	delMsg, err := client_ext.Integrations.Delete(context.Background(), integrationID)
 	assert.NoError(t, err)
 	assert.NotNil(t, delMsg)
 	assert.True(t, delMsg.Status)
 
-	listProviderMsg, err := client_ext.Integrations.ListProviders()
+	listProviderMsg, err := client_ext.Integrations.ListProviders(context.Background())
 	assert.NoError(t, err)
 	assert.NotNil(t, listProviderMsg)
 	assert.Equal(t, len(listProviderMsg), 2)
@@ -1,6 +1,7 @@
 package acceptance
 
 import (
+	"context"
 	"github.com/nordcloud/go-pingdom/solarwinds"
 	"net/http"
 	"os"
@@ -32,7 +33,7 @@ func TestListChecks(t *testing.T) {
 	if !runAcceptance {
 		t.Skip()
 	}
-	checks, err := client.Checks.List()
+	checks, err := client.Checks.List(context.Background())
 	assert.NoError(t, err)
 	assert.NotNil(t, checks)
 }
@@ -48,20 +49,20 @@ func TestHTTPCheck(t *testing.T) {
 		SendNotificationWhenDown: 100,
 		Tags:                     "tag",
 	}
-	check, err := client.Checks.Create(&newCheck)
+	check, err := client.Checks.Create(context.Background(), &newCheck)
 	assert.NoError(t, err)
 	assert.NotNil(t, check)
 
 	newCheck.Name = "Test Check 2"
-	up, err := client.Checks.Update(check.ID, &newCheck)
+	up, err := client.Checks.Update(context.Background(), check.ID, &newCheck)
 	assert.NoError(t, err)
 	assert.NotNil(t, up)
 
-	resp, err := client.Checks.Read(check.ID)
+	resp, err := client.Checks.Read(context.Background(), check.ID)
 	assert.NoError(t, err)
 	assert.Equal(t, newCheck.Name, resp.Name)
 
-	delMsg, err := client.Checks.Delete(check.ID)
+	delMsg, err := client.Checks.Delete(context.Background(), check.ID)
 	assert.NoError(t, err)
 	assert.NotNil(t, delMsg)
 }
@@ -78,22 +79,22 @@ func TestDNSCheck(t *testing.T) {
 		SendNotificationWhenDown: 100,
 		Tags:                     "dns",
 	}
-	check, err := client.Checks.Create(&newCheck)
+	check, err := client.Checks.Create(context.Background(), &newCheck)
 	assert.NoError(t, err)
 	assert.NotNil(t, check)
 	assert.Equal(t, check.Name, "Test Check")
 
 	newCheck.Name = "Test Check 2"
-	up, err := client.Checks.Update(check.ID, &newCheck)
+	up, err := client.Checks.Update(context.Background(), check.ID, &newCheck)
 	assert.NoError(t, err)
 	assert.NotNil(t, up)
 
-	resp, err := client.Checks.Read(check.ID)
+	resp, err := client.Checks.Read(context.Background(), check.ID)
 	assert.NoError(t, err)
 	assert.Equal(t, newCheck.Name, resp.Name)
 	assert.Equal(t, resp.Resolution, 5)
 
-	delMsg, err := client.Checks.Delete(check.ID)
+	delMsg, err := client.Checks.Delete(context.Background(), check.ID)
 	assert.NoError(t, err)
 	assert.NotNil(t, delMsg)
 }
@@ -109,7 +110,7 @@ func TestTagSupport(t *testing.T) {
 		SendNotificationWhenDown: 100,
 		Tags:                     "tag",
 	}
-	check, err := client.Checks.Create(&newCheck)
+	check, err := client.Checks.Create(context.Background(), &newCheck)
 	assert.NoError(t, err)
 	assert.NotNil(t, check)
 
@@ -117,12 +118,12 @@ func TestTagSupport(t *testing.T) {
 	params["include_tags"] = "true"
 	params["tags"] = "tag"
 
-	checks, err := client.Checks.List(params)
+	checks, err := client.Checks.List(context.Background(), params)
 	assert.NoError(t, err)
 	assert.NotNil(t, checks)
 	assert.Equal(t, 1, len(checks))
 
-	delMsg, err := client.Checks.Delete(check.ID)
+	delMsg, err := client.Checks.Delete(context.Background(), check.ID)
 	assert.NoError(t, err)
 	assert.NotNil(t, delMsg)
 }
@@ -133,7 +134,7 @@ func TestProbes(t *testing.T) {
 	}
 	params := make(map[string]string)
 
-	probes, err := client.Probes.List(params)
+	probes, err := client.Probes.List(context.Background(), params)
 	assert.NoError(t, err)
 	assert.NotNil(t, probes)
 	assert.NotEmpty(t, probes)
@@ -165,24 +166,24 @@ func TestContacts(t *testing.T) {
 		},
 	}
 
-	createMsg, err := client.Contacts.Create(&contact)
+	createMsg, err := client.Contacts.Create(context.Background(), &contact)
 	assert.NoError(t, err)
 	assert.NotNil(t, createMsg)
 	assert.NotEmpty(t, createMsg)
 
 	contact.ID = createMsg.ID
 
-	listMsg, err := client.Contacts.List()
+	listMsg, err := client.Contacts.List(context.Background())
 	assert.NoError(t, err)
 	assert.NotNil(t, listMsg)
 	assert.NotEmpty(t, listMsg)
 
 	contact.NotificationTargets.SMS[0].Number = "2222222222"
-	updateMsg, err := client.Contacts.Update(contact.ID, &contact)
+	updateMsg, err := client.Contacts.Update(context.Background(), contact.ID, &contact)
 	assert.NoError(t, err)
 	assert.NotNil(t, updateMsg)
 
-	delMsg, err := client.Contacts.Delete(contact.ID)
+	delMsg, err := client.Contacts.Delete(context.Background(), contact.ID)
 	assert.NoError(t, err)
 	assert.NotNil(t, delMsg)
 }
@@ -197,25 +198,25 @@ func TestTeams(t *testing.T) {
 		MemberIDs: []int{},
 	}
 
-	createMsg, err := client.Teams.Create(&team)
+	createMsg, err := client.Teams.Create(context.Background(), &team)
 	assert.NoError(t, err)
 	assert.NotNil(t, createMsg)
 	assert.NotEmpty(t, createMsg)
 
 	team.ID = createMsg.ID
 
-	listMsg, err := client.Teams.List()
+	listMsg, err := client.Teams.List(context.Background())
 	assert.NoError(t, err)
 	assert.NotNil(t, listMsg)
 	assert.NotEmpty(t, listMsg)
 
 	team.Name = "Test team renamed"
-	updateMsg, err := client.Teams.Update(team.ID, &team)
+	updateMsg, err := client.Teams.Update(context.Background(), team.ID, &team)
 	assert.NoError(t, err)
 	assert.NotNil(t, updateMsg)
 	assert.NotEmpty(t, updateMsg)
 
-	delMsg, err := client.Teams.Delete(team.ID)
+	delMsg, err := client.Teams.Delete(context.Background(), team.ID)
 	assert.NoError(t, err)
 	assert.NotNil(t, delMsg)
 }
@@ -251,14 +252,14 @@ func TestTeamAndContactConnections(t *testing.T) {
 		MemberIDs: []int{},
 	}
 
-	createTeamMsg, err := client.Teams.Create(&team)
+	createTeamMsg, err := client.Teams.Create(context.Background(), &team)
 	assert.NoError(t, err)
 	assert.NotNil(t, createTeamMsg)
 	assert.NotEmpty(t, createTeamMsg)
 
 	team.ID = createTeamMsg.ID
 
-	createContactMsg, err := client.Contacts.Create(&contact)
+	createContactMsg, err := client.Contacts.Create(context.Background(), &contact)
 	assert.NoError(t, err)
 	assert.NotNil(t, createContactMsg)
 	assert.NotEmpty(t, createContactMsg)
@@ -268,7 +269,7 @@ func TestTeamAndContactConnections(t *testing.T) {
 	team.MemberIDs = append(team.MemberIDs, contact.ID)
 
 	// Verify we can add contacts
-	updateMsg, err := client.Teams.Update(team.ID, &team)
+	updateMsg, err := client.Teams.Update(context.Background(), team.ID, &team)
 	assert.NoError(t, err)
 	assert.NotNil(t, updateMsg)
 	assert.NotEmpty(t, updateMsg)
@@ -276,13 +277,13 @@ func TestTeamAndContactConnections(t *testing.T) {
 
 	team.MemberIDs = []int{}
 	// Verify we can remove contacts
-	updateMsg, err = client.Teams.Update(team.ID, &team)
+	updateMsg, err = client.Teams.Update(context.Background(), team.ID, &team)
 	assert.NoError(t, err)
 	assert.NotNil(t, updateMsg)
 	assert.NotEmpty(t, updateMsg)
 	assert.Empty(t, updateMsg.Members)
 
-	delMsg, err := client.Teams.Delete(team.ID)
+	delMsg, err := client.Teams.Delete(context.Background(), team.ID)
 	assert.NoError(t, err)
 	assert.NotNil(t, delMsg)
 }
@@ -305,12 +306,12 @@ func TestOccurrences(t *testing.T) {
 		RepeatEvery:    1,
 		EffectiveTo:    to.Add(3 * 24 * time.Hour).Unix(),
 	}
-	createMaintenanceMsg, err := client.Maintenances.Create(&maintenance)
+	createMaintenanceMsg, err := client.Maintenances.Create(context.Background(), &maintenance)
 	assert.NoError(t, err)
 	assert.NotNil(t, createMaintenanceMsg)
 	assert.NotEmpty(t, createMaintenanceMsg)
 
-	occurrences, err := client.Occurrences.List(pingdom.ListOccurrenceQuery{
+	occurrences, err := client.Occurrences.List(context.Background(), pingdom.ListOccurrenceQuery{
 		MaintenanceId: int64(createMaintenanceMsg.ID),
 	})
 	assert.NoError(t, err)
@@ -318,22 +319,22 @@ func TestOccurrences(t *testing.T) {
 	occurrence := occurrences[0]
 
 	newTo := time.Unix(occurrence.To, 0).Add(1 * time.Hour).Unix()
-	resp, err := client.Occurrences.Update(occurrence.Id, pingdom.Occurrence{
+	resp, err := client.Occurrences.Update(context.Background(), occurrence.Id, pingdom.Occurrence{
 		From: occurrence.From,
 		To:   newTo,
 	})
 	assert.NoError(t, err)
 	assert.NotEmpty(t, resp)
 
-	afterUpdate, err := client.Occurrences.Read(occurrence.Id)
+	afterUpdate, err := client.Occurrences.Read(context.Background(), occurrence.Id)
 	assert.NoError(t, err)
 	assert.Equal(t, newTo, afterUpdate.To)
 
-	resp, err = client.Occurrences.Delete(occurrence.Id)
+	resp, err = client.Occurrences.Delete(context.Background(), occurrence.Id)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, resp)
 
-	resp, err = client.Maintenances.Delete(createMaintenanceMsg.ID)
+	resp, err = client.Maintenances.Delete(context.Background(), createMaintenanceMsg.ID)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, resp)
 
@@ -341,11 +342,11 @@ func TestOccurrences(t *testing.T) {
 	for _, occ := range occurrences[1:] {
 		idsToDelete = append(idsToDelete, occ.Id)
 	}
-	resp, err = client.Occurrences.MultiDelete(idsToDelete)
+	resp, err = client.Occurrences.MultiDelete(context.Background(), idsToDelete)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, resp)
 
-	occurrences, err = client.Occurrences.List(pingdom.ListOccurrenceQuery{
+	occurrences, err = client.Occurrences.List(context.Background(), pingdom.ListOccurrenceQuery{
 		MaintenanceId: int64(createMaintenanceMsg.ID),
 	})
 	assert.NoError(t, err)
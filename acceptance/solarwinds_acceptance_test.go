@@ -1,6 +1,7 @@
 package acceptance
 
 import (
+	"context"
 	"github.com/nordcloud/go-pingdom/solarwinds"
 	"github.com/stretchr/testify/assert"
 	"os"
@@ -33,7 +34,7 @@ func createSolarwindsClient() (*solarwinds.Client, error) {
 	if err != nil {
 		return nil, err
 	}
-	err = client.Init()
+	err = client.Init(context.Background())
 	if err != nil {
 		return nil, err
 	}
@@ -47,13 +48,13 @@ func TestInitWithOrganizationId(t *testing.T) {
 	os.Setenv(solarwinds.EnvSolarwindsOrganizationId, "31479999098992640")
 	client1, err := createSolarwindsClient()
 	assert.NoError(t, err)
-	userList1, err := client1.UserService.ActiveUserService.List()
+	userList1, err := client1.UserService.ActiveUserService.List(context.Background())
 	assert.NoError(t, err)
 
 	os.Setenv(solarwinds.EnvSolarwindsOrganizationId, "106269109693582336")
 	client2, err := createSolarwindsClient()
 	assert.NoError(t, err)
-	userList2, err := client2.UserService.ActiveUserService.List()
+	userList2, err := client2.UserService.ActiveUserService.List(context.Background())
 	assert.NoError(t, err)
 
 	assert.NotEqual(t, userList1.Organization.Id, userList2.Organization.Id)
@@ -65,7 +66,7 @@ func TestInvitations(t *testing.T) {
 	}
 	email := solarwinds.RandString(10) + "@foo.com"
 	invitationService := solarwindsClient.InvitationService
-	err := invitationService.Create(solarwinds.Invitation{
+	err := invitationService.Create(context.Background(), solarwinds.Invitation{
 		Email: email,
 		Role:  "MEMBER",
 		Products: []solarwinds.Product{
@@ -77,17 +78,17 @@ func TestInvitations(t *testing.T) {
 	})
 	assert.NoError(t, err)
 
-	invitationList, err := invitationService.List()
+	invitationList, err := invitationService.List(context.Background())
 	assert.NoError(t, err)
 	assert.True(t, len(invitationList.Organization.Invitations) > 0)
 
-	err = invitationService.Resend(email)
+	err = invitationService.Resend(context.Background(), email)
 	assert.NoError(t, err)
 
-	err = invitationService.Revoke(email)
+	err = invitationService.Revoke(context.Background(), email)
 	assert.NoError(t, err)
 
-	err = invitationService.Resend(email)
+	err = invitationService.Resend(context.Background(), email)
 	assert.Error(t, err)
 }
 
@@ -99,7 +100,7 @@ func TestActiveUsers(t *testing.T) {
 	userService := solarwindsClient.ActiveUserService
 	currentUserEmail := os.Getenv("SOLARWINDS_USER")
 
-	userList, err := userService.List()
+	userList, err := userService.List(context.Background())
 	assert.NoError(t, err)
 	var currentMember *solarwinds.OrganizationMember
 	for _, member := range userList.Organization.Members {
@@ -112,7 +113,7 @@ func TestActiveUsers(t *testing.T) {
 	if currentMember == nil {
 		t.Errorf("current member is nil")
 	} else {
-		singleUser, err := userService.Get(currentMember.User.Id)
+		singleUser, err := userService.Get(context.Background(), currentMember.User.Id)
 		assert.NoError(t, err)
 		assert.Equal(t, currentMember.User.Email, singleUser.Organization.Members[0].User.Email)
 
@@ -135,10 +136,10 @@ func TestActiveUsers(t *testing.T) {
 			},
 		}
 		assert.True(t, containsRole(currentMember, "LOGGLY", "NO_ACCESS"))
-		err = userService.Update(updateAddRole)
+		err = userService.Update(context.Background(), updateAddRole)
 		assert.NoError(t, err)
 
-		singleUser, err = userService.Get(currentMember.User.Id)
+		singleUser, err = userService.Get(context.Background(), currentMember.User.Id)
 		assert.NoError(t, err)
 		assert.True(t, containsRole(&singleUser.Organization.Members[0], "LOGGLY", "MEMBER"))
 
@@ -152,9 +153,9 @@ func TestActiveUsers(t *testing.T) {
 				},
 			},
 		}
-		err = userService.Update(updateRevokeRole)
+		err = userService.Update(context.Background(), updateRevokeRole)
 		assert.NoError(t, err)
-		singleUser, _ = userService.Get(currentMember.User.Id)
+		singleUser, _ = userService.Get(context.Background(), currentMember.User.Id)
 		assert.True(t, containsRole(&singleUser.Organization.Members[0], "LOGGLY", "NO_ACCESS"))
 	}
 }
@@ -175,10 +176,10 @@ func TestUsers(t *testing.T) {
 		},
 	}
 	userService := solarwindsClient.UserService
-	err := userService.Create(userToCreate)
+	err := userService.Create(context.Background(), userToCreate)
 	assert.NoError(t, err)
 
-	user, err := userService.Retrieve(email)
+	user, err := userService.Retrieve(context.Background(), email)
 	assert.NoError(t, err)
 	assert.Equal(t, userToCreate, *user)
 
@@ -189,17 +190,17 @@ func TestUsers(t *testing.T) {
 			Role: "ADMIN",
 		},
 	}
-	err = userService.Update(userUpdate)
+	err = userService.Update(context.Background(), userUpdate)
 	assert.NoError(t, err)
 
-	userAfterUpdate, err := userService.Retrieve(email)
+	userAfterUpdate, err := userService.Retrieve(context.Background(), email)
 	assert.NoError(t, err)
 	assert.Equal(t, userUpdate, *userAfterUpdate)
 
-	err = userService.Delete(email)
+	err = userService.Delete(context.Background(), email)
 	assert.NoError(t, err)
 
-	userAfterDelete, err := userService.Retrieve(email)
+	userAfterDelete, err := userService.Retrieve(context.Background(), email)
 	assert.NoError(t, err)
 	assert.Nil(t, userAfterDelete)
 }
@@ -0,0 +1,64 @@
+// Package apierror defines the interface shared by the errors the Pingdom and
+// SolarWinds APIs report, so that callers using several clients can handle
+// their failures uniformly:
+//
+//	if apiErr, ok := apierror.As(err); ok {
+//		log.Printf("%s API error %d: %s", apiErr.APIBackend(), apiErr.HTTPStatus(), apiErr.APIMessage())
+//	}
+//
+// It is implemented by *pingdom.PingdomError, which the pingdomext client
+// returns as well, and by *solarwinds.GraphQLError.  Errors which are not
+// reported by an API, such as network errors or timeouts, do not implement
+// it.
+package apierror
+
+import (
+	"errors"
+	"net/http"
+)
+
+// The backends reported by APIError.APIBackend.
+const (
+	BackendPingdom    = "pingdom"
+	BackendSolarWinds = "solarwinds"
+)
+
+// APIError is an error reported by an API.
+type APIError interface {
+	error
+
+	// APIBackend names the API which reported the error, e.g.
+	// BackendPingdom.
+	APIBackend() string
+
+	// HTTPStatus is the HTTP status code of the response, 0 when the API
+	// reported the error in a successful response, as GraphQL does.
+	HTTPStatus() int
+
+	// APIMessage is the message of the API, without the status.
+	APIMessage() string
+}
+
+// As returns the first APIError in the chain of err.
+func As(err error) (APIError, bool) {
+	var apiErr APIError
+	if errors.As(err, &apiErr) {
+		return apiErr, true
+	}
+	return nil, false
+}
+
+// Status returns the HTTP status code of the first APIError in the chain of
+// err, 0 when there is none or the API reported no status.
+func Status(err error) int {
+	if apiErr, ok := As(err); ok {
+		return apiErr.HTTPStatus()
+	}
+	return 0
+}
+
+// IsNotFound reports whether err is an APIError with the status 404 Not
+// Found.
+func IsNotFound(err error) bool {
+	return Status(err) == http.StatusNotFound
+}
@@ -0,0 +1,37 @@
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeError struct {
+	status int
+}
+
+func (e *fakeError) Error() string      { return fmt.Sprintf("%d failed", e.status) }
+func (e *fakeError) APIBackend() string { return "fake" }
+func (e *fakeError) HTTPStatus() int    { return e.status }
+func (e *fakeError) APIMessage() string { return "failed" }
+
+func TestAs(t *testing.T) {
+	err := fmt.Errorf("reading check: %w", &fakeError{status: 404})
+	apiErr, ok := As(err)
+	if assert.True(t, ok) {
+		assert.Equal(t, "fake", apiErr.APIBackend())
+		assert.Equal(t, "failed", apiErr.APIMessage())
+	}
+	assert.Equal(t, 404, Status(err))
+	assert.True(t, IsNotFound(err))
+
+	err = errors.New("connection reset")
+	_, ok = As(err)
+	assert.False(t, ok)
+	assert.Equal(t, 0, Status(err))
+	assert.False(t, IsNotFound(err))
+	assert.False(t, IsNotFound(&fakeError{status: 500}))
+	assert.False(t, IsNotFound(nil))
+}
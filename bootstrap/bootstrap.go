@@ -0,0 +1,275 @@
+// Package bootstrap sets up a standard monitoring baseline on a Pingdom
+// account in one call: alerting contacts, an on-call team made of them, an
+// HTTP check for each given URL alerting that team, and a weekly maintenance
+// window covering those checks.
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+)
+
+const (
+	defaultTeamName   = "On-call"
+	defaultResolution = 5
+	defaultTag        = "bootstrap"
+	defaultSeverity   = "HIGH"
+
+	defaultMaintenanceWeeks = 52
+)
+
+// ContactStore creates Pingdom contacts.  It is implemented by
+// *pingdom.ContactService.
+type ContactStore interface {
+	Create(ctx context.Context, contact pingdom.ContactAPI) (*pingdom.Contact, error)
+}
+
+// TeamStore creates Pingdom alerting teams.  It is implemented by
+// *pingdom.TeamService.
+type TeamStore interface {
+	Create(ctx context.Context, team pingdom.TeamAPI) (*pingdom.TeamResponse, error)
+}
+
+// CheckStore creates Pingdom checks.  It is implemented by
+// *pingdom.CheckService.
+type CheckStore interface {
+	Create(ctx context.Context, check pingdom.Check) (*pingdom.CheckResponse, error)
+}
+
+// MaintenanceStore creates Pingdom maintenance windows.  It is implemented by
+// *pingdom.MaintenanceService.
+type MaintenanceStore interface {
+	Create(ctx context.Context, maintenance pingdom.Maintenance) (*pingdom.MaintenanceResponse, error)
+}
+
+// Contact is an alerting contact to create, notified by email and, when
+// Phone is set (e.g. "+46 701234567"), by text message.
+type Contact struct {
+	Name  string
+	Email string
+	Phone string
+}
+
+// Window is a weekly maintenance window, starting on Weekday at Start after
+// midnight UTC and lasting Duration.
+type Window struct {
+	Weekday  time.Weekday
+	Start    time.Duration
+	Duration time.Duration
+}
+
+// DefaultWindow is a two hours window on Sunday nights.
+var DefaultWindow = Window{Weekday: time.Sunday, Start: 2 * time.Hour, Duration: 2 * time.Hour}
+
+// Bootstrapper creates the baseline.
+type Bootstrapper struct {
+	Contacts     ContactStore
+	Teams        TeamStore
+	Checks       CheckStore
+	Maintenances MaintenanceStore
+
+	// Members of the on-call team.
+	People []Contact
+
+	// TeamName of the on-call team, defaults to "On-call".
+	TeamName string
+
+	// URLs to create HTTP checks for, e.g. "https://example.com/health".
+	URLs []string
+
+	// Resolution of the checks in minutes, defaults to 5.
+	Resolution int
+
+	// Tag added to every check, defaults to "bootstrap".
+	Tag string
+
+	// Maintenance is the weekly maintenance window of the checks, none is
+	// created when nil.
+	Maintenance *Window
+
+	// MaintenanceWeeks is the number of weeks the maintenance window recurs,
+	// defaults to 52.
+	MaintenanceWeeks int
+
+	// Now defaults to time.Now, the maintenance window starts at its first
+	// occurrence after Now.
+	Now func() time.Time
+}
+
+// New returns a Bootstrapper creating the baseline through the given client.
+func New(client *pingdom.Client) *Bootstrapper {
+	return &Bootstrapper{
+		Contacts:     client.Contacts,
+		Teams:        client.Teams,
+		Checks:       client.Checks,
+		Maintenances: client.Maintenances,
+	}
+}
+
+// Result lists what a bootstrap created.
+type Result struct {
+	ContactIDs    []int
+	TeamID        int
+	CheckIDs      []int
+	MaintenanceID int
+}
+
+// Run validates the baseline, then creates it.  It stops at the first error,
+// the returned result then lists what was created before it.
+func (b *Bootstrapper) Run(ctx context.Context) (*Result, error) {
+	checks := make([]*pingdom.HttpCheck, 0, len(b.URLs))
+	for _, u := range b.URLs {
+		check, err := b.NewCheck(u)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, check)
+	}
+	for _, person := range b.People {
+		if person.Name == "" || person.Email == "" {
+			return nil, fmt.Errorf("contact %q: name and email are required", person.Name+person.Email)
+		}
+	}
+
+	result := &Result{}
+	for _, person := range b.People {
+		contact, err := b.Contacts.Create(ctx, newContact(person))
+		if err != nil {
+			return result, fmt.Errorf("contact %s: %w", person.Email, err)
+		}
+		result.ContactIDs = append(result.ContactIDs, contact.ID)
+	}
+
+	if len(result.ContactIDs) > 0 {
+		team, err := b.Teams.Create(ctx, &pingdom.Team{Name: b.teamName(), MemberIDs: result.ContactIDs})
+		if err != nil {
+			return result, fmt.Errorf("team %s: %w", b.teamName(), err)
+		}
+		result.TeamID = team.ID
+	}
+
+	for _, check := range checks {
+		if result.TeamID != 0 {
+			check.TeamIds = []int{result.TeamID}
+		}
+		created, err := b.Checks.Create(ctx, check)
+		if err != nil {
+			return result, fmt.Errorf("check %s: %w", check.Name, err)
+		}
+		result.CheckIDs = append(result.CheckIDs, created.ID)
+	}
+
+	if b.Maintenance != nil && len(result.CheckIDs) > 0 {
+		window := b.NewMaintenance(*b.Maintenance, result.CheckIDs)
+		maintenance, err := b.Maintenances.Create(ctx, window)
+		if err != nil {
+			return result, fmt.Errorf("maintenance: %w", err)
+		}
+		result.MaintenanceID = maintenance.ID
+	}
+	return result, nil
+}
+
+// NewCheck returns the HTTP check of the given URL.
+func (b *Bootstrapper) NewCheck(rawURL string) (*pingdom.HttpCheck, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid URL %q: scheme must be http or https", rawURL)
+	}
+
+	check := &pingdom.HttpCheck{
+		Name:       u.Host + u.Path,
+		Hostname:   u.Hostname(),
+		Resolution: b.Resolution,
+		Url:        u.RequestURI(),
+		Encryption: u.Scheme == "https",
+		Tags:       b.Tag,
+	}
+	if check.Resolution == 0 {
+		check.Resolution = defaultResolution
+	}
+	if check.Tags == "" {
+		check.Tags = defaultTag
+	}
+	if port := u.Port(); port != "" {
+		if check.Port, err = strconv.Atoi(port); err != nil {
+			return nil, fmt.Errorf("invalid URL %q: %w", rawURL, err)
+		}
+	}
+	if err := check.Valid(); err != nil {
+		return nil, fmt.Errorf("invalid URL %q: %w", rawURL, err)
+	}
+	return check, nil
+}
+
+// NewMaintenance returns the weekly maintenance window of the given checks,
+// starting at the first occurrence of the window after Now and recurring for
+// MaintenanceWeeks.
+func (b *Bootstrapper) NewMaintenance(window Window, checkIDs []int) *pingdom.MaintenanceWindow {
+	now := time.Now
+	if b.Now != nil {
+		now = b.Now
+	}
+	t := now().UTC()
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	days := (int(window.Weekday) - int(t.Weekday()) + 7) % 7
+	from := midnight.AddDate(0, 0, days).Add(window.Start)
+	if !from.After(t) {
+		from = from.AddDate(0, 0, 7)
+	}
+
+	ids := make([]string, len(checkIDs))
+	for i, id := range checkIDs {
+		ids[i] = strconv.Itoa(id)
+	}
+	return &pingdom.MaintenanceWindow{
+		Description:    "Weekly maintenance",
+		From:           from.Unix(),
+		To:             from.Add(window.Duration).Unix(),
+		RecurrenceType: pingdom.RecurrenceWeek,
+		RepeatEvery:    1,
+		EffectiveTo:    from.AddDate(0, 0, 7*(b.maintenanceWeeks()-1)).Add(window.Duration).Unix(),
+		UptimeIDs:      strings.Join(ids, ","),
+	}
+}
+
+func (b *Bootstrapper) maintenanceWeeks() int {
+	if b.MaintenanceWeeks <= 0 {
+		return defaultMaintenanceWeeks
+	}
+	return b.MaintenanceWeeks
+}
+
+func (b *Bootstrapper) teamName() string {
+	if b.TeamName == "" {
+		return defaultTeamName
+	}
+	return b.TeamName
+}
+
+func newContact(person Contact) *pingdom.Contact {
+	contact := &pingdom.Contact{
+		Name: person.Name,
+		NotificationTargets: pingdom.NotificationTargets{
+			Email: []pingdom.EmailNotification{{Address: person.Email, Severity: defaultSeverity}},
+		},
+	}
+	if person.Phone != "" {
+		sms := pingdom.SMSNotification{Number: person.Phone, Provider: "nexmo", Severity: defaultSeverity}
+		if fields := strings.Fields(person.Phone); len(fields) == 2 {
+			sms.CountryCode = strings.TrimPrefix(fields[0], "+")
+			sms.Number = fields[1]
+		}
+		contact.NotificationTargets.SMS = []pingdom.SMSNotification{sms}
+	}
+	return contact
+}
@@ -0,0 +1,171 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeAccount struct {
+	contacts     []*pingdom.Contact
+	teams        []*pingdom.Team
+	checks       []*pingdom.HttpCheck
+	maintenances []*pingdom.MaintenanceWindow
+	checkErr     error
+}
+
+type fakeContacts struct{ *fakeAccount }
+
+func (f fakeContacts) Create(ctx context.Context, contact pingdom.ContactAPI) (*pingdom.Contact, error) {
+	f.contacts = append(f.contacts, contact.(*pingdom.Contact))
+	return &pingdom.Contact{ID: 100 + len(f.contacts)}, nil
+}
+
+type fakeTeams struct{ *fakeAccount }
+
+func (f fakeTeams) Create(ctx context.Context, team pingdom.TeamAPI) (*pingdom.TeamResponse, error) {
+	f.teams = append(f.teams, team.(*pingdom.Team))
+	return &pingdom.TeamResponse{ID: 200 + len(f.teams)}, nil
+}
+
+type fakeChecks struct{ *fakeAccount }
+
+func (f fakeChecks) Create(ctx context.Context, check pingdom.Check) (*pingdom.CheckResponse, error) {
+	if f.checkErr != nil {
+		return nil, f.checkErr
+	}
+	f.checks = append(f.checks, check.(*pingdom.HttpCheck))
+	return &pingdom.CheckResponse{ID: 300 + len(f.checks)}, nil
+}
+
+type fakeMaintenances struct{ *fakeAccount }
+
+func (f fakeMaintenances) Create(ctx context.Context, maintenance pingdom.Maintenance) (*pingdom.MaintenanceResponse, error) {
+	f.maintenances = append(f.maintenances, maintenance.(*pingdom.MaintenanceWindow))
+	return &pingdom.MaintenanceResponse{ID: 400 + len(f.maintenances)}, nil
+}
+
+func newBootstrapper(account *fakeAccount) *Bootstrapper {
+	return &Bootstrapper{
+		Contacts:     fakeContacts{account},
+		Teams:        fakeTeams{account},
+		Checks:       fakeChecks{account},
+		Maintenances: fakeMaintenances{account},
+		People: []Contact{
+			{Name: "Alice", Email: "alice@example.com", Phone: "+46 701234567"},
+			{Name: "Bob", Email: "bob@example.com"},
+		},
+		URLs:        []string{"https://example.com/health?full=1", "http://example.org:8080"},
+		Maintenance: &DefaultWindow,
+		// A Wednesday.
+		Now: func() time.Time { return time.Date(2021, 3, 10, 12, 0, 0, 0, time.UTC) },
+	}
+}
+
+func TestRun(t *testing.T) {
+	account := &fakeAccount{}
+	result, err := newBootstrapper(account).Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, &Result{
+		ContactIDs:    []int{101, 102},
+		TeamID:        201,
+		CheckIDs:      []int{301, 302},
+		MaintenanceID: 401,
+	}, result)
+
+	if assert.Len(t, account.contacts, 2) {
+		assert.Equal(t, []pingdom.EmailNotification{{Address: "alice@example.com", Severity: "HIGH"}}, account.contacts[0].NotificationTargets.Email)
+		assert.Equal(t, []pingdom.SMSNotification{{CountryCode: "46", Number: "701234567", Provider: "nexmo", Severity: "HIGH"}}, account.contacts[0].NotificationTargets.SMS)
+		assert.Empty(t, account.contacts[1].NotificationTargets.SMS)
+	}
+	assert.Equal(t, []*pingdom.Team{{Name: "On-call", MemberIDs: []int{101, 102}}}, account.teams)
+	assert.Equal(t, []*pingdom.HttpCheck{
+		{
+			Name:       "example.com/health",
+			Hostname:   "example.com",
+			Resolution: 5,
+			Url:        "/health?full=1",
+			Encryption: true,
+			Tags:       "bootstrap",
+			TeamIds:    []int{201},
+		},
+		{
+			Name:       "example.org:8080",
+			Hostname:   "example.org",
+			Resolution: 5,
+			Url:        "/",
+			Port:       8080,
+			Tags:       "bootstrap",
+			TeamIds:    []int{201},
+		},
+	}, account.checks)
+
+	from := time.Date(2021, 3, 14, 2, 0, 0, 0, time.UTC)
+	assert.Equal(t, []*pingdom.MaintenanceWindow{{
+		Description:    "Weekly maintenance",
+		From:           from.Unix(),
+		To:             from.Add(2 * time.Hour).Unix(),
+		RecurrenceType: pingdom.RecurrenceWeek,
+		RepeatEvery:    1,
+		EffectiveTo:    time.Date(2022, 3, 6, 4, 0, 0, 0, time.UTC).Unix(),
+		UptimeIDs:      "301,302",
+	}}, account.maintenances)
+}
+
+func TestRunWithoutContactsNorMaintenance(t *testing.T) {
+	account := &fakeAccount{}
+	b := newBootstrapper(account)
+	b.People = nil
+	b.Maintenance = nil
+	b.Resolution = 1
+	b.Tag = "baseline"
+
+	result, err := b.Run(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, &Result{CheckIDs: []int{301, 302}}, result)
+	assert.Empty(t, account.teams)
+	assert.Empty(t, account.maintenances)
+	assert.Nil(t, account.checks[0].TeamIds)
+	assert.Equal(t, 1, account.checks[0].Resolution)
+	assert.Equal(t, "baseline", account.checks[0].Tags)
+}
+
+func TestRunErrors(t *testing.T) {
+	account := &fakeAccount{}
+	b := newBootstrapper(account)
+	b.URLs = []string{"ftp://example.com"}
+	_, err := b.Run(context.Background())
+	assert.EqualError(t, err, `invalid URL "ftp://example.com": scheme must be http or https`)
+	assert.Empty(t, account.contacts)
+
+	b = newBootstrapper(account)
+	b.People = []Contact{{Name: "Alice"}}
+	_, err = b.Run(context.Background())
+	assert.EqualError(t, err, `contact "Alice": name and email are required`)
+
+	account.checkErr = errors.New("boom")
+	result, err := newBootstrapper(account).Run(context.Background())
+	assert.EqualError(t, err, "check example.com/health: boom")
+	assert.Equal(t, &Result{ContactIDs: []int{101, 102}, TeamID: 201}, result)
+}
+
+func TestNewMaintenance(t *testing.T) {
+	b := &Bootstrapper{Now: func() time.Time { return time.Date(2021, 3, 14, 3, 0, 0, 0, time.UTC) }}
+
+	// The window of this Sunday has already started, the next one is used.
+	window := b.NewMaintenance(DefaultWindow, []int{1})
+	assert.Equal(t, time.Date(2021, 3, 21, 2, 0, 0, 0, time.UTC).Unix(), window.From)
+
+	window = b.NewMaintenance(Window{Weekday: time.Sunday, Start: 4 * time.Hour, Duration: time.Hour}, []int{1})
+	assert.Equal(t, time.Date(2021, 3, 14, 4, 0, 0, 0, time.UTC).Unix(), window.From)
+	assert.Equal(t, time.Date(2021, 3, 14, 5, 0, 0, 0, time.UTC).Unix(), window.To)
+
+	// The window recurs until the end of its last occurrence.
+	b.MaintenanceWeeks = 4
+	window = b.NewMaintenance(DefaultWindow, []int{1})
+	assert.Equal(t, time.Date(2021, 4, 11, 4, 0, 0, 0, time.UTC).Unix(), window.EffectiveTo)
+}
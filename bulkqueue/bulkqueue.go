@@ -0,0 +1,246 @@
+// Package bulkqueue runs bulk check mutations through a durable queue, so that
+// a very large sync survives restarts of the process driving it.  The jobs
+// are enqueued first, then a Worker drains the queue with the adaptive
+// parallelism of pingdom.RunBulk, acknowledging each job once it is done.
+//
+// The queue is provided by the caller, e.g. backed by a database table or a
+// message broker, and is expected to deliver each job at least once: a job
+// which is not acknowledged, because the process stopped or the request
+// failed transiently, is delivered again.  Jobs are therefore idempotent.
+// Updates and deletions are by nature, a deletion of a check which no longer
+// exists counting as done.  Creations tag the check with the key of the job
+// and are skipped when a check with that tag already exists.
+package bulkqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/nordcloud/go-pingdom/apierror"
+	"github.com/nordcloud/go-pingdom/pingdom"
+)
+
+const (
+	defaultTagPrefix = "job-"
+	defaultBatchSize = 50
+)
+
+// Operations of a Job.
+const (
+	OpCreate = "create"
+	OpUpdate = "update"
+	OpDelete = "delete"
+)
+
+var validKey = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+// CheckStore manages Pingdom checks.  It is implemented by
+// *pingdom.CheckService.
+type CheckStore interface {
+	List(ctx context.Context, params ...map[string]string) ([]pingdom.CheckResponse, error)
+	Create(ctx context.Context, check pingdom.Check) (*pingdom.CheckResponse, error)
+	Update(ctx context.Context, id int, check pingdom.Check) (*pingdom.PingdomResponse, error)
+	Delete(ctx context.Context, id int) (*pingdom.PingdomResponse, error)
+}
+
+// Queue is a durable queue of jobs with at-least-once delivery.
+type Queue interface {
+	// Push adds the jobs to the queue.
+	Push(ctx context.Context, jobs ...Job) error
+	// Pop returns up to n jobs which are neither acknowledged nor already
+	// delivered to a running worker, none when the queue is drained.
+	Pop(ctx context.Context, n int) ([]Job, error)
+	// Ack removes the job with the given key from the queue.
+	Ack(ctx context.Context, key string) error
+}
+
+// Job is a mutation of a check, serializable as JSON to be stored by the
+// queue.  Key identifies the job, it must be unique and made of lowercase
+// letters, digits, dashes and underscores since creations use it in a tag.
+// Params are the parameters of the request, taken from the check when the
+// job is made.
+type Job struct {
+	Key     string            `json:"key"`
+	Op      string            `json:"op"`
+	CheckID int               `json:"check_id,omitempty"`
+	Params  map[string]string `json:"params,omitempty"`
+}
+
+// CreateJob returns the job creating the check.
+func CreateJob(key string, check pingdom.Check) (Job, error) {
+	if err := validateJob(key, check); err != nil {
+		return Job{}, err
+	}
+	return Job{Key: key, Op: OpCreate, Params: check.PostParams()}, nil
+}
+
+// UpdateJob returns the job updating the check with the given ID.
+func UpdateJob(key string, id int, check pingdom.Check) (Job, error) {
+	if err := validateJob(key, check); err != nil {
+		return Job{}, err
+	}
+	return Job{Key: key, Op: OpUpdate, CheckID: id, Params: check.PutParams()}, nil
+}
+
+// DeleteJob returns the job deleting the check with the given ID.
+func DeleteJob(key string, id int) (Job, error) {
+	if err := validateJob(key, nil); err != nil {
+		return Job{}, err
+	}
+	return Job{Key: key, Op: OpDelete, CheckID: id}, nil
+}
+
+func validateJob(key string, check pingdom.Check) error {
+	if !validKey.MatchString(key) {
+		return fmt.Errorf("invalid job key %q, must be made of lowercase letters, digits, dashes and underscores", key)
+	}
+	if check != nil {
+		return check.Valid()
+	}
+	return nil
+}
+
+// Failure is a job which failed for good, e.g. rejected by Pingdom as
+// invalid.  It has been acknowledged and is not attempted again.
+type Failure struct {
+	Job Job
+	Err error
+}
+
+// Report is the outcome of Worker.Run.
+type Report struct {
+	Done     []Job // Including the creations skipped as already done
+	Failures []Failure
+}
+
+// Worker executes the jobs of a queue.
+type Worker struct {
+	Queue     Queue
+	Checks    CheckStore
+	Bulk      pingdom.BulkConfig
+	BatchSize int    // Jobs popped at once, defaults to 50
+	TagPrefix string // Prefix of the tags of created checks, defaults to "job-"
+}
+
+// Run executes the jobs until the queue is drained.  Jobs which succeed or
+// fail for good are acknowledged, the latter being returned in the report.
+// Run stops at the first batch with a transient failure, such as a network
+// error or Pingdom still being overloaded after the retries of RunBulk, and
+// returns its error: the jobs left are delivered again on the next run.
+func (w *Worker) Run(ctx context.Context) (*Report, error) {
+	report := &Report{}
+	for {
+		jobs, err := w.Queue.Pop(ctx, w.batchSize())
+		if err != nil {
+			return report, err
+		}
+		if len(jobs) == 0 {
+			return report, nil
+		}
+
+		errs := pingdom.RunBulk(ctx, w.Bulk, len(jobs), func(i int) error {
+			return w.execute(ctx, jobs[i])
+		})
+		var transient error
+		for i, job := range jobs {
+			switch {
+			case errs[i] == nil:
+				report.Done = append(report.Done, job)
+			case isPermanent(errs[i]):
+				report.Failures = append(report.Failures, Failure{Job: job, Err: errs[i]})
+			default:
+				if transient == nil {
+					transient = fmt.Errorf("job %s: %w", job.Key, errs[i])
+				}
+				continue
+			}
+			if err := w.Queue.Ack(ctx, job.Key); err != nil {
+				return report, err
+			}
+		}
+		if transient != nil {
+			return report, transient
+		}
+	}
+}
+
+func (w *Worker) execute(ctx context.Context, job Job) error {
+	switch job.Op {
+	case OpCreate:
+		tag := w.tag(job.Key)
+		existing, err := w.Checks.List(ctx, map[string]string{"tags": tag})
+		if err != nil {
+			return err
+		}
+		if len(existing) > 0 {
+			return nil
+		}
+		params := make(map[string]string, len(job.Params)+1)
+		for k, v := range job.Params {
+			params[k] = v
+		}
+		if params["tags"] == "" {
+			params["tags"] = tag
+		} else {
+			params["tags"] += "," + tag
+		}
+		_, err = w.Checks.Create(ctx, paramsCheck(params))
+		return err
+	case OpUpdate:
+		_, err := w.Checks.Update(ctx, job.CheckID, paramsCheck(job.Params))
+		return err
+	case OpDelete:
+		_, err := w.Checks.Delete(ctx, job.CheckID)
+		if apierror.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	return &unknownOpError{op: job.Op}
+}
+
+// tag returns the tag of the check created by the job with the given key.
+func (w *Worker) tag(key string) string {
+	prefix := w.TagPrefix
+	if prefix == "" {
+		prefix = defaultTagPrefix
+	}
+	return prefix + key
+}
+
+func (w *Worker) batchSize() int {
+	if w.BatchSize <= 0 {
+		return defaultBatchSize
+	}
+	return w.BatchSize
+}
+
+// isPermanent reports whether attempting the job again can not succeed: an
+// unknown operation or a client error other than 429 Too Many Requests.
+func isPermanent(err error) bool {
+	var opErr *unknownOpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+	status := apierror.Status(err)
+	return status >= 400 && status < 500 && status != http.StatusTooManyRequests
+}
+
+type unknownOpError struct {
+	op string
+}
+
+func (e *unknownOpError) Error() string {
+	return fmt.Sprintf("unknown job operation %q", e.op)
+}
+
+// paramsCheck sends the parameters of a job as they were taken from the
+// check, which has been validated when the job was made.
+type paramsCheck map[string]string
+
+func (c paramsCheck) PutParams() map[string]string  { return c }
+func (c paramsCheck) PostParams() map[string]string { return c }
+func (c paramsCheck) Valid() error                  { return nil }
@@ -0,0 +1,179 @@
+package bulkqueue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeQueue delivers each job until it is acknowledged, once per Pop.
+type fakeQueue struct {
+	jobs      []Job
+	delivered map[string]bool
+	acked     []string
+}
+
+func (q *fakeQueue) Push(ctx context.Context, jobs ...Job) error {
+	q.jobs = append(q.jobs, jobs...)
+	return nil
+}
+
+func (q *fakeQueue) Pop(ctx context.Context, n int) ([]Job, error) {
+	var jobs []Job
+	for _, job := range q.jobs {
+		if len(jobs) < n && !q.delivered[job.Key] {
+			q.delivered[job.Key] = true
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs, nil
+}
+
+func (q *fakeQueue) Ack(ctx context.Context, key string) error {
+	q.acked = append(q.acked, key)
+	for i, job := range q.jobs {
+		if job.Key == key {
+			q.jobs = append(q.jobs[:i], q.jobs[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// restart forgets the deliveries, as a queue does for the jobs of a worker
+// which stopped without acknowledging them.
+func (q *fakeQueue) restart() {
+	q.delivered = map[string]bool{}
+}
+
+type fakeChecks struct {
+	mu      sync.Mutex
+	checks  []pingdom.CheckResponse
+	listed  []string
+	created []map[string]string
+	updated map[int]map[string]string
+	deleted []int
+	errs    map[int]error
+}
+
+func (f *fakeChecks) List(ctx context.Context, params ...map[string]string) ([]pingdom.CheckResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.listed = append(f.listed, params[0]["tags"])
+	var checks []pingdom.CheckResponse
+	for _, check := range f.checks {
+		for _, tag := range check.Tags {
+			if tag.Name == params[0]["tags"] {
+				checks = append(checks, check)
+			}
+		}
+	}
+	return checks, nil
+}
+
+func (f *fakeChecks) Create(ctx context.Context, check pingdom.Check) (*pingdom.CheckResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.created = append(f.created, check.PostParams())
+	return &pingdom.CheckResponse{ID: 100 + len(f.created)}, nil
+}
+
+func (f *fakeChecks) Update(ctx context.Context, id int, check pingdom.Check) (*pingdom.PingdomResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.errs[id]; err != nil {
+		return nil, err
+	}
+	if f.updated == nil {
+		f.updated = map[int]map[string]string{}
+	}
+	f.updated[id] = check.PutParams()
+	return &pingdom.PingdomResponse{Message: "ok"}, nil
+}
+
+func (f *fakeChecks) Delete(ctx context.Context, id int) (*pingdom.PingdomResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.errs[id]; err != nil {
+		return nil, err
+	}
+	f.deleted = append(f.deleted, id)
+	return &pingdom.PingdomResponse{Message: "ok"}, nil
+}
+
+func mustJob(job Job, err error) Job {
+	if err != nil {
+		panic(err)
+	}
+	return job
+}
+
+func TestJobs(t *testing.T) {
+	job := mustJob(CreateJob("web-1", &pingdom.HttpCheck{Name: "Web", Hostname: "example.com", Resolution: 5}))
+	assert.Equal(t, OpCreate, job.Op)
+	assert.Equal(t, "http", job.Params["type"])
+
+	job = mustJob(UpdateJob("web-2", 12, &pingdom.PingCheck{Name: "Web", Hostname: "example.com", Resolution: 5}))
+	assert.Equal(t, Job{Key: "web-2", Op: OpUpdate, CheckID: 12, Params: (&pingdom.PingCheck{Name: "Web", Hostname: "example.com", Resolution: 5}).PutParams()}, job)
+
+	assert.Equal(t, Job{Key: "web-3", Op: OpDelete, CheckID: 12}, mustJob(DeleteJob("web-3", 12)))
+
+	_, err := DeleteJob("Web 3", 12)
+	assert.EqualError(t, err, `invalid job key "Web 3", must be made of lowercase letters, digits, dashes and underscores`)
+	_, err = CreateJob("web-4", &pingdom.HttpCheck{Hostname: "example.com", Resolution: 5})
+	assert.Error(t, err)
+}
+
+func TestWorkerRun(t *testing.T) {
+	queue := &fakeQueue{}
+	queue.restart()
+	checks := &fakeChecks{
+		// Created by a previous run which stopped before acknowledging.
+		checks: []pingdom.CheckResponse{{ID: 7, Tags: []pingdom.CheckResponseTag{{Name: "job-create-a"}}}},
+		errs: map[int]error{
+			20: &pingdom.PingdomError{StatusCode: 404, StatusDesc: "Not Found", Message: "no such check"},
+			21: &pingdom.PingdomError{StatusCode: 400, StatusDesc: "Bad Request", Message: "invalid name"},
+			22: errors.New("connection reset"),
+		},
+	}
+	assert.NoError(t, queue.Push(context.Background(),
+		mustJob(CreateJob("create-a", &pingdom.HttpCheck{Name: "A", Hostname: "a.example.com", Resolution: 5})),
+		mustJob(CreateJob("create-b", &pingdom.HttpCheck{Name: "B", Hostname: "b.example.com", Resolution: 5, Tags: "web"})),
+		mustJob(UpdateJob("update-10", 10, &pingdom.HttpCheck{Name: "C", Hostname: "c.example.com", Resolution: 5})),
+		mustJob(DeleteJob("delete-20", 20)),
+		mustJob(UpdateJob("update-21", 21, &pingdom.HttpCheck{Name: "D", Hostname: "d.example.com", Resolution: 5})),
+		mustJob(DeleteJob("delete-22", 22)),
+		Job{Key: "rename", Op: "rename", CheckID: 1},
+	))
+
+	worker := &Worker{Queue: queue, Checks: checks, BatchSize: 4, Bulk: pingdom.BulkConfig{Wait: time.Millisecond}}
+	report, err := worker.Run(context.Background())
+	assert.EqualError(t, err, "job delete-22: connection reset")
+	assert.Len(t, report.Done, 4)
+	// The batch with the transient failure is completed first.
+	if assert.Len(t, report.Failures, 2) {
+		assert.Equal(t, "update-21", report.Failures[0].Job.Key)
+		assert.EqualError(t, report.Failures[1].Err, `unknown job operation "rename"`)
+	}
+	assert.ElementsMatch(t, []string{"job-create-a", "job-create-b"}, checks.listed)
+	if assert.Len(t, checks.created, 1) {
+		assert.Equal(t, "web,job-create-b", checks.created[0]["tags"])
+	}
+	assert.Contains(t, checks.updated, 10)
+	assert.Empty(t, checks.deleted)
+
+	// The transient failure is delivered again after a restart.
+	delete(checks.errs, 22)
+	queue.restart()
+	report, err = worker.Run(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, report.Done, 1)
+	assert.Empty(t, report.Failures)
+	assert.Equal(t, []int{22}, checks.deleted)
+	assert.Empty(t, queue.jobs)
+}
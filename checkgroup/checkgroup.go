@@ -0,0 +1,364 @@
+// Package checkgroup manages the checks of a service exposed through several
+// regional endpoints as a single logical unit: one HTTP check per endpoint,
+// sharing the settings of a template, created, updated and deleted together,
+// and whose statuses are aggregated into the status of the service, weighted
+// by the importance of each region.
+package checkgroup
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+)
+
+const (
+	defaultTagPrefix = "group-"
+	defaultThreshold = 0.5
+)
+
+// Aggregated states of a group.  Up, down and unknown are those of the checks.
+const (
+	StateUp       = string(pingdom.CheckStatusUp)
+	StateDegraded = "degraded"
+	StateDown     = string(pingdom.CheckStatusDown)
+	StateUnknown  = string(pingdom.CheckStatusUnknown)
+)
+
+// CheckStore manages Pingdom checks.  It is implemented by
+// *pingdom.CheckService.
+type CheckStore interface {
+	List(ctx context.Context, params ...map[string]string) ([]pingdom.CheckResponse, error)
+	Create(ctx context.Context, check pingdom.Check) (*pingdom.CheckResponse, error)
+	Update(ctx context.Context, id int, check pingdom.Check) (*pingdom.PingdomResponse, error)
+	Delete(ctx context.Context, id int) (*pingdom.PingdomResponse, error)
+}
+
+// Endpoint is the URL of the service in a region, e.g.
+// "https://eu.api.example.com/health".  ProbeFilters restricts the probes
+// testing it, e.g. "region: EU".  Weight is the importance of the region in
+// the aggregated status, defaults to 1.
+type Endpoint struct {
+	Region       string
+	URL          string
+	ProbeFilters string
+	Weight       float64
+}
+
+// Group is a service and its regional endpoints.  The checks of the group are
+// named "<Name> [<Region>]" and tagged with the tag of the group.
+type Group struct {
+	Name      string
+	Endpoints []Endpoint
+
+	// Template holds the settings shared by the checks, e.g. resolution,
+	// alerting and shouldcontain.  Its name, hostname, URL, port,
+	// encryption and probe filters are set from the endpoints.
+	Template pingdom.HttpCheck
+
+	// TagPrefix defaults to "group-", the tag of the group being the prefix
+	// followed by the lower cased name, e.g. "group-payments-api".
+	TagPrefix string
+
+	// Threshold is the share of the weight of the monitored regions which
+	// must be up for the group to be degraded rather than down, defaults to
+	// 0.5.
+	Threshold float64
+}
+
+var invalidTagChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// Tag returns the tag identifying the checks of the group.
+func (g *Group) Tag() string {
+	prefix := g.TagPrefix
+	if prefix == "" {
+		prefix = defaultTagPrefix
+	}
+	name := invalidTagChars.ReplaceAllString(strings.ToLower(g.Name), "-")
+	return prefix + strings.Trim(name, "-")
+}
+
+// CheckName returns the name of the check of a region.
+func (g *Group) CheckName(region string) string {
+	return fmt.Sprintf("%s [%s]", g.Name, region)
+}
+
+// NewCheck returns the check of an endpoint.
+func (g *Group) NewCheck(e Endpoint) (*pingdom.HttpCheck, error) {
+	u, err := url.Parse(e.URL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid URL %q: scheme must be http or https", e.URL)
+	}
+
+	check := g.Template
+	check.Name = g.CheckName(e.Region)
+	check.Hostname = u.Hostname()
+	check.Url = u.RequestURI()
+	check.Encryption = u.Scheme == "https"
+	check.Port = 0
+	if port := u.Port(); port != "" {
+		if check.Port, err = strconv.Atoi(port); err != nil {
+			return nil, fmt.Errorf("invalid URL %q: %w", e.URL, err)
+		}
+	}
+	check.ProbeFilters = e.ProbeFilters
+	check.Tags = g.Tag()
+	if g.Template.Tags != "" {
+		check.Tags = g.Template.Tags + "," + check.Tags
+	}
+	if err := check.Valid(); err != nil {
+		return nil, fmt.Errorf("region %s: %w", e.Region, err)
+	}
+	return &check, nil
+}
+
+// Manager applies groups to an account.
+type Manager struct {
+	Checks CheckStore
+
+	// Bulk controls the concurrency of the fan-out of changes.
+	Bulk pingdom.BulkConfig
+}
+
+// Member is a check of a group.
+type Member struct {
+	Region  string
+	CheckID int
+	Err     error
+}
+
+// Report lists the checks changed by Apply or Delete.
+type Report struct {
+	Created []Member
+	Updated []Member
+	Deleted []Member
+}
+
+// Failed returns whether any of the changes failed.
+func (r *Report) Failed() bool {
+	for _, members := range [][]Member{r.Created, r.Updated, r.Deleted} {
+		for _, m := range members {
+			if m.Err != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// members lists the checks of the group by region.  Checks tagged with the
+// group whose region is no longer one of its endpoints are listed under the
+// region found in their name, or their name when it has none.
+func (m *Manager) members(ctx context.Context, g *Group) (map[string]pingdom.CheckResponse, error) {
+	checks, err := m.Checks.List(ctx, map[string]string{"tags": g.Tag(), "include_tags": "true"})
+	if err != nil {
+		return nil, err
+	}
+	members := map[string]pingdom.CheckResponse{}
+	prefix := g.Name + " ["
+	for _, check := range checks {
+		if !hasTag(check, g.Tag()) {
+			continue
+		}
+		region := check.Name
+		if strings.HasPrefix(check.Name, prefix) && strings.HasSuffix(check.Name, "]") {
+			region = strings.TrimSuffix(strings.TrimPrefix(check.Name, prefix), "]")
+		}
+		members[region] = check
+	}
+	return members, nil
+}
+
+// Apply creates the checks of the endpoints which have none, updates the
+// others with the template, and deletes the checks of the group whose
+// endpoint was removed.  The changes are sent concurrently; the checks are
+// all validated before any change, and errors on individual checks are
+// recorded in the report.
+func (m *Manager) Apply(ctx context.Context, g *Group) (*Report, error) {
+	checks := make([]*pingdom.HttpCheck, len(g.Endpoints))
+	regions := map[string]bool{}
+	for i, e := range g.Endpoints {
+		if regions[e.Region] {
+			return nil, fmt.Errorf("duplicate region %q", e.Region)
+		}
+		regions[e.Region] = true
+		check, err := g.NewCheck(e)
+		if err != nil {
+			return nil, err
+		}
+		checks[i] = check
+	}
+	existing, err := m.members(ctx, g)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{}
+	var ops []operation
+	for i, e := range g.Endpoints {
+		check := checks[i]
+		if current, ok := existing[e.Region]; ok {
+			id := current.ID
+			report.Updated = append(report.Updated, Member{Region: e.Region, CheckID: id})
+			ops = append(ops, operation{list: &report.Updated, index: len(report.Updated) - 1, run: func() (int, error) {
+				_, err := m.Checks.Update(ctx, id, check)
+				return id, err
+			}})
+			continue
+		}
+		report.Created = append(report.Created, Member{Region: e.Region})
+		ops = append(ops, operation{list: &report.Created, index: len(report.Created) - 1, run: func() (int, error) {
+			created, err := m.Checks.Create(ctx, check)
+			if err != nil {
+				return 0, pingdom.Creation(err)
+			}
+			return created.ID, nil
+		}})
+	}
+	ops = append(ops, m.deletions(ctx, report, existing, regions)...)
+	m.run(ctx, ops)
+	return report, nil
+}
+
+// Delete deletes all the checks of the group.
+func (m *Manager) Delete(ctx context.Context, g *Group) (*Report, error) {
+	existing, err := m.members(ctx, g)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{}
+	m.run(ctx, m.deletions(ctx, report, existing, nil))
+	return report, nil
+}
+
+// operation is a change of a check, recording its outcome in the member at
+// index of list.
+type operation struct {
+	list  *[]Member
+	index int
+	run   func() (id int, err error)
+}
+
+func (m *Manager) deletions(ctx context.Context, report *Report, existing map[string]pingdom.CheckResponse, keep map[string]bool) []operation {
+	regions := make([]string, 0, len(existing))
+	for region := range existing {
+		if !keep[region] {
+			regions = append(regions, region)
+		}
+	}
+	sort.Strings(regions)
+
+	var ops []operation
+	for _, region := range regions {
+		id := existing[region].ID
+		report.Deleted = append(report.Deleted, Member{Region: region, CheckID: id})
+		ops = append(ops, operation{list: &report.Deleted, index: len(report.Deleted) - 1, run: func() (int, error) {
+			_, err := m.Checks.Delete(ctx, id)
+			return id, err
+		}})
+	}
+	return ops
+}
+
+// run performs the operations concurrently, once every member is in its
+// list.
+func (m *Manager) run(ctx context.Context, ops []operation) {
+	members := make([]*Member, len(ops))
+	for i, o := range ops {
+		members[i] = &(*o.list)[o.index]
+	}
+	errs := pingdom.RunBulk(ctx, m.Bulk, len(ops), func(i int) error {
+		id, err := ops[i].run()
+		if id != 0 {
+			members[i].CheckID = id
+		}
+		return err
+	})
+	for i, err := range errs {
+		members[i].Err = err
+	}
+}
+
+// MemberStatus is the status of the check of a region.
+type MemberStatus struct {
+	Region  string
+	CheckID int
+	Status  string // As reported by Pingdom, e.g. "up", "down" or "paused"
+	Weight  float64
+}
+
+// Status is the aggregated status of a group.  Availability is the share of
+// the weight of the monitored regions which is up; paused checks, checks not
+// tested yet and endpoints without check are not monitored.
+type Status struct {
+	State        string
+	Availability float64
+	Members      []MemberStatus
+}
+
+// Status returns the aggregated status of the group: up when every monitored
+// region is up, down when less than Threshold of their weight is, degraded
+// otherwise, and unknown when no region is monitored.
+func (m *Manager) Status(ctx context.Context, g *Group) (*Status, error) {
+	existing, err := m.members(ctx, g)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &Status{}
+	var up, monitored float64
+	for _, e := range g.Endpoints {
+		weight := e.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+		member := MemberStatus{Region: e.Region, Weight: weight, Status: "missing"}
+		if check, ok := existing[e.Region]; ok {
+			member.CheckID = check.ID
+			member.Status = check.Status
+		}
+		switch pingdom.CheckStatus(member.Status) {
+		case pingdom.CheckStatusUp:
+			up += weight
+			monitored += weight
+		case pingdom.CheckStatusDown:
+			monitored += weight
+		}
+		status.Members = append(status.Members, member)
+	}
+
+	threshold := g.Threshold
+	if threshold <= 0 {
+		threshold = defaultThreshold
+	}
+	switch {
+	case monitored == 0:
+		status.State = StateUnknown
+		return status, nil
+	case up == monitored:
+		status.State = StateUp
+	case up/monitored < threshold:
+		status.State = StateDown
+	default:
+		status.State = StateDegraded
+	}
+	status.Availability = up / monitored
+	return status, nil
+}
+
+func hasTag(check pingdom.CheckResponse, tag string) bool {
+	for _, t := range check.Tags {
+		if t.Name == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,191 @@
+package checkgroup
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeChecks struct {
+	mu      sync.Mutex
+	checks  []pingdom.CheckResponse
+	params  map[string]string
+	created []*pingdom.HttpCheck
+	updated map[int]*pingdom.HttpCheck
+	deleted []int
+	fail    string
+}
+
+func (f *fakeChecks) List(ctx context.Context, params ...map[string]string) ([]pingdom.CheckResponse, error) {
+	f.params = params[0]
+	return f.checks, nil
+}
+
+func (f *fakeChecks) Create(ctx context.Context, check pingdom.Check) (*pingdom.CheckResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c := check.(*pingdom.HttpCheck)
+	if c.Name == f.fail {
+		return nil, errors.New("boom")
+	}
+	f.created = append(f.created, c)
+	return &pingdom.CheckResponse{ID: 100 + len(f.created), Name: c.Name}, nil
+}
+
+func (f *fakeChecks) Update(ctx context.Context, id int, check pingdom.Check) (*pingdom.PingdomResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.updated == nil {
+		f.updated = map[int]*pingdom.HttpCheck{}
+	}
+	f.updated[id] = check.(*pingdom.HttpCheck)
+	return &pingdom.PingdomResponse{Message: "ok"}, nil
+}
+
+func (f *fakeChecks) Delete(ctx context.Context, id int) (*pingdom.PingdomResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleted = append(f.deleted, id)
+	return &pingdom.PingdomResponse{Message: "ok"}, nil
+}
+
+func tagged(id int, name, status string, tags ...string) pingdom.CheckResponse {
+	check := pingdom.CheckResponse{ID: id, Name: name, Status: status}
+	for _, tag := range tags {
+		check.Tags = append(check.Tags, pingdom.CheckResponseTag{Name: tag, Type: "u"})
+	}
+	return check
+}
+
+func newGroup() *Group {
+	return &Group{
+		Name: "Payments API",
+		Endpoints: []Endpoint{
+			{Region: "eu", URL: "https://eu.example.com/health", ProbeFilters: "region: EU", Weight: 2},
+			{Region: "us", URL: "https://us.example.com:8443/health?deep=1", ProbeFilters: "region: NA"},
+		},
+		Template: pingdom.HttpCheck{Resolution: 1, ShouldContain: "ok", Tags: "team-payments"},
+	}
+}
+
+func TestGroupTag(t *testing.T) {
+	assert.Equal(t, "group-payments-api", newGroup().Tag())
+	assert.Equal(t, "svc-a_b", (&Group{Name: " A_b! ", TagPrefix: "svc-"}).Tag())
+}
+
+func TestGroupNewCheck(t *testing.T) {
+	g := newGroup()
+
+	check, err := g.NewCheck(g.Endpoints[1])
+	assert.NoError(t, err)
+	assert.Equal(t, "Payments API [us]", check.Name)
+	assert.Equal(t, "us.example.com", check.Hostname)
+	assert.Equal(t, "/health?deep=1", check.Url)
+	assert.Equal(t, 8443, check.Port)
+	assert.True(t, check.Encryption)
+	assert.Equal(t, "region: NA", check.ProbeFilters)
+	assert.Equal(t, "team-payments,group-payments-api", check.Tags)
+	assert.Equal(t, "ok", check.ShouldContain)
+	assert.Equal(t, "", g.Template.Name)
+
+	_, err = g.NewCheck(Endpoint{Region: "ap", URL: "ftp://ap.example.com"})
+	assert.EqualError(t, err, `invalid URL "ftp://ap.example.com": scheme must be http or https`)
+}
+
+func TestManagerApply(t *testing.T) {
+	store := &fakeChecks{checks: []pingdom.CheckResponse{
+		tagged(1, "Payments API [eu]", "up", "group-payments-api"),
+		tagged(2, "Payments API [ap]", "up", "group-payments-api"),
+		tagged(3, "Payments API [us]", "up", "group-payments-api-v2"),
+	}}
+	m := &Manager{Checks: store}
+
+	report, err := m.Apply(context.Background(), newGroup())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"tags": "group-payments-api", "include_tags": "true"}, store.params)
+	assert.Equal(t, &Report{
+		Created: []Member{{Region: "us", CheckID: 101}},
+		Updated: []Member{{Region: "eu", CheckID: 1}},
+		Deleted: []Member{{Region: "ap", CheckID: 2}},
+	}, report)
+	assert.False(t, report.Failed())
+	assert.Equal(t, "Payments API [us]", store.created[0].Name)
+	assert.Equal(t, "eu.example.com", store.updated[1].Hostname)
+	assert.Equal(t, []int{2}, store.deleted)
+}
+
+func TestManagerApplyErrors(t *testing.T) {
+	store := &fakeChecks{fail: "Payments API [us]"}
+	m := &Manager{Checks: store}
+
+	report, err := m.Apply(context.Background(), newGroup())
+	assert.NoError(t, err)
+	assert.True(t, report.Failed())
+	assert.Equal(t, 101, report.Created[0].CheckID)
+	assert.NoError(t, report.Created[0].Err)
+	assert.Equal(t, 0, report.Created[1].CheckID)
+	assert.EqualError(t, report.Created[1].Err, "boom")
+
+	g := newGroup()
+	g.Endpoints = append(g.Endpoints, Endpoint{Region: "eu", URL: "https://eu2.example.com"})
+	_, err = m.Apply(context.Background(), g)
+	assert.EqualError(t, err, `duplicate region "eu"`)
+
+	g = newGroup()
+	g.Template.ShouldNotContain = "error"
+	_, err = m.Apply(context.Background(), g)
+	assert.Error(t, err)
+	assert.Len(t, store.created, 1)
+}
+
+func TestManagerDelete(t *testing.T) {
+	store := &fakeChecks{checks: []pingdom.CheckResponse{
+		tagged(1, "Payments API [eu]", "up", "group-payments-api"),
+		tagged(2, "Payments API [us]", "down", "group-payments-api"),
+	}}
+	m := &Manager{Checks: store}
+
+	report, err := m.Delete(context.Background(), newGroup())
+	assert.NoError(t, err)
+	assert.Equal(t, []Member{{Region: "eu", CheckID: 1}, {Region: "us", CheckID: 2}}, report.Deleted)
+	assert.ElementsMatch(t, []int{1, 2}, store.deleted)
+}
+
+func TestManagerStatus(t *testing.T) {
+	tests := []struct {
+		name         string
+		eu, us       string
+		state        string
+		availability float64
+	}{
+		{name: "all up", eu: "up", us: "up", state: StateUp, availability: 1},
+		{name: "minor region down", eu: "up", us: "down", state: StateDegraded, availability: 2.0 / 3},
+		{name: "major region down", eu: "down", us: "up", state: StateDown, availability: 1.0 / 3},
+		{name: "paused region", eu: "paused", us: "up", state: StateUp, availability: 1},
+		{name: "nothing monitored", eu: "paused", us: "unknown", state: StateUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeChecks{checks: []pingdom.CheckResponse{
+				tagged(1, "Payments API [eu]", tt.eu, "group-payments-api"),
+				tagged(2, "Payments API [us]", tt.us, "group-payments-api"),
+			}}
+			status, err := (&Manager{Checks: store}).Status(context.Background(), newGroup())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.state, status.State)
+			assert.InDelta(t, tt.availability, status.Availability, 1e-9)
+		})
+	}
+
+	store := &fakeChecks{checks: []pingdom.CheckResponse{tagged(1, "Payments API [eu]", "up", "group-payments-api")}}
+	status, err := (&Manager{Checks: store}).Status(context.Background(), newGroup())
+	assert.NoError(t, err)
+	assert.Equal(t, []MemberStatus{
+		{Region: "eu", CheckID: 1, Status: "up", Weight: 2},
+		{Region: "us", Status: "missing", Weight: 1},
+	}, status.Members)
+}
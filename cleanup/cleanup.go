@@ -0,0 +1,170 @@
+// Package cleanup finds the maintenance windows which will no longer have any
+// effect and deletes them, i.e. the windows whose checks were all deleted.
+// Accounts collect such windows over the years, and they clutter the
+// maintenance list of the Pingdom UI.  Pingdom only deletes future windows,
+// those which already started are left alone.
+//
+// A dry run returns the same report without deleting anything:
+//
+//	c := &cleanup.Collector{Maintenances: client.Maintenances, Checks: client.Checks, DryRun: true}
+//	report, err := c.Run(ctx)
+//	fmt.Print(report)
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+)
+
+// MaintenanceStore lists and deletes maintenance windows.  It is implemented
+// by *pingdom.MaintenanceService.
+type MaintenanceStore interface {
+	List(ctx context.Context, params ...map[string]string) ([]pingdom.MaintenanceResponse, error)
+	DeleteMany(ctx context.Context, ids []int, config pingdom.BulkConfig) ([]*pingdom.PingdomResponse, []error)
+}
+
+// CheckLister lists Pingdom checks.  It is implemented by
+// *pingdom.CheckService.
+type CheckLister interface {
+	List(ctx context.Context, params ...map[string]string) ([]pingdom.CheckResponse, error)
+}
+
+// Reasons of an Orphan.
+const (
+	ReasonNoChecks = "no checks"
+)
+
+// Orphan is a maintenance window which will no longer have any effect.
+// MissingCheckIDs are the deleted checks it still refers to, and Err the
+// error deleting it, if any.
+type Orphan struct {
+	Maintenance     pingdom.MaintenanceResponse
+	Reason          string
+	MissingCheckIDs []int
+	Err             error
+}
+
+func (o Orphan) String() string {
+	return fmt.Sprintf("maintenance %d (%s): %s", o.Maintenance.ID, o.Maintenance.Description, o.Reason)
+}
+
+// Report lists the orphaned windows, and whether they were deleted.
+type Report struct {
+	Orphans []Orphan
+	DryRun  bool
+}
+
+// String returns one line per orphan, e.g. for the output of a dry run.
+func (r *Report) String() string {
+	var b strings.Builder
+	verb := "deleted"
+	if r.DryRun {
+		verb = "would delete"
+	}
+	for _, o := range r.Orphans {
+		if o.Err != nil {
+			fmt.Fprintf(&b, "failed to delete %s: %v\n", o, o.Err)
+			continue
+		}
+		fmt.Fprintf(&b, "%s %s\n", verb, o)
+	}
+	return b.String()
+}
+
+// Failed reports whether an orphan could not be deleted.
+func (r *Report) Failed() bool {
+	for _, o := range r.Orphans {
+		if o.Err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Collector finds and deletes orphaned maintenance windows.
+type Collector struct {
+	Maintenances MaintenanceStore
+	Checks       CheckLister
+
+	// Bulk controls the concurrency of the deletions of the windows which
+	// Pingdom refuses to delete in batches, see DeleteMany.
+	Bulk pingdom.BulkConfig
+
+	// DryRun reports the orphans without deleting them.
+	DryRun bool
+
+	// Now defaults to time.Now.
+	Now func() time.Time
+}
+
+// Find returns the orphaned windows, sorted by ID.  A window is orphaned when
+// it starts after Now and refers to no check left, unless it refers to
+// transaction checks, which are not listed.
+func (c *Collector) Find(ctx context.Context) ([]Orphan, error) {
+	windows, err := c.Maintenances.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	checks, err := c.Checks.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	exists := make(map[int]bool, len(checks))
+	for _, check := range checks {
+		exists[check.ID] = true
+	}
+
+	now := c.now().Unix()
+	var orphans []Orphan
+	for _, window := range windows {
+		if window.From <= now {
+			continue
+		}
+		var missing []int
+		for _, id := range window.Checks.Uptime {
+			if !exists[id] {
+				missing = append(missing, id)
+			}
+		}
+		if len(missing) == len(window.Checks.Uptime) && len(window.Checks.Tms) == 0 {
+			orphans = append(orphans, Orphan{Maintenance: window, Reason: ReasonNoChecks, MissingCheckIDs: missing})
+		}
+	}
+	sort.Slice(orphans, func(i, j int) bool { return orphans[i].Maintenance.ID < orphans[j].Maintenance.ID })
+	return orphans, nil
+}
+
+// Run finds the orphaned windows and deletes them, unless DryRun is set, see
+// DeleteMany.  The error of each window which could not be deleted is in its
+// orphan, see Report.Failed.
+func (c *Collector) Run(ctx context.Context) (*Report, error) {
+	orphans, err := c.Find(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{Orphans: orphans, DryRun: c.DryRun}
+	if c.DryRun || len(orphans) == 0 {
+		return report, nil
+	}
+	ids := make([]int, len(orphans))
+	for i, o := range orphans {
+		ids[i] = o.Maintenance.ID
+	}
+	_, errs := c.Maintenances.DeleteMany(ctx, ids, c.Bulk)
+	for i, err := range errs {
+		report.Orphans[i].Err = err
+	}
+	return report, nil
+}
+
+func (c *Collector) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
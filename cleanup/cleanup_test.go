@@ -0,0 +1,118 @@
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+var now = time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+
+type fakeMaintenances struct {
+	windows []pingdom.MaintenanceResponse
+	deleted []int
+	failing map[int]error
+}
+
+func (f *fakeMaintenances) List(ctx context.Context, params ...map[string]string) ([]pingdom.MaintenanceResponse, error) {
+	return f.windows, nil
+}
+
+func (f *fakeMaintenances) DeleteMany(ctx context.Context, ids []int, config pingdom.BulkConfig) ([]*pingdom.PingdomResponse, []error) {
+	responses := make([]*pingdom.PingdomResponse, len(ids))
+	errs := make([]error, len(ids))
+	for i, id := range ids {
+		if err := f.failing[id]; err != nil {
+			errs[i] = err
+			continue
+		}
+		f.deleted = append(f.deleted, id)
+		responses[i] = &pingdom.PingdomResponse{Message: "deleted"}
+	}
+	return responses, errs
+}
+
+type fakeChecks struct {
+	checks []pingdom.CheckResponse
+}
+
+func (f *fakeChecks) List(ctx context.Context, params ...map[string]string) ([]pingdom.CheckResponse, error) {
+	return f.checks, nil
+}
+
+func window(id int, from int64, uptime []int, tms []int) pingdom.MaintenanceResponse {
+	return pingdom.MaintenanceResponse{
+		ID:          id,
+		Description: fmt.Sprintf("window %d", id),
+		From:        from,
+		To:          from + 3600,
+		Checks:      pingdom.MaintenanceCheckResponse{Uptime: uptime, Tms: tms},
+	}
+}
+
+func newCollector() (*Collector, *fakeMaintenances) {
+	past := now.AddDate(0, 0, -1).Unix()
+	future := now.AddDate(0, 0, 1).Unix()
+	maintenances := &fakeMaintenances{windows: []pingdom.MaintenanceResponse{
+		window(5, future, []int{1, 2}, nil),     // Kept
+		window(4, future, []int{8, 9}, nil),     // Checks deleted
+		window(3, past, []int{9}, nil),          // Already started, cannot be deleted
+		window(2, future, []int{1, 9}, nil),     // A check left
+		window(7, future, []int{9}, []int{100}), // Transaction check
+		window(8, future, nil, nil),             // No check at all
+	}}
+	checks := &fakeChecks{checks: []pingdom.CheckResponse{{ID: 1}, {ID: 2}}}
+	return &Collector{Maintenances: maintenances, Checks: checks, Now: func() time.Time { return now }}, maintenances
+}
+
+func TestFind(t *testing.T) {
+	c, _ := newCollector()
+	orphans, err := c.Find(context.Background())
+	assert.NoError(t, err)
+	var ids, reasons []interface{}
+	for _, o := range orphans {
+		ids = append(ids, o.Maintenance.ID)
+		reasons = append(reasons, o.Reason)
+	}
+	assert.Equal(t, []interface{}{4, 8}, ids)
+	assert.Equal(t, []interface{}{ReasonNoChecks, ReasonNoChecks}, reasons)
+	assert.Equal(t, []int{8, 9}, orphans[0].MissingCheckIDs)
+}
+
+func TestRun(t *testing.T) {
+	c, maintenances := newCollector()
+	c.DryRun = true
+	report, err := c.Run(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, maintenances.deleted)
+	assert.Equal(t, "would delete maintenance 4 (window 4): no checks\n"+
+		"would delete maintenance 8 (window 8): no checks\n", report.String())
+
+	c.DryRun = false
+	report, err = c.Run(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, report.Failed())
+	assert.Equal(t, []int{4, 8}, maintenances.deleted)
+	assert.Len(t, report.Orphans, 2)
+}
+
+func TestRunReportsErrorsPerOrphan(t *testing.T) {
+	maintenances := &fakeMaintenances{failing: map[int]error{2: errors.New("boom")}}
+	for id := 1; id <= 150; id++ {
+		maintenances.windows = append(maintenances.windows, window(id, now.Add(time.Hour).Unix(), []int{9}, nil))
+	}
+	c := &Collector{Maintenances: maintenances, Checks: &fakeChecks{}, Now: func() time.Time { return now }}
+	report, err := c.Run(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, report.Failed())
+	assert.Len(t, report.Orphans, 150)
+	assert.Len(t, maintenances.deleted, 149)
+	assert.EqualError(t, report.Orphans[1].Err, "boom")
+	assert.NoError(t, report.Orphans[149].Err)
+	assert.Contains(t, report.String(), "failed to delete maintenance 2 (window 2): no checks: boom\n")
+}
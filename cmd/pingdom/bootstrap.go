@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/bootstrap"
+	"github.com/nordcloud/go-pingdom/pingdom"
+)
+
+// contactsFlag collects the repeated -contact flags.
+type contactsFlag []bootstrap.Contact
+
+func (f *contactsFlag) String() string {
+	return fmt.Sprint(*f)
+}
+
+// Set parses "Name <email>" or "Name <email> +46 701234567".
+func (f *contactsFlag) Set(value string) error {
+	lt, gt := strings.Index(value, "<"), strings.Index(value, ">")
+	if lt < 1 || gt < lt {
+		return fmt.Errorf("invalid contact %q, expected \"Name <email> [phone]\"", value)
+	}
+	*f = append(*f, bootstrap.Contact{
+		Name:  strings.TrimSpace(value[:lt]),
+		Email: strings.TrimSpace(value[lt+1 : gt]),
+		Phone: strings.TrimSpace(value[gt+1:]),
+	})
+	return nil
+}
+
+func parseWeekday(value string) (time.Weekday, error) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if name := day.String(); strings.EqualFold(value, name) || strings.EqualFold(value, name[:3]) {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid weekday %q", value)
+}
+
+// newBootstrapper parses the flags of the bootstrap command.
+func newBootstrapper(client *pingdom.Client, args []string) (*bootstrap.Bootstrapper, error) {
+	b := bootstrap.New(client)
+	var contacts contactsFlag
+
+	flags := flag.NewFlagSet("bootstrap", flag.ContinueOnError)
+	flags.SetOutput(flagOutput)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: pingdom bootstrap [flags] URL...")
+		flags.PrintDefaults()
+	}
+	flags.Var(&contacts, "contact", "member of the on-call team, as \"Name <email> [phone]\", may be repeated")
+	flags.StringVar(&b.TeamName, "team", "On-call", "name of the on-call team")
+	flags.IntVar(&b.Resolution, "resolution", 5, "resolution of the checks in minutes")
+	flags.StringVar(&b.Tag, "tag", "bootstrap", "tag added to the checks")
+	noMaintenance := flags.Bool("no-maintenance", false, "do not create a weekly maintenance window")
+	day := flags.String("maintenance-day", "sunday", "weekday of the maintenance window")
+	start := flags.Duration("maintenance-start", bootstrap.DefaultWindow.Start, "start of the maintenance window after midnight UTC")
+	duration := flags.Duration("maintenance-duration", bootstrap.DefaultWindow.Duration, "duration of the maintenance window")
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return nil, fmt.Errorf("at least one URL is required")
+	}
+
+	b.People = contacts
+	b.URLs = flags.Args()
+	if !*noMaintenance {
+		weekday, err := parseWeekday(*day)
+		if err != nil {
+			return nil, err
+		}
+		b.Maintenance = &bootstrap.Window{Weekday: weekday, Start: *start, Duration: *duration}
+	}
+	return b, nil
+}
+
+func runBootstrap(ctx context.Context, client *pingdom.Client, args []string, out io.Writer) error {
+	b, err := newBootstrapper(client, args)
+	if err != nil {
+		return err
+	}
+
+	result, err := b.Run(ctx)
+	if result != nil {
+		fmt.Fprintf(out, "contacts: %v\n", result.ContactIDs)
+		fmt.Fprintf(out, "team: %d\n", result.TeamID)
+		fmt.Fprintf(out, "checks: %v\n", result.CheckIDs)
+		fmt.Fprintf(out, "maintenance: %d\n", result.MaintenanceID)
+	}
+	return err
+}
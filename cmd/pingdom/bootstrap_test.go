@@ -0,0 +1,57 @@
+package main
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/bootstrap"
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBootstrapper(t *testing.T) {
+	client, _ := pingdom.NewClientWithConfig(pingdom.ClientConfig{APIToken: "token"})
+
+	b, err := newBootstrapper(client, []string{
+		"-contact", "Alice Smith <alice@example.com> +46 701234567",
+		"-contact", "Bob <bob@example.com>",
+		"-team", "Ops",
+		"-maintenance-day", "sat",
+		"-maintenance-start", "1h30m",
+		"https://example.com", "https://example.org",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []bootstrap.Contact{
+		{Name: "Alice Smith", Email: "alice@example.com", Phone: "+46 701234567"},
+		{Name: "Bob", Email: "bob@example.com"},
+	}, b.People)
+	assert.Equal(t, "Ops", b.TeamName)
+	assert.Equal(t, 5, b.Resolution)
+	assert.Equal(t, []string{"https://example.com", "https://example.org"}, b.URLs)
+	assert.Equal(t, &bootstrap.Window{Weekday: time.Saturday, Start: 90 * time.Minute, Duration: 2 * time.Hour}, b.Maintenance)
+
+	b, err = newBootstrapper(client, []string{"-no-maintenance", "https://example.com"})
+	assert.NoError(t, err)
+	assert.Nil(t, b.Maintenance)
+}
+
+func TestNewBootstrapperErrors(t *testing.T) {
+	client, _ := pingdom.NewClientWithConfig(pingdom.ClientConfig{APIToken: "token"})
+	for _, args := range [][]string{
+		{},
+		{"-contact", "alice@example.com", "https://example.com"},
+		{"-maintenance-day", "someday", "https://example.com"},
+	} {
+		_, err := newBootstrapperQuiet(client, args)
+		assert.Error(t, err, args)
+	}
+}
+
+// newBootstrapperQuiet is newBootstrapper with the usage output discarded.
+func newBootstrapperQuiet(client *pingdom.Client, args []string) (*bootstrap.Bootstrapper, error) {
+	stderr := flagOutput
+	flagOutput = ioutil.Discard
+	defer func() { flagOutput = stderr }()
+	return newBootstrapper(client, args)
+}
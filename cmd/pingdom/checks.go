@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/nordcloud/go-pingdom/filter"
+	"github.com/nordcloud/go-pingdom/pingdom"
+)
+
+// runChecks lists the checks of the account matching the -filter flag.
+func runChecks(ctx context.Context, client *pingdom.Client, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("checks", flag.ContinueOnError)
+	flags.SetOutput(flagOutput)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: pingdom checks [flags]")
+		flags.PrintDefaults()
+	}
+	expr := flags.String("filter", "", "filter expression selecting the checks, e.g. \"tag=prod AND status=down\"")
+	idsOnly := flags.Bool("ids", false, "only print the IDs of the checks")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		flags.Usage()
+		return fmt.Errorf("unexpected arguments %v", flags.Args())
+	}
+
+	f, err := filter.Parse(*expr)
+	if err != nil {
+		return err
+	}
+	checks, err := filter.Checks(ctx, client.Checks, f)
+	if err != nil {
+		return err
+	}
+
+	if *idsOnly {
+		for _, check := range checks {
+			fmt.Fprintln(out, check.ID)
+		}
+		return nil
+	}
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTYPE\tSTATUS\tNAME\tHOSTNAME")
+	for _, check := range checks {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", check.ID, check.Type.Name, check.Status, check.Name, check.Hostname)
+	}
+	return w.Flush()
+}
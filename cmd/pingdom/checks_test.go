@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunChecks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/checks", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("include_tags"))
+		fmt.Fprint(w, `{"checks":[
+			{"id":1,"name":"API","hostname":"api.example.com","status":"down","type":"http","tags":[{"name":"prod"}]},
+			{"id":2,"name":"Staging","hostname":"staging.example.com","status":"down","type":"http","tags":[{"name":"staging"}]},
+			{"id":3,"name":"Mail","hostname":"mx.example.com","status":"up","type":"tcp","tags":[{"name":"prod"}]}
+		]}`)
+	}))
+	defer server.Close()
+	client, _ := pingdom.NewClientWithConfig(pingdom.ClientConfig{APIToken: "token", BaseURL: server.URL})
+
+	out := &bytes.Buffer{}
+	assert.NoError(t, runChecks(context.Background(), client, []string{"-filter", "tag=prod AND status=down"}, out))
+	assert.Equal(t, "ID  TYPE  STATUS  NAME  HOSTNAME\n1   http  down    API   api.example.com\n", out.String())
+
+	out.Reset()
+	assert.NoError(t, runChecks(context.Background(), client, []string{"-ids", "-filter", "tag=prod"}, out))
+	assert.Equal(t, "1\n3\n", out.String())
+
+	stderr := flagOutput
+	flagOutput = ioutil.Discard
+	defer func() { flagOutput = stderr }()
+	assert.Error(t, runChecks(context.Background(), client, []string{"-filter", "tag="}, out))
+	assert.Error(t, runChecks(context.Background(), client, []string{"extra"}, out))
+}
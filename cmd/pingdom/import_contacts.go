@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/nordcloud/go-pingdom/contactimport"
+	"github.com/nordcloud/go-pingdom/pingdom"
+)
+
+// runImportContacts creates and updates contacts from a CSV file, "-" reading
+// it from the standard input.
+func runImportContacts(ctx context.Context, client *pingdom.Client, args []string, out io.Writer) error {
+	im := &contactimport.Importer{Contacts: client.Contacts}
+
+	flags := flag.NewFlagSet("import-contacts", flag.ContinueOnError)
+	flags.SetOutput(flagOutput)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: pingdom import-contacts [flags] FILE")
+		flags.PrintDefaults()
+	}
+	flags.BoolVar(&im.DryRun, "dry-run", false, "only print the changes")
+	flags.StringVar(&im.Severity, "severity", "HIGH", "severity of the notification targets of created contacts")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("exactly one file is required")
+	}
+
+	in := os.Stdin
+	if name := flags.Arg(0); name != "-" {
+		f, err := os.Open(name)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	records, err := contactimport.ReadCSV(in)
+	if err != nil {
+		return err
+	}
+
+	report, err := im.Import(ctx, records)
+	if err != nil {
+		return err
+	}
+	for _, change := range report.Changes {
+		line := fmt.Sprintf("%s %s", change.Action, change.Record.Email)
+		if len(change.Diff) > 0 {
+			line += " (" + strings.Join(change.Diff, ", ") + ")"
+		}
+		if change.Err != nil {
+			line += ": " + change.Err.Error()
+		}
+		fmt.Fprintln(out, line)
+	}
+	if report.Failed() {
+		return fmt.Errorf("some contacts could not be imported")
+	}
+	return nil
+}
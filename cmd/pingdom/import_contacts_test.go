@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunImportContacts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method, "dry runs do not change anything")
+		fmt.Fprint(w, `{"contacts":[{"id":1,"name":"Bob","notification_targets":{"email":[{"address":"bob@example.com","severity":"HIGH"}]}}]}`)
+	}))
+	defer server.Close()
+	client, _ := pingdom.NewClientWithConfig(pingdom.ClientConfig{APIToken: "token", BaseURL: server.URL})
+
+	dir, err := ioutil.TempDir("", "import-contacts")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "contacts.csv")
+	assert.NoError(t, ioutil.WriteFile(file, []byte("name,email\nAlice,alice@example.com\nBob Smith,bob@example.com\n"), 0600))
+
+	out := &bytes.Buffer{}
+	assert.NoError(t, runImportContacts(context.Background(), client, []string{"-dry-run", file}, out))
+	assert.Equal(t, "create alice@example.com\nupdate bob@example.com (name: \"Bob\" -> \"Bob Smith\")\n", out.String())
+
+	stderr := flagOutput
+	flagOutput = ioutil.Discard
+	defer func() { flagOutput = stderr }()
+	assert.Error(t, runImportContacts(context.Background(), client, []string{"-dry-run"}, out))
+	assert.Error(t, runImportContacts(context.Background(), client, []string{"-dry-run", filepath.Join(dir, "missing.csv")}, out))
+}
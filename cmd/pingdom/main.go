@@ -0,0 +1,63 @@
+// Command pingdom manages a Pingdom account from the command line.  The API
+// token is read from PINGDOM_API_TOKEN.
+//
+// Usage:
+//
+//	pingdom <command> [flags] [arguments]
+//
+// Run "pingdom <command> -h" for the flags of a command.
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+)
+
+// command runs a sub command with its arguments, writing its output to out.
+type command func(ctx context.Context, client *pingdom.Client, args []string, out io.Writer) error
+
+// flagOutput receives the usage and parse errors of the flags of commands.
+var flagOutput io.Writer = os.Stderr
+
+var commands = map[string]command{
+	"bootstrap":       runBootstrap,
+	"checks":          runChecks,
+	"import-contacts": runImportContacts,
+	"search":          runSearch,
+}
+
+func main() {
+	if len(os.Args) < 2 || commands[os.Args[1]] == nil {
+		usage(os.Stderr)
+		os.Exit(2)
+	}
+
+	client, err := pingdom.NewClientWithConfig(pingdom.ClientConfig{})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+	if err := commands[os.Args[1]](context.Background(), client, os.Args[2:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+func usage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "Usage: pingdom <command> [flags] [arguments]")
+	fmt.Fprintln(w, "Commands:")
+	for _, name := range names {
+		fmt.Fprintln(w, "  "+name)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/nordcloud/go-pingdom/search"
+)
+
+// runSearch lists the checks of the account matching the search query of the
+// arguments, best first.
+func runSearch(ctx context.Context, client *pingdom.Client, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("search", flag.ContinueOnError)
+	flags.SetOutput(flagOutput)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: pingdom search [flags] <query>")
+		flags.PrintDefaults()
+	}
+	details := flags.Bool("details", false, "read every check to also search URLs and custom messages")
+	limit := flags.Int("limit", 0, "maximum number of results, 0 for all")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return fmt.Errorf("missing search query")
+	}
+
+	s := &search.Searcher{Checks: client.Checks, Details: *details}
+	results, err := s.Search(ctx, strings.Join(flags.Args(), " "))
+	if err != nil {
+		return err
+	}
+	if *limit > 0 && len(results) > *limit {
+		results = results[:*limit]
+	}
+
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSCORE\tNAME\tHOSTNAME\tMATCHES")
+	for _, r := range results {
+		fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%s\n", r.Check.ID, r.Score, r.Check.Name, r.Check.Hostname, strings.Join(r.Fields, ","))
+	}
+	return w.Flush()
+}
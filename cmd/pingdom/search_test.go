@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/checks":
+			assert.Equal(t, "true", r.URL.Query().Get("include_tags"))
+			fmt.Fprint(w, `{"checks":[
+				{"id":1,"name":"API staging","hostname":"api.staging.example.com","type":"http","tags":[{"name":"staging"}]},
+				{"id":2,"name":"API","hostname":"api.example.com","type":"http","tags":[{"name":"prod"}]},
+				{"id":3,"name":"Mail","hostname":"mx.example.com","type":"tcp"}
+			]}`)
+		case "/checks/3":
+			fmt.Fprint(w, `{"check":{"id":3,"name":"Mail","hostname":"mx.example.com","custom_message":"Relays the API mails","type":{"tcp":{"port":25}}}}`)
+		default:
+			fmt.Fprintf(w, `{"check":{"id":%s,"name":"API","type":{"http":{"url":"/"}}}}`, r.URL.Path[len("/checks/"):])
+		}
+	}))
+	defer server.Close()
+	client, _ := pingdom.NewClientWithConfig(pingdom.ClientConfig{APIToken: "token", BaseURL: server.URL})
+
+	out := &bytes.Buffer{}
+	assert.NoError(t, runSearch(context.Background(), client, []string{"api"}, out))
+	assert.Equal(t, ""+
+		"ID  SCORE  NAME         HOSTNAME                 MATCHES\n"+
+		"2   30     API          api.example.com          name\n"+
+		"1   20     API staging  api.staging.example.com  name\n", out.String())
+
+	out.Reset()
+	assert.NoError(t, runSearch(context.Background(), client, []string{"-details", "-limit", "1", "api", "mails"}, out))
+	assert.Equal(t, ""+
+		"ID  SCORE  NAME  HOSTNAME        MATCHES\n"+
+		"3   8      Mail  mx.example.com  custom_message\n", out.String())
+
+	stderr := flagOutput
+	flagOutput = ioutil.Discard
+	defer func() { flagOutput = stderr }()
+	assert.EqualError(t, runSearch(context.Background(), client, nil, out), "missing search query")
+	assert.Error(t, runSearch(context.Background(), client, []string{`"api`}, out))
+}
@@ -0,0 +1,255 @@
+// Package contactimport creates and updates Pingdom alerting contacts in bulk
+// from a list of people, e.g. a CSV file or a Google Workspace users export.
+// People are matched to existing contacts by email address, so that importing
+// the same file twice does not create duplicates, and a dry run reports the
+// differences without changing anything.
+package contactimport
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+)
+
+const (
+	defaultSeverity = "HIGH"
+	defaultProvider = "nexmo"
+)
+
+// ContactStore manages Pingdom contacts.  It is implemented by
+// *pingdom.ContactService.
+type ContactStore interface {
+	List(ctx context.Context) ([]pingdom.Contact, error)
+	Create(ctx context.Context, contact pingdom.ContactAPI) (*pingdom.Contact, error)
+	Update(ctx context.Context, id int, contact pingdom.ContactAPI) (*pingdom.PingdomResponse, error)
+}
+
+// Actions taken for a record.
+const (
+	ActionCreate    = "create"
+	ActionUpdate    = "update"
+	ActionUnchanged = "unchanged"
+	ActionDuplicate = "duplicate"
+)
+
+// Importer imports records as contacts.
+type Importer struct {
+	Contacts ContactStore
+
+	// Severity of the notification targets of created contacts, defaults to
+	// HIGH.  Updated contacts keep the severity of their targets.
+	Severity string
+
+	// Provider of the text messages, defaults to nexmo.
+	Provider string
+
+	// Bulk controls the concurrency of the creations and updates.
+	Bulk pingdom.BulkConfig
+
+	// DryRun computes the report without changing anything.
+	DryRun bool
+}
+
+// Change describes what the import does with a record.  Diff lists the
+// changes of updated contacts, e.g. `name: "Bob" -> "Bob Smith"`.
+type Change struct {
+	Record    Record
+	Action    string
+	ContactID int
+	Diff      []string
+	Err       error
+}
+
+// Report is the outcome of an import, with one change per record in order.
+type Report struct {
+	Changes []Change
+}
+
+// Failed returns whether any of the changes failed.
+func (r *Report) Failed() bool {
+	for _, c := range r.Changes {
+		if c.Err != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Count returns the number of changes with the given action.
+func (r *Report) Count(action string) int {
+	n := 0
+	for _, c := range r.Changes {
+		if c.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// Import creates a contact for each record whose email address does not
+// belong to any contact yet, named after the address when the record has no
+// name, and updates the name and phone number of the others when the record
+// has different ones.  Records repeating the email address of a previous
+// one are reported as duplicates and ignored.  Errors listing contacts abort
+// the import; errors on individual contacts are recorded in the report.
+func (im *Importer) Import(ctx context.Context, records []Record) (*Report, error) {
+	contacts, err := im.Contacts.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	existing := map[string]pingdom.Contact{}
+	for _, contact := range contacts {
+		for _, email := range contact.NotificationTargets.Email {
+			existing[normalizeEmail(email.Address)] = contact
+		}
+	}
+
+	report := &Report{Changes: make([]Change, len(records))}
+	pending := map[int]*pingdom.Contact{}
+	var todo []int
+	seen := map[string]bool{}
+	for i, record := range records {
+		change := Change{Record: record}
+		email := normalizeEmail(record.Email)
+		switch contact, ok := existing[email]; {
+		case seen[email]:
+			change.Action = ActionDuplicate
+		case !ok:
+			change.Action = ActionCreate
+			pending[i] = im.newContact(record)
+			todo = append(todo, i)
+		default:
+			change.ContactID = contact.ID
+			updated, diff := im.update(contact, record)
+			change.Diff = diff
+			if len(diff) == 0 {
+				change.Action = ActionUnchanged
+			} else {
+				change.Action = ActionUpdate
+				pending[i] = updated
+				todo = append(todo, i)
+			}
+		}
+		seen[email] = true
+		report.Changes[i] = change
+	}
+	if im.DryRun || len(todo) == 0 {
+		return report, nil
+	}
+
+	errs := pingdom.RunBulk(ctx, im.Bulk, len(todo), func(n int) error {
+		i := todo[n]
+		change := &report.Changes[i]
+		if change.Action == ActionUpdate {
+			_, err := im.Contacts.Update(ctx, change.ContactID, pending[i])
+			return err
+		}
+		created, err := im.Contacts.Create(ctx, pending[i])
+		if err == nil {
+			change.ContactID = created.ID
+		}
+		return pingdom.Creation(err)
+	})
+	for n, err := range errs {
+		report.Changes[todo[n]].Err = err
+	}
+	return report, nil
+}
+
+func (im *Importer) newContact(record Record) *pingdom.Contact {
+	severity := im.Severity
+	if severity == "" {
+		severity = defaultSeverity
+	}
+	contact := &pingdom.Contact{
+		Name: record.Name,
+		NotificationTargets: pingdom.NotificationTargets{
+			Email: []pingdom.EmailNotification{{Address: record.Email, Severity: severity}},
+		},
+	}
+	if contact.Name == "" {
+		contact.Name = record.Email
+	}
+	if record.Phone != "" {
+		contact.NotificationTargets.SMS = []pingdom.SMSNotification{im.sms(record, severity)}
+	}
+	return contact
+}
+
+// update returns the contact updated with the name and phone number of the
+// record, along with the differences.  A new phone number replaces the first
+// SMS target of the contact only.
+func (im *Importer) update(contact pingdom.Contact, record Record) (*pingdom.Contact, []string) {
+	updated := &pingdom.Contact{
+		Name:                contact.Name,
+		NotificationTargets: contact.NotificationTargets,
+		Paused:              contact.Paused,
+	}
+	var diff []string
+	if record.Name != "" && record.Name != contact.Name {
+		diff = append(diff, fmt.Sprintf("name: %q -> %q", contact.Name, record.Name))
+		updated.Name = record.Name
+	}
+	if record.Phone == "" || hasPhone(contact, record) {
+		return updated, diff
+	}
+
+	// The number of the record replaces the first SMS target, the one shown
+	// as the phone number of the contact, and the others are kept.
+	sms := make([]pingdom.SMSNotification, len(contact.NotificationTargets.SMS))
+	copy(sms, contact.NotificationTargets.SMS)
+	change := fmt.Sprintf("phone: none -> %s", formatPhone(record.CountryCode, record.Phone))
+	if len(sms) > 0 {
+		change = fmt.Sprintf("phone: %s -> %s", formatPhone(sms[0].CountryCode, sms[0].Number), formatPhone(record.CountryCode, record.Phone))
+		if others := len(sms) - 1; others == 1 {
+			change += ", keeping 1 other SMS target"
+		} else if others > 1 {
+			change += fmt.Sprintf(", keeping %d other SMS targets", others)
+		}
+		sms[0] = im.sms(record, sms[0].Severity)
+	} else {
+		severity := defaultSeverity
+		if len(contact.NotificationTargets.Email) > 0 {
+			severity = contact.NotificationTargets.Email[0].Severity
+		}
+		sms = append(sms, im.sms(record, severity))
+	}
+	diff = append(diff, change)
+	updated.NotificationTargets.SMS = sms
+	return updated, diff
+}
+
+func (im *Importer) sms(record Record, severity string) pingdom.SMSNotification {
+	provider := im.Provider
+	if provider == "" {
+		provider = defaultProvider
+	}
+	return pingdom.SMSNotification{
+		CountryCode: record.CountryCode,
+		Number:      record.Phone,
+		Provider:    provider,
+		Severity:    severity,
+	}
+}
+
+func hasPhone(contact pingdom.Contact, record Record) bool {
+	for _, sms := range contact.NotificationTargets.SMS {
+		if sms.Number == record.Phone && sms.CountryCode == record.CountryCode {
+			return true
+		}
+	}
+	return false
+}
+
+func formatPhone(countryCode, number string) string {
+	if countryCode == "" {
+		return number
+	}
+	return "+" + countryCode + " " + number
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
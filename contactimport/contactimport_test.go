@@ -0,0 +1,142 @@
+package contactimport
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeContacts struct {
+	mu       sync.Mutex
+	contacts []pingdom.Contact
+	created  []*pingdom.Contact
+	updated  map[int]*pingdom.Contact
+	fail     string
+}
+
+func (f *fakeContacts) List(ctx context.Context) ([]pingdom.Contact, error) {
+	return f.contacts, nil
+}
+
+func (f *fakeContacts) Create(ctx context.Context, contact pingdom.ContactAPI) (*pingdom.Contact, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	c := contact.(*pingdom.Contact)
+	if c.Name == f.fail {
+		return nil, errors.New("boom")
+	}
+	f.created = append(f.created, c)
+	return &pingdom.Contact{ID: 100 + len(f.created), Name: c.Name}, nil
+}
+
+func (f *fakeContacts) Update(ctx context.Context, id int, contact pingdom.ContactAPI) (*pingdom.PingdomResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.updated == nil {
+		f.updated = map[int]*pingdom.Contact{}
+	}
+	f.updated[id] = contact.(*pingdom.Contact)
+	return &pingdom.PingdomResponse{Message: "ok"}, nil
+}
+
+func newFakeContacts() *fakeContacts {
+	return &fakeContacts{contacts: []pingdom.Contact{
+		{
+			ID:   1,
+			Name: "Bob",
+			NotificationTargets: pingdom.NotificationTargets{
+				Email: []pingdom.EmailNotification{{Address: "Bob@example.com", Severity: "LOW"}},
+			},
+		},
+		{
+			ID:   2,
+			Name: "Carol",
+			NotificationTargets: pingdom.NotificationTargets{
+				Email: []pingdom.EmailNotification{{Address: "carol@example.com", Severity: "HIGH"}},
+				SMS:   []pingdom.SMSNotification{{CountryCode: "1", Number: "5550100", Provider: "nexmo", Severity: "HIGH"}},
+			},
+		},
+	}}
+}
+
+var records = []Record{
+	{Line: 2, Name: "Alice", Email: "alice@example.com", Phone: "701234567", CountryCode: "46"},
+	{Line: 3, Name: "Bob Smith", Email: "bob@example.com", Phone: "5550199", CountryCode: "1"},
+	{Line: 4, Name: "Carol", Email: "carol@example.com", Phone: "5550100", CountryCode: "1"},
+	{Line: 5, Email: "ALICE@example.com"},
+	{Line: 6, Email: "dave@example.com"},
+}
+
+func TestImportDryRun(t *testing.T) {
+	store := newFakeContacts()
+	im := &Importer{Contacts: store, DryRun: true}
+
+	report, err := im.Import(context.Background(), records)
+	assert.NoError(t, err)
+	assert.Equal(t, []Change{
+		{Record: records[0], Action: ActionCreate},
+		{Record: records[1], Action: ActionUpdate, ContactID: 1, Diff: []string{`name: "Bob" -> "Bob Smith"`, "phone: none -> +1 5550199"}},
+		{Record: records[2], Action: ActionUnchanged, ContactID: 2},
+		{Record: records[3], Action: ActionDuplicate},
+		{Record: records[4], Action: ActionCreate},
+	}, report.Changes)
+	assert.Equal(t, 2, report.Count(ActionCreate))
+	assert.Empty(t, store.created)
+	assert.Empty(t, store.updated)
+}
+
+func TestImport(t *testing.T) {
+	store := newFakeContacts()
+	store.fail = "dave@example.com"
+	im := &Importer{Contacts: store, Bulk: pingdom.BulkConfig{MaxConcurrency: 1}}
+
+	report, err := im.Import(context.Background(), records)
+	assert.NoError(t, err)
+	assert.True(t, report.Failed())
+	assert.Equal(t, 101, report.Changes[0].ContactID)
+	assert.NoError(t, report.Changes[1].Err)
+	assert.EqualError(t, report.Changes[4].Err, "boom")
+
+	assert.Equal(t, []*pingdom.Contact{{
+		Name: "Alice",
+		NotificationTargets: pingdom.NotificationTargets{
+			Email: []pingdom.EmailNotification{{Address: "alice@example.com", Severity: "HIGH"}},
+			SMS:   []pingdom.SMSNotification{{CountryCode: "46", Number: "701234567", Provider: "nexmo", Severity: "HIGH"}},
+		},
+	}}, store.created)
+	assert.Equal(t, map[int]*pingdom.Contact{1: {
+		Name: "Bob Smith",
+		NotificationTargets: pingdom.NotificationTargets{
+			Email: []pingdom.EmailNotification{{Address: "Bob@example.com", Severity: "LOW"}},
+			SMS:   []pingdom.SMSNotification{{CountryCode: "1", Number: "5550199", Provider: "nexmo", Severity: "LOW"}},
+		},
+	}}, store.updated)
+}
+
+func TestImportKeepsOtherSMSTargets(t *testing.T) {
+	store := &fakeContacts{contacts: []pingdom.Contact{{
+		ID:   3,
+		Name: "Erin",
+		NotificationTargets: pingdom.NotificationTargets{
+			Email: []pingdom.EmailNotification{{Address: "erin@example.com", Severity: "HIGH"}},
+			SMS: []pingdom.SMSNotification{
+				{CountryCode: "1", Number: "5550100", Provider: "nexmo", Severity: "LOW"},
+				{CountryCode: "46", Number: "701234567", Provider: "esendex", Severity: "HIGH"},
+			},
+		},
+	}}}
+	im := &Importer{Contacts: store}
+
+	report, err := im.Import(context.Background(), []Record{{Line: 2, Email: "erin@example.com", Phone: "5550199", CountryCode: "1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"phone: +1 5550100 -> +1 5550199, keeping 1 other SMS target"}, report.Changes[0].Diff)
+	assert.Equal(t, []pingdom.SMSNotification{
+		{CountryCode: "1", Number: "5550199", Provider: "nexmo", Severity: "LOW"},
+		{CountryCode: "46", Number: "701234567", Provider: "esendex", Severity: "HIGH"},
+	}, store.updated[3].NotificationTargets.SMS)
+	assert.Equal(t, "5550100", store.contacts[0].NotificationTargets.SMS[0].Number)
+}
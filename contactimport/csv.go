@@ -0,0 +1,113 @@
+package contactimport
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Record is a contact read from a CSV file.
+type Record struct {
+	Line        int // Line of the record, the header being line 1
+	Name        string
+	Email       string
+	Phone       string
+	CountryCode string // Calling code of the phone number, e.g. "46"
+}
+
+// columns maps the accepted headers, lower cased and without the
+// " [Required]" suffix of Google Workspace exports, to the fields of a
+// record.
+var columns = map[string]string{
+	"name":          "name",
+	"full name":     "name",
+	"first name":    "first",
+	"given name":    "first",
+	"last name":     "last",
+	"family name":   "last",
+	"email":         "email",
+	"email address": "email",
+	"primary email": "email",
+	"phone":         "phone",
+	"phone number":  "phone",
+	"mobile":        "phone",
+	"mobile phone":  "phone",
+	"country":       "country",
+	"country code":  "country",
+}
+
+// ReadCSV reads contacts from a CSV file whose first line is a header.  The
+// columns are recognised by name: name (or first name and last name), email,
+// phone and country, which is the calling code of the phone number.  The
+// exports of Google Workspace users, e.g. "Email Address [Required]" or
+// "Mobile Phone", are accepted as is.  Phone numbers may also include the
+// calling code, as in "+46 701234567".  Other columns are ignored.
+func ReadCSV(r io.Reader) ([]Record, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("missing header")
+	}
+	if err != nil {
+		return nil, err
+	}
+	index := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		name = strings.TrimSpace(strings.TrimSuffix(name, "[required]"))
+		if field, ok := columns[name]; ok {
+			if _, dup := index[field]; !dup {
+				index[field] = i
+			}
+		}
+	}
+	if _, ok := index["email"]; !ok {
+		return nil, errors.New("missing email column")
+	}
+
+	var records []Record
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			return records, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		get := func(field string) string {
+			if i, ok := index[field]; ok && i < len(row) {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+		if strings.Join(row, "") == "" {
+			continue
+		}
+
+		record := Record{
+			Line:        line,
+			Name:        get("name"),
+			Email:       get("email"),
+			Phone:       get("phone"),
+			CountryCode: strings.TrimPrefix(get("country"), "+"),
+		}
+		if record.Name == "" {
+			record.Name = strings.TrimSpace(get("first") + " " + get("last"))
+		}
+		if strings.HasPrefix(record.Phone, "+") {
+			if fields := strings.Fields(record.Phone); len(fields) >= 2 {
+				record.CountryCode = strings.TrimPrefix(fields[0], "+")
+				record.Phone = strings.Join(fields[1:], "")
+			}
+		}
+		if record.Email == "" {
+			return nil, fmt.Errorf("line %d: missing email", line)
+		}
+		records = append(records, record)
+	}
+}
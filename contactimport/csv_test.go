@@ -0,0 +1,47 @@
+package contactimport
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadCSV(t *testing.T) {
+	records, err := ReadCSV(strings.NewReader(`name,email,phone,country
+Alice Smith,alice@example.com,701234567,+46
+Bob,bob@example.com,,
+,carol@example.com,+1 555 0100,
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, []Record{
+		{Line: 2, Name: "Alice Smith", Email: "alice@example.com", Phone: "701234567", CountryCode: "46"},
+		{Line: 3, Name: "Bob", Email: "bob@example.com"},
+		{Line: 4, Email: "carol@example.com", Phone: "5550100", CountryCode: "1"},
+	}, records)
+}
+
+func TestReadCSVGoogleWorkspace(t *testing.T) {
+	records, err := ReadCSV(strings.NewReader("\ufeff" + `First Name [Required],Last Name [Required],Email Address [Required],Password [Required],Mobile Phone
+Alice,Smith,alice@example.com,****,+46 701234567
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, []Record{
+		{Line: 2, Name: "Alice Smith", Email: "alice@example.com", Phone: "701234567", CountryCode: "46"},
+	}, records)
+}
+
+func TestReadCSVErrors(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"name,phone\nAlice,701234567\n",
+		"name,email\nAlice,\n",
+		"name,email\n\"Alice,alice@example.com\n",
+	} {
+		_, err := ReadCSV(strings.NewReader(input))
+		assert.Error(t, err, input)
+	}
+
+	_, err := ReadCSV(strings.NewReader("name,email\nAlice,alice@example.com\nBob,\n"))
+	assert.EqualError(t, err, "line 3: missing email")
+}
@@ -0,0 +1,288 @@
+// Package contactsync keeps Pingdom alerting contacts in line with the members
+// of a SolarWinds organization.  Members are matched to contacts by email
+// address: members without a contact get one created (optionally joining an
+// alerting team) and, when pruning is enabled, contacts whose address no
+// longer belongs to any member are removed.
+package contactsync
+
+import (
+	"context"
+	"strings"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/nordcloud/go-pingdom/solarwinds"
+)
+
+const defaultSeverity = "HIGH"
+
+// UserLister lists the active members of a SolarWinds organization.  It is
+// implemented by *solarwinds.ActiveUserService.
+type UserLister interface {
+	List(ctx context.Context) (*solarwinds.ActiveUserList, error)
+}
+
+// ContactStore manages Pingdom contacts.  It is implemented by
+// *pingdom.ContactService.
+type ContactStore interface {
+	List(ctx context.Context) ([]pingdom.Contact, error)
+	Create(ctx context.Context, contact pingdom.ContactAPI) (*pingdom.Contact, error)
+	Delete(ctx context.Context, id int) (*pingdom.PingdomResponse, error)
+}
+
+// TeamStore manages Pingdom alerting teams.  It is implemented by
+// *pingdom.TeamService.
+type TeamStore interface {
+	Read(ctx context.Context, id int) (*pingdom.TeamResponse, error)
+	Update(ctx context.Context, id int, team pingdom.TeamAPI) (*pingdom.TeamResponse, error)
+}
+
+// Syncer synchronises organization members to Pingdom contacts.
+type Syncer struct {
+	Users    UserLister
+	Contacts ContactStore
+
+	// Teams and TeamID, when both set, make every synchronised contact a
+	// member of the given alerting team.
+	Teams  TeamStore
+	TeamID int
+
+	// Product restricts the synchronisation to members with access to the
+	// named product (e.g. "PINGDOM").  Empty means every member.
+	Product string
+
+	// Prune removes contacts whose email address does not belong to any
+	// member.  The account owner is never removed.
+	Prune bool
+
+	// Severity of the email notification target of created contacts,
+	// defaults to HIGH.
+	Severity string
+
+	// DryRun computes the report without changing anything.
+	DryRun bool
+
+	// Progress, when set, is called after each contact created or removed,
+	// the contacts to create coming first.
+	Progress pingdom.ProgressFunc
+}
+
+// Change describes a contact created or removed by a synchronisation.
+type Change struct {
+	Email     string
+	Name      string
+	ContactID int
+	Err       error
+}
+
+// Report is the outcome of a synchronisation.
+type Report struct {
+	Created   []Change
+	Removed   []Change
+	Unchanged []string
+}
+
+// Failed returns whether any of the changes failed.
+func (r *Report) Failed() bool {
+	for _, changes := range [][]Change{r.Created, r.Removed} {
+		for _, c := range changes {
+			if c.Err != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Sync creates and removes contacts so that they match the organization
+// members.  Errors listing members or contacts abort the synchronisation;
+// errors on individual contacts are recorded in the report.
+func (s *Syncer) Sync(ctx context.Context) (*Report, error) {
+	users, err := s.Users.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	contacts, err := s.Contacts.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	members := map[string]solarwinds.OrganizationMember{}
+	var emails []string
+	for _, member := range users.Organization.Members {
+		if s.Product != "" && !hasProduct(member, s.Product) {
+			continue
+		}
+		email := normalizeEmail(member.User.Email)
+		if _, ok := members[email]; !ok {
+			emails = append(emails, email)
+		}
+		members[email] = member
+	}
+
+	existing := map[string]pingdom.Contact{}
+	for _, contact := range contacts {
+		for _, email := range contact.NotificationTargets.Email {
+			existing[normalizeEmail(email.Address)] = contact
+		}
+	}
+
+	var create []string
+	var stale []pingdom.Contact
+	for _, email := range emails {
+		if _, ok := existing[email]; !ok {
+			create = append(create, email)
+		}
+	}
+	if s.Prune {
+		for _, contact := range contacts {
+			if !contact.Owner && isStale(contact, members) {
+				stale = append(stale, contact)
+			}
+		}
+	}
+
+	total, failed := len(create)+len(stale), 0
+	done := func(index int, item string, err error) {
+		if err != nil {
+			failed++
+		}
+		if s.Progress != nil {
+			s.Progress(pingdom.Progress{Done: index + 1, Total: total, Index: index, Item: item, Errors: failed})
+		}
+	}
+
+	report := &Report{}
+	var added, removed []int
+	for _, email := range emails {
+		if contact, ok := existing[email]; ok {
+			report.Unchanged = append(report.Unchanged, email)
+			added = append(added, contact.ID)
+		}
+	}
+	for _, email := range create {
+		member := members[email]
+		change := Change{Email: member.User.Email, Name: contactName(member.User)}
+		if !s.DryRun {
+			created, err := s.Contacts.Create(ctx, s.newContact(change))
+			if err != nil {
+				change.Err = err
+			} else {
+				change.ContactID = created.ID
+				added = append(added, created.ID)
+			}
+		}
+		report.Created = append(report.Created, change)
+		done(len(report.Created)-1, change.Email, change.Err)
+	}
+
+	for _, contact := range stale {
+		change := Change{Name: contact.Name, ContactID: contact.ID}
+		if len(contact.NotificationTargets.Email) > 0 {
+			change.Email = contact.NotificationTargets.Email[0].Address
+		}
+		if !s.DryRun {
+			if _, err := s.Contacts.Delete(ctx, contact.ID); err != nil {
+				change.Err = err
+			} else {
+				removed = append(removed, contact.ID)
+			}
+		}
+		report.Removed = append(report.Removed, change)
+		done(len(create)+len(report.Removed)-1, change.Email, change.Err)
+	}
+
+	if s.Teams != nil && s.TeamID != 0 && !s.DryRun {
+		if err := s.updateTeam(ctx, added, removed); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+func (s *Syncer) newContact(change Change) *pingdom.Contact {
+	severity := s.Severity
+	if severity == "" {
+		severity = defaultSeverity
+	}
+	return &pingdom.Contact{
+		Name: change.Name,
+		NotificationTargets: pingdom.NotificationTargets{
+			Email: []pingdom.EmailNotification{
+				{Address: change.Email, Severity: severity},
+			},
+		},
+	}
+}
+
+// updateTeam adds the given contacts to the team and removes the pruned ones.
+func (s *Syncer) updateTeam(ctx context.Context, added []int, removed []int) error {
+	team, err := s.Teams.Read(ctx, s.TeamID)
+	if err != nil {
+		return err
+	}
+
+	drop := map[int]bool{}
+	for _, id := range removed {
+		drop[id] = true
+	}
+	seen := map[int]bool{}
+	memberIDs := []int{}
+	for _, member := range team.Members {
+		if !drop[member.ID] && !seen[member.ID] {
+			seen[member.ID] = true
+			memberIDs = append(memberIDs, member.ID)
+		}
+	}
+
+	changed := len(memberIDs) != len(team.Members)
+	for _, id := range added {
+		if !seen[id] {
+			seen[id] = true
+			memberIDs = append(memberIDs, id)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	_, err = s.Teams.Update(ctx, s.TeamID, &pingdom.Team{Name: team.Name, MemberIDs: memberIDs})
+	return err
+}
+
+// isStale reports whether a contact has email targets but none of them
+// belongs to a member.  Contacts without email targets are left alone as they
+// were not created by a synchronisation.
+func isStale(contact pingdom.Contact, members map[string]solarwinds.OrganizationMember) bool {
+	if len(contact.NotificationTargets.Email) == 0 {
+		return false
+	}
+	for _, email := range contact.NotificationTargets.Email {
+		if _, ok := members[normalizeEmail(email.Address)]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+// hasProduct reports whether the member has access to the product.  The API
+// lists every product, with the role NO_ACCESS for those the member lacks.
+func hasProduct(member solarwinds.OrganizationMember, product string) bool {
+	for _, p := range member.Products {
+		if strings.EqualFold(p.Name, product) && p.Role != "NO_ACCESS" {
+			return true
+		}
+	}
+	return false
+}
+
+func contactName(user solarwinds.ActiveUser) string {
+	if name := strings.TrimSpace(user.FirstName + " " + user.LastName); name != "" {
+		return name
+	}
+	return user.Email
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,174 @@
+package contactsync
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/nordcloud/go-pingdom/solarwinds"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeUsers struct {
+	members []solarwinds.OrganizationMember
+}
+
+func (f *fakeUsers) List(ctx context.Context) (*solarwinds.ActiveUserList, error) {
+	return &solarwinds.ActiveUserList{
+		Organization: solarwinds.OrganizationWithMembers{Members: f.members},
+	}, nil
+}
+
+type fakeContacts struct {
+	contacts  []pingdom.Contact
+	created   []*pingdom.Contact
+	deleted   []int
+	nextID    int
+	createErr error
+}
+
+func (f *fakeContacts) List(ctx context.Context) ([]pingdom.Contact, error) {
+	return f.contacts, nil
+}
+
+func (f *fakeContacts) Create(ctx context.Context, contact pingdom.ContactAPI) (*pingdom.Contact, error) {
+	if f.createErr != nil {
+		return nil, f.createErr
+	}
+	c := *contact.(*pingdom.Contact)
+	f.nextID++
+	c.ID = f.nextID
+	f.created = append(f.created, &c)
+	return &c, nil
+}
+
+func (f *fakeContacts) Delete(ctx context.Context, id int) (*pingdom.PingdomResponse, error) {
+	f.deleted = append(f.deleted, id)
+	return &pingdom.PingdomResponse{}, nil
+}
+
+type fakeTeams struct {
+	team    pingdom.TeamResponse
+	updated *pingdom.Team
+}
+
+func (f *fakeTeams) Read(ctx context.Context, id int) (*pingdom.TeamResponse, error) {
+	return &f.team, nil
+}
+
+func (f *fakeTeams) Update(ctx context.Context, id int, team pingdom.TeamAPI) (*pingdom.TeamResponse, error) {
+	f.updated = team.(*pingdom.Team)
+	return &f.team, nil
+}
+
+func member(first string, email string, products ...string) solarwinds.OrganizationMember {
+	m := solarwinds.OrganizationMember{
+		User: solarwinds.ActiveUser{FirstName: first, Email: email},
+	}
+	for _, p := range products {
+		m.Products = append(m.Products, solarwinds.Product{Name: p, Role: "MEMBER"})
+	}
+	return m
+}
+
+func emailContact(id int, name string, email string) pingdom.Contact {
+	return pingdom.Contact{
+		ID:   id,
+		Name: name,
+		NotificationTargets: pingdom.NotificationTargets{
+			Email: []pingdom.EmailNotification{{Address: email, Severity: "HIGH"}},
+		},
+	}
+}
+
+func TestSync(t *testing.T) {
+	users := &fakeUsers{members: []solarwinds.OrganizationMember{
+		member("Alice", "alice@example.com", "PINGDOM"),
+		member("Bob", "Bob@Example.com", "PINGDOM"),
+		member("Carol", "carol@example.com", "LOGGLY"),
+		{
+			User:     solarwinds.ActiveUser{FirstName: "Erin", Email: "erin@example.com"},
+			Products: []solarwinds.Product{{Name: "PINGDOM", Role: "NO_ACCESS"}, {Name: "LOGGLY", Role: "MEMBER"}},
+		},
+	}}
+	owner := emailContact(1, "Owner", "owner@example.com")
+	owner.Owner = true
+	contacts := &fakeContacts{
+		nextID: 100,
+		contacts: []pingdom.Contact{
+			owner,
+			emailContact(2, "Bob", "bob@example.com"),
+			emailContact(3, "Dave", "dave@example.com"),
+			{ID: 4, Name: "SMS only"},
+		},
+	}
+	teams := &fakeTeams{team: pingdom.TeamResponse{
+		ID:      7,
+		Name:    "Oncall",
+		Members: []pingdom.TeamMemberResponse{{ID: 2}, {ID: 3}},
+	}}
+
+	syncer := &Syncer{
+		Users:    users,
+		Contacts: contacts,
+		Teams:    teams,
+		TeamID:   7,
+		Product:  "pingdom",
+		Prune:    true,
+	}
+	var progress []pingdom.Progress
+	syncer.Progress = func(p pingdom.Progress) { progress = append(progress, p) }
+	report, err := syncer.Sync(context.Background())
+	assert.NoError(t, err)
+	assert.False(t, report.Failed())
+
+	assert.Equal(t, []Change{{Email: "alice@example.com", Name: "Alice", ContactID: 101}}, report.Created)
+	assert.Equal(t, []Change{{Email: "dave@example.com", Name: "Dave", ContactID: 3}}, report.Removed)
+	assert.Equal(t, []string{"bob@example.com"}, report.Unchanged)
+	assert.Equal(t, []pingdom.Progress{
+		{Done: 1, Total: 2, Index: 0, Item: "alice@example.com"},
+		{Done: 2, Total: 2, Index: 1, Item: "dave@example.com"},
+	}, progress)
+
+	assert.Len(t, contacts.created, 1)
+	assert.Equal(t, "alice@example.com", contacts.created[0].NotificationTargets.Email[0].Address)
+	assert.Equal(t, "HIGH", contacts.created[0].NotificationTargets.Email[0].Severity)
+	assert.Equal(t, []int{3}, contacts.deleted)
+	assert.Equal(t, &pingdom.Team{Name: "Oncall", MemberIDs: []int{2, 101}}, teams.updated)
+}
+
+func TestSyncDryRun(t *testing.T) {
+	users := &fakeUsers{members: []solarwinds.OrganizationMember{
+		member("Alice", "alice@example.com"),
+	}}
+	contacts := &fakeContacts{contacts: []pingdom.Contact{
+		emailContact(3, "Dave", "dave@example.com"),
+	}}
+	teams := &fakeTeams{}
+
+	syncer := &Syncer{Users: users, Contacts: contacts, Teams: teams, TeamID: 7, Prune: true, DryRun: true}
+	report, err := syncer.Sync(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, report.Created, 1)
+	assert.Len(t, report.Removed, 1)
+	assert.Empty(t, contacts.created)
+	assert.Empty(t, contacts.deleted)
+	assert.Nil(t, teams.updated)
+}
+
+func TestSyncRecordsErrors(t *testing.T) {
+	users := &fakeUsers{members: []solarwinds.OrganizationMember{
+		member("", "alice@example.com"),
+	}}
+	contacts := &fakeContacts{createErr: errors.New("boom")}
+
+	var last pingdom.Progress
+	syncer := &Syncer{Users: users, Contacts: contacts, Progress: func(p pingdom.Progress) { last = p }}
+	report, err := syncer.Sync(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, report.Failed())
+	assert.Equal(t, "alice@example.com", report.Created[0].Name)
+	assert.EqualError(t, report.Created[0].Err, "boom")
+	assert.Equal(t, pingdom.Progress{Done: 1, Total: 1, Item: "alice@example.com", Errors: 1}, last)
+}
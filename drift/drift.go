@@ -0,0 +1,210 @@
+// Package drift detects configuration drift: it periodically compares the
+// live configuration of an account with a declarative source, a snapshot
+// kept in version control for instance, and exports the differences as
+// Prometheus gauges, so that GitOps setups can alert when someone changes a
+// check by hand.
+//
+// The metrics are served in the Prometheus text format:
+//
+//	pingdom_config_drift_total{resource="check"}                    Resources of the type which drifted
+//	pingdom_config_drift{resource="check",action="update"}          Drifted resources by change needed to restore them
+//	pingdom_config_drift_last_run_timestamp_seconds                 Time of the last comparison
+//	pingdom_config_drift_last_run_success                           Whether the last comparison succeeded
+package drift
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/snapshot"
+)
+
+const defaultInterval = 5 * time.Minute
+
+var (
+	resources = []string{
+		snapshot.ResourceCheck,
+		snapshot.ResourceContact,
+		snapshot.ResourceMaintenance,
+		snapshot.ResourceTeam,
+	}
+	actions = []snapshot.Action{
+		snapshot.ActionCreate,
+		snapshot.ActionDelete,
+		snapshot.ActionUpdate,
+	}
+)
+
+// Source returns the desired configuration of the account.
+type Source func() (*snapshot.Snapshot, error)
+
+// FileSource reads the desired configuration from a snapshot file, read
+// again on every comparison so that updates of the file are picked up.
+func FileSource(path string) Source {
+	return func() (*snapshot.Snapshot, error) {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return snapshot.Read(f)
+	}
+}
+
+// Result is the outcome of a comparison.  Changes are the changes which
+// would bring the account back to the desired configuration, as returned by
+// snapshot.Diff.
+type Result struct {
+	At      time.Time
+	Changes []snapshot.Change
+	Err     error
+}
+
+// Count returns the number of changes to resources of the given type, and
+// of the given action unless it is empty.
+func (r *Result) Count(resource string, action snapshot.Action) int {
+	n := 0
+	for _, c := range r.Changes {
+		if c.Resource == resource && (action == "" || c.Action == action) {
+			n++
+		}
+	}
+	return n
+}
+
+// Exporter compares the account with the desired configuration, and serves
+// the drift as Prometheus metrics.  It is safe for concurrent use.
+type Exporter struct {
+	Account snapshot.Account
+	Desired Source
+
+	// Interval between comparisons in Run, defaults to 5 minutes.
+	Interval time.Duration
+
+	// Now defaults to time.Now.
+	Now func() time.Time
+
+	mu     sync.Mutex
+	last   *Result
+	lastOK *Result
+}
+
+// Compare takes a snapshot of the account and compares it with the desired
+// configuration.  The result is kept for the metrics, errors included.
+func (e *Exporter) Compare(ctx context.Context) (*Result, error) {
+	result := &Result{At: e.now()}
+	result.Changes, result.Err = e.compare(ctx)
+
+	e.mu.Lock()
+	e.last = result
+	if result.Err == nil {
+		e.lastOK = result
+	}
+	e.mu.Unlock()
+	return result, result.Err
+}
+
+func (e *Exporter) compare(ctx context.Context) ([]snapshot.Change, error) {
+	desired, err := e.Desired()
+	if err != nil {
+		return nil, fmt.Errorf("reading desired configuration: %w", err)
+	}
+	current, err := snapshot.Take(ctx, e.Account)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.Diff(current, desired), nil
+}
+
+// Run compares the configurations every Interval until the context is done.
+// Errors of comparisons are passed to onError, if any, and do not stop the
+// exporter.
+func (e *Exporter) Run(ctx context.Context, onError func(error)) error {
+	interval := e.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for ctx.Err() == nil {
+		if _, err := e.Compare(ctx); err != nil && onError != nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-ticker.C:
+		}
+	}
+	return ctx.Err()
+}
+
+// ServeHTTP serves the metrics of the last comparison.  The drift gauges are
+// only served once a comparison succeeded, and keep the values of the last
+// successful one when a comparison fails.
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	e.WriteMetrics(w)
+}
+
+// WriteMetrics writes the metrics in the Prometheus text format.
+func (e *Exporter) WriteMetrics(w io.Writer) error {
+	e.mu.Lock()
+	last, lastOK := e.last, e.lastOK
+	e.mu.Unlock()
+	if last == nil {
+		return nil
+	}
+
+	m := &metricWriter{w: w}
+	if lastOK != nil {
+		m.help("pingdom_config_drift_total", "Number of resources whose live configuration differs from the desired one.")
+		for _, resource := range resources {
+			m.printf("pingdom_config_drift_total{resource=%q} %d\n", resource, lastOK.Count(resource, ""))
+		}
+		m.help("pingdom_config_drift", "Number of drifted resources by action needed to restore them.")
+		for _, resource := range resources {
+			for _, action := range actions {
+				m.printf("pingdom_config_drift{resource=%q,action=%q} %d\n", resource, action, lastOK.Count(resource, action))
+			}
+		}
+	}
+	m.help("pingdom_config_drift_last_run_timestamp_seconds", "Time of the last comparison.")
+	m.printf("pingdom_config_drift_last_run_timestamp_seconds %d\n", last.At.Unix())
+	m.help("pingdom_config_drift_last_run_success", "Whether the last comparison succeeded.")
+	success := 1
+	if last.Err != nil {
+		success = 0
+	}
+	m.printf("pingdom_config_drift_last_run_success %d\n", success)
+	return m.err
+}
+
+func (e *Exporter) now() time.Time {
+	if e.Now != nil {
+		return e.Now()
+	}
+	return time.Now()
+}
+
+// metricWriter writes metrics, keeping the first error.
+type metricWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (m *metricWriter) help(name, help string) {
+	m.printf("# HELP %s %s\n# TYPE %s gauge\n", name, help, name)
+}
+
+func (m *metricWriter) printf(format string, args ...interface{}) {
+	if m.err == nil {
+		_, m.err = fmt.Fprintf(m.w, format, args...)
+	}
+}
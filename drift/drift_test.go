@@ -0,0 +1,181 @@
+package drift
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/nordcloud/go-pingdom/snapshot"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeStore is a read-only account with a single HTTP check.
+type fakeStore struct {
+	checks []pingdom.CheckResponse
+	err    error
+}
+
+func (f *fakeStore) account() snapshot.Account {
+	return snapshot.Account{Contacts: f, Teams: fakeTeams{}, Checks: fakeChecks{f}, Maintenances: fakeMaintenances{}}
+}
+
+func (f *fakeStore) List(context.Context) ([]pingdom.Contact, error) {
+	return []pingdom.Contact{{ID: 1, Name: "Owner", Owner: true}}, f.err
+}
+func (f *fakeStore) Create(context.Context, pingdom.ContactAPI) (*pingdom.Contact, error) {
+	return nil, nil
+}
+func (f *fakeStore) Update(context.Context, int, pingdom.ContactAPI) (*pingdom.PingdomResponse, error) {
+	return nil, nil
+}
+func (f *fakeStore) Delete(context.Context, int) (*pingdom.PingdomResponse, error) { return nil, nil }
+
+type fakeTeams struct{}
+
+func (fakeTeams) List(context.Context) ([]pingdom.TeamResponse, error) { return nil, nil }
+func (fakeTeams) Create(context.Context, pingdom.TeamAPI) (*pingdom.TeamResponse, error) {
+	return nil, nil
+}
+func (fakeTeams) Update(context.Context, int, pingdom.TeamAPI) (*pingdom.TeamResponse, error) {
+	return nil, nil
+}
+func (fakeTeams) Delete(context.Context, int) (*pingdom.TeamDeleteResponse, error) { return nil, nil }
+
+type fakeChecks struct{ f *fakeStore }
+
+func (c fakeChecks) List(context.Context, ...map[string]string) ([]pingdom.CheckResponse, error) {
+	return c.f.checks, nil
+}
+func (c fakeChecks) Read(ctx context.Context, id int) (*pingdom.CheckResponse, error) {
+	for _, check := range c.f.checks {
+		if check.ID == id {
+			return &check, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+func (fakeChecks) Create(context.Context, pingdom.Check) (*pingdom.CheckResponse, error) {
+	return nil, nil
+}
+func (fakeChecks) Update(context.Context, int, pingdom.Check) (*pingdom.PingdomResponse, error) {
+	return nil, nil
+}
+func (fakeChecks) Delete(context.Context, int) (*pingdom.PingdomResponse, error) { return nil, nil }
+
+type fakeMaintenances struct{}
+
+func (fakeMaintenances) List(context.Context, ...map[string]string) ([]pingdom.MaintenanceResponse, error) {
+	return nil, nil
+}
+func (fakeMaintenances) Create(context.Context, pingdom.Maintenance) (*pingdom.MaintenanceResponse, error) {
+	return nil, nil
+}
+func (fakeMaintenances) Update(context.Context, int, pingdom.Maintenance) (*pingdom.PingdomResponse, error) {
+	return nil, nil
+}
+func (fakeMaintenances) Delete(context.Context, int) (*pingdom.PingdomResponse, error) {
+	return nil, nil
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{checks: []pingdom.CheckResponse{{
+		ID:         20,
+		Name:       "web",
+		Hostname:   "example.com",
+		Resolution: 5,
+		Type:       pingdom.CheckResponseType{Name: "http", HTTP: &pingdom.CheckResponseHTTPDetails{Url: "/"}},
+	}}}
+}
+
+// desired returns the configuration of the fake account with the hostname
+// of the check changed and a new check.
+func desired(t *testing.T) *snapshot.Snapshot {
+	s, err := snapshot.Take(context.Background(), newFakeStore().account())
+	assert.NoError(t, err)
+	s.Checks[0].Check.(*pingdom.HttpCheck).Hostname = "example.org"
+	s.Checks = append(s.Checks, snapshot.CheckEntry{Type: "ping", Check: &pingdom.PingCheck{Name: "ping", Hostname: "example.org", Resolution: 1}})
+	return s
+}
+
+var now = time.Unix(1614600000, 0)
+
+func TestExporterCompare(t *testing.T) {
+	store := newFakeStore()
+	e := &Exporter{
+		Account: store.account(),
+		Desired: func() (*snapshot.Snapshot, error) { return desired(t), nil },
+		Now:     func() time.Time { return now },
+	}
+
+	result, err := e.Compare(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, now, result.At)
+	assert.Equal(t, 2, result.Count(snapshot.ResourceCheck, ""))
+	assert.Equal(t, 1, result.Count(snapshot.ResourceCheck, snapshot.ActionUpdate))
+	assert.Equal(t, 1, result.Count(snapshot.ResourceCheck, snapshot.ActionCreate))
+	assert.Equal(t, 0, result.Count(snapshot.ResourceContact, ""))
+}
+
+func TestExporterMetrics(t *testing.T) {
+	store := newFakeStore()
+	e := &Exporter{
+		Account: store.account(),
+		Desired: func() (*snapshot.Snapshot, error) { return desired(t), nil },
+		Now:     func() time.Time { return now },
+	}
+
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, "", w.Body.String())
+
+	e.Compare(context.Background())
+	w = httptest.NewRecorder()
+	e.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	assert.Equal(t, "text/plain; version=0.0.4", w.Header().Get("Content-Type"))
+	body := w.Body.String()
+	assert.Contains(t, body, "# TYPE pingdom_config_drift_total gauge\n")
+	assert.Contains(t, body, `pingdom_config_drift_total{resource="check"} 2`+"\n")
+	assert.Contains(t, body, `pingdom_config_drift_total{resource="team"} 0`+"\n")
+	assert.Contains(t, body, `pingdom_config_drift{resource="check",action="update"} 1`+"\n")
+	assert.Contains(t, body, "pingdom_config_drift_last_run_timestamp_seconds 1614600000\n")
+	assert.Contains(t, body, "pingdom_config_drift_last_run_success 1\n")
+
+	store.err = errors.New("boom")
+	_, err := e.Compare(context.Background())
+	assert.EqualError(t, err, "listing contacts: boom")
+	w = httptest.NewRecorder()
+	e.ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	body = w.Body.String()
+	assert.Contains(t, body, `pingdom_config_drift_total{resource="check"} 2`+"\n")
+	assert.Contains(t, body, "pingdom_config_drift_last_run_success 0\n")
+}
+
+func TestFileSource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "drift")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "desired.json")
+
+	f, err := os.Create(path)
+	assert.NoError(t, err)
+	assert.NoError(t, desired(t).Write(f))
+	f.Close()
+
+	s, err := FileSource(path)()
+	assert.NoError(t, err)
+	assert.Len(t, s.Checks, 2)
+
+	_, err = FileSource(filepath.Join(dir, "missing.json"))()
+	assert.True(t, os.IsNotExist(err))
+
+	e := &Exporter{Account: newFakeStore().account(), Desired: FileSource(filepath.Join(dir, "missing.json"))}
+	_, err = e.Compare(context.Background())
+	assert.True(t, strings.HasPrefix(err.Error(), "reading desired configuration: "))
+}
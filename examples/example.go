@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 
 	"encoding/json"
@@ -48,6 +49,7 @@ func getConfig() credentials {
 }
 
 func main() {
+	ctx := context.Background()
 	client, err := pingdom.NewClientWithConfig(pingdom.ClientConfig{
 		APIToken: "api_token",
 	})
@@ -57,29 +59,29 @@ func main() {
 	}
 
 	// List all checks
-	checks, _ := client.Checks.List()
+	checks, _ := client.Checks.List(ctx)
 	fmt.Println("All checks:", checks)
 
 	// Create a new http check
 	newCheck := pingdom.HttpCheck{Name: "Test Check", Hostname: "example.com", Resolution: 5}
-	check, _ := client.Checks.Create(&newCheck)
+	check, _ := client.Checks.Create(ctx, &newCheck)
 	fmt.Println("Created check:", check) // {ID, Name}
 
 	// Create a new ping check
 	newPingCheck := pingdom.PingCheck{Name: "Test Ping", Hostname: "example.com", Resolution: 1}
-	pingcheck, _ := client.Checks.Create(&newPingCheck)
+	pingcheck, _ := client.Checks.Create(ctx, &newPingCheck)
 	fmt.Println("Created check:", pingcheck) // {ID, Name}
 
 	// Get details for a check
-	details, _ := client.Checks.Read(check.ID)
+	details, _ := client.Checks.Read(ctx, check.ID)
 	fmt.Println("Details:", details)
 
 	// Update a check
 	updatedCheck := pingdom.HttpCheck{Name: "Updated Check", Hostname: "example2.com", Resolution: 5}
-	upMsg, _ := client.Checks.Update(check.ID, &updatedCheck)
+	upMsg, _ := client.Checks.Update(ctx, check.ID, &updatedCheck)
 	fmt.Println("Modified check, message:", upMsg)
 
 	// Delete a check
-	delMsg, _ := client.Checks.Delete(check.ID)
+	delMsg, _ := client.Checks.Delete(ctx, check.ID)
 	fmt.Println("Deleted check, message:", delMsg)
 }
@@ -0,0 +1,65 @@
+// Command provider is a minimal, dependency free sketch of a Terraform
+// provider built on top of go-pingdom.  It mirrors the structure of a real
+// provider (configure, resources with CRUD functions operating on resource
+// data) and drives a full create/update/delete lifecycle of a team and a check
+// that alerts it.
+//
+// Run it with a Pingdom API token in PINGDOM_API_TOKEN; it creates and removes
+// real resources.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+func main() {
+	ctx := context.Background()
+	provider := NewProvider()
+	if err := provider.Configure(map[string]string{}); err != nil {
+		fail(err)
+	}
+
+	team := newResourceData(map[string]interface{}{
+		"name":       "provider example team",
+		"member_ids": []int{},
+	})
+	if err := provider.Apply(ctx, "pingdom_team", "create", team); err != nil {
+		fail(err)
+	}
+	fmt.Println("Created team:", team.Id())
+
+	teamID, _ := strconv.Atoi(team.Id())
+	check := newResourceData(map[string]interface{}{
+		"name":       "provider example check",
+		"hostname":   "example.com",
+		"url":        "/",
+		"resolution": 5,
+		"teamids":    []int{teamID},
+	})
+	if err := provider.Apply(ctx, "pingdom_check", "create", check); err != nil {
+		fail(err)
+	}
+	fmt.Println("Created check:", check.Id())
+
+	_ = check.Set("resolution", 15)
+	if err := provider.Apply(ctx, "pingdom_check", "update", check); err != nil {
+		fail(err)
+	}
+	fmt.Println("Updated check resolution:", check.Get("resolution"))
+
+	if err := provider.Apply(ctx, "pingdom_check", "delete", check); err != nil {
+		fail(err)
+	}
+	if err := provider.Apply(ctx, "pingdom_team", "delete", team); err != nil {
+		fail(err)
+	}
+	fmt.Println("Deleted check and team")
+}
+
+func fail(err error) {
+	fmt.Println("Error", err)
+	os.Exit(1)
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+)
+
+// ResourceData mirrors the subset of the Terraform SDK's schema.ResourceData a
+// provider needs, so the resources below read exactly like a real provider.
+type ResourceData interface {
+	Id() string
+	SetId(id string)
+	Get(key string) interface{}
+	Set(key string, value interface{}) error
+}
+
+// Resource is a set of CRUD functions operating on a ResourceData, with the
+// configured *pingdom.Client passed as meta.  Like the context-aware functions
+// of the Terraform SDK, they receive the context of the operation.
+type Resource struct {
+	Create func(ctx context.Context, d ResourceData, meta interface{}) error
+	Read   func(ctx context.Context, d ResourceData, meta interface{}) error
+	Update func(ctx context.Context, d ResourceData, meta interface{}) error
+	Delete func(ctx context.Context, d ResourceData, meta interface{}) error
+}
+
+// Provider holds the configured resources and the client shared between them.
+type Provider struct {
+	Resources map[string]*Resource
+	meta      interface{}
+}
+
+// NewProvider returns a provider exposing the pingdom_check and pingdom_team
+// resources.
+func NewProvider() *Provider {
+	return &Provider{
+		Resources: map[string]*Resource{
+			"pingdom_check": resourceCheck(),
+			"pingdom_team":  resourceTeam(),
+		},
+	}
+}
+
+// Configure builds the Pingdom client from the provider configuration.  An
+// empty api_token falls back to PINGDOM_API_TOKEN.
+func (p *Provider) Configure(config map[string]string) error {
+	client, err := pingdom.NewClientWithConfig(pingdom.ClientConfig{
+		APIToken: config["api_token"],
+		BaseURL:  config["base_url"],
+	})
+	if err != nil {
+		return err
+	}
+	p.meta = client
+	return nil
+}
+
+// Apply runs the lifecycle function of the named resource.
+func (p *Provider) Apply(ctx context.Context, resource string, op string, d ResourceData) error {
+	r, ok := p.Resources[resource]
+	if !ok {
+		return fmt.Errorf("unknown resource %q", resource)
+	}
+
+	var fn func(context.Context, ResourceData, interface{}) error
+	switch op {
+	case "create":
+		fn = r.Create
+	case "read":
+		fn = r.Read
+	case "update":
+		fn = r.Update
+	case "delete":
+		fn = r.Delete
+	default:
+		return fmt.Errorf("unknown operation %q", op)
+	}
+	return fn(ctx, d, p.meta)
+}
+
+// mapResourceData is an in-memory ResourceData backed by a map.
+type mapResourceData struct {
+	id     string
+	values map[string]interface{}
+}
+
+func newResourceData(values map[string]interface{}) *mapResourceData {
+	return &mapResourceData{values: values}
+}
+
+func (d *mapResourceData) Id() string {
+	return d.id
+}
+
+func (d *mapResourceData) SetId(id string) {
+	d.id = id
+}
+
+func (d *mapResourceData) Get(key string) interface{} {
+	return d.values[key]
+}
+
+func (d *mapResourceData) Set(key string, value interface{}) error {
+	d.values[key] = value
+	return nil
+}
+
+func idFromData(d ResourceData) (int, error) {
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return 0, fmt.Errorf("invalid resource id %q: %v", d.Id(), err)
+	}
+	return id, nil
+}
+
+func intsFromData(d ResourceData, key string) []int {
+	if v, ok := d.Get(key).([]int); ok {
+		return v
+	}
+	return nil
+}
+
+func stringFromData(d ResourceData, key string) string {
+	if v, ok := d.Get(key).(string); ok {
+		return v
+	}
+	return ""
+}
+
+func intFromData(d ResourceData, key string) int {
+	if v, ok := d.Get(key).(int); ok {
+		return v
+	}
+	return 0
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+)
+
+func resourceCheck() *Resource {
+	return &Resource{
+		Create: resourceCheckCreate,
+		Read:   resourceCheckRead,
+		Update: resourceCheckUpdate,
+		Delete: resourceCheckDelete,
+	}
+}
+
+func checkFromData(d ResourceData) *pingdom.HttpCheck {
+	return &pingdom.HttpCheck{
+		Name:       stringFromData(d, "name"),
+		Hostname:   stringFromData(d, "hostname"),
+		Url:        stringFromData(d, "url"),
+		Resolution: intFromData(d, "resolution"),
+		Tags:       stringFromData(d, "tags"),
+		TeamIds:    intsFromData(d, "teamids"),
+		UserIds:    intsFromData(d, "userids"),
+	}
+}
+
+func resourceCheckCreate(ctx context.Context, d ResourceData, meta interface{}) error {
+	client := meta.(*pingdom.Client)
+
+	check, err := client.Checks.Create(ctx, checkFromData(d))
+	if err != nil {
+		return err
+	}
+	d.SetId(strconv.Itoa(check.ID))
+	return resourceCheckRead(ctx, d, meta)
+}
+
+func resourceCheckRead(ctx context.Context, d ResourceData, meta interface{}) error {
+	client := meta.(*pingdom.Client)
+
+	id, err := idFromData(d)
+	if err != nil {
+		return err
+	}
+	check, err := client.Checks.Read(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	_ = d.Set("name", check.Name)
+	_ = d.Set("hostname", check.Hostname)
+	_ = d.Set("resolution", check.Resolution)
+	_ = d.Set("teamids", check.TeamIds)
+	_ = d.Set("userids", check.UserIds)
+	if check.Type.HTTP != nil {
+		_ = d.Set("url", check.Type.HTTP.Url)
+	}
+	return nil
+}
+
+func resourceCheckUpdate(ctx context.Context, d ResourceData, meta interface{}) error {
+	client := meta.(*pingdom.Client)
+
+	id, err := idFromData(d)
+	if err != nil {
+		return err
+	}
+	if _, err := client.Checks.Update(ctx, id, checkFromData(d)); err != nil {
+		return err
+	}
+	return resourceCheckRead(ctx, d, meta)
+}
+
+func resourceCheckDelete(ctx context.Context, d ResourceData, meta interface{}) error {
+	client := meta.(*pingdom.Client)
+
+	id, err := idFromData(d)
+	if err != nil {
+		return err
+	}
+	if _, err := client.Checks.Delete(ctx, id); err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+)
+
+func resourceTeam() *Resource {
+	return &Resource{
+		Create: resourceTeamCreate,
+		Read:   resourceTeamRead,
+		Update: resourceTeamUpdate,
+		Delete: resourceTeamDelete,
+	}
+}
+
+func teamFromData(d ResourceData) *pingdom.Team {
+	return &pingdom.Team{
+		Name:      stringFromData(d, "name"),
+		MemberIDs: intsFromData(d, "member_ids"),
+	}
+}
+
+func resourceTeamCreate(ctx context.Context, d ResourceData, meta interface{}) error {
+	client := meta.(*pingdom.Client)
+
+	team, err := client.Teams.Create(ctx, teamFromData(d))
+	if err != nil {
+		return err
+	}
+	d.SetId(strconv.Itoa(team.ID))
+	return resourceTeamRead(ctx, d, meta)
+}
+
+func resourceTeamRead(ctx context.Context, d ResourceData, meta interface{}) error {
+	client := meta.(*pingdom.Client)
+
+	id, err := idFromData(d)
+	if err != nil {
+		return err
+	}
+	team, err := client.Teams.Read(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	memberIDs := make([]int, 0, len(team.Members))
+	for _, member := range team.Members {
+		memberIDs = append(memberIDs, member.ID)
+	}
+	_ = d.Set("name", team.Name)
+	_ = d.Set("member_ids", memberIDs)
+	return nil
+}
+
+func resourceTeamUpdate(ctx context.Context, d ResourceData, meta interface{}) error {
+	client := meta.(*pingdom.Client)
+
+	id, err := idFromData(d)
+	if err != nil {
+		return err
+	}
+	if _, err := client.Teams.Update(ctx, id, teamFromData(d)); err != nil {
+		return err
+	}
+	return resourceTeamRead(ctx, d, meta)
+}
+
+func resourceTeamDelete(ctx context.Context, d ResourceData, meta interface{}) error {
+	client := meta.(*pingdom.Client)
+
+	id, err := idFromData(d)
+	if err != nil {
+		return err
+	}
+	if _, err := client.Teams.Delete(ctx, id); err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
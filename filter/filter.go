@@ -0,0 +1,211 @@
+// Package filter implements a small expression language selecting checks
+// client side, e.g. to pick the checks of a bulk operation:
+//
+//	tag=prod AND type=http AND status=down
+//	(name~api OR hostname~api) AND NOT paused=true
+//	resolution>=5
+//
+// A condition compares a field of the check to a value with one of the
+// operators = and != (case insensitive equality), ~ (case insensitive
+// substring) or, for numeric fields, <, <=, > and >=.  Conditions are combined
+// with AND, OR and NOT, which are case insensitive, AND binding tighter than
+// OR, and grouped with parentheses.  Values containing spaces or operators
+// are quoted with double quotes.
+//
+// The fields are id, name, hostname, type, status, tag, paused, resolution
+// and severity.  The tag field matches when any tag of the check does, and
+// != when none does.
+package filter
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+)
+
+// CheckStore lists Pingdom checks.  It is implemented by
+// *pingdom.CheckService.
+type CheckStore interface {
+	List(ctx context.Context, params ...map[string]string) ([]pingdom.CheckResponse, error)
+}
+
+// Filter is a parsed filter expression.
+type Filter struct {
+	expr string
+	root node
+}
+
+// Parse parses a filter expression.  An empty expression matches every
+// check.
+func Parse(expr string) (*Filter, error) {
+	p := &parser{tokens: tokenize(expr)}
+	if p.peek().kind == tokenEOF {
+		return &Filter{expr: expr, root: all{}}, nil
+	}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, p.errorf(t, "unexpected %q", t.text)
+	}
+	return &Filter{expr: expr, root: root}, nil
+}
+
+// MustParse is like Parse but panics when the expression is invalid.
+func MustParse(expr string) *Filter {
+	f, err := Parse(expr)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// String returns the expression the filter was parsed from.
+func (f *Filter) String() string {
+	return f.expr
+}
+
+// Match returns whether the check matches the filter.
+func (f *Filter) Match(check pingdom.CheckResponse) bool {
+	return f.root.match(&check)
+}
+
+// Select returns the checks matching the filter, in order.
+func (f *Filter) Select(checks []pingdom.CheckResponse) []pingdom.CheckResponse {
+	selected := []pingdom.CheckResponse{}
+	for _, check := range checks {
+		if f.root.match(&check) {
+			selected = append(selected, check)
+		}
+	}
+	return selected
+}
+
+// Checks lists the checks of the account, along with their tags, and returns
+// the ones matching the filter.
+func Checks(ctx context.Context, store CheckStore, f *Filter) ([]pingdom.CheckResponse, error) {
+	checks, err := store.List(ctx, map[string]string{"include_tags": "true"})
+	if err != nil {
+		return nil, err
+	}
+	return f.Select(checks), nil
+}
+
+// IDs returns the IDs of the checks, e.g. to pass a selection to
+// CheckService.DeleteMany.
+func IDs(checks []pingdom.CheckResponse) []int {
+	ids := make([]int, len(checks))
+	for i, check := range checks {
+		ids[i] = check.ID
+	}
+	return ids
+}
+
+// field describes a field of the checks which can be filtered on: either
+// numeric, or a list of strings.
+type field struct {
+	numeric func(c *pingdom.CheckResponse) int
+	text    func(c *pingdom.CheckResponse) []string
+	values  []string // Restricts the valid values, if set
+}
+
+var fields = map[string]field{
+	"id":         {numeric: func(c *pingdom.CheckResponse) int { return c.ID }},
+	"resolution": {numeric: func(c *pingdom.CheckResponse) int { return c.Resolution }},
+	"name":       {text: func(c *pingdom.CheckResponse) []string { return []string{c.Name} }},
+	"hostname":   {text: func(c *pingdom.CheckResponse) []string { return []string{c.Hostname} }},
+	"type":       {text: func(c *pingdom.CheckResponse) []string { return []string{c.Type.Name} }},
+	"severity":   {text: func(c *pingdom.CheckResponse) []string { return []string{c.SeverityLevel} }},
+	"status": {
+		text:   func(c *pingdom.CheckResponse) []string { return []string{c.Status} },
+		values: checkStatuses(),
+	},
+	"tag": {text: func(c *pingdom.CheckResponse) []string {
+		tags := make([]string, len(c.Tags))
+		for i, tag := range c.Tags {
+			tags[i] = tag.Name
+		}
+		return tags
+	}},
+	"paused": {
+		text:   func(c *pingdom.CheckResponse) []string { return []string{strconv.FormatBool(c.Paused)} },
+		values: []string{"true", "false"},
+	},
+}
+
+func checkStatuses() []string {
+	values := make([]string, len(pingdom.CheckStatuses))
+	for i, s := range pingdom.CheckStatuses {
+		values[i] = s.String()
+	}
+	return values
+}
+
+type node interface {
+	match(c *pingdom.CheckResponse) bool
+}
+
+type all struct{}
+
+func (all) match(*pingdom.CheckResponse) bool { return true }
+
+type and struct{ left, right node }
+
+func (n and) match(c *pingdom.CheckResponse) bool { return n.left.match(c) && n.right.match(c) }
+
+type or struct{ left, right node }
+
+func (n or) match(c *pingdom.CheckResponse) bool { return n.left.match(c) || n.right.match(c) }
+
+type not struct{ node node }
+
+func (n not) match(c *pingdom.CheckResponse) bool { return !n.node.match(c) }
+
+type condition struct {
+	field  field
+	op     string
+	value  string
+	number int
+}
+
+func (n condition) match(c *pingdom.CheckResponse) bool {
+	if n.field.numeric != nil {
+		v := n.field.numeric(c)
+		switch n.op {
+		case "=":
+			return v == n.number
+		case "!=":
+			return v != n.number
+		case "<":
+			return v < n.number
+		case "<=":
+			return v <= n.number
+		case ">":
+			return v > n.number
+		default:
+			return v >= n.number
+		}
+	}
+
+	values := n.field.text(c)
+	if n.op == "!=" {
+		for _, v := range values {
+			if strings.EqualFold(v, n.value) {
+				return false
+			}
+		}
+		return true
+	}
+	for _, v := range values {
+		if n.op == "=" && strings.EqualFold(v, n.value) {
+			return true
+		}
+		if n.op == "~" && strings.Contains(strings.ToLower(v), strings.ToLower(n.value)) {
+			return true
+		}
+	}
+	return false
+}
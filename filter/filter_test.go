@@ -0,0 +1,115 @@
+package filter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+var checks = []pingdom.CheckResponse{
+	{
+		ID: 1, Name: "API", Hostname: "api.example.com", Status: "down", Resolution: 1, SeverityLevel: "HIGH",
+		Type: pingdom.CheckResponseType{Name: "http"},
+		Tags: []pingdom.CheckResponseTag{{Name: "prod"}, {Name: "api"}},
+	},
+	{
+		ID: 2, Name: "API staging", Hostname: "api.staging.example.com", Status: "up", Resolution: 5, SeverityLevel: "LOW",
+		Type: pingdom.CheckResponseType{Name: "http"},
+		Tags: []pingdom.CheckResponseTag{{Name: "staging"}, {Name: "api"}},
+	},
+	{
+		ID: 3, Name: "Mail", Hostname: "mx.example.com", Status: "paused", Paused: true, Resolution: 15,
+		Type: pingdom.CheckResponseType{Name: "tcp"},
+		Tags: []pingdom.CheckResponseTag{{Name: "prod"}},
+	},
+	{
+		ID: 4, Name: "Gateway", Hostname: "10.0.0.1", Status: "down", Resolution: 1,
+		Type: pingdom.CheckResponseType{Name: "ping"},
+	},
+}
+
+func TestFilterSelect(t *testing.T) {
+	tests := []struct {
+		expr string
+		want []int
+	}{
+		{"", []int{1, 2, 3, 4}},
+		{"tag=prod AND type=http AND status=down", []int{1}},
+		{"tag=PROD", []int{1, 3}},
+		{"tag!=prod", []int{2, 4}},
+		{"tag~stag", []int{2}},
+		{"type=http or type=tcp and paused=true", []int{1, 2, 3}},
+		{"(type=http OR type=tcp) AND paused=false", []int{1, 2}},
+		{"NOT status=down", []int{2, 3}},
+		{"not not status=down", []int{1, 4}},
+		{"resolution>=5", []int{2, 3}},
+		{"resolution<5 AND id!=4", []int{1}},
+		{"id>1 AND id<=3", []int{2, 3}},
+		{`name="API staging"`, []int{2}},
+		{"hostname~example.com AND severity=high", []int{1}},
+	}
+	for _, tt := range tests {
+		f, err := Parse(tt.expr)
+		if !assert.NoError(t, err, tt.expr) {
+			continue
+		}
+		assert.Equal(t, tt.want, IDs(f.Select(checks)), tt.expr)
+		assert.Equal(t, tt.expr, f.String())
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, expr := range []string{
+		"tag",
+		"tag=",
+		"color=red",
+		"tag=prod AND",
+		"(tag=prod",
+		"tag=prod)",
+		"resolution=often",
+		"resolution~5",
+		"name>a",
+		"paused=maybe",
+		"status=dwon",
+		`name="unterminated`,
+		"name!a",
+		"tag=prod OR OR type=http",
+		"name~api hostname~example",
+	} {
+		_, err := Parse(expr)
+		assert.Error(t, err, expr)
+	}
+
+	_, err := Parse("tag=prod AND color=red")
+	assert.EqualError(t, err, `invalid filter at offset 13: unknown field "color"`)
+}
+
+func TestMustParse(t *testing.T) {
+	assert.NotPanics(t, func() { MustParse("tag=prod") })
+	assert.Panics(t, func() { MustParse("tag") })
+}
+
+type fakeChecks struct {
+	params map[string]string
+	err    error
+}
+
+func (f *fakeChecks) List(ctx context.Context, params ...map[string]string) ([]pingdom.CheckResponse, error) {
+	f.params = params[0]
+	return checks, f.err
+}
+
+func TestChecks(t *testing.T) {
+	store := &fakeChecks{}
+	selected, err := Checks(context.Background(), store, MustParse("tag=api"))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, IDs(selected))
+	assert.Equal(t, "true", store.params["include_tags"])
+
+	store.err = errors.New("boom")
+	_, err = Checks(context.Background(), store, MustParse("tag=api"))
+	assert.EqualError(t, err, "boom")
+}
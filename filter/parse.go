@@ -0,0 +1,217 @@
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOp
+	tokenLParen
+	tokenRParen
+	tokenInvalid
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// tokenize splits an expression into tokens, the last one being tokenEOF or
+// tokenInvalid.
+func tokenize(expr string) []token {
+	var tokens []token
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: i})
+			i++
+		case strings.IndexByte("=!<>~", c) >= 0:
+			op := string(c)
+			if i+1 < len(expr) && expr[i+1] == '=' && c != '=' && c != '~' {
+				op += "="
+			}
+			kind := tokenOp
+			if op == "!" {
+				kind = tokenInvalid
+			}
+			tokens = append(tokens, token{kind: kind, text: op, pos: i})
+			if kind == tokenInvalid {
+				return tokens
+			}
+			i += len(op)
+		case c == '"':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(expr) && expr[j] != '"'; j++ {
+				if expr[j] == '\\' && j+1 < len(expr) {
+					j++
+				}
+				b.WriteByte(expr[j])
+			}
+			if j == len(expr) {
+				return append(tokens, token{kind: tokenInvalid, text: expr[i:], pos: i})
+			}
+			tokens = append(tokens, token{kind: tokenString, text: b.String(), pos: i})
+			i = j + 1
+		default:
+			j := i
+			for j < len(expr) && strings.IndexByte(" \t\n\r()=!<>~\"", expr[j]) < 0 {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenWord, text: expr[i:j], pos: i})
+			i = j
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(expr)})
+}
+
+// parser is a recursive descent parser of the grammar:
+//
+//	or        = and { "OR" and }
+//	and       = unary { "AND" unary }
+//	unary     = "NOT" unary | "(" or ")" | condition
+//	condition = field op value
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF && t.kind != tokenInvalid {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) keyword(name string) bool {
+	t := p.peek()
+	if t.kind == tokenWord && strings.EqualFold(t.text, name) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) errorf(t token, format string, args ...interface{}) error {
+	return fmt.Errorf("invalid filter at offset %d: %s", t.pos, fmt.Sprintf(format, args...))
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = or{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("and") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = and{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.keyword("not") {
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return not{n}, nil
+	}
+	if p.peek().kind == tokenLParen {
+		p.next()
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind != tokenRParen {
+			return nil, p.errorf(t, "expected \")\"")
+		}
+		return n, nil
+	}
+	return p.parseCondition()
+}
+
+func (p *parser) parseCondition() (node, error) {
+	name := p.next()
+	if name.kind != tokenWord {
+		return nil, p.errorf(name, "expected a field")
+	}
+	f, ok := fields[strings.ToLower(name.text)]
+	if !ok {
+		return nil, p.errorf(name, "unknown field %q", name.text)
+	}
+
+	op := p.next()
+	if op.kind != tokenOp {
+		return nil, p.errorf(op, "expected an operator after %q", name.text)
+	}
+	value := p.next()
+	if value.kind != tokenWord && value.kind != tokenString {
+		return nil, p.errorf(value, "expected a value after %q", name.text+op.text)
+	}
+
+	c := condition{field: f, op: op.text, value: value.text}
+	if f.numeric != nil {
+		n, err := strconv.Atoi(value.text)
+		if err != nil {
+			return nil, p.errorf(value, "field %q is numeric, got %q", name.text, value.text)
+		}
+		if op.text == "~" {
+			return nil, p.errorf(op, "operator ~ does not apply to numeric field %q", name.text)
+		}
+		c.number = n
+		return c, nil
+	}
+	if op.text != "=" && op.text != "!=" && op.text != "~" {
+		return nil, p.errorf(op, "operator %s only applies to numeric fields", op.text)
+	}
+	if f.values != nil && op.text != "~" && !contains(f.values, strings.ToLower(value.text)) {
+		return nil, p.errorf(value, "field %q must be one of %s", name.text, strings.Join(f.values, ", "))
+	}
+	return c, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+// Package heartbeat periodically publishes the activity of a Pingdom client,
+// i.e. when a request last succeeded, the remaining rate limit and whether
+// the credentials are accepted, so that platform teams can monitor their
+// monitoring integration itself.
+//
+// Beats are given to a callback, e.g. to push them to a metrics system, and
+// published in expvar when a name is set, e.g. for a /debug/vars scrape.
+package heartbeat
+
+import (
+	"context"
+	"expvar"
+	"sync"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+)
+
+const defaultInterval = time.Minute
+
+// Source tells the activity of a client.  It is implemented by
+// *pingdom.Client.
+type Source interface {
+	Activity() pingdom.Activity
+}
+
+// Beat is the activity of the client at the time of a heartbeat.
+type Beat struct {
+	At time.Time
+	pingdom.Activity
+
+	// RateLimitRemaining is the lowest remaining request count of the rate
+	// limits, or -1 while they are unknown.
+	RateLimitRemaining int
+
+	// Healthy reports whether the session is valid and a request succeeded
+	// within MaxAge.
+	Healthy bool
+}
+
+// Heartbeat publishes the activity of a client.
+type Heartbeat struct {
+	Client Source
+
+	// Probe, if any, is called when no request succeeded for an Interval, so
+	// that the beats of an idle client still tell whether it works, e.g.
+	// with a cheap request such as (*pingdom.Client).SyncClock.
+	Probe func(ctx context.Context) error
+
+	// Publish, if any, is given every beat.
+	Publish func(Beat)
+
+	// Expvar, if set, is the name under which Run publishes the last beat in
+	// expvar, unless a variable already has the name.
+	Expvar string
+
+	// Interval between beats in Run, defaults to a minute, and MaxAge of the
+	// last success of a healthy client, defaults to twice the Interval.
+	Interval time.Duration
+	MaxAge   time.Duration
+
+	// Now defaults to time.Now.
+	Now func() time.Time
+
+	mu   sync.Mutex
+	last *Beat
+}
+
+// Beat probes the client when it was idle, publishes its activity and
+// returns the beat.
+func (h *Heartbeat) Beat(ctx context.Context) Beat {
+	activity := h.Client.Activity()
+	if h.Probe != nil && h.now().Sub(activity.LastSuccess) >= h.interval() {
+		// A failed probe is recorded in the activity.
+		_ = h.Probe(ctx)
+		activity = h.Client.Activity()
+	}
+
+	now := h.now()
+	beat := Beat{
+		At:                 now,
+		Activity:           activity,
+		RateLimitRemaining: -1,
+		Healthy:            activity.SessionValid && now.Sub(activity.LastSuccess) <= h.maxAge(),
+	}
+	for _, limit := range []*pingdom.RateLimit{activity.ShortRateLimit, activity.LongRateLimit} {
+		if limit != nil && (beat.RateLimitRemaining < 0 || limit.Remaining < beat.RateLimitRemaining) {
+			beat.RateLimitRemaining = limit.Remaining
+		}
+	}
+
+	h.mu.Lock()
+	h.last = &beat
+	h.mu.Unlock()
+	if h.Publish != nil {
+		h.Publish(beat)
+	}
+	return beat
+}
+
+// Last returns the last beat, or nil before the first.
+func (h *Heartbeat) Last() *Beat {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.last == nil {
+		return nil
+	}
+	beat := *h.last
+	return &beat
+}
+
+// Run beats every Interval until the context is done, having published the
+// last beat under the Expvar name first, if set.
+func (h *Heartbeat) Run(ctx context.Context) error {
+	if h.Expvar != "" {
+		h.publishExpvar()
+	}
+	ticker := time.NewTicker(h.interval())
+	defer ticker.Stop()
+
+	for ctx.Err() == nil {
+		h.Beat(ctx)
+
+		select {
+		case <-ctx.Done():
+		case <-ticker.C:
+		}
+	}
+	return ctx.Err()
+}
+
+// expvarMu serialises publishExpvar, since expvar.Publish panics when the
+// name is taken between its expvar.Get check and the publishing.
+var expvarMu sync.Mutex
+
+// publishExpvar publishes the last beat under the Expvar name, unless a
+// variable already has it, e.g. published by a previous Run.
+func (h *Heartbeat) publishExpvar() {
+	expvarMu.Lock()
+	defer expvarMu.Unlock()
+	if expvar.Get(h.Expvar) != nil {
+		return
+	}
+	expvar.Publish(h.Expvar, expvar.Func(func() interface{} {
+		return h.Last()
+	}))
+}
+
+func (h *Heartbeat) interval() time.Duration {
+	if h.Interval <= 0 {
+		return defaultInterval
+	}
+	return h.Interval
+}
+
+func (h *Heartbeat) maxAge() time.Duration {
+	if h.MaxAge <= 0 {
+		return 2 * h.interval()
+	}
+	return h.MaxAge
+}
+
+func (h *Heartbeat) now() time.Time {
+	if h.Now != nil {
+		return h.Now()
+	}
+	return time.Now()
+}
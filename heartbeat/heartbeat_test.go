@@ -0,0 +1,120 @@
+package heartbeat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSource struct {
+	activity pingdom.Activity
+}
+
+func (s *fakeSource) Activity() pingdom.Activity {
+	return s.activity
+}
+
+func TestBeat(t *testing.T) {
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	source := &fakeSource{activity: pingdom.Activity{
+		Requests:       3,
+		LastSuccess:    now.Add(-time.Minute),
+		SessionValid:   true,
+		ShortRateLimit: &pingdom.RateLimit{Remaining: 394},
+		LongRateLimit:  &pingdom.RateLimit{Remaining: 71994},
+	}}
+	var published []Beat
+	h := &Heartbeat{
+		Client:  source,
+		Publish: func(b Beat) { published = append(published, b) },
+		Now:     func() time.Time { return now },
+	}
+	assert.Nil(t, h.Last())
+
+	beat := h.Beat(context.Background())
+	assert.Equal(t, now, beat.At)
+	assert.Equal(t, int64(3), beat.Requests)
+	assert.Equal(t, 394, beat.RateLimitRemaining)
+	assert.True(t, beat.Healthy)
+	assert.Equal(t, []Beat{beat}, published)
+	assert.Equal(t, &beat, h.Last())
+
+	// Stale successes and rejected credentials are unhealthy.
+	source.activity.LastSuccess = now.Add(-3 * time.Minute)
+	assert.False(t, h.Beat(context.Background()).Healthy)
+	source.activity.LastSuccess = now
+	source.activity.SessionValid = false
+	assert.False(t, h.Beat(context.Background()).Healthy)
+
+	source.activity = pingdom.Activity{}
+	assert.Equal(t, -1, h.Beat(context.Background()).RateLimitRemaining)
+}
+
+func TestBeatProbesIdleClient(t *testing.T) {
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	source := &fakeSource{activity: pingdom.Activity{LastSuccess: now.Add(-time.Hour), SessionValid: true}}
+	probes := 0
+	h := &Heartbeat{
+		Client: source,
+		Probe: func(ctx context.Context) error {
+			probes++
+			source.activity.LastSuccess = now
+			return nil
+		},
+		Now: func() time.Time { return now },
+	}
+
+	assert.True(t, h.Beat(context.Background()).Healthy)
+	assert.Equal(t, 1, probes)
+
+	// A recent success needs no probe.
+	h.Beat(context.Background())
+	assert.Equal(t, 1, probes)
+
+	// A failed probe leaves the client unhealthy.
+	source.activity.LastSuccess = now.Add(-time.Hour)
+	h.Probe = func(ctx context.Context) error { return errors.New("unreachable") }
+	assert.False(t, h.Beat(context.Background()).Healthy)
+}
+
+func TestRunPublishesExpvar(t *testing.T) {
+	source := &fakeSource{activity: pingdom.Activity{Requests: 1, LastSuccess: time.Now(), SessionValid: true}}
+	h := &Heartbeat{Client: source, Expvar: "pingdom_heartbeat_test", Interval: time.Millisecond, MaxAge: time.Hour}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, h.Run(ctx))
+
+	var beat Beat
+	assert.NoError(t, json.Unmarshal([]byte(expvar.Get("pingdom_heartbeat_test").String()), &beat))
+	assert.Equal(t, int64(1), beat.Requests)
+	assert.True(t, beat.Healthy)
+
+	// Running again does not publish the name twice.
+	assert.NotPanics(t, func() { _ = h.Run(ctx) })
+}
+
+func TestConcurrentRunsPublishExpvarOnce(t *testing.T) {
+	source := &fakeSource{activity: pingdom.Activity{Requests: 1, LastSuccess: time.Now(), SessionValid: true}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			h := &Heartbeat{Client: source, Expvar: "pingdom_heartbeat_concurrent_test"}
+			assert.NotPanics(t, func() { _ = h.Run(ctx) })
+		}()
+	}
+	wg.Wait()
+	assert.NotNil(t, expvar.Get("pingdom_heartbeat_concurrent_test"))
+}
@@ -0,0 +1,30 @@
+package atomicfile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "atomicfile")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "state.json")
+
+	assert.NoError(t, WriteFile(path, []byte("old")))
+	assert.NoError(t, WriteFile(path, []byte("new")))
+	b, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "new", string(b))
+
+	// No temporary file is left behind.
+	files, err := ioutil.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	assert.Error(t, WriteFile(filepath.Join(dir, "missing", "state.json"), []byte("x")))
+}
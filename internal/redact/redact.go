@@ -0,0 +1,187 @@
+// Package redact removes credentials, such as passwords, tokens, cookies and
+// API keys, from the strings which end up in errors and logs.
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Placeholder replaces every redacted value.
+const Placeholder = "[REDACTED]"
+
+// sensitiveKeys are matched against keys lowercased and stripped of '-' and
+// '_', so that e.g. "X-CSRF-Token", "api_key" and "apiToken" are all caught.
+var sensitiveKeys = []string{
+	"password", "passwd", "secret", "token", "apikey", "appkey", "auth",
+	"cookie", "swicus", "swisettings", "csrf", "jwt", "session",
+}
+
+// headerParamPrefix is the prefix of the query parameters carrying the request
+// headers of HTTP checks, as "Name:value".
+const headerParamPrefix = "requestheader"
+
+// IsSensitiveKey reports whether the value of a field, parameter or header
+// with the given name must not be shown.
+func IsSensitiveKey(key string) bool {
+	normalized := strings.NewReplacer("-", "", "_", "").Replace(strings.ToLower(key))
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(normalized, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// Values returns a copy of the values with the sensitive ones redacted.
+func Values(values url.Values) url.Values {
+	redacted := make(url.Values, len(values))
+	for key, vs := range values {
+		copied := make([]string, len(vs))
+		for i, v := range vs {
+			switch {
+			case IsSensitiveKey(key):
+				copied[i] = Placeholder
+			case strings.HasPrefix(strings.ToLower(key), headerParamPrefix):
+				copied[i] = headerParam(v)
+			default:
+				copied[i] = v
+			}
+		}
+		redacted[key] = copied
+	}
+	return redacted
+}
+
+func headerParam(v string) string {
+	parts := strings.SplitN(v, ":", 2)
+	if len(parts) == 2 && IsSensitiveKey(parts[0]) {
+		return parts[0] + ":" + Placeholder
+	}
+	return v
+}
+
+// URL redacts the user info and the sensitive query parameters of the URL.
+// Anything which can not be parsed as a URL is returned unchanged.
+func URL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	if u.User != nil {
+		u.User = url.User(Placeholder)
+	}
+	if u.RawQuery != "" {
+		u.RawQuery = Values(u.Query()).Encode()
+	}
+	return u.String()
+}
+
+// Header returns a copy of the header with the sensitive values redacted,
+// e.g. for logging requests.
+func Header(header http.Header) http.Header {
+	redacted := make(http.Header, len(header))
+	for key, vs := range header {
+		copied := make([]string, len(vs))
+		for i, v := range vs {
+			if IsSensitiveKey(key) {
+				copied[i] = Placeholder
+			} else {
+				copied[i] = v
+			}
+		}
+		redacted[key] = copied
+	}
+	return redacted
+}
+
+// JSON redacts the values of the sensitive fields of a JSON document. Anything
+// which is not valid JSON is returned unchanged.
+func JSON(doc []byte) []byte {
+	var root interface{}
+	decoder := json.NewDecoder(bytes.NewReader(doc))
+	decoder.UseNumber()
+	if err := decoder.Decode(&root); err != nil {
+		return doc
+	}
+	redacted, err := json.Marshal(jsonValue(root))
+	if err != nil {
+		return doc
+	}
+	return redacted
+}
+
+func jsonValue(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, elem := range value {
+			if IsSensitiveKey(key) {
+				value[key] = Placeholder
+			} else {
+				value[key] = jsonValue(elem)
+			}
+		}
+	case []interface{}:
+		for i := range value {
+			value[i] = jsonValue(value[i])
+		}
+	}
+	return v
+}
+
+// Error redacts the URL of a *url.Error, as returned by http.Client, which
+// would otherwise include the query parameters of the request. The returned
+// error still unwraps to the cause of the original one.
+func Error(err error) error {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return err
+	}
+	return &url.Error{Op: urlErr.Op, URL: URL(urlErr.URL), Err: urlErr.Err}
+}
+
+// Secrets are known secret values, such as the password of the current user,
+// to be removed wherever they appear.
+type Secrets []string
+
+// String replaces every occurrence of the secrets in s.
+func (s Secrets) String(str string) string {
+	for _, secret := range s {
+		if secret != "" {
+			str = strings.Replace(str, secret, Placeholder, -1)
+		}
+	}
+	return str
+}
+
+// Error returns err with every occurrence of the secrets removed from its
+// message, and its URL redacted as by Error. The returned error still unwraps
+// to err.
+func (s Secrets) Error(err error) error {
+	if err == nil {
+		return nil
+	}
+	err = Error(err)
+	msg := s.String(err.Error())
+	if msg == err.Error() {
+		return err
+	}
+	return &redactedError{msg: msg, err: err}
+}
+
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
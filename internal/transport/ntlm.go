@@ -0,0 +1,295 @@
+package transport
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+// The flags of the NTLM messages: Unicode strings, NTLM with extended
+// session security, 128 and 56 bit keys, and the target info requested.
+const (
+	ntlmNegotiateUnicode    = 0x00000001
+	ntlmRequestTarget       = 0x00000004
+	ntlmNegotiateNTLM       = 0x00000200
+	ntlmAlwaysSign          = 0x00008000
+	ntlmExtendedSessionSec  = 0x00080000
+	ntlmNegotiate128        = 0x20000000
+	ntlmNegotiate56         = 0x80000000
+	ntlmFlags               = ntlmNegotiateUnicode | ntlmRequestTarget | ntlmNegotiateNTLM | ntlmAlwaysSign | ntlmExtendedSessionSec | ntlmNegotiate128 | ntlmNegotiate56
+	ntlmAvTimestamp         = 7
+	ntlmChallengeHeaderSize = 48
+)
+
+var ntlmSignature = []byte("NTLMSSP\x00")
+
+// ntlmCredentials are those NTLMv2 authenticates with.
+type ntlmCredentials struct {
+	Domain   string
+	Username string
+	Password string
+}
+
+// ntlmNegotiate returns the NEGOTIATE_MESSAGE opening the handshake.
+func ntlmNegotiate() []byte {
+	b := make([]byte, 32)
+	copy(b, ntlmSignature)
+	binary.LittleEndian.PutUint32(b[8:], 1)
+	binary.LittleEndian.PutUint32(b[12:], ntlmFlags)
+	return b
+}
+
+// ntlmChallenge is what the CHALLENGE_MESSAGE of the server holds.
+type ntlmChallenge struct {
+	ServerChallenge []byte
+	TargetInfo      []byte
+}
+
+func parseNTLMChallenge(b []byte) (*ntlmChallenge, error) {
+	if len(b) < ntlmChallengeHeaderSize || !bytes.Equal(b[:8], ntlmSignature) || binary.LittleEndian.Uint32(b[8:]) != 2 {
+		return nil, errors.New("invalid NTLM challenge")
+	}
+	info, err := ntlmField(b, 40)
+	if err != nil {
+		return nil, err
+	}
+	return &ntlmChallenge{ServerChallenge: b[24:32], TargetInfo: info}, nil
+}
+
+// ntlmField returns the payload of the field whose length and offset are at
+// the given position of the message.
+func ntlmField(b []byte, at int) ([]byte, error) {
+	length := int(binary.LittleEndian.Uint16(b[at:]))
+	offset := int(binary.LittleEndian.Uint32(b[at+4:]))
+	if offset+length > len(b) || offset < 0 {
+		return nil, errors.New("invalid NTLM challenge")
+	}
+	return b[offset : offset+length], nil
+}
+
+// timestamp returns the MsvAvTimestamp of the target info, if any.
+func (c *ntlmChallenge) timestamp() ([]byte, bool) {
+	info := c.TargetInfo
+	for len(info) >= 4 {
+		id := binary.LittleEndian.Uint16(info)
+		length := int(binary.LittleEndian.Uint16(info[2:]))
+		if id == 0 || len(info) < 4+length {
+			break
+		}
+		if id == ntlmAvTimestamp && length == 8 {
+			return info[4:12], true
+		}
+		info = info[4+length:]
+	}
+	return nil, false
+}
+
+// ntlmAuthenticate returns the AUTHENTICATE_MESSAGE answering the challenge
+// with the NTLMv2 responses of the credentials.  now is the FILETIME of the
+// response, used when the server sends none, clientChallenge 8 random bytes.
+func ntlmAuthenticate(creds ntlmCredentials, challenge *ntlmChallenge, now uint64, clientChallenge []byte) []byte {
+	ntHash := md4Sum(utf16LE(creds.Password))
+	key := hmacMD5(ntHash[:], utf16LE(strings.ToUpper(creds.Username)+creds.Domain))
+
+	timestamp, serverTime := challenge.timestamp()
+	if !serverTime {
+		timestamp = make([]byte, 8)
+		binary.LittleEndian.PutUint64(timestamp, now)
+	}
+	var temp []byte
+	temp = append(temp, 1, 1, 0, 0, 0, 0, 0, 0)
+	temp = append(temp, timestamp...)
+	temp = append(temp, clientChallenge...)
+	temp = append(temp, 0, 0, 0, 0)
+	temp = append(temp, challenge.TargetInfo...)
+	temp = append(temp, 0, 0, 0, 0)
+	ntProof := hmacMD5(key, append(append([]byte(nil), challenge.ServerChallenge...), temp...))
+	nt := append(ntProof, temp...)
+
+	// With a timestamp of the server, the LMv2 response must be empty.
+	lm := make([]byte, 24)
+	if !serverTime {
+		lm = append(hmacMD5(key, append(append([]byte(nil), challenge.ServerChallenge...), clientChallenge...)), clientChallenge...)
+	}
+
+	fields := [][]byte{lm, nt, utf16LE(creds.Domain), utf16LE(creds.Username), nil, nil}
+	b := make([]byte, 64)
+	copy(b, ntlmSignature)
+	binary.LittleEndian.PutUint32(b[8:], 3)
+	for i, field := range fields {
+		at := 12 + 8*i
+		binary.LittleEndian.PutUint16(b[at:], uint16(len(field)))
+		binary.LittleEndian.PutUint16(b[at+2:], uint16(len(field)))
+		binary.LittleEndian.PutUint32(b[at+4:], uint32(len(b)))
+		b = append(b, field...)
+	}
+	binary.LittleEndian.PutUint32(b[60:], ntlmFlags)
+	return b
+}
+
+// ntlmFiletime returns t as a FILETIME, in tenths of microseconds since 1601.
+func ntlmFiletime(t time.Time) uint64 {
+	return uint64(t.UnixNano()/100 + 116444736000000000)
+}
+
+func utf16LE(s string) []byte {
+	units := utf16.Encode([]rune(s))
+	b := make([]byte, 2*len(units))
+	for i, u := range units {
+		binary.LittleEndian.PutUint16(b[2*i:], u)
+	}
+	return b
+}
+
+func hmacMD5(key, data []byte) []byte {
+	mac := hmac.New(md5.New, key)
+	_, _ = mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// ntlmDialer opens the connections of a transport through CONNECT tunnels of
+// a proxy asking for NTLM, which authenticates connections rather than
+// requests, so that net/http cannot answer it itself.
+type ntlmDialer struct {
+	proxy  *url.URL
+	creds  ntlmCredentials
+	header http.Header
+	dial   func(ctx context.Context, network, addr string) (net.Conn, error)
+}
+
+// DialContext returns a connection tunnelled to addr.
+func (d *ntlmDialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	proxyAddr := d.proxy.Host
+	if d.proxy.Port() == "" {
+		port := "80"
+		if d.proxy.Scheme == "https" {
+			port = "443"
+		}
+		proxyAddr = net.JoinHostPort(d.proxy.Hostname(), port)
+	}
+	conn, err := d.dial(ctx, network, proxyAddr)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	if d.proxy.Scheme == "https" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: d.proxy.Hostname()})
+		if err := tlsConn.Handshake(); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		conn = tlsConn
+	}
+	tunnel, err := d.connect(conn, addr)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	_ = conn.SetDeadline(time.Time{})
+	return tunnel, nil
+}
+
+// connect opens the tunnel on conn, negotiating NTLM when the proxy asks
+// for it.
+func (d *ntlmDialer) connect(conn net.Conn, addr string) (net.Conn, error) {
+	br := bufio.NewReader(conn)
+	resp, err := d.roundTrip(conn, br, addr, ntlmNegotiate())
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusProxyAuthRequired {
+		challenge, err := proxyNTLMChallenge(resp)
+		if err != nil {
+			return nil, err
+		}
+		clientChallenge := make([]byte, 8)
+		if _, err := rand.Read(clientChallenge); err != nil {
+			return nil, err
+		}
+		resp, err = d.roundTrip(conn, br, addr, ntlmAuthenticate(d.creds, challenge, ntlmFiletime(time.Now()), clientChallenge))
+		if err != nil {
+			return nil, err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("proxy refused the tunnel to %s: %s", addr, resp.Status)
+	}
+	if br.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, r: br}, nil
+	}
+	return conn, nil
+}
+
+// roundTrip sends a CONNECT request with the NTLM message and reads the
+// answer, whose body is drained so that the connection can be reused.
+func (d *ntlmDialer) roundTrip(conn net.Conn, br *bufio.Reader, addr string, msg []byte) (*http.Response, error) {
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: d.header.Clone(),
+	}
+	if req.Header == nil {
+		req.Header = http.Header{}
+	}
+	req.Header.Set("Proxy-Authorization", "NTLM "+base64.StdEncoding.EncodeToString(msg))
+	req.Header.Set("Proxy-Connection", "Keep-Alive")
+	if err := req.Write(conn); err != nil {
+		return nil, err
+	}
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+	}
+	return resp, nil
+}
+
+// proxyNTLMChallenge returns the NTLM challenge of a 407 answer.
+func proxyNTLMChallenge(resp *http.Response) (*ntlmChallenge, error) {
+	if resp.Close {
+		return nil, errors.New("proxy closed the connection during the NTLM handshake")
+	}
+	for _, value := range resp.Header.Values("Proxy-Authenticate") {
+		if !strings.HasPrefix(value, "NTLM ") {
+			continue
+		}
+		b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value[len("NTLM "):]))
+		if err != nil {
+			return nil, errors.New("invalid NTLM challenge")
+		}
+		return parseNTLMChallenge(b)
+	}
+	return nil, errors.New("proxy rejected the NTLM credentials")
+}
+
+// bufferedConn reads what the reader of the handshake buffered first.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
@@ -0,0 +1,106 @@
+package transport
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// The authentication schemes of a proxy.
+const (
+	ProxyAuthBasic = "basic"
+	ProxyAuthNTLM  = "ntlm"
+)
+
+// ProxyConfig routes the requests of a client through a proxy instead of the
+// one of the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+//
+// URL is the address of the proxy, e.g. "http://proxy.example.com:3128", and
+// may hold the credentials, which Username and Password override.
+// ConnectHeader adds headers to the CONNECT requests only.
+//
+// Auth is the authentication scheme, ProxyAuthBasic by default, with which
+// the credentials are sent in the Proxy-Authorization header of plain HTTP
+// requests and of the CONNECT requests opening the tunnels to HTTPS
+// endpoints. With ProxyAuthNTLM, which needs an http or https proxy, each
+// connection is authenticated with an NTLMv2 handshake instead, and every
+// request goes through a CONNECT tunnel, plain HTTP ones included. The
+// domain of the account is Domain, or the one of a "DOMAIN\user" Username.
+// Negotiate (Kerberos) is not supported.
+type ProxyConfig struct {
+	URL           string
+	Username      string
+	Password      string
+	Domain        string
+	Auth          string
+	ConnectHeader http.Header
+}
+
+// Apply sets the proxy of t. It fails if the URL is not an absolute http,
+// https or socks5 URL, or if the authentication scheme is unknown.
+func (c *ProxyConfig) Apply(t *http.Transport) error {
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		// Not the error itself, which quotes the URL and its password.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("invalid proxy URL: %v", err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return fmt.Errorf("invalid proxy URL %q, the scheme must be http, https or socks5", u.Redacted())
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid proxy URL %q, the host is missing", u.Redacted())
+	}
+	if c.Username != "" {
+		u.User = url.UserPassword(c.Username, c.Password)
+	}
+	switch c.Auth {
+	case "", ProxyAuthBasic:
+	case ProxyAuthNTLM:
+		if u.Scheme == "socks5" {
+			return fmt.Errorf("invalid proxy URL %q, NTLM needs an http or https proxy", u.Redacted())
+		}
+		return c.applyNTLM(t, u)
+	default:
+		return fmt.Errorf("invalid proxy authentication %q, must be %q or %q", c.Auth, ProxyAuthBasic, ProxyAuthNTLM)
+	}
+	t.Proxy = http.ProxyURL(u)
+	if len(c.ConnectHeader) > 0 {
+		t.ProxyConnectHeader = c.ConnectHeader.Clone()
+	}
+	return nil
+}
+
+// applyNTLM makes t open its connections through the NTLM tunnels of the
+// proxy at u, whose user info are the credentials.
+func (c *ProxyConfig) applyNTLM(t *http.Transport, u *url.URL) error {
+	creds := ntlmCredentials{Domain: c.Domain}
+	if u.User != nil {
+		creds.Username = u.User.Username()
+		creds.Password, _ = u.User.Password()
+	}
+	if i := strings.IndexByte(creds.Username, '\\'); i >= 0 && creds.Domain == "" {
+		creds.Domain, creds.Username = creds.Username[:i], creds.Username[i+1:]
+	}
+	if creds.Username == "" {
+		return errors.New("invalid proxy configuration, NTLM needs a username")
+	}
+	dial := t.DialContext
+	if dial == nil {
+		dial = (&net.Dialer{Timeout: defaultDialTimeout}).DialContext
+	}
+	proxy := *u
+	proxy.User = nil
+	d := &ntlmDialer{proxy: &proxy, creds: creds, header: c.ConnectHeader.Clone(), dial: dial}
+	t.Proxy = nil
+	t.DialContext = d.DialContext
+	return nil
+}
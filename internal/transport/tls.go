@@ -0,0 +1,109 @@
+package transport
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrCertificateNotPinned is wrapped by the errors of the TLS handshakes
+// whose certificate chain matches none of the pins of a TLSConfig.
+var ErrCertificateNotPinned = errors.New("certificate chain matches no pin")
+
+// TLSConfig hardens the TLS connections made by a client.
+//
+// MinVersion is the lowest TLS version accepted, e.g. tls.VersionTLS12, the
+// default of crypto/tls applies when zero.
+//
+// PinnedPublicKeys and PinnedCertificates are the base64 encoded SHA-256
+// digests of the DER encoded public keys (SubjectPublicKeyInfo, as in the
+// pin-sha256 of RFC 7469) and of the certificates accepted. When any pin is
+// set, a connection is only made if a certificate of the verified chain
+// matches one of them; the usual verification still applies. The pin of a
+// public key can be computed with:
+//
+//	openssl x509 -in cert.pem -pubkey -noout | openssl pkey -pubin -outform der | openssl dgst -sha256 -binary | base64
+type TLSConfig struct {
+	MinVersion         uint16
+	PinnedPublicKeys   []string
+	PinnedCertificates []string
+}
+
+// Apply sets up the TLS client configuration of t, keeping what is already
+// configured. It fails if the minimum version is unknown or a pin is not a
+// base64 encoded SHA-256 digest.
+func (c *TLSConfig) Apply(t *http.Transport) error {
+	switch c.MinVersion {
+	case 0, tls.VersionTLS10, tls.VersionTLS11, tls.VersionTLS12, tls.VersionTLS13:
+	default:
+		return fmt.Errorf("unknown minimum TLS version %#04x", c.MinVersion)
+	}
+	keys, err := decodePins(c.PinnedPublicKeys)
+	if err != nil {
+		return err
+	}
+	certs, err := decodePins(c.PinnedCertificates)
+	if err != nil {
+		return err
+	}
+
+	config := &tls.Config{}
+	if t.TLSClientConfig != nil {
+		config = t.TLSClientConfig.Clone()
+	}
+	if c.MinVersion != 0 {
+		config.MinVersion = c.MinVersion
+	}
+	if len(keys) > 0 || len(certs) > 0 {
+		config.VerifyConnection = func(cs tls.ConnectionState) error {
+			return verifyPins(cs, keys, certs)
+		}
+	}
+	t.TLSClientConfig = config
+	return nil
+}
+
+func decodePins(pins []string) ([][]byte, error) {
+	var digests [][]byte
+	for _, pin := range pins {
+		digest, err := base64.StdEncoding.DecodeString(pin)
+		if err != nil || len(digest) != sha256.Size {
+			return nil, fmt.Errorf("invalid pin %q, must be a base64 encoded SHA-256 digest", pin)
+		}
+		digests = append(digests, digest)
+	}
+	return digests, nil
+}
+
+// verifyPins checks the certificates of the verified chains, or those sent
+// by the server if verification is turned off.
+func verifyPins(cs tls.ConnectionState, keys, certs [][]byte) error {
+	chains := cs.VerifiedChains
+	if len(chains) == 0 {
+		chains = [][]*x509.Certificate{cs.PeerCertificates}
+	}
+	for _, chain := range chains {
+		for _, cert := range chain {
+			key := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+			raw := sha256.Sum256(cert.Raw)
+			if matchPin(keys, key[:]) || matchPin(certs, raw[:]) {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("%s: %w", cs.ServerName, ErrCertificateNotPinned)
+}
+
+func matchPin(pins [][]byte, digest []byte) bool {
+	for _, pin := range pins {
+		if bytes.Equal(pin, digest) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package transport
+
+import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func pinnedClient(t *testing.T, server *httptest.Server, config TLSConfig) *http.Client {
+	rt := NewTransport(Timeouts{})
+	// Trust the certificate of the test server.
+	rt.TLSClientConfig = server.Client().Transport.(*http.Transport).TLSClientConfig
+	assert.NoError(t, config.Apply(rt))
+	return &http.Client{Transport: rt}
+}
+
+func TestTLSConfigPins(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	key := sha256.Sum256(server.Certificate().RawSubjectPublicKeyInfo)
+	cert := sha256.Sum256(server.Certificate().Raw)
+	other := base64.StdEncoding.EncodeToString(make([]byte, sha256.Size))
+
+	for _, config := range []TLSConfig{
+		{},
+		{PinnedPublicKeys: []string{other, base64.StdEncoding.EncodeToString(key[:])}},
+		{PinnedCertificates: []string{base64.StdEncoding.EncodeToString(cert[:])}},
+	} {
+		resp, err := pinnedClient(t, server, config).Get(server.URL)
+		if assert.NoError(t, err) {
+			resp.Body.Close()
+		}
+	}
+
+	_, err := pinnedClient(t, server, TLSConfig{PinnedPublicKeys: []string{other}}).Get(server.URL)
+	assert.True(t, errors.Is(err, ErrCertificateNotPinned), "unexpected error: %v", err)
+}
+
+func TestTLSConfigMinVersion(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	server.StartTLS()
+	defer server.Close()
+
+	_, err := pinnedClient(t, server, TLSConfig{MinVersion: tls.VersionTLS13}).Get(server.URL)
+	assert.Error(t, err)
+
+	resp, err := pinnedClient(t, server, TLSConfig{MinVersion: tls.VersionTLS12}).Get(server.URL)
+	if assert.NoError(t, err) {
+		resp.Body.Close()
+	}
+}
+
+func TestTLSConfigInvalid(t *testing.T) {
+	rt := NewTransport(Timeouts{})
+	assert.EqualError(t, (&TLSConfig{MinVersion: 0x0200}).Apply(rt), "unknown minimum TLS version 0x0200")
+	assert.EqualError(t, (&TLSConfig{PinnedPublicKeys: []string{"c2hvcnQ="}}).Apply(rt),
+		`invalid pin "c2hvcnQ=", must be a base64 encoded SHA-256 digest`)
+	assert.EqualError(t, (&TLSConfig{PinnedCertificates: []string{"%%%"}}).Apply(rt),
+		`invalid pin "%%%", must be a base64 encoded SHA-256 digest`)
+	assert.Nil(t, rt.TLSClientConfig)
+}
@@ -0,0 +1,252 @@
+// Package transport builds the HTTP transports shared by the clients of this
+// module, and tells apart the different kinds of timeouts of a request.
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Phases of a request a timeout can occur in.
+const (
+	PhaseDial    = "dial"
+	PhaseTLS     = "tls"
+	PhaseHeader  = "header"
+	PhaseBody    = "body"
+	PhaseOverall = "overall"
+)
+
+const (
+	defaultDialTimeout = 30 * time.Second
+	defaultTLSTimeout  = 10 * time.Second
+)
+
+// Timeouts limits the time spent in each phase of a request. Dial and
+// TLSHandshake default to the values of http.DefaultTransport, the other
+// phases are not limited unless set.
+type Timeouts struct {
+	Dial           time.Duration // Establishing the TCP connection
+	TLSHandshake   time.Duration // Negotiating TLS once connected
+	ResponseHeader time.Duration // Waiting for the response headers once the request is written
+	BodyRead       time.Duration // Reading the whole response body once the headers arrived
+	Overall        time.Duration // The whole request, from dialing to reading the last byte of the body
+}
+
+// TimeoutError is returned when a request times out, Phase tells which of the
+// Timeouts has been exceeded.
+type TimeoutError struct {
+	Phase string
+	Limit time.Duration // The configured timeout of the phase, zero when its default applied
+	Err   error
+}
+
+// Error returns the string representation of the TimeoutError.
+func (e *TimeoutError) Error() string {
+	if e.Limit > 0 {
+		return fmt.Sprintf("%s timeout after %v: %v", e.Phase, e.Limit, e.Err)
+	}
+	return fmt.Sprintf("%s timeout: %v", e.Phase, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// Timeout always returns true, making TimeoutError a net.Error timeout.
+func (e *TimeoutError) Timeout() bool {
+	return true
+}
+
+// Temporary always returns true, a timed out request may succeed if tried again.
+func (e *TimeoutError) Temporary() bool {
+	return true
+}
+
+// NewTransport returns a transport with the defaults of http.DefaultTransport
+// and the dial, TLS handshake and response header timeouts applied.
+func NewTransport(timeouts Timeouts) *http.Transport {
+	dial := timeouts.Dial
+	if dial <= 0 {
+		dial = defaultDialTimeout
+	}
+	tlsHandshake := timeouts.TLSHandshake
+	if tlsHandshake <= 0 {
+		tlsHandshake = defaultTLSTimeout
+	}
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   dial,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   tlsHandshake,
+		ResponseHeaderTimeout: timeouts.ResponseHeader,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+}
+
+// New returns NewTransport wrapped with WithTimeouts.
+func New(timeouts Timeouts) http.RoundTripper {
+	return WithTimeouts(NewTransport(timeouts), timeouts)
+}
+
+// Options configures the transport returned by Build, each of them is
+// optional.
+type Options struct {
+	Timeouts *Timeouts
+	TLS      *TLSConfig
+	Proxy    *ProxyConfig
+}
+
+// Build returns the transport of a client configured with the options. The
+// round tripper is only wrapped with WithTimeouts when timeouts are given.
+func Build(opts Options) (http.RoundTripper, error) {
+	var timeouts Timeouts
+	if opts.Timeouts != nil {
+		timeouts = *opts.Timeouts
+	}
+	t := NewTransport(timeouts)
+	if opts.TLS != nil {
+		if err := opts.TLS.Apply(t); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Proxy != nil {
+		if err := opts.Proxy.Apply(t); err != nil {
+			return nil, err
+		}
+	}
+	if opts.Timeouts == nil {
+		return t, nil
+	}
+	return WithTimeouts(t, timeouts), nil
+}
+
+// WithTimeouts wraps the round tripper so that the body read and overall
+// timeouts are enforced, and timeouts of any phase are returned as
+// TimeoutError. The dial, TLS and header timeouts themselves must be enforced
+// by the wrapped round tripper, see NewTransport.
+func WithTimeouts(rt http.RoundTripper, timeouts Timeouts) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &timeoutTransport{base: rt, timeouts: timeouts}
+}
+
+type timeoutTransport struct {
+	base     http.RoundTripper
+	timeouts Timeouts
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithCancel(req.Context())
+	deadline := &deadlines{cancel: cancel}
+	deadline.start(PhaseOverall, t.timeouts.Overall)
+
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		deadline.stop()
+		return nil, t.classify(err, deadline.fired())
+	}
+	deadline.start(PhaseBody, t.timeouts.BodyRead)
+	resp.Body = &timeoutBody{ReadCloser: resp.Body, transport: t, deadline: deadline}
+	return resp, nil
+}
+
+// classify turns the timeouts reported by net/http into TimeoutError.
+func (t *timeoutTransport) classify(err error, fired string) error {
+	var timeoutErr *TimeoutError
+	if errors.As(err, &timeoutErr) {
+		return err
+	}
+	switch fired {
+	case PhaseOverall:
+		return &TimeoutError{Phase: PhaseOverall, Limit: t.timeouts.Overall, Err: err}
+	case PhaseBody:
+		return &TimeoutError{Phase: PhaseBody, Limit: t.timeouts.BodyRead, Err: err}
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" && opErr.Timeout() {
+		return &TimeoutError{Phase: PhaseDial, Limit: t.timeouts.Dial, Err: err}
+	}
+	msg := err.Error()
+	if strings.Contains(msg, "TLS handshake timeout") {
+		return &TimeoutError{Phase: PhaseTLS, Limit: t.timeouts.TLSHandshake, Err: err}
+	}
+	if strings.Contains(msg, "timeout awaiting response headers") {
+		return &TimeoutError{Phase: PhaseHeader, Limit: t.timeouts.ResponseHeader, Err: err}
+	}
+	return err
+}
+
+// deadlines cancels the request once the timer of a phase fires, remembering
+// which one did.
+type deadlines struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	timers []*time.Timer
+	phase  string
+}
+
+func (d *deadlines) start(phase string, timeout time.Duration) {
+	if timeout <= 0 {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.timers = append(d.timers, time.AfterFunc(timeout, func() {
+		d.mu.Lock()
+		if d.phase == "" {
+			d.phase = phase
+		}
+		d.mu.Unlock()
+		d.cancel()
+	}))
+}
+
+func (d *deadlines) fired() string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.phase
+}
+
+func (d *deadlines) stop() {
+	d.mu.Lock()
+	for _, timer := range d.timers {
+		timer.Stop()
+	}
+	d.mu.Unlock()
+	d.cancel()
+}
+
+type timeoutBody struct {
+	io.ReadCloser
+	transport *timeoutTransport
+	deadline  *deadlines
+}
+
+func (b *timeoutBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil && err != io.EOF {
+		err = b.transport.classify(err, b.deadline.fired())
+	}
+	return n, err
+}
+
+func (b *timeoutBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.deadline.stop()
+	return err
+}
@@ -0,0 +1,115 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func phaseOf(t *testing.T, err error) string {
+	var timeoutErr *TimeoutError
+	if !assert.True(t, errors.As(err, &timeoutErr), "not a timeout error: %v", err) {
+		return ""
+	}
+	return timeoutErr.Phase
+}
+
+func TestHeaderTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: New(Timeouts{ResponseHeader: 20 * time.Millisecond})}
+	_, err := client.Get(server.URL)
+	assert.Equal(t, PhaseHeader, phaseOf(t, err))
+}
+
+func TestBodyTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("partial"))
+		w.(http.Flusher).Flush()
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: New(Timeouts{BodyRead: 20 * time.Millisecond})}
+	resp, err := client.Get(server.URL)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+	_, err = ioutil.ReadAll(resp.Body)
+	assert.Equal(t, PhaseBody, phaseOf(t, err))
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestOverallTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: New(Timeouts{Overall: 20 * time.Millisecond, ResponseHeader: time.Second})}
+	_, err := client.Get(server.URL)
+	assert.Equal(t, PhaseOverall, phaseOf(t, err))
+
+	var netErr net.Error
+	assert.True(t, errors.As(err, &netErr) && netErr.Timeout())
+}
+
+func TestTLSHandshakeTimeout(t *testing.T) {
+	// Accepts connections but never answers the TLS client hello.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	client := &http.Client{Transport: New(Timeouts{TLSHandshake: 20 * time.Millisecond})}
+	_, err = client.Get("https://" + listener.Addr().String())
+	assert.Equal(t, PhaseTLS, phaseOf(t, err))
+}
+
+func TestClassifyDial(t *testing.T) {
+	tt := &timeoutTransport{timeouts: Timeouts{Dial: time.Second}}
+	err := tt.classify(&net.OpError{Op: "dial", Net: "tcp", Err: &timeoutErr{}}, "")
+	assert.Equal(t, PhaseDial, phaseOf(t, err))
+	assert.Equal(t, "dial timeout after 1s: dial tcp: i/o timeout", err.Error())
+
+	other := errors.New("connection refused")
+	assert.Equal(t, other, tt.classify(other, ""))
+}
+
+func TestNoTimeouts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: New(Timeouts{})}
+	resp, err := client.Get(server.URL)
+	assert.NoError(t, err)
+	body, err := ioutil.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.NoError(t, resp.Body.Close())
+	assert.Equal(t, "ok", string(body))
+}
+
+type timeoutErr struct{}
+
+func (timeoutErr) Error() string   { return "i/o timeout" }
+func (timeoutErr) Timeout() bool   { return true }
+func (timeoutErr) Temporary() bool { return true }
@@ -0,0 +1,365 @@
+// Package migrate copies the contacts, alerting teams, checks and maintenance
+// windows of a Pingdom account to another one, e.g. after a company merger or
+// a plan move.  The references between resources, such as the teams alerted
+// by a check or the checks covered by a maintenance window, are remapped to
+// the IDs of the copies.
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+)
+
+// ContactStore manages Pingdom contacts.  It is implemented by
+// *pingdom.ContactService.
+type ContactStore interface {
+	List(ctx context.Context) ([]pingdom.Contact, error)
+	Create(ctx context.Context, contact pingdom.ContactAPI) (*pingdom.Contact, error)
+}
+
+// TeamStore manages Pingdom alerting teams.  It is implemented by
+// *pingdom.TeamService.
+type TeamStore interface {
+	List(ctx context.Context) ([]pingdom.TeamResponse, error)
+	Create(ctx context.Context, team pingdom.TeamAPI) (*pingdom.TeamResponse, error)
+}
+
+// CheckStore manages Pingdom checks.  It is implemented by
+// *pingdom.CheckService.
+type CheckStore interface {
+	List(ctx context.Context, params ...map[string]string) ([]pingdom.CheckResponse, error)
+	Read(ctx context.Context, id int) (*pingdom.CheckResponse, error)
+	Create(ctx context.Context, check pingdom.Check) (*pingdom.CheckResponse, error)
+}
+
+// MaintenanceStore manages Pingdom maintenance windows.  It is implemented by
+// *pingdom.MaintenanceService.
+type MaintenanceStore interface {
+	List(ctx context.Context, params ...map[string]string) ([]pingdom.MaintenanceResponse, error)
+	Create(ctx context.Context, maintenance pingdom.Maintenance) (*pingdom.MaintenanceResponse, error)
+}
+
+// Account gives access to the resources of a Pingdom account.
+type Account struct {
+	Contacts     ContactStore
+	Teams        TeamStore
+	Checks       CheckStore
+	Maintenances MaintenanceStore
+}
+
+// NewAccount returns the Account accessed through the given client.
+func NewAccount(client *pingdom.Client) Account {
+	return Account{
+		Contacts:     client.Contacts,
+		Teams:        client.Teams,
+		Checks:       client.Checks,
+		Maintenances: client.Maintenances,
+	}
+}
+
+// Migrator copies the resources of an account to another.
+type Migrator struct {
+	From Account
+	To   Account
+
+	// DryRun computes the report without creating anything.  The target
+	// IDs of the report are then left to 0.
+	DryRun bool
+
+	// Now defaults to time.Now, maintenance windows which are over by then
+	// are not copied.
+	Now func() time.Time
+}
+
+// Item describes the copy of a single resource.  Warnings list the settings
+// which could not be carried over, Skipped why the resource was not copied
+// at all.
+type Item struct {
+	Name     string
+	SourceID int
+	TargetID int
+	Warnings []string
+	Skipped  string
+	Err      error
+}
+
+// Report is the outcome of a migration.
+type Report struct {
+	Contacts     []Item
+	Teams        []Item
+	Checks       []Item
+	Maintenances []Item
+}
+
+// Failed returns whether any of the copies failed.
+func (r *Report) Failed() bool {
+	for _, items := range [][]Item{r.Contacts, r.Teams, r.Checks, r.Maintenances} {
+		for _, item := range items {
+			if item.Err != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// idMap maps the IDs of the source account to the IDs of the target one.
+type idMap map[int]int
+
+// remap returns the target IDs of the given source IDs, and the source IDs
+// which have none.
+func (m idMap) remap(ids []int) (mapped []int, missing []int) {
+	for _, id := range ids {
+		if target, ok := m[id]; ok {
+			mapped = append(mapped, target)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	return mapped, missing
+}
+
+// Migrate copies contacts, teams, checks and maintenance windows, in that
+// order so that references can be remapped.  Errors listing resources abort
+// the migration; errors on individual resources are recorded in the report,
+// and references to them are dropped.
+func (m *Migrator) Migrate(ctx context.Context) (*Report, error) {
+	report := &Report{}
+	contacts, err := m.migrateContacts(ctx, report)
+	if err != nil {
+		return nil, err
+	}
+	teams, err := m.migrateTeams(ctx, report, contacts)
+	if err != nil {
+		return nil, err
+	}
+	checks, err := m.migrateChecks(ctx, report, contacts, teams)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.migrateMaintenances(ctx, report, checks); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (m *Migrator) migrateContacts(ctx context.Context, report *Report) (idMap, error) {
+	source, err := m.From.Contacts.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing source contacts: %w", err)
+	}
+	target, err := m.To.Contacts.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing target contacts: %w", err)
+	}
+	targetOwner := 0
+	for _, contact := range target {
+		if contact.Owner {
+			targetOwner = contact.ID
+		}
+	}
+
+	ids := idMap{}
+	for _, contact := range source {
+		item := Item{Name: contact.Name, SourceID: contact.ID}
+		if contact.Owner {
+			// The owner of an account can not be created, references to it
+			// are mapped to the owner of the target account.
+			item.Skipped = "account owner"
+			if targetOwner != 0 {
+				ids[contact.ID] = targetOwner
+				item.TargetID = targetOwner
+			}
+			report.Contacts = append(report.Contacts, item)
+			continue
+		}
+
+		copied := &pingdom.Contact{
+			Name:                contact.Name,
+			NotificationTargets: contact.NotificationTargets,
+			Paused:              contact.Paused,
+		}
+		if len(copied.NotificationTargets.APNS) > 0 || len(copied.NotificationTargets.AGCM) > 0 {
+			item.Warnings = append(item.Warnings, "mobile app notification targets are tied to the source account and are not copied")
+			copied.NotificationTargets.APNS = nil
+			copied.NotificationTargets.AGCM = nil
+		}
+		if !m.DryRun {
+			created, err := m.To.Contacts.Create(ctx, copied)
+			if err != nil {
+				item.Err = err
+			} else {
+				item.TargetID = created.ID
+				ids[contact.ID] = created.ID
+			}
+		}
+		report.Contacts = append(report.Contacts, item)
+	}
+	return ids, nil
+}
+
+func (m *Migrator) migrateTeams(ctx context.Context, report *Report, contacts idMap) (idMap, error) {
+	source, err := m.From.Teams.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing source teams: %w", err)
+	}
+
+	ids := idMap{}
+	for _, team := range source {
+		item := Item{Name: team.Name, SourceID: team.ID}
+		members := make([]int, 0, len(team.Members))
+		for _, member := range team.Members {
+			members = append(members, member.ID)
+		}
+		mapped, missing := contacts.remap(members)
+		if len(missing) > 0 && !m.DryRun {
+			item.Warnings = append(item.Warnings, fmt.Sprintf("members %v were not copied", missing))
+		}
+		if !m.DryRun {
+			created, err := m.To.Teams.Create(ctx, &pingdom.Team{Name: team.Name, MemberIDs: mapped})
+			if err != nil {
+				item.Err = err
+			} else {
+				item.TargetID = created.ID
+				ids[team.ID] = created.ID
+			}
+		}
+		report.Teams = append(report.Teams, item)
+	}
+	return ids, nil
+}
+
+func (m *Migrator) migrateChecks(ctx context.Context, report *Report, contacts, teams idMap) (idMap, error) {
+	source, err := m.From.Checks.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing source checks: %w", err)
+	}
+
+	ids := idMap{}
+	for _, summary := range source {
+		item := Item{Name: summary.Name, SourceID: summary.ID}
+		check, err := m.readCheck(ctx, summary.ID, contacts, teams, &item)
+		if err != nil {
+			item.Err = err
+		} else if !m.DryRun {
+			created, err := m.To.Checks.Create(ctx, check)
+			if err != nil {
+				item.Err = err
+			} else {
+				item.TargetID = created.ID
+				ids[summary.ID] = created.ID
+			}
+		}
+		report.Checks = append(report.Checks, item)
+	}
+	return ids, nil
+}
+
+// readCheck reads the full definition of a source check and remaps its
+// references to the target account.
+func (m *Migrator) readCheck(ctx context.Context, id int, contacts, teams idMap, item *Item) (pingdom.Check, error) {
+	details, err := m.From.Checks.Read(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(details.IntegrationIds) > 0 {
+		item.Warnings = append(item.Warnings, fmt.Sprintf("integrations %v are not copied", details.IntegrationIds))
+		details.IntegrationIds = nil
+	}
+	userIDs, missingUsers := contacts.remap(details.UserIds)
+	teamIDs, missingTeams := teams.remap(details.TeamIds)
+	if !m.DryRun {
+		if len(missingUsers) > 0 {
+			item.Warnings = append(item.Warnings, fmt.Sprintf("alerted contacts %v were not copied", missingUsers))
+		}
+		if len(missingTeams) > 0 {
+			item.Warnings = append(item.Warnings, fmt.Sprintf("alerted teams %v were not copied", missingTeams))
+		}
+	}
+	details.UserIds = userIDs
+	details.TeamIds = teamIDs
+	return details.ToCheck()
+}
+
+func (m *Migrator) migrateMaintenances(ctx context.Context, report *Report, checks idMap) error {
+	source, err := m.From.Maintenances.List(ctx)
+	if err != nil {
+		return fmt.Errorf("listing source maintenance windows: %w", err)
+	}
+
+	now := time.Now
+	if m.Now != nil {
+		now = m.Now
+	}
+	for _, window := range source {
+		item := Item{Name: window.Description, SourceID: window.ID}
+		if isOver(window, now()) {
+			item.Skipped = "maintenance window is over"
+			report.Maintenances = append(report.Maintenances, item)
+			continue
+		}
+
+		uptimeIDs, missing := checks.remap(window.Checks.Uptime)
+		if len(uptimeIDs) == 0 && len(missing) > 0 && !m.DryRun {
+			item.Skipped = "none of its checks were copied"
+			report.Maintenances = append(report.Maintenances, item)
+			continue
+		}
+		if len(missing) > 0 && !m.DryRun {
+			item.Warnings = append(item.Warnings, fmt.Sprintf("checks %v were not copied", missing))
+		}
+		if len(window.Checks.Tms) > 0 {
+			item.Warnings = append(item.Warnings, fmt.Sprintf("transaction checks %v are not copied", window.Checks.Tms))
+		}
+		copied := &pingdom.MaintenanceWindow{
+			Description: window.Description,
+			From:        window.From,
+			To:          window.To,
+			UptimeIDs:   joinIDs(uptimeIDs),
+		}
+		if window.RecurrenceType != "" && window.RecurrenceType != "none" {
+			copied.RecurrenceType = window.RecurrenceType
+			copied.RepeatEvery = window.RepeatEvery
+			copied.EffectiveTo = window.EffectiveTo
+		}
+		if !m.DryRun {
+			created, err := m.To.Maintenances.Create(ctx, copied)
+			if err != nil {
+				item.Err = err
+			} else {
+				item.TargetID = created.ID
+			}
+		}
+		report.Maintenances = append(report.Maintenances, item)
+	}
+	return nil
+}
+
+// isOver returns whether the maintenance window will not happen again after
+// now.
+func isOver(window pingdom.MaintenanceResponse, now time.Time) bool {
+	end := window.To
+	if window.RecurrenceType != "" && window.RecurrenceType != "none" {
+		if window.EffectiveTo == 0 {
+			return false
+		}
+		end = window.EffectiveTo
+	}
+	return end < now.Unix()
+}
+
+func joinIDs(ids []int) string {
+	sorted := append([]int(nil), ids...)
+	sort.Ints(sorted)
+	s := make([]string, len(sorted))
+	for i, id := range sorted {
+		s[i] = strconv.Itoa(id)
+	}
+	return strings.Join(s, ",")
+}
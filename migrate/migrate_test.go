@@ -0,0 +1,223 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeStore is an in-memory account, created resources get IDs from nextID.
+type fakeStore struct {
+	nextID       int
+	contacts     []pingdom.Contact
+	teams        []pingdom.TeamResponse
+	checks       []pingdom.CheckResponse
+	maintenances []pingdom.MaintenanceResponse
+
+	createdTeams        []*pingdom.Team
+	createdChecks       []pingdom.Check
+	createdMaintenances []*pingdom.MaintenanceWindow
+	createErr           error
+	listErr             error
+}
+
+func (f *fakeStore) id() int {
+	f.nextID++
+	return f.nextID
+}
+
+type fakeContacts struct{ *fakeStore }
+
+func (f fakeContacts) List(ctx context.Context) ([]pingdom.Contact, error) {
+	return f.contacts, f.listErr
+}
+
+func (f fakeContacts) Create(ctx context.Context, contact pingdom.ContactAPI) (*pingdom.Contact, error) {
+	c := *contact.(*pingdom.Contact)
+	c.ID = f.id()
+	f.contacts = append(f.contacts, c)
+	return &c, nil
+}
+
+type fakeTeams struct{ *fakeStore }
+
+func (f fakeTeams) List(ctx context.Context) ([]pingdom.TeamResponse, error) {
+	return f.teams, nil
+}
+
+func (f fakeTeams) Create(ctx context.Context, team pingdom.TeamAPI) (*pingdom.TeamResponse, error) {
+	f.createdTeams = append(f.createdTeams, team.(*pingdom.Team))
+	return &pingdom.TeamResponse{ID: f.id()}, nil
+}
+
+type fakeChecks struct{ *fakeStore }
+
+func (f fakeChecks) List(ctx context.Context, params ...map[string]string) ([]pingdom.CheckResponse, error) {
+	return f.checks, nil
+}
+
+func (f fakeChecks) Read(ctx context.Context, id int) (*pingdom.CheckResponse, error) {
+	for _, check := range f.checks {
+		if check.ID == id {
+			return &check, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (f fakeChecks) Create(ctx context.Context, check pingdom.Check) (*pingdom.CheckResponse, error) {
+	if f.createErr != nil {
+		return nil, f.createErr
+	}
+	f.createdChecks = append(f.createdChecks, check)
+	return &pingdom.CheckResponse{ID: f.id()}, nil
+}
+
+type fakeMaintenances struct{ *fakeStore }
+
+func (f fakeMaintenances) List(ctx context.Context, params ...map[string]string) ([]pingdom.MaintenanceResponse, error) {
+	return f.maintenances, nil
+}
+
+func (f fakeMaintenances) Create(ctx context.Context, maintenance pingdom.Maintenance) (*pingdom.MaintenanceResponse, error) {
+	f.createdMaintenances = append(f.createdMaintenances, maintenance.(*pingdom.MaintenanceWindow))
+	return &pingdom.MaintenanceResponse{ID: f.id()}, nil
+}
+
+func (f *fakeStore) account() Account {
+	return Account{
+		Contacts:     fakeContacts{f},
+		Teams:        fakeTeams{f},
+		Checks:       fakeChecks{f},
+		Maintenances: fakeMaintenances{f},
+	}
+}
+
+var now = time.Date(2021, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func newSource() *fakeStore {
+	return &fakeStore{
+		contacts: []pingdom.Contact{
+			{ID: 1, Name: "Owner", Owner: true},
+			{ID: 2, Name: "Alice", NotificationTargets: pingdom.NotificationTargets{
+				Email: []pingdom.EmailNotification{{Address: "alice@example.com", Severity: "HIGH"}},
+				APNS:  []pingdom.APNSNotification{{Device: "device", Severity: "HIGH"}},
+			}},
+			{ID: 3, Name: "Bob"},
+		},
+		teams: []pingdom.TeamResponse{
+			{ID: 10, Name: "Ops", Members: []pingdom.TeamMemberResponse{{ID: 2}, {ID: 3}}},
+		},
+		checks: []pingdom.CheckResponse{
+			{
+				ID:             20,
+				Name:           "web",
+				Hostname:       "example.com",
+				Resolution:     5,
+				Type:           pingdom.CheckResponseType{Name: "http", HTTP: &pingdom.CheckResponseHTTPDetails{Url: "/"}},
+				TeamIds:        []int{10},
+				UserIds:        []int{1, 3},
+				IntegrationIds: []int{99},
+			},
+			{ID: 21, Name: "login", Type: pingdom.CheckResponseType{Name: "transaction"}},
+		},
+		maintenances: []pingdom.MaintenanceResponse{
+			{
+				ID:             30,
+				Description:    "weekly",
+				From:           now.Unix() - 3600,
+				To:             now.Unix(),
+				RecurrenceType: "week",
+				RepeatEvery:    1,
+				Checks:         pingdom.MaintenanceCheckResponse{Uptime: []int{20, 21}, Tms: []int{5}},
+			},
+			{ID: 31, Description: "past", From: now.Unix() - 7200, To: now.Unix() - 3600, RecurrenceType: "none"},
+		},
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	source := newSource()
+	target := &fakeStore{nextID: 100, contacts: []pingdom.Contact{{ID: 50, Name: "New owner", Owner: true}}}
+	migrator := &Migrator{From: source.account(), To: target.account(), Now: func() time.Time { return now }}
+
+	report, err := migrator.Migrate(context.Background())
+	assert.NoError(t, err)
+	assert.True(t, report.Failed())
+
+	assert.Equal(t, []Item{
+		{Name: "Owner", SourceID: 1, TargetID: 50, Skipped: "account owner"},
+		{Name: "Alice", SourceID: 2, TargetID: 101, Warnings: []string{"mobile app notification targets are tied to the source account and are not copied"}},
+		{Name: "Bob", SourceID: 3, TargetID: 102},
+	}, report.Contacts)
+	assert.Equal(t, pingdom.NotificationTargets{
+		Email: []pingdom.EmailNotification{{Address: "alice@example.com", Severity: "HIGH"}},
+	}, target.contacts[1].NotificationTargets)
+
+	assert.Equal(t, []Item{{Name: "Ops", SourceID: 10, TargetID: 103}}, report.Teams)
+	assert.Equal(t, []*pingdom.Team{{Name: "Ops", MemberIDs: []int{101, 102}}}, target.createdTeams)
+
+	if assert.Len(t, report.Checks, 2) {
+		assert.Equal(t, Item{Name: "web", SourceID: 20, TargetID: 104, Warnings: []string{"integrations [99] are not copied"}}, report.Checks[0])
+		assert.EqualError(t, report.Checks[1].Err, `unsupported check type "transaction"`)
+	}
+	if assert.Len(t, target.createdChecks, 1) {
+		check := target.createdChecks[0].(*pingdom.HttpCheck)
+		assert.Equal(t, []int{103}, check.TeamIds)
+		assert.Equal(t, []int{50, 102}, check.UserIds)
+		assert.Nil(t, check.IntegrationIds)
+	}
+
+	assert.Equal(t, []Item{
+		{Name: "weekly", SourceID: 30, TargetID: 105, Warnings: []string{"checks [21] were not copied", "transaction checks [5] are not copied"}},
+		{Name: "past", SourceID: 31, Skipped: "maintenance window is over"},
+	}, report.Maintenances)
+	assert.Equal(t, []*pingdom.MaintenanceWindow{{
+		Description:    "weekly",
+		From:           now.Unix() - 3600,
+		To:             now.Unix(),
+		RecurrenceType: "week",
+		RepeatEvery:    1,
+		UptimeIDs:      "104",
+	}}, target.createdMaintenances)
+}
+
+func TestMigrateDryRun(t *testing.T) {
+	target := &fakeStore{}
+	migrator := &Migrator{From: newSource().account(), To: target.account(), DryRun: true, Now: func() time.Time { return now }}
+
+	report, err := migrator.Migrate(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, report.Contacts, 3)
+	assert.Equal(t, Item{Name: "web", SourceID: 20, Warnings: []string{"integrations [99] are not copied"}}, report.Checks[0])
+	assert.Equal(t, Item{Name: "weekly", SourceID: 30, Warnings: []string{"transaction checks [5] are not copied"}}, report.Maintenances[0])
+	assert.Empty(t, target.contacts)
+	assert.Empty(t, target.createdTeams)
+	assert.Empty(t, target.createdChecks)
+	assert.Empty(t, target.createdMaintenances)
+}
+
+func TestMigrateErrors(t *testing.T) {
+	source := newSource()
+	source.listErr = errors.New("boom")
+	_, err := (&Migrator{From: source.account(), To: (&fakeStore{}).account()}).Migrate(context.Background())
+	assert.EqualError(t, err, "listing source contacts: boom")
+
+	target := &fakeStore{createErr: errors.New("quota exceeded")}
+	report, err := (&Migrator{From: newSource().account(), To: target.account(), Now: func() time.Time { return now }}).Migrate(context.Background())
+	assert.NoError(t, err)
+	assert.EqualError(t, report.Checks[0].Err, "quota exceeded")
+	assert.Equal(t, "none of its checks were copied", report.Maintenances[0].Skipped)
+	assert.Empty(t, target.createdMaintenances)
+}
+
+func TestIsOver(t *testing.T) {
+	assert.True(t, isOver(pingdom.MaintenanceResponse{To: now.Unix() - 1}, now))
+	assert.False(t, isOver(pingdom.MaintenanceResponse{To: now.Unix() + 1}, now))
+	assert.False(t, isOver(pingdom.MaintenanceResponse{To: now.Unix() - 1, RecurrenceType: "day"}, now))
+	assert.True(t, isOver(pingdom.MaintenanceResponse{To: now.Unix() - 10, RecurrenceType: "day", EffectiveTo: now.Unix() - 1}, now))
+}
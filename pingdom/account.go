@@ -0,0 +1,88 @@
+package pingdom
+
+import "context"
+
+import "fmt"
+
+// AccountService provides an interface to the users of a Pingdom account,
+// i.e. everyone who can be notified when an alert fires.  Pingdom 3.1 no
+// longer exposes a dedicated users endpoint, so users are derived from the
+// alerting contacts.
+type AccountService struct {
+	client *Client
+}
+
+// Users returns every user of the account together with the addresses they
+// can be alerted on.
+func (as *AccountService) Users(ctx context.Context) ([]AccountUser, error) {
+	contacts, err := as.client.Contacts.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]AccountUser, 0, len(contacts))
+	for _, contact := range contacts {
+		users = append(users, newAccountUser(contact))
+	}
+	return users, nil
+}
+
+// Owner returns the owner of the account.
+func (as *AccountService) Owner(ctx context.Context) (*AccountUser, error) {
+	users, err := as.Users(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range users {
+		if users[i].Owner {
+			return &users[i], nil
+		}
+	}
+	return nil, fmt.Errorf("account has no owner contact")
+}
+
+// Recipients returns the users that currently receive alerts, that is users
+// which are not paused and have at least one notification target.
+func (as *AccountService) Recipients(ctx context.Context) ([]AccountUser, error) {
+	users, err := as.Users(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	recipients := []AccountUser{}
+	for _, user := range users {
+		if !user.Paused && (len(user.Emails) > 0 || len(user.Phones) > 0 || user.Devices > 0) {
+			recipients = append(recipients, user)
+		}
+	}
+	return recipients, nil
+}
+
+// Credits returns the remaining check and SMS credits of the account.
+func (as *AccountService) Credits(ctx context.Context) (*Credits, error) {
+	req, err := as.client.NewRequest("GET", "/credits", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &creditsJSONResponse{}
+	_, err = as.client.Do(req.WithContext(ctx), m)
+	if err != nil {
+		return nil, err
+	}
+	return &m.Credits, nil
+}
+
+// EnsureCheckCredits returns a *CheckLimitError when the account cannot have
+// n more checks, e.g. to fail fast before creating a batch of checks.
+func (as *AccountService) EnsureCheckCredits(ctx context.Context, n int) error {
+	credits, err := as.Credits(ctx)
+	if err != nil {
+		return err
+	}
+	if n > credits.AvailableChecks {
+		return &CheckLimitError{Requested: n, Available: credits.AvailableChecks, Limit: credits.CheckLimit}
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package pingdom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const accountContactsResponse = `{
+	"contacts": [
+		{
+			"id": 1,
+			"name": "John Doe",
+			"paused": false,
+			"type": "user",
+			"owner": true,
+			"notification_targets": {
+				"email": [
+					{
+						"severity": "HIGH",
+						"address": "johndoe@teamrocket.com"
+					}
+				],
+				"sms": [
+					{
+						"severity": "HIGH",
+						"country_code": "46",
+						"number": "111111111",
+						"provider": "provider's name"
+					}
+				]
+			},
+			"teams": [
+				{
+					"id": 123456,
+					"name": "The Dream Team"
+				}
+			]
+		},
+		{
+			"id": 2,
+			"name": "John \"Hannibal\" Smith",
+			"paused": true,
+			"type": "user",
+			"notification_targets": {
+				"email": [
+					{
+						"severity": "HIGH",
+						"address": "hannibal@ateam.org"
+					}
+				]
+			},
+			"teams": []
+		},
+		{
+			"id": 3,
+			"name": "Nobody",
+			"paused": false,
+			"type": "user",
+			"notification_targets": {},
+			"teams": []
+		}
+	]
+}`
+
+func TestAccountServiceUsers(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/alerting/contacts", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, accountContactsResponse)
+	})
+
+	want := []AccountUser{
+		{
+			ID:     1,
+			Name:   "John Doe",
+			Type:   "user",
+			Owner:  true,
+			Emails: []string{"johndoe@teamrocket.com"},
+			Phones: []string{"+46111111111"},
+			Teams:  []ContactTeam{{ID: 123456, Name: "The Dream Team"}},
+		},
+		{
+			ID:     2,
+			Name:   "John \"Hannibal\" Smith",
+			Type:   "user",
+			Paused: true,
+			Emails: []string{"hannibal@ateam.org"},
+			Teams:  []ContactTeam{},
+		},
+		{
+			ID:    3,
+			Name:  "Nobody",
+			Type:  "user",
+			Teams: []ContactTeam{},
+		},
+	}
+
+	users, err := client.Account.Users(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, want, users)
+}
+
+func TestAccountServiceOwner(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/alerting/contacts", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, accountContactsResponse)
+	})
+
+	owner, err := client.Account.Owner(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, owner.ID)
+}
+
+func TestAccountServiceRecipients(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/alerting/contacts", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, accountContactsResponse)
+	})
+
+	recipients, err := client.Account.Recipients(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, recipients, 1)
+	assert.Equal(t, "John Doe", recipients[0].Name)
+}
+
+const creditsResponse = `{
+	"credits": {
+		"checklimit": 50,
+		"availablechecks": 2,
+		"useddefault": 46,
+		"usedtransaction": 2,
+		"availablesms": 10,
+		"availablesmstests": 100,
+		"autofillsms": false,
+		"autofillsms_amount": 0,
+		"autofillsms_when_left": 0,
+		"max_sms_overage": 0,
+		"availablerumsites": 10,
+		"usedrumsites": 0,
+		"maxrumfilters": 10,
+		"maxrumpageviews": 10000
+	}
+}`
+
+func TestAccountServiceCredits(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/credits", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, creditsResponse)
+	})
+
+	credits, err := client.Account.Credits(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, &Credits{
+		CheckLimit:        50,
+		AvailableChecks:   2,
+		UsedDefault:       46,
+		UsedTransaction:   2,
+		AvailableSMS:      10,
+		AvailableSMSTests: 100,
+		AvailableRUMSites: 10,
+		MaxRUMFilters:     10,
+		MaxRUMPageViews:   10000,
+	}, credits)
+
+	assert.NoError(t, client.Account.EnsureCheckCredits(context.Background(), 2))
+	err = client.Account.EnsureCheckCredits(context.Background(), 3)
+	assert.True(t, errors.Is(err, ErrCheckLimit))
+	assert.EqualError(t, err, "cannot add 3 checks: 2 of 50 available")
+}
@@ -0,0 +1,57 @@
+package pingdom
+
+// AccountUser represents a user of a Pingdom account as seen by the alerting
+// system.
+type AccountUser struct {
+	ID      int           `json:"id"`
+	Name    string        `json:"name"`
+	Type    string        `json:"type"`
+	Owner   bool          `json:"owner"`
+	Paused  bool          `json:"paused"`
+	Emails  []string      `json:"emails,omitempty"`
+	Phones  []string      `json:"phones,omitempty"`
+	Devices int           `json:"devices,omitempty"`
+	Teams   []ContactTeam `json:"teams,omitempty"`
+}
+
+// Credits represents the credits of a Pingdom account, i.e. how many checks
+// and SMS alerts it has left.
+type Credits struct {
+	CheckLimit          int  `json:"checklimit"`
+	AvailableChecks     int  `json:"availablechecks"`
+	UsedDefault         int  `json:"useddefault"`
+	UsedTransaction     int  `json:"usedtransaction"`
+	AvailableSMS        int  `json:"availablesms"`
+	AvailableSMSTests   int  `json:"availablesmstests"`
+	AutoFillSMS         bool `json:"autofillsms"`
+	AutoFillSMSAmount   int  `json:"autofillsms_amount"`
+	AutoFillSMSWhenLeft int  `json:"autofillsms_when_left"`
+	MaxSMSOverage       int  `json:"max_sms_overage"`
+	AvailableRUMSites   int  `json:"availablerumsites"`
+	UsedRUMSites        int  `json:"usedrumsites"`
+	MaxRUMFilters       int  `json:"maxrumfilters"`
+	MaxRUMPageViews     int  `json:"maxrumpageviews"`
+}
+
+type creditsJSONResponse struct {
+	Credits Credits `json:"credits"`
+}
+
+func newAccountUser(c Contact) AccountUser {
+	u := AccountUser{
+		ID:      c.ID,
+		Name:    c.Name,
+		Type:    c.Type,
+		Owner:   c.Owner,
+		Paused:  c.Paused,
+		Devices: len(c.NotificationTargets.APNS) + len(c.NotificationTargets.AGCM),
+		Teams:   c.Teams,
+	}
+	for _, email := range c.NotificationTargets.Email {
+		u.Emails = append(u.Emails, email.Address)
+	}
+	for _, sms := range c.NotificationTargets.SMS {
+		u.Phones = append(u.Phones, "+"+sms.CountryCode+sms.Number)
+	}
+	return u
+}
@@ -0,0 +1,117 @@
+package pingdom
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// ActionMaxLimit is the largest number of alerts Pingdom returns at once.
+const ActionMaxLimit = 300
+
+// ActionsService provides an interface to the alerts Pingdom sent.
+type ActionsService struct {
+	client *Client
+}
+
+// ActionsRequest is the API request to Pingdom for the alerts sent.  From and
+// To are unix timestamps.  Status holds any of "sent", "delivered", "error",
+// "not_delivered" and "no_credits", Via any of "email", "sms", "twitter",
+// "iphone" and "android".  Alerts are returned newest first, Limit and
+// Offset page through them.
+type ActionsRequest struct {
+	From     int
+	To       int
+	CheckIDs []int
+	UserIDs  []int
+	Status   []string
+	Via      []string
+	Limit    int
+	Offset   int
+}
+
+// Valid determines whether an ActionsRequest contains valid fields for the Pingdom API.
+func (ar ActionsRequest) Valid() error {
+	for _, status := range ar.Status {
+		switch status {
+		case "sent", "delivered", "error", "not_delivered", "no_credits":
+		default:
+			return ErrBadActionStatus
+		}
+	}
+
+	for _, via := range ar.Via {
+		switch via {
+		case "email", "sms", "twitter", "iphone", "android":
+		default:
+			return ErrBadActionVia
+		}
+	}
+
+	if ar.Limit < 0 || ar.Limit > ActionMaxLimit {
+		return ErrBadActionLimit
+	}
+	return nil
+}
+
+// GetParams returns a map of params for a Pingdom ActionsRequest.
+func (ar ActionsRequest) GetParams() (params map[string]string) {
+	params = make(map[string]string)
+
+	if ar.From != 0 {
+		params["from"] = strconv.Itoa(ar.From)
+	}
+
+	if ar.To != 0 {
+		params["to"] = strconv.Itoa(ar.To)
+	}
+
+	if len(ar.CheckIDs) > 0 {
+		params["checkids"] = intListToCDString(ar.CheckIDs)
+	}
+
+	if len(ar.UserIDs) > 0 {
+		params["userids"] = intListToCDString(ar.UserIDs)
+	}
+
+	if len(ar.Status) > 0 {
+		params["status"] = strings.Join(ar.Status, ",")
+	}
+
+	if len(ar.Via) > 0 {
+		params["via"] = strings.Join(ar.Via, ",")
+	}
+
+	if ar.Limit != 0 {
+		params["limit"] = strconv.Itoa(ar.Limit)
+	}
+
+	if ar.Offset != 0 {
+		params["offset"] = strconv.Itoa(ar.Offset)
+	}
+
+	return
+}
+
+// List returns the alerts matching the request.
+func (as *ActionsService) List(ctx context.Context, request ActionsRequest) ([]AlertResponse, error) {
+	if err := request.Valid(); err != nil {
+		return nil, err
+	}
+
+	req, err := as.client.NewRequest("GET", "/actions", request.GetParams())
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := as.client.exec(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	m := &listActionsJSONResponse{}
+	err = as.client.decodeResponse(resp, m)
+
+	return m.Actions.Alerts, err
+}
@@ -0,0 +1,228 @@
+package pingdom
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AlertFormat is a format of the events Export writes for a SIEM.
+type AlertFormat string
+
+// The formats of the exported alerts.
+const (
+	// AlertFormatCEF is the ArcSight Common Event Format, one line per alert,
+	// as ingested by e.g. Splunk or ArcSight.
+	AlertFormatCEF AlertFormat = "cef"
+
+	// AlertFormatECS is a JSON object per line following the Elastic Common
+	// Schema, as ingested by e.g. Elasticsearch or Filebeat.
+	AlertFormatECS AlertFormat = "ecs"
+)
+
+// ecsVersion is the version of the Elastic Common Schema of the ECS events.
+const ecsVersion = "8.11.0"
+
+// AlertEncoder writes alerts to a stream as SIEM events, one per line, as
+// they come, so that an export of any length is not held in memory.
+type AlertEncoder struct {
+	w      io.Writer
+	format AlertFormat
+	enc    *json.Encoder
+}
+
+// NewAlertEncoder returns an encoder writing alerts to w in the given format.
+func NewAlertEncoder(w io.Writer, format AlertFormat) (*AlertEncoder, error) {
+	switch format {
+	case AlertFormatCEF, AlertFormatECS:
+	default:
+		return nil, fmt.Errorf("invalid alert format %q, must be %q or %q", format, AlertFormatCEF, AlertFormatECS)
+	}
+	return &AlertEncoder{w: w, format: format, enc: json.NewEncoder(w)}, nil
+}
+
+// Encode writes the event of an alert.
+func (e *AlertEncoder) Encode(alert AlertResponse) error {
+	if e.format == AlertFormatECS {
+		return e.enc.Encode(ecsEvent(alert))
+	}
+	_, err := io.WriteString(e.w, cefEvent(alert)+"\n")
+	return err
+}
+
+// Export writes every alert matching the request to w in the given format,
+// newest first.  Alerts are fetched one page of request.Limit, which
+// defaults to ActionMaxLimit, at a time from request.Offset on, and written
+// straight away.
+func (as *ActionsService) Export(ctx context.Context, request ActionsRequest, w io.Writer, format AlertFormat) error {
+	enc, err := NewAlertEncoder(w, format)
+	if err != nil {
+		return err
+	}
+	if request.Limit == 0 {
+		request.Limit = ActionMaxLimit
+	}
+	for {
+		alerts, err := as.List(ctx, request)
+		if err != nil {
+			return err
+		}
+		for _, alert := range alerts {
+			if err := enc.Encode(alert); err != nil {
+				return err
+			}
+		}
+		if len(alerts) < request.Limit {
+			return nil
+		}
+		request.Offset += len(alerts)
+	}
+}
+
+// alertDelivered reports whether the alert reached its recipient, ok being
+// false when its status is unknown.
+func alertDelivered(alert AlertResponse) (delivered, ok bool) {
+	switch alert.Status {
+	case "sent", "delivered":
+		return true, true
+	case "error", "not_delivered", "no_credits":
+		return false, true
+	}
+	return false, false
+}
+
+// cefEvent returns the CEF line of an alert.  Alerts which failed to be
+// delivered have a higher severity, as someone was not told about the
+// outage.
+func cefEvent(alert AlertResponse) string {
+	severity := "3"
+	if delivered, ok := alertDelivered(alert); ok && !delivered {
+		severity = "7"
+	}
+	name := alert.MessageShort
+	if name == "" {
+		name = "alert"
+	}
+	header := []string{
+		"CEF:0", "SolarWinds", "Pingdom", "3.1",
+		cefHeader("alert-" + alert.Status), cefHeader(name), severity,
+	}
+	extension := []string{
+		"rt=" + strconv.FormatInt(int64(alert.Time)*1000, 10),
+		"cs1Label=checkId", "cs1=" + strconv.Itoa(alert.CheckID),
+		"suid=" + strconv.Itoa(alert.UserID),
+		"suser=" + cefValue(alert.UserName),
+		"duser=" + cefValue(alert.SentTo),
+		"cs2Label=via", "cs2=" + cefValue(alert.Via),
+		"outcome=" + cefValue(alert.Status),
+		"cs3Label=charged", "cs3=" + strconv.FormatBool(alert.Charged),
+		"msg=" + cefValue(alert.MessageFull),
+	}
+	return strings.Join(header, "|") + "|" + strings.Join(extension, " ")
+}
+
+var (
+	cefHeaderEscaper = strings.NewReplacer(`\`, `\\`, "|", `\|`, "\n", " ", "\r", " ")
+	cefValueEscaper  = strings.NewReplacer(`\`, `\\`, "=", `\=`, "\n", `\n`, "\r", `\r`)
+)
+
+func cefHeader(s string) string {
+	return cefHeaderEscaper.Replace(s)
+}
+
+func cefValue(s string) string {
+	return cefValueEscaper.Replace(s)
+}
+
+// ecs is the ECS event of an alert, the fields of which have no ECS
+// equivalent being under "pingdom".
+type ecs struct {
+	Timestamp string      `json:"@timestamp"`
+	Message   string      `json:"message,omitempty"`
+	ECS       ecsMeta     `json:"ecs"`
+	Event     ecsEventSet `json:"event"`
+	Observer  ecsObserver `json:"observer"`
+	User      ecsUser     `json:"user"`
+	Pingdom   ecsPingdom  `json:"pingdom"`
+}
+
+type ecsMeta struct {
+	Version string `json:"version"`
+}
+
+type ecsEventSet struct {
+	Kind     string `json:"kind"`
+	Dataset  string `json:"dataset"`
+	Provider string `json:"provider"`
+	Action   string `json:"action"`
+	Outcome  string `json:"outcome"`
+}
+
+type ecsObserver struct {
+	Vendor  string `json:"vendor"`
+	Product string `json:"product"`
+}
+
+type ecsUser struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+type ecsPingdom struct {
+	Check ecsPingdomCheck `json:"check"`
+	Alert ecsPingdomAlert `json:"alert"`
+}
+
+type ecsPingdomCheck struct {
+	ID int `json:"id"`
+}
+
+type ecsPingdomAlert struct {
+	Via          string `json:"via"`
+	Status       string `json:"status"`
+	SentTo       string `json:"sent_to,omitempty"`
+	MessageShort string `json:"message_short,omitempty"`
+	Charged      bool   `json:"charged"`
+}
+
+func ecsEvent(alert AlertResponse) ecs {
+	outcome := "unknown"
+	switch delivered, ok := alertDelivered(alert); {
+	case ok && delivered:
+		outcome = "success"
+	case ok:
+		outcome = "failure"
+	}
+	message := alert.MessageFull
+	if message == "" {
+		message = alert.MessageShort
+	}
+	return ecs{
+		Timestamp: time.Unix(int64(alert.Time), 0).UTC().Format(time.RFC3339),
+		Message:   message,
+		ECS:       ecsMeta{Version: ecsVersion},
+		Event: ecsEventSet{
+			Kind:     "alert",
+			Dataset:  "pingdom.alert",
+			Provider: "pingdom",
+			Action:   alert.Via,
+			Outcome:  outcome,
+		},
+		Observer: ecsObserver{Vendor: "SolarWinds", Product: "Pingdom"},
+		User:     ecsUser{ID: strconv.Itoa(alert.UserID), Name: alert.UserName},
+		Pingdom: ecsPingdom{
+			Check: ecsPingdomCheck{ID: alert.CheckID},
+			Alert: ecsPingdomAlert{
+				Via:          alert.Via,
+				Status:       alert.Status,
+				SentTo:       alert.SentTo,
+				MessageShort: alert.MessageShort,
+				Charged:      alert.Charged,
+			},
+		},
+	}
+}
@@ -0,0 +1,114 @@
+package pingdom
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var exportedAlert = AlertResponse{
+	UserID:       42,
+	UserName:     "Alice",
+	CheckID:      12345,
+	Time:         1563370611,
+	Via:          "sms",
+	Status:       "delivered",
+	MessageShort: "down",
+	MessageFull:  "Example is down since 13:36:51",
+	SentTo:       "+46701234567",
+	Charged:      true,
+}
+
+func TestAlertEncoderCEF(t *testing.T) {
+	var buf bytes.Buffer
+	enc, err := NewAlertEncoder(&buf, AlertFormatCEF)
+	assert.NoError(t, err)
+	assert.NoError(t, enc.Encode(exportedAlert))
+
+	failed := exportedAlert
+	failed.Status = "not_delivered"
+	failed.MessageShort = "a|b"
+	failed.MessageFull = "x=1\\2\nthree"
+	assert.NoError(t, enc.Encode(failed))
+
+	assert.Equal(t, "CEF:0|SolarWinds|Pingdom|3.1|alert-delivered|down|3|"+
+		"rt=1563370611000 cs1Label=checkId cs1=12345 suid=42 suser=Alice duser=+46701234567 cs2Label=via cs2=sms "+
+		"outcome=delivered cs3Label=charged cs3=true msg=Example is down since 13:36:51\n"+
+		"CEF:0|SolarWinds|Pingdom|3.1|alert-not_delivered|a\\|b|7|"+
+		"rt=1563370611000 cs1Label=checkId cs1=12345 suid=42 suser=Alice duser=+46701234567 cs2Label=via cs2=sms "+
+		"outcome=not_delivered cs3Label=charged cs3=true msg=x\\=1\\\\2\\nthree\n", buf.String())
+}
+
+func TestAlertEncoderECS(t *testing.T) {
+	var buf bytes.Buffer
+	enc, err := NewAlertEncoder(&buf, AlertFormatECS)
+	assert.NoError(t, err)
+	assert.NoError(t, enc.Encode(exportedAlert))
+
+	assert.JSONEq(t, `{
+		"@timestamp": "2019-07-17T13:36:51Z",
+		"message": "Example is down since 13:36:51",
+		"ecs": {"version": "8.11.0"},
+		"event": {"kind": "alert", "dataset": "pingdom.alert", "provider": "pingdom", "action": "sms", "outcome": "success"},
+		"observer": {"vendor": "SolarWinds", "product": "Pingdom"},
+		"user": {"id": "42", "name": "Alice"},
+		"pingdom": {
+			"check": {"id": 12345},
+			"alert": {"via": "sms", "status": "delivered", "sent_to": "+46701234567", "message_short": "down", "charged": true}
+		}
+	}`, buf.String())
+	assert.True(t, strings.HasSuffix(buf.String(), "}\n"))
+
+	for status, outcome := range map[string]string{"no_credits": "failure", "sent": "success", "queued": "unknown"} {
+		alert := exportedAlert
+		alert.Status = status
+		assert.Equal(t, outcome, ecsEvent(alert).Event.Outcome, status)
+	}
+
+	_, err = NewAlertEncoder(&buf, "leef")
+	assert.EqualError(t, err, `invalid alert format "leef", must be "cef" or "ecs"`)
+}
+
+func TestActionsServiceExport(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var offsets []string
+	mux.HandleFunc("/actions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "2", r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		offsets = append(offsets, r.URL.Query().Get("offset"))
+		var alerts []AlertResponse
+		for i := offset; i < 3 && i < offset+2; i++ {
+			alert := exportedAlert
+			alert.CheckID = i + 1
+			alerts = append(alerts, alert)
+		}
+		b, _ := json.Marshal(alerts)
+		fmt.Fprintf(w, `{"actions": {"alerts": %s}}`, b)
+	})
+
+	var buf bytes.Buffer
+	err := client.Actions.Export(context.Background(), ActionsRequest{Limit: 2}, &buf, AlertFormatECS)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"", "2"}, offsets)
+
+	var ids []int
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var event ecs
+		assert.NoError(t, dec.Decode(&event))
+		ids = append(ids, event.Pingdom.Check.ID)
+	}
+	assert.Equal(t, []int{1, 2, 3}, ids)
+
+	assert.Equal(t, ErrBadActionVia, client.Actions.Export(context.Background(), ActionsRequest{Via: []string{"pigeon"}}, &buf, AlertFormatCEF))
+}
@@ -0,0 +1,88 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActionsRequestValid(t *testing.T) {
+	assert.NoError(t, ActionsRequest{}.Valid())
+	assert.NoError(t, ActionsRequest{Status: []string{"sent", "no_credits"}, Via: []string{"sms"}, Limit: 300}.Valid())
+	assert.Equal(t, ErrBadActionStatus, ActionsRequest{Status: []string{"read"}}.Valid())
+	assert.Equal(t, ErrBadActionVia, ActionsRequest{Via: []string{"slack"}}.Valid())
+	assert.Equal(t, ErrBadActionLimit, ActionsRequest{Limit: 301}.Valid())
+}
+
+func TestActionsRequestGetParams(t *testing.T) {
+	assert.Equal(t, map[string]string{}, ActionsRequest{}.GetParams())
+	assert.Equal(t, map[string]string{
+		"from":     "1563370000",
+		"to":       "1563380000",
+		"checkids": "12345,12346",
+		"userids":  "42",
+		"status":   "sent,delivered",
+		"via":      "email,sms",
+		"limit":    "50",
+		"offset":   "100",
+	}, ActionsRequest{
+		From:     1563370000,
+		To:       1563380000,
+		CheckIDs: []int{12345, 12346},
+		UserIDs:  []int{42},
+		Status:   []string{"sent", "delivered"},
+		Via:      []string{"email", "sms"},
+		Limit:    50,
+		Offset:   100,
+	}.GetParams())
+}
+
+func TestActionsServiceList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/actions", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "12345", r.URL.Query().Get("checkids"))
+		fmt.Fprint(w, `{
+			"actions": {
+				"alerts": [
+					{
+						"userid": 42,
+						"username": "Alice",
+						"checkid": 12345,
+						"time": 1563370611,
+						"via": "sms",
+						"status": "delivered",
+						"messageshort": "down",
+						"messagefull": "Example is down since 13:36:51",
+						"sentto": "+46701234567",
+						"charged": true
+					}
+				]
+			}
+		}`)
+	})
+	want := []AlertResponse{{
+		UserID:       42,
+		UserName:     "Alice",
+		CheckID:      12345,
+		Time:         1563370611,
+		Via:          "sms",
+		Status:       "delivered",
+		MessageShort: "down",
+		MessageFull:  "Example is down since 13:36:51",
+		SentTo:       "+46701234567",
+		Charged:      true,
+	}}
+
+	alerts, err := client.Actions.List(context.Background(), ActionsRequest{CheckIDs: []int{12345}})
+	assert.NoError(t, err)
+	assert.Equal(t, want, alerts)
+
+	_, err = client.Actions.List(context.Background(), ActionsRequest{Via: []string{"pigeon"}})
+	assert.Equal(t, ErrBadActionVia, err)
+}
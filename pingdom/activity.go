@@ -0,0 +1,131 @@
+package pingdom
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The headers of the responses of Pingdom telling the remaining requests of
+// the account, e.g. "Remaining: 394 Time until reset: 3589".
+const (
+	shortRateLimitHeader = "Req-Limit-Short"
+	longRateLimitHeader  = "Req-Limit-Long"
+)
+
+// RateLimit is the number of requests the account may still send until the
+// limit is reset.
+type RateLimit struct {
+	Remaining int
+	ResetAt   time.Time
+}
+
+// Activity describes the requests sent by a client, so that the integration
+// itself can be monitored, e.g. by the heartbeat package.  Responses served
+// from the cache are not counted.
+type Activity struct {
+	// Requests is the number of requests sent and Failures the number of
+	// those which failed, LastError telling the last failure.
+	Requests  int64
+	Failures  int64
+	LastError string
+
+	LastSuccess time.Time
+	LastFailure time.Time
+
+	// SessionValid reports whether the credentials were accepted by the
+	// last response.  It is false before any response, after a 401 response
+	// and after the credentials could not be read, see SecretProvider.
+	SessionValid bool
+
+	// ShortRateLimit and LongRateLimit are the limits of the last response
+	// which had them, nil before.
+	ShortRateLimit *RateLimit
+	LongRateLimit  *RateLimit
+}
+
+// activity tracks the Activity of a client.
+type activity struct {
+	mu    sync.Mutex
+	state Activity
+	now   func() time.Time
+}
+
+func (a *activity) localNow() time.Time {
+	if a.now != nil {
+		return a.now()
+	}
+	return time.Now()
+}
+
+// observe records the outcome of a request: the response, if any, and the
+// error of the request or of the response.
+func (a *activity) observe(resp *http.Response, err error) {
+	now := a.localNow()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.state.Requests++
+	if resp != nil {
+		a.state.SessionValid = resp.StatusCode != http.StatusUnauthorized
+		if limit, ok := parseRateLimit(resp.Header.Get(shortRateLimitHeader), now); ok {
+			a.state.ShortRateLimit = &limit
+		}
+		if limit, ok := parseRateLimit(resp.Header.Get(longRateLimitHeader), now); ok {
+			a.state.LongRateLimit = &limit
+		}
+	}
+	if err != nil {
+		a.state.Failures++
+		a.state.LastFailure, a.state.LastError = now, err.Error()
+		return
+	}
+	a.state.LastSuccess = now
+}
+
+// sessionFailed records credentials which could not be read.
+func (a *activity) sessionFailed(err error) {
+	now := a.localNow()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.state.SessionValid = false
+	a.state.LastFailure, a.state.LastError = now, err.Error()
+}
+
+// parseRateLimit parses a rate limit header, e.g. "Remaining: 394 Time until
+// reset: 3589", the reset being in seconds.
+func parseRateLimit(header string, now time.Time) (RateLimit, bool) {
+	fields := strings.Fields(header)
+	var limit RateLimit
+	var remaining, reset bool
+	for i := 0; i+1 < len(fields); i++ {
+		n, err := strconv.Atoi(fields[i+1])
+		if err != nil {
+			continue
+		}
+		switch strings.ToLower(fields[i]) {
+		case "remaining:":
+			limit.Remaining, remaining = n, true
+		case "reset:":
+			limit.ResetAt, reset = now.Add(time.Duration(n)*time.Second), true
+		}
+	}
+	return limit, remaining && reset
+}
+
+// Activity returns the activity of the client since it was made.
+func (pc *Client) Activity() Activity {
+	pc.activity.mu.Lock()
+	defer pc.activity.mu.Unlock()
+	a := pc.activity.state
+	if a.ShortRateLimit != nil {
+		limit := *a.ShortRateLimit
+		a.ShortRateLimit = &limit
+	}
+	if a.LongRateLimit != nil {
+		limit := *a.LongRateLimit
+		a.LongRateLimit = &limit
+	}
+	return a
+}
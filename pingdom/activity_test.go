@@ -0,0 +1,84 @@
+package pingdom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActivity(t *testing.T) {
+	setup()
+	defer teardown()
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	client.activity.now = func() time.Time { return now }
+
+	assert.Equal(t, Activity{}, client.Activity())
+
+	mux.HandleFunc("/checks/1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Req-Limit-Short", "Remaining: 394 Time until reset: 3589")
+		w.Header().Set("Req-Limit-Long", "Remaining: 71994 Time until reset: 2591989")
+		fmt.Fprint(w, `{"check": {"id": 1, "name": "Test"}}`)
+	})
+	mux.HandleFunc("/checks/2", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"error": {"statuscode": 401, "statusdesc": "Unauthorized", "errormessage": "Invalid token"}}`)
+	})
+
+	_, err := client.Checks.Read(context.Background(), 1)
+	assert.NoError(t, err)
+	a := client.Activity()
+	assert.Equal(t, int64(1), a.Requests)
+	assert.Equal(t, now, a.LastSuccess)
+	assert.True(t, a.SessionValid)
+	assert.Equal(t, &RateLimit{Remaining: 394, ResetAt: now.Add(3589 * time.Second)}, a.ShortRateLimit)
+	assert.Equal(t, 71994, a.LongRateLimit.Remaining)
+
+	// The snapshot is not changed by later requests.
+	a.ShortRateLimit.Remaining = 0
+	assert.Equal(t, 394, client.Activity().ShortRateLimit.Remaining)
+
+	later := now.Add(time.Minute)
+	client.activity.now = func() time.Time { return later }
+	_, err = client.Checks.Read(context.Background(), 2)
+	assert.Error(t, err)
+	a = client.Activity()
+	assert.Equal(t, int64(2), a.Requests)
+	assert.Equal(t, int64(1), a.Failures)
+	assert.Equal(t, now, a.LastSuccess)
+	assert.Equal(t, later, a.LastFailure)
+	assert.Contains(t, a.LastError, "Invalid token")
+	assert.False(t, a.SessionValid)
+
+	// Responses without rate limits keep the last ones.
+	assert.Equal(t, 394, a.ShortRateLimit.Remaining)
+}
+
+func TestActivitySessionFailed(t *testing.T) {
+	setup()
+	defer teardown()
+	client.auth = &SecretTokenAuth{APIToken: &countingSecret{err: errors.New("vault is sealed")}}
+
+	_, err := client.Checks.Read(context.Background(), 1)
+	assert.Error(t, err)
+	a := client.Activity()
+	assert.False(t, a.SessionValid)
+	assert.Equal(t, int64(0), a.Requests)
+	assert.Contains(t, a.LastError, "vault is sealed")
+}
+
+func TestParseRateLimit(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	limit, ok := parseRateLimit("Remaining: 12 Time until reset: 60", now)
+	assert.True(t, ok)
+	assert.Equal(t, RateLimit{Remaining: 12, ResetAt: now.Add(time.Minute)}, limit)
+
+	for _, header := range []string{"", "Remaining: 12", "Remaining: many Time until reset: 60"} {
+		_, ok := parseRateLimit(header, now)
+		assert.False(t, ok, header)
+	}
+}
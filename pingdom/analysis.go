@@ -0,0 +1,55 @@
+package pingdom
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+)
+
+// AnalysisService provides an interface to the root cause analyses Pingdom
+// runs when a check goes down.
+type AnalysisService struct {
+	client *Client
+}
+
+// List returns the analyses of a check, newest first.  The params can
+// include limit, offset, from and to.
+func (as *AnalysisService) List(ctx context.Context, checkID int, params ...map[string]string) ([]AnalysisResponse, error) {
+	param := map[string]string{}
+	if len(params) == 1 {
+		param = params[0]
+	}
+	req, err := as.client.NewRequest("GET", "/analysis/"+strconv.Itoa(checkID), param)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := as.client.exec(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	m := &listAnalysisJSONResponse{}
+	err = as.client.decodeResponse(resp, m)
+
+	return m.Analysis, err
+}
+
+// RawData returns the detailed payload of an analysis of a check.  Its
+// structure depends on the type of the check and on the tests run, so it is
+// returned undecoded.
+func (as *AnalysisService) RawData(ctx context.Context, checkID int, analysisID int) (json.RawMessage, error) {
+	req, err := as.client.NewRequest("GET", "/analysis/"+strconv.Itoa(checkID)+"/"+strconv.Itoa(analysisID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := as.client.exec(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	return readBody(resp)
+}
@@ -0,0 +1,52 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalysisServiceList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/analysis/12345", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "10", r.URL.Query().Get("limit"))
+		fmt.Fprint(w, `{
+			"analysis": [
+				{"id": 987654, "timefirsttest": 1563370611, "timeconfirmtest": 1563370671},
+				{"id": 987321, "timefirsttest": 1563300000, "timeconfirmtest": 1563300060}
+			]
+		}`)
+	})
+	want := []AnalysisResponse{
+		{ID: 987654, TimeFirstTest: 1563370611, TimeConfirmTest: 1563370671},
+		{ID: 987321, TimeFirstTest: 1563300000, TimeConfirmTest: 1563300060},
+	}
+
+	analysis, err := client.Analysis.List(context.Background(), 12345, map[string]string{"limit": "10"})
+	assert.NoError(t, err)
+	assert.Equal(t, want, analysis)
+}
+
+func TestAnalysisServiceRawData(t *testing.T) {
+	setup()
+	defer teardown()
+
+	body := `{"analysisid": 987654, "result": {"tasks": [{"type": "dig", "result": "NOERROR"}]}}`
+	mux.HandleFunc("/analysis/12345/987654", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, body)
+	})
+
+	raw, err := client.Analysis.RawData(context.Background(), 12345, 987654)
+	assert.NoError(t, err)
+	assert.JSONEq(t, body, string(raw))
+
+	_, err = client.Analysis.RawData(context.Background(), 12345, 1)
+	assert.Error(t, err)
+}
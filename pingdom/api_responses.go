@@ -1,8 +1,14 @@
 package pingdom
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/apierror"
 )
 
 // PingdomResponse represents a general response from the Pingdom API.
@@ -41,10 +47,11 @@ type CheckResponse struct {
 	ResponseTimeThreshold    int                 `json:"responsetime_threshold,omitempty"`
 	ProbeFilters             []string            `json:"probe_filters,omitempty"`
 	IPv6                     bool                `json:"ipv6,omitempty"`
+	CustomMessage            string              `json:"custom_message,omitempty"`
 
 	// Legacy; this is not returned by the API, we backfill the value from the
 	// Teams field.
-	TeamIds []int
+	TeamIds []int `json:"teamids,omitempty"`
 }
 
 // CheckTeamResponse is a Team returned inside of a Check instance. (We can't
@@ -56,10 +63,15 @@ type CheckTeamResponse struct {
 
 // CheckResponseType is the type of the Pingdom check.
 type CheckResponseType struct {
-	Name string                    `json:"-"`
-	HTTP *CheckResponseHTTPDetails `json:"http,omitempty"`
-	TCP  *CheckResponseTCPDetails  `json:"tcp,omitempty"`
-	DNS  *CheckResponseDNSDetails  `json:"dns,omitempty"`
+	Name       string                          `json:"-"`
+	HTTP       *CheckResponseHTTPDetails       `json:"http,omitempty"`
+	HTTPCustom *CheckResponseHTTPCustomDetails `json:"httpcustom,omitempty"`
+	TCP        *CheckResponseTCPDetails        `json:"tcp,omitempty"`
+	UDP        *CheckResponseUDPDetails        `json:"udp,omitempty"`
+	SMTP       *CheckResponseSMTPDetails       `json:"smtp,omitempty"`
+	POP3       *CheckResponseMailDetails       `json:"pop3,omitempty"`
+	IMAP       *CheckResponseMailDetails       `json:"imap,omitempty"`
+	DNS        *CheckResponseDNSDetails        `json:"dns,omitempty"`
 }
 
 // CheckResponseTag is an optional tag that can be added to checks.
@@ -141,6 +153,231 @@ type SummaryPerformanceSummary struct {
 	Uptime      int `json:"uptime"`
 }
 
+// SummaryOutageResponse represents the JSON response for a summary outage from the Pingdom API.
+type SummaryOutageResponse struct {
+	Summary SummaryOutageStates `json:"summary"`
+}
+
+// SummaryOutageStates is the list of states of a check over a period of time.
+type SummaryOutageStates struct {
+	States []SummaryOutageState `json:"states"`
+}
+
+// SummaryOutageState is an interval during which a check was up, down or unknown.
+type SummaryOutageState struct {
+	Status   string `json:"status"`
+	TimeFrom int    `json:"timefrom"`
+	TimeTo   int    `json:"timeto"`
+}
+
+// SummaryHoursOfDayResponse represents the JSON response for a summary of the hours of day from the Pingdom API.
+type SummaryHoursOfDayResponse struct {
+	HoursOfDay []SummaryHourOfDay `json:"hoursofday"`
+}
+
+// SummaryHourOfDay is the average response time, in milliseconds, of an hour
+// of the day, from 0 to 23.
+type SummaryHourOfDay struct {
+	Hour        int `json:"hour"`
+	AvgResponse int `json:"avgresponse"`
+}
+
+// AnalysisResponse represents the JSON response for a root cause analysis from the Pingdom API.
+// TimeFirstTest and TimeConfirmTest are the unix timestamps of the test
+// which detected the outage and of the test which confirmed it.
+type AnalysisResponse struct {
+	ID              int `json:"id"`
+	TimeFirstTest   int `json:"timefirsttest"`
+	TimeConfirmTest int `json:"timeconfirmtest"`
+}
+
+// AlertResponse represents the JSON response for an alert sent by Pingdom.
+// Time is a unix timestamp, Charged whether the alert used an SMS credit.
+type AlertResponse struct {
+	UserID       int    `json:"userid"`
+	UserName     string `json:"username"`
+	CheckID      int    `json:"checkid"`
+	Time         int    `json:"time"`
+	Via          string `json:"via"`
+	Status       string `json:"status"`
+	MessageShort string `json:"messageshort"`
+	MessageFull  string `json:"messagefull"`
+	SentTo       string `json:"sentto"`
+	Charged      bool   `json:"charged"`
+}
+
+// TMSCheckResponse represents the JSON response for a TMS check from the Pingdom API.
+// Lists leave out the steps and metadata.
+type TMSCheckResponse struct {
+	ID                       int               `json:"id"`
+	Name                     string            `json:"name"`
+	Active                   bool              `json:"active"`
+	Type                     string            `json:"type,omitempty"`
+	Status                   string            `json:"status,omitempty"`
+	Interval                 int               `json:"interval,omitempty"`
+	Region                   string            `json:"region,omitempty"`
+	Steps                    []TMSCheckStep    `json:"steps,omitempty"`
+	Metadata                 *TMSCheckMetadata `json:"metadata,omitempty"`
+	ContactIDs               []int             `json:"contact_ids,omitempty"`
+	TeamIDs                  []int             `json:"team_ids,omitempty"`
+	IntegrationIDs           []int             `json:"integration_ids,omitempty"`
+	SendNotificationWhenDown int               `json:"send_notification_when_down,omitempty"`
+	SeverityLevel            string            `json:"severity_level,omitempty"`
+	CustomMessage            string            `json:"custom_message,omitempty"`
+	Tags                     []string          `json:"tags,omitempty"`
+	CreatedAt                int64             `json:"created_at,omitempty"`
+	ModifiedAt               int64             `json:"modified_at,omitempty"`
+}
+
+// TMSStatusReport represents the JSON response for the status report of a TMS check from the Pingdom API.
+type TMSStatusReport struct {
+	CheckID int              `json:"check_id"`
+	Name    string           `json:"name"`
+	States  []TMSStatusState `json:"states"`
+}
+
+// TMSStatusState is a period during which a TMS check was "successful",
+// "failing" or "unknown".  ErrorInStep is the index of the step which failed.
+type TMSStatusState struct {
+	Status      string    `json:"status"`
+	From        time.Time `json:"from"`
+	To          time.Time `json:"to"`
+	ErrorInStep int       `json:"error_in_step,omitempty"`
+	Message     string    `json:"message,omitempty"`
+}
+
+// Duration returns the length of the period.
+func (s TMSStatusState) Duration() time.Duration {
+	return s.To.Sub(s.From)
+}
+
+// TMSPerformanceReport represents the JSON response for the performance report of a TMS check from the Pingdom API.
+type TMSPerformanceReport struct {
+	CheckID    int                      `json:"check_id"`
+	Name       string                   `json:"name"`
+	Resolution string                   `json:"resolution"`
+	Intervals  []TMSPerformanceInterval `json:"intervals"`
+}
+
+// Availability returns the ratio of monitored time the check was up over the
+// intervals of the report, or 1 when it was not monitored at all.  The
+// report must have been requested with IncludeUptime.
+func (r *TMSPerformanceReport) Availability() float64 {
+	var up, down int
+	for _, interval := range r.Intervals {
+		up += interval.Uptime
+		down += interval.Downtime
+	}
+	if up+down == 0 {
+		return 1
+	}
+	return float64(up) / float64(up+down)
+}
+
+// TMSPerformanceInterval is the performance of a TMS check during one
+// interval.  Response times are in milliseconds, Uptime, Downtime and
+// Unmonitored in seconds.
+type TMSPerformanceInterval struct {
+	From            time.Time            `json:"from"`
+	AverageResponse int                  `json:"average_response"`
+	Uptime          int                  `json:"uptime,omitempty"`
+	Downtime        int                  `json:"downtime,omitempty"`
+	Unmonitored     int                  `json:"unmonitored,omitempty"`
+	Steps           []TMSStepPerformance `json:"steps"`
+}
+
+// TMSStepPerformance is the average response time of a step of a TMS check.
+type TMSStepPerformance struct {
+	Step            TMSCheckStep `json:"step"`
+	AverageResponse int          `json:"average_response"`
+}
+
+// Reference represents the JSON response for the reference data from the Pingdom API.
+type Reference struct {
+	Regions         []ReferenceRegion         `json:"regions"`
+	Timezones       []ReferenceTimezone       `json:"timezones"`
+	DateTimeFormats []ReferenceDateTimeFormat `json:"datetimeformats"`
+	NumberFormats   []ReferenceNumberFormat   `json:"numberformats"`
+	Countries       []ReferenceCountry        `json:"countries"`
+	PhoneCodes      []ReferencePhoneCode      `json:"phonecodes"`
+}
+
+// ReferenceRegion is a region, with its default country, time zone and
+// formats.
+type ReferenceRegion struct {
+	ID               int    `json:"id"`
+	Description      string `json:"description"`
+	CountryID        int    `json:"countryid"`
+	DateTimeFormatID int    `json:"datetimeformatid"`
+	NumberFormatID   int    `json:"numberformatid"`
+	TimezoneID       string `json:"timezoneid"`
+}
+
+// ReferenceTimezone is a time zone known to Pingdom.
+type ReferenceTimezone struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+}
+
+// ReferenceDateTimeFormat is a date and time format known to Pingdom.
+type ReferenceDateTimeFormat struct {
+	ID          int    `json:"id"`
+	Description string `json:"description"`
+}
+
+// ReferenceNumberFormat is a number format known to Pingdom.
+type ReferenceNumberFormat struct {
+	ID          int    `json:"id"`
+	Description string `json:"description"`
+}
+
+// ReferenceCountry is a country known to Pingdom.
+type ReferenceCountry struct {
+	ID  int    `json:"id"`
+	ISO string `json:"iso"`
+}
+
+// ReferencePhoneCode is the phone code of a country.
+type ReferencePhoneCode struct {
+	CountryID int    `json:"countryid"`
+	Name      string `json:"name"`
+	PhoneCode string `json:"phonecode"`
+}
+
+// SingleResult represents the JSON response for a single test from the Pingdom API.
+type SingleResult struct {
+	Status         string `json:"status"`
+	ResponseTime   int    `json:"responsetime"`
+	StatusDesc     string `json:"statusdesc"`
+	StatusDescLong string `json:"statusdesclong"`
+	ProbeID        int    `json:"probeid"`
+	ProbeDesc      string `json:"probedesc"`
+}
+
+// TracerouteResponse represents the JSON response for a traceroute from the Pingdom API.
+// Hops is parsed from Result by TracerouteService.Run.
+type TracerouteResponse struct {
+	Result           string          `json:"result"`
+	ProbeID          int             `json:"probeid"`
+	ProbeDescription string          `json:"probedescription"`
+	Hops             []TracerouteHop `json:"-"`
+}
+
+// TracerouteHop is a hop of a traceroute, numbered from 1.
+type TracerouteHop struct {
+	Number   int
+	Attempts []TracerouteAttempt
+}
+
+// TracerouteAttempt is a probe packet sent to a hop, answered by Host after
+// Latency unless it timed out.
+type TracerouteAttempt struct {
+	Host    string
+	IP      string
+	Latency time.Duration
+	Timeout bool
+}
+
 // ResultsResponse represents the JSON response for detailed check results from the Pingdom API.
 type ResultsResponse struct {
 	ActiveProbes []int    `json:"activeprobes"`
@@ -155,44 +392,101 @@ type Result struct {
 	ResponseTime   int    `json:"responsetime"`
 	StatusDesc     string `json:"statusdesc"`
 	StatusDescLong string `json:"statusdesclong"`
+	// AnalysisID is only set when the results were requested with
+	// ResultsRequest.IncludeAnalysis, for the results which have one.
+	AnalysisID int `json:"analysisid,omitempty"`
+	// Probe is only set by CheckService.ResultsWithProbes.
+	Probe *ProbeResponse `json:"probe,omitempty"`
 }
 
-// UnmarshalJSON converts a byte array into a CheckResponseType.
+// UnmarshalJSON converts a byte array into a CheckResponseType.  It decodes
+// the details straight into their struct, without going through generic maps,
+// since it runs for every check of a list.
 func (c *CheckResponseType) UnmarshalJSON(b []byte) error {
-	var raw interface{}
-
-	err := json.Unmarshal(b, &raw)
-	if err != nil {
-		return err
+	if len(b) == 0 {
+		return nil
 	}
-
-	switch v := raw.(type) {
-	case string:
-		c.Name = v
-	case map[string]interface{}:
-		if len(v) != 1 {
-			return fmt.Errorf("Check detailed response `check.type` contains more than one object: %+v", v)
+	switch b[0] {
+	case '"':
+		return json.Unmarshal(b, &c.Name)
+	case '{':
+		var details map[string]json.RawMessage
+		if err := json.Unmarshal(b, &details); err != nil {
+			return err
 		}
-		for k := range v {
-			c.Name = k
+		if len(details) != 1 {
+			names := make([]string, 0, len(details))
+			for name := range details {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return fmt.Errorf("Check detailed response `check.type` contains more than one object: %v", names)
 		}
 
-		// Allow continue use json.Unmarshall using a type != Unmarshaller
-		// This avoid enter in a infinite loop
-		type t CheckResponseType
-		var rawCheckDetails t
-
-		err := json.Unmarshal(b, &rawCheckDetails)
-		if err != nil {
-			return err
+		c.HTTP, c.HTTPCustom, c.TCP, c.UDP, c.SMTP, c.POP3, c.IMAP, c.DNS = nil, nil, nil, nil, nil, nil, nil, nil
+		for name, raw := range details {
+			c.Name = name
+			switch name {
+			case "http":
+				c.HTTP = &CheckResponseHTTPDetails{}
+				return json.Unmarshal(raw, c.HTTP)
+			case "httpcustom":
+				c.HTTPCustom = &CheckResponseHTTPCustomDetails{}
+				return json.Unmarshal(raw, c.HTTPCustom)
+			case "tcp":
+				c.TCP = &CheckResponseTCPDetails{}
+				return json.Unmarshal(raw, c.TCP)
+			case "udp":
+				c.UDP = &CheckResponseUDPDetails{}
+				return json.Unmarshal(raw, c.UDP)
+			case "smtp":
+				c.SMTP = &CheckResponseSMTPDetails{}
+				return json.Unmarshal(raw, c.SMTP)
+			case "pop3":
+				c.POP3 = &CheckResponseMailDetails{}
+				return json.Unmarshal(raw, c.POP3)
+			case "imap":
+				c.IMAP = &CheckResponseMailDetails{}
+				return json.Unmarshal(raw, c.IMAP)
+			case "dns":
+				c.DNS = &CheckResponseDNSDetails{}
+				return json.Unmarshal(raw, c.DNS)
+			}
 		}
-		c.HTTP = rawCheckDetails.HTTP
-		c.TCP = rawCheckDetails.TCP
-		c.DNS = rawCheckDetails.DNS
 	}
 	return nil
 }
 
+// MarshalJSON converts a CheckResponseType into the object form returned by
+// the API, e.g. {"http":{...}} or {"ping":{}}, so that it can be unmarshaled
+// back.
+func (c CheckResponseType) MarshalJSON() ([]byte, error) {
+	if c.Name == "" {
+		return []byte("null"), nil
+	}
+
+	var details interface{} = struct{}{}
+	switch {
+	case c.Name == "http" && c.HTTP != nil:
+		details = c.HTTP
+	case c.Name == "httpcustom" && c.HTTPCustom != nil:
+		details = c.HTTPCustom
+	case c.Name == "tcp" && c.TCP != nil:
+		details = c.TCP
+	case c.Name == "udp" && c.UDP != nil:
+		details = c.UDP
+	case c.Name == "smtp" && c.SMTP != nil:
+		details = c.SMTP
+	case c.Name == "pop3" && c.POP3 != nil:
+		details = c.POP3
+	case c.Name == "imap" && c.IMAP != nil:
+		details = c.IMAP
+	case c.Name == "dns" && c.DNS != nil:
+		details = c.DNS
+	}
+	return json.Marshal(map[string]interface{}{c.Name: details})
+}
+
 // CheckResponseHTTPDetails represents the details specific to HTTP checks.
 type CheckResponseHTTPDetails struct {
 	Url               string            `json:"url,omitempty"`
@@ -208,6 +502,101 @@ type CheckResponseHTTPDetails struct {
 	SSLDownDaysBefore int               `json:"ssl_down_days_before,omitempty"`
 }
 
+// requestHeaderPrefix is the prefix of the numbered request header fields,
+// e.g. "requestheader0", whose values are "Name:value".
+const requestHeaderPrefix = "requestheader"
+
+// UnmarshalJSON decodes the details of an HTTP check.  The request headers
+// are also accepted as numbered fields, e.g. "requestheader0": "Pragma:no-cache",
+// as they are sent by HttpCheck.PutParams, besides the requestheaders object.
+// Numbered fields which are not "Name:value" strings are skipped rather than
+// failing the decode of the check, as only outgoing headers are validated.
+func (d *CheckResponseHTTPDetails) UnmarshalJSON(b []byte) error {
+	type details CheckResponseHTTPDetails
+	if err := json.Unmarshal(b, (*details)(d)); err != nil {
+		return err
+	}
+	if !hasNumberedRequestHeader(b) {
+		return nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		if strings.HasPrefix(key, requestHeaderPrefix) && isDigits(key[len(requestHeaderPrefix):]) {
+			keys = append(keys, key)
+		}
+	}
+	// The requestheaders object wins over the numbered fields, and the lower
+	// numbers over the higher.
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) < len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	for _, key := range keys {
+		var header string
+		if err := json.Unmarshal(fields[key], &header); err != nil {
+			continue
+		}
+		i := strings.Index(header, ":")
+		if i < 0 {
+			continue
+		}
+		name := strings.TrimSpace(header[:i])
+		if name == "" {
+			continue
+		}
+		if d.RequestHeaders == nil {
+			d.RequestHeaders = map[string]string{}
+		}
+		if _, ok := d.RequestHeaders[name]; !ok {
+			d.RequestHeaders[name] = strings.TrimSpace(header[i+1:])
+		}
+	}
+	return nil
+}
+
+// hasNumberedRequestHeader reports whether the JSON object may have a
+// numbered request header field, so that the common case is decoded once.
+func hasNumberedRequestHeader(b []byte) bool {
+	prefix := []byte(`"` + requestHeaderPrefix)
+	for {
+		i := bytes.Index(b, prefix)
+		if i < 0 {
+			return false
+		}
+		b = b[i+len(prefix):]
+		if len(b) > 0 && b[0] >= '0' && b[0] <= '9' {
+			return true
+		}
+	}
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// CheckResponseHTTPCustomDetails represents the details specific to HTTP
+// custom checks.
+type CheckResponseHTTPCustomDetails struct {
+	Url            string   `json:"url,omitempty"`
+	Encryption     bool     `json:"encryption,omitempty"`
+	Port           int      `json:"port,omitempty"`
+	Username       string   `json:"username,omitempty"`
+	Password       string   `json:"password,omitempty"`
+	AdditionalUrls []string `json:"additionalurls,omitempty"`
+}
+
 // CheckResponseTCPDetails represents the details specific to TCP checks.
 type CheckResponseTCPDetails struct {
 	Port           int    `json:"port,omitempty"`
@@ -215,6 +604,29 @@ type CheckResponseTCPDetails struct {
 	StringToExpect string `json:"stringtoexpect,omitempty"`
 }
 
+// CheckResponseUDPDetails represents the details specific to UDP checks.
+type CheckResponseUDPDetails struct {
+	Port           int    `json:"port,omitempty"`
+	StringToSend   string `json:"stringtosend,omitempty"`
+	StringToExpect string `json:"stringtoexpect,omitempty"`
+}
+
+// CheckResponseSMTPDetails represents the details specific to SMTP checks.
+type CheckResponseSMTPDetails struct {
+	Port           int    `json:"port,omitempty"`
+	Auth           string `json:"auth,omitempty"`
+	Encryption     bool   `json:"encryption,omitempty"`
+	StringToExpect string `json:"stringtoexpect,omitempty"`
+}
+
+// CheckResponseMailDetails represents the details specific to POP3 and IMAP
+// checks.
+type CheckResponseMailDetails struct {
+	Port           int    `json:"port,omitempty"`
+	Encryption     bool   `json:"encryption,omitempty"`
+	StringToExpect string `json:"stringtoexpect,omitempty"`
+}
+
 // CheckResponseDNSDetails represents the details specific to DNS checks.
 type CheckResponseDNSDetails struct {
 	ExpectedIP string `json:"expectedip,omitempty"`
@@ -226,16 +638,62 @@ func (r *PingdomError) Error() string {
 	return fmt.Sprintf("%d %v: %v", r.StatusCode, r.StatusDesc, r.Message)
 }
 
+// APIBackend returns apierror.BackendPingdom, PingdomError implements
+// apierror.APIError.
+func (r *PingdomError) APIBackend() string {
+	return apierror.BackendPingdom
+}
+
+// HTTPStatus returns the status code of the error.
+func (r *PingdomError) HTTPStatus() int {
+	return r.StatusCode
+}
+
+// APIMessage returns the message of the error.
+func (r *PingdomError) APIMessage() string {
+	return r.Message
+}
+
 // private types used to unmarshall JSON responses from Pingdom.
 
 type listChecksJSONResponse struct {
 	Checks []CheckResponse `json:"checks"`
 }
 
+type listTMSChecksJSONResponse struct {
+	Checks []TMSCheckResponse `json:"checks"`
+}
+
+type tmsStatusReportJSONResponse struct {
+	Report *TMSStatusReport `json:"report"`
+}
+
+type tmsPerformanceReportJSONResponse struct {
+	Report *TMSPerformanceReport `json:"report"`
+}
+
 type listMaintenanceJSONResponse struct {
 	Maintenances []MaintenanceResponse `json:"maintenance"`
 }
 
+type listAnalysisJSONResponse struct {
+	Analysis []AnalysisResponse `json:"analysis"`
+}
+
+type tracerouteJSONResponse struct {
+	Traceroute TracerouteResponse `json:"traceroute"`
+}
+
+type listActionsJSONResponse struct {
+	Actions struct {
+		Alerts []AlertResponse `json:"alerts"`
+	} `json:"actions"`
+}
+
+type singleJSONResponse struct {
+	Result SingleResult `json:"result"`
+}
+
 type listProbesJSONResponse struct {
 	Probes []ProbeResponse `json:"probes"`
 }
@@ -1,9 +1,13 @@
 package pingdom
 
 import (
+	"bytes"
 	"encoding/json"
+	"io/ioutil"
+	"net/http"
 	"testing"
 
+	"github.com/nordcloud/go-pingdom/apierror"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -40,6 +44,47 @@ func TestPingdomError(t *testing.T) {
 	pe := PingdomError{StatusCode: 400, StatusDesc: "Bad Request", Message: "Missing param foo"}
 	want := "400 Bad Request: Missing param foo"
 	assert.Equal(t, want, pe.Error())
+
+	var apiErr apierror.APIError = &pe
+	assert.Equal(t, apierror.BackendPingdom, apiErr.APIBackend())
+	assert.Equal(t, 400, apiErr.HTTPStatus())
+	assert.Equal(t, "Missing param foo", apiErr.APIMessage())
+}
+
+func TestCheckResponseHTTPDetailsNumberedHeaders(t *testing.T) {
+	var d CheckResponseHTTPDetails
+	err := json.Unmarshal([]byte(`{
+		"url": "/health",
+		"postdata": "ping=1",
+		"requestheaders": {"Pragma": "no-cache"},
+		"requestheader0": "Pragma:from numbered field",
+		"requestheader10": "Authorization:Bearer ten",
+		"requestheader2": "Authorization: Bearer two",
+		"requestheader1": "X-Api-Version:2021-01-01:beta"
+	}`), &d)
+	assert.NoError(t, err)
+	assert.Equal(t, "ping=1", d.PostData)
+	assert.Equal(t, map[string]string{
+		"Pragma":        "no-cache",
+		"Authorization": "Bearer two",
+		"X-Api-Version": "2021-01-01:beta",
+	}, d.RequestHeaders)
+
+	// Malformed headers sent by the server are skipped.
+	d = CheckResponseHTTPDetails{}
+	err = json.Unmarshal([]byte(`{"requestheader0": "no colon", "requestheader1": ": no name", "requestheader2": 7, "requestheader3": "Pragma:no-cache"}`), &d)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"Pragma": "no-cache"}, d.RequestHeaders)
+
+	// They do not fail the decode of the check either.
+	var check CheckResponse
+	assert.NoError(t, json.Unmarshal([]byte(`{"id": 1, "type": {"http": {"url": "/", "requestheader0": "no colon"}}}`), &check))
+	assert.Equal(t, "/", check.Type.HTTP.Url)
+
+	// Other fields starting like the numbered ones are ignored.
+	d = CheckResponseHTTPDetails{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"requestheader0x": 1}`), &d))
+	assert.Nil(t, d.RequestHeaders)
 }
 
 func TestCheckResponseUnmarshal(t *testing.T) {
@@ -177,3 +222,129 @@ func TestCheckContactUnmarshal(t *testing.T) {
 	assert.NotNil(t, contact.ID)
 	assert.Equal(t, expectedNotificationTargets, contact.NotificationTargets)
 }
+
+func TestCheckResponseTypeJSONRoundTrip(t *testing.T) {
+	tests := []CheckResponseType{
+		{Name: "http", HTTP: &CheckResponseHTTPDetails{Url: "/health", Encryption: true, Port: 443, RequestHeaders: map[string]string{"Accept": "text/plain"}}},
+		{Name: "tcp", TCP: &CheckResponseTCPDetails{Port: 25, StringToSend: "HELO", StringToExpect: "250"}},
+		{Name: "httpcustom", HTTPCustom: &CheckResponseHTTPCustomDetails{Url: "/status.xml", Port: 8443, AdditionalUrls: []string{"https://a.example.com/status.xml"}}},
+		{Name: "pop3", POP3: &CheckResponseMailDetails{Port: 995, Encryption: true, StringToExpect: "+OK"}},
+		{Name: "imap", IMAP: &CheckResponseMailDetails{Port: 143, StringToExpect: "* OK"}},
+		{Name: "smtp", SMTP: &CheckResponseSMTPDetails{Port: 587, Encryption: true, StringToExpect: "ESMTP"}},
+		{Name: "udp", UDP: &CheckResponseUDPDetails{Port: 53, StringToSend: "ping", StringToExpect: "pong"}},
+		{Name: "dns", DNS: &CheckResponseDNSDetails{ExpectedIP: "93.184.216.34", NameServer: "a.iana-servers.net"}},
+		{Name: "ping"},
+		{},
+	}
+	for _, want := range tests {
+		b, err := json.Marshal(want)
+		assert.NoError(t, err)
+
+		var got CheckResponseType
+		assert.NoError(t, json.Unmarshal(b, &got))
+		assert.Equal(t, want, got, string(b))
+	}
+
+	b, err := json.Marshal(CheckResponseType{Name: "ping"})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"ping":{}}`, string(b))
+}
+
+func TestCheckResponseTypeUnmarshal(t *testing.T) {
+	var got CheckResponseType
+	assert.NoError(t, json.Unmarshal([]byte(`"http"`), &got))
+	assert.Equal(t, CheckResponseType{Name: "http"}, got)
+
+	got = CheckResponseType{Name: "http", HTTP: &CheckResponseHTTPDetails{Port: 80}}
+	assert.NoError(t, json.Unmarshal([]byte(`{"tcp":{"port":25}}`), &got))
+	assert.Equal(t, CheckResponseType{Name: "tcp", TCP: &CheckResponseTCPDetails{Port: 25}}, got)
+
+	err := json.Unmarshal([]byte(`{"http":{},"tcp":{}}`), &got)
+	assert.EqualError(t, err, "Check detailed response `check.type` contains more than one object: [http tcp]")
+	assert.Error(t, json.Unmarshal([]byte(`{"http":{"port":"80"}}`), &got))
+}
+
+func TestCheckResponseJSONRoundTrip(t *testing.T) {
+	want := CheckResponse{
+		ID:                    85975,
+		Name:                  "My check 7",
+		Resolution:            1,
+		Created:               1240394682,
+		Hostname:              "s7.mydomain.com",
+		Status:                "up",
+		LastErrorTime:         1293143467,
+		LastTestTime:          1294064823,
+		SeverityLevel:         "HIGH",
+		Type:                  CheckResponseType{Name: "http", HTTP: &CheckResponseHTTPDetails{Url: "/", Port: 80}},
+		Tags:                  []CheckResponseTag{{Name: "apache", Type: "a", Count: float64(2)}},
+		UserIds:               []int{1},
+		Teams:                 []CheckTeamResponse{{ID: 123456, Name: "The Dream Team"}},
+		ResponseTimeThreshold: 2300,
+		ProbeFilters:          []string{"region: EU"},
+		IPv6:                  true,
+		TeamIds:               []int{123456},
+	}
+
+	b, err := json.Marshal(want)
+	assert.NoError(t, err)
+
+	var got CheckResponse
+	assert.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, want, got)
+}
+
+func TestMaintenanceResponseJSONRoundTrip(t *testing.T) {
+	want := MaintenanceResponse{
+		ID:             1,
+		Description:    "Weekly maintenance",
+		From:           1524040922,
+		To:             1524044522,
+		RecurrenceType: "week",
+		RepeatEvery:    1,
+		EffectiveTo:    1555576922,
+		Checks:         MaintenanceCheckResponse{Uptime: []int{12345}, Tms: []int{67890}},
+	}
+	b, err := json.Marshal(want)
+	assert.NoError(t, err)
+
+	var got MaintenanceResponse
+	assert.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, want, got)
+}
+
+// benchmarkPayload returns a response body listing n copies of item in the
+// given field, e.g. a page of checks or results.
+func benchmarkPayload(field string, item string, n int) []byte {
+	var b bytes.Buffer
+	b.WriteString(`{"` + field + `":[`)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(item)
+	}
+	b.WriteString(`]}`)
+	return b.Bytes()
+}
+
+func benchmarkDecode(b *testing.B, payload []byte, newValue func() interface{}) {
+	b.ReportAllocs()
+	b.SetBytes(int64(len(payload)))
+	for i := 0; i < b.N; i++ {
+		resp := &http.Response{Body: ioutil.NopCloser(bytes.NewReader(payload)), ContentLength: int64(len(payload))}
+		if err := decodeResponse(resp, newValue()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeChecks(b *testing.B) {
+	payload := benchmarkPayload("checks", detailedCheckJSON, 1000)
+	benchmarkDecode(b, payload, func() interface{} { return &listChecksJSONResponse{} })
+}
+
+func BenchmarkDecodeResults(b *testing.B) {
+	result := `{"probeid":33,"time":1294235764,"status":"up","responsetime":91,"statusdesc":"OK","statusdesclong":"OK"}`
+	payload := benchmarkPayload("results", result, 10000)
+	benchmarkDecode(b, payload, func() interface{} { return &ResultsResponse{} })
+}
@@ -0,0 +1,40 @@
+package pingdom
+
+import "net/http"
+
+// Authenticator adds Pingdom credentials to an outgoing request.  The client
+// selects an implementation automatically from ClientConfig, but a custom one
+// can be supplied through ClientConfig.Auth.
+type Authenticator interface {
+	Authenticate(req *http.Request)
+}
+
+// TokenAuth authenticates against the Pingdom 3.1 API using an API token
+// sent as a Bearer token.
+type TokenAuth struct {
+	APIToken string
+}
+
+// LegacyAuth authenticates the way the Pingdom 2.x API expects: HTTP basic
+// auth with the account credentials plus an application key header.
+// AccountEmail is only required for multi-user accounts.
+type LegacyAuth struct {
+	Username     string
+	Password     string
+	AppKey       string
+	AccountEmail string
+}
+
+// Authenticate sets the Bearer token on the request.
+func (a *TokenAuth) Authenticate(req *http.Request) {
+	req.Header.Set("Authorization", "Bearer "+a.APIToken)
+}
+
+// Authenticate sets basic auth and the App-Key header on the request.
+func (a *LegacyAuth) Authenticate(req *http.Request) {
+	req.SetBasicAuth(a.Username, a.Password)
+	req.Header.Set("App-Key", a.AppKey)
+	if a.AccountEmail != "" {
+		req.Header.Set("Account-Email", a.AccountEmail)
+	}
+}
@@ -0,0 +1,88 @@
+package pingdom
+
+import (
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenAuth(t *testing.T) {
+	req, _ := http.NewRequest("GET", "https://api.pingdom.com/api/3.1/checks", nil)
+	(&TokenAuth{APIToken: "token"}).Authenticate(req)
+	assert.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+}
+
+func TestLegacyAuth(t *testing.T) {
+	req, _ := http.NewRequest("GET", "https://api.pingdom.com/api/2.1/checks", nil)
+	(&LegacyAuth{
+		Username:     "user@example.com",
+		Password:     "secret",
+		AppKey:       "appkey",
+		AccountEmail: "owner@example.com",
+	}).Authenticate(req)
+
+	username, password, ok := req.BasicAuth()
+	assert.True(t, ok)
+	assert.Equal(t, "user@example.com", username)
+	assert.Equal(t, "secret", password)
+	assert.Equal(t, "appkey", req.Header.Get("App-Key"))
+	assert.Equal(t, "owner@example.com", req.Header.Get("Account-Email"))
+}
+
+func TestNewClientWithConfigSelectsAuth(t *testing.T) {
+	tests := []struct {
+		name       string
+		giveConfig ClientConfig
+		wantAuth   Authenticator
+	}{
+		{
+			name:       "token",
+			giveConfig: ClientConfig{APIToken: "token", Username: "user", AppKey: "key"},
+			wantAuth:   nil,
+		},
+		{
+			name:       "legacy",
+			giveConfig: ClientConfig{Username: "user", Password: "pass", AppKey: "key"},
+			wantAuth:   &LegacyAuth{Username: "user", Password: "pass", AppKey: "key"},
+		},
+		{
+			name:       "legacy without app key",
+			giveConfig: ClientConfig{Username: "user", Password: "pass"},
+			wantAuth:   nil,
+		},
+		{
+			name:       "explicit",
+			giveConfig: ClientConfig{APIToken: "token", Auth: &TokenAuth{APIToken: "other"}},
+			wantAuth:   &TokenAuth{APIToken: "other"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewClientWithConfig(tt.giveConfig)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantAuth, c.auth)
+		})
+	}
+}
+
+func TestNewClientWithEnvLegacyAuth(t *testing.T) {
+	os.Setenv("PINGDOM_USER", "user")
+	os.Setenv("PINGDOM_PASSWORD", "pass")
+	os.Setenv("PINGDOM_APP_KEY", "key")
+	defer os.Unsetenv("PINGDOM_USER")
+	defer os.Unsetenv("PINGDOM_PASSWORD")
+	defer os.Unsetenv("PINGDOM_APP_KEY")
+
+	c, err := NewClientWithConfig(ClientConfig{})
+	assert.NoError(t, err)
+	assert.Equal(t, &LegacyAuth{Username: "user", Password: "pass", AppKey: "key"}, c.auth)
+
+	req, err := c.NewRequest("GET", "/checks", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "key", req.Header.Get("App-Key"))
+	_, _, ok := req.BasicAuth()
+	assert.True(t, ok)
+}
@@ -0,0 +1,244 @@
+package pingdom
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBulkInitialConcurrency = 2
+	defaultBulkMaxConcurrency     = 10
+	defaultBulkDecrease           = 0.5
+	defaultBulkMaxAttempts        = 3
+	defaultBulkWait               = time.Second
+)
+
+// BulkConfig controls the concurrency of bulk operations.  Parallelism
+// starts at InitialConcurrency and grows additively, by roughly one request
+// for each round of successful requests, up to MaxConcurrency.  Whenever
+// Pingdom answers with a 429 or a 5xx the parallelism is multiplied by
+// Decrease (AIMD), the operation waits for Wait and is attempted again, up to
+// MaxAttempts in total.  As with RetryPolicy, operations creating resources
+// are only attempted again after a 429, see Creation.  Zero values are
+// replaced by the defaults.
+//
+// Progress, when set, is called whenever an operation is done, see
+// ProgressFunc.
+type BulkConfig struct {
+	InitialConcurrency int
+	MaxConcurrency     int
+	Decrease           float64
+	MaxAttempts        int
+	Wait               time.Duration
+	Progress           ProgressFunc
+}
+
+// Progress is the state of a long operation on many items, e.g. a bulk
+// operation or a synchronisation, after one of its items is done.
+type Progress struct {
+	Done   int
+	Total  int
+	Index  int    // Of the item done, in the items of the operation
+	Item   string // Describes the item done when known, e.g. an email address
+	Errors int    // So far
+}
+
+// ProgressFunc is called after each item of a long operation, e.g. to render
+// a progress bar or publish the status of a job.  Calls are not concurrent,
+// but may come from different goroutines, and should return quickly as the
+// operation waits for them.
+type ProgressFunc func(Progress)
+
+// progress counts the items done for a ProgressFunc.
+type progress struct {
+	fn     ProgressFunc
+	total  int
+	done   int
+	errors int
+}
+
+// report records that the item at index is done, with err.
+func (p *progress) report(index int, item string, err error) {
+	p.done++
+	if err != nil {
+		p.errors++
+	}
+	if p.fn != nil {
+		p.fn(Progress{Done: p.done, Total: p.total, Index: index, Item: item, Errors: p.errors})
+	}
+}
+
+func (bc BulkConfig) withDefaults() BulkConfig {
+	if bc.MaxConcurrency <= 0 {
+		bc.MaxConcurrency = defaultBulkMaxConcurrency
+	}
+	if bc.InitialConcurrency <= 0 {
+		bc.InitialConcurrency = defaultBulkInitialConcurrency
+	}
+	if bc.InitialConcurrency > bc.MaxConcurrency {
+		bc.InitialConcurrency = bc.MaxConcurrency
+	}
+	if bc.Decrease <= 0 || bc.Decrease >= 1 {
+		bc.Decrease = defaultBulkDecrease
+	}
+	if bc.MaxAttempts <= 0 {
+		bc.MaxAttempts = defaultBulkMaxAttempts
+	}
+	if bc.Wait <= 0 {
+		bc.Wait = defaultBulkWait
+	}
+	return bc
+}
+
+// aimd is the additive increase / multiplicative decrease controller of the
+// number of requests allowed in flight.
+type aimd struct {
+	limit float64
+	max   float64
+	dec   float64
+}
+
+func (a *aimd) allowed() int {
+	return int(a.limit)
+}
+
+func (a *aimd) success() {
+	a.limit += 1 / a.limit
+	if a.limit > a.max {
+		a.limit = a.max
+	}
+}
+
+func (a *aimd) throttled() {
+	a.limit *= a.dec
+	if a.limit < 1 {
+		a.limit = 1
+	}
+}
+
+// RunBulk calls op for every index in [0, n) with a parallelism adapted to the
+// rate limits of Pingdom, see BulkConfig.  The returned slice holds the error
+// of each operation at its index.  Once the context is done, the operations
+// not started yet fail with its error.
+func RunBulk(ctx context.Context, config BulkConfig, n int, op func(i int) error) []error {
+	config = config.withDefaults()
+	errs := make([]error, n)
+	attempts := make([]int, n)
+	controller := &aimd{
+		limit: float64(config.InitialConcurrency),
+		max:   float64(config.MaxConcurrency),
+		dec:   config.Decrease,
+	}
+
+	queue := make([]int, n)
+	for i := range queue {
+		queue[i] = i
+	}
+	pending := n
+	inFlight := 0
+	tracker := &progress{fn: config.Progress, total: n}
+
+	var mu sync.Mutex
+	cond := sync.NewCond(&mu)
+
+	mu.Lock()
+	defer mu.Unlock()
+	for pending > 0 {
+		if len(queue) == 0 || inFlight >= controller.allowed() {
+			cond.Wait()
+			continue
+		}
+		i := queue[0]
+		queue = queue[1:]
+		if err := ctx.Err(); err != nil {
+			errs[i] = err
+			pending--
+			tracker.report(i, "", err)
+			continue
+		}
+		attempts[i]++
+		inFlight++
+
+		go func(i int) {
+			err := op(i)
+			throttled := isThrottled(err)
+			var creation *creationError
+			if errors.As(err, &creation) {
+				err = creation.err
+			}
+			if throttled {
+				// Keep the slot while waiting so that the others slow down too.
+				timer := time.NewTimer(config.Wait)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+				case <-timer.C:
+				}
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			inFlight--
+			switch {
+			case throttled && attempts[i] < config.MaxAttempts && ctx.Err() == nil:
+				controller.throttled()
+				queue = append(queue, i)
+			case throttled:
+				controller.throttled()
+				errs[i] = err
+				pending--
+				tracker.report(i, "", err)
+			case err == nil:
+				controller.success()
+				pending--
+				tracker.report(i, "", nil)
+			default:
+				errs[i] = err
+				pending--
+				tracker.report(i, "", err)
+			}
+			cond.Broadcast()
+		}(i)
+	}
+	return errs
+}
+
+// Creation marks the error of an operation of RunBulk creating a resource,
+// which is only attempted again after a 429: after a 5xx, Pingdom may already
+// have created the resource, and another attempt would create it twice.
+// RunBulk returns the error itself, not marked.
+func Creation(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &creationError{err: err}
+}
+
+type creationError struct {
+	err error
+}
+
+func (e *creationError) Error() string {
+	return e.err.Error()
+}
+
+func (e *creationError) Unwrap() error {
+	return e.err
+}
+
+// isThrottled reports whether the error means Pingdom is overloaded or rate
+// limiting the client, and the operation can be attempted again.
+func isThrottled(err error) bool {
+	var pingdomErr *PingdomError
+	if !errors.As(err, &pingdomErr) {
+		return false
+	}
+	if pingdomErr.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	var creation *creationError
+	return pingdomErr.StatusCode >= 500 && !errors.As(err, &creation)
+}
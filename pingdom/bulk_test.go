@@ -0,0 +1,156 @@
+package pingdom
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAIMD(t *testing.T) {
+	a := &aimd{limit: 2, max: 4, dec: 0.5}
+	// A full round of successes at the current limit grows it by about one.
+	a.success()
+	a.success()
+	assert.Equal(t, 2, a.allowed())
+	a.success()
+	assert.Equal(t, 3, a.allowed())
+	for i := 0; i < 10; i++ {
+		a.success()
+	}
+	assert.Equal(t, 4, a.allowed())
+
+	a.throttled()
+	assert.Equal(t, 2, a.allowed())
+	a.throttled()
+	a.throttled()
+	assert.Equal(t, 1, a.allowed())
+}
+
+func TestRunBulk(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	errs := RunBulk(context.Background(), BulkConfig{InitialConcurrency: 1, MaxConcurrency: 5}, 50, func(i int) error {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(time.Millisecond)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		if i == 7 {
+			return errors.New("failed")
+		}
+		return nil
+	})
+
+	assert.Equal(t, 50, len(errs))
+	for i, err := range errs {
+		if i == 7 {
+			assert.EqualError(t, err, "failed")
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+	assert.True(t, maxInFlight > 1, "concurrency should have increased")
+	assert.True(t, maxInFlight <= 5, "concurrency should be capped")
+}
+
+func TestRunBulkThrottled(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[int]int{}
+	errs := RunBulk(context.Background(), BulkConfig{MaxAttempts: 2, Wait: time.Millisecond}, 3, func(i int) error {
+		mu.Lock()
+		defer mu.Unlock()
+		calls[i]++
+		switch {
+		case i == 0 && calls[i] == 1:
+			return &PingdomError{StatusCode: 429, StatusDesc: "Too Many Requests"}
+		case i == 1:
+			return &RetryError{Attempts: 1, Err: &PingdomError{StatusCode: 503, StatusDesc: "Service Unavailable"}}
+		}
+		return nil
+	})
+
+	assert.NoError(t, errs[0])
+	assert.Error(t, errs[1])
+	assert.NoError(t, errs[2])
+	assert.Equal(t, map[int]int{0: 2, 1: 2, 2: 1}, calls)
+}
+
+func TestRunBulkCreation(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[int]int{}
+	serverErr := &PingdomError{StatusCode: 500, StatusDesc: "Internal Server Error"}
+	errs := RunBulk(context.Background(), BulkConfig{MaxAttempts: 3, Wait: time.Millisecond}, 2, func(i int) error {
+		mu.Lock()
+		defer mu.Unlock()
+		calls[i]++
+		if i == 0 && calls[i] == 1 {
+			return Creation(&PingdomError{StatusCode: 429, StatusDesc: "Too Many Requests"})
+		}
+		if i == 1 {
+			return Creation(serverErr)
+		}
+		return Creation(nil)
+	})
+
+	// A creation may have succeeded despite a 5xx, it is not attempted again.
+	assert.NoError(t, errs[0])
+	assert.Equal(t, serverErr, errs[1])
+	assert.Equal(t, map[int]int{0: 2, 1: 1}, calls)
+}
+
+func TestRunBulkCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	start := time.Now()
+	errs := RunBulk(ctx, BulkConfig{InitialConcurrency: 1, MaxConcurrency: 1, Wait: time.Hour}, 2, func(i int) error {
+		calls++
+		cancel()
+		return &PingdomError{StatusCode: 429, StatusDesc: "Too Many Requests"}
+	})
+
+	assert.True(t, time.Since(start) < time.Minute, "the wait should end with the context")
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, 429, errs[0].(*PingdomError).StatusCode)
+	assert.Equal(t, context.Canceled, errs[1])
+}
+
+func TestRunBulkProgress(t *testing.T) {
+	var updates []Progress
+	config := BulkConfig{MaxConcurrency: 3, Progress: func(p Progress) {
+		// Never called concurrently.
+		updates = append(updates, p)
+	}}
+	RunBulk(context.Background(), config, 10, func(i int) error {
+		if i%4 == 0 {
+			return errors.New("failed")
+		}
+		return nil
+	})
+
+	assert.Len(t, updates, 10)
+	seen := map[int]bool{}
+	for n, p := range updates {
+		assert.Equal(t, n+1, p.Done)
+		assert.Equal(t, 10, p.Total)
+		seen[p.Index] = true
+	}
+	assert.Len(t, seen, 10)
+	assert.Equal(t, 3, updates[9].Errors)
+}
+
+func TestRunBulkEmpty(t *testing.T) {
+	errs := RunBulk(context.Background(), BulkConfig{}, 0, func(i int) error {
+		t.Error("should not be called")
+		return nil
+	})
+	assert.Empty(t, errs)
+}
@@ -0,0 +1,140 @@
+package pingdom
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// responseCache keeps the successful responses of GET requests for a TTL, see
+// ClientConfig.CacheTTL.  Writes invalidate the cached responses of their
+// resource, so that a list read right after a write is never stale.
+type responseCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cachedResponse
+}
+
+type cachedResponse struct {
+	resource string
+	status   int
+	header   http.Header
+	body     []byte
+	expires  time.Time
+}
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &responseCache{ttl: ttl, entries: map[string]cachedResponse{}}
+}
+
+// resource returns the resource the request is about, i.e. the first segment
+// of its path relative to BaseURL up to any ".", e.g. "checks" for
+// "checks/123" and "maintenance" for "maintenance.occurrences".  Related
+// endpoints such as the contacts and teams of "alerting" share it, as
+// writing one may change the other.
+func (pc *Client) resource(req *http.Request) string {
+	path := strings.TrimPrefix(req.URL.Path, strings.TrimSuffix(pc.BaseURL.Path, "/"))
+	path = strings.TrimPrefix(path, "/")
+	if i := strings.IndexAny(path, "/."); i >= 0 {
+		path = path[:i]
+	}
+	return path
+}
+
+// get returns a copy of the cached response to the request, or nil when
+// there is none.  Only GET requests are cached.
+func (rc *responseCache) get(req *http.Request) *http.Response {
+	if rc == nil || req.Method != http.MethodGet {
+		return nil
+	}
+	key := req.URL.String()
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	entry, ok := rc.entries[key]
+	if !ok {
+		return nil
+	}
+	if time.Now().After(entry.expires) {
+		delete(rc.entries, key)
+		return nil
+	}
+	return &http.Response{
+		Status:        http.StatusText(entry.status),
+		StatusCode:    entry.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        entry.header.Clone(),
+		Body:          ioutil.NopCloser(bytes.NewReader(entry.body)),
+		ContentLength: int64(len(entry.body)),
+		Request:       req,
+	}
+}
+
+// update caches the successful response of a GET request, whose body is read
+// and replaced, and invalidates the resource of a write, whether or not it
+// succeeded since a failed write may still have been applied.
+func (rc *responseCache) update(req *http.Request, resource string, resp *http.Response, err error) error {
+	if rc == nil {
+		return nil
+	}
+	if isMutating(req.Method) {
+		rc.invalidate(resource)
+		return nil
+	}
+	if req.Method != http.MethodGet || err != nil {
+		return nil
+	}
+
+	body, rerr := readBody(resp)
+	resp.Body.Close()
+	if rerr != nil {
+		return rerr
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.entries[req.URL.String()] = cachedResponse{
+		resource: resource,
+		status:   resp.StatusCode,
+		header:   resp.Header.Clone(),
+		body:     body,
+		expires:  time.Now().Add(rc.ttl),
+	}
+	return nil
+}
+
+// invalidate removes the cached responses of the resource.
+func (rc *responseCache) invalidate(resource string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	for key, entry := range rc.entries {
+		if entry.resource == resource {
+			delete(rc.entries, key)
+		}
+	}
+}
+
+// clear removes every cached response.
+func (rc *responseCache) clear() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.entries = map[string]cachedResponse{}
+}
+
+// ClearCache removes the responses cached by the client, e.g. after the
+// account was changed by other means.  It does nothing when caching is
+// disabled.
+func (pc *Client) ClearCache() {
+	if pc.cache != nil {
+		pc.cache.clear()
+	}
+}
@@ -0,0 +1,126 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseCache(t *testing.T) {
+	setup()
+	defer teardown()
+	client.cache = newResponseCache(time.Minute)
+
+	lists, reads := 0, 0
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		lists++
+		fmt.Fprintf(w, `{"checks": [{"id": 12345, "name": "list %d"}]}`, lists)
+	})
+	mux.HandleFunc("/checks/12345", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			reads++
+			fmt.Fprint(w, `{"check": {"id": 12345, "name": "Test"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"message": "Modification of check was successful!"}`)
+	})
+	mux.HandleFunc("/alerting/contacts", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"contacts": []}`)
+	})
+
+	ctx := context.Background()
+	checks, err := client.Checks.List(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "list 1", checks[0].Name)
+	checks, err = client.Checks.List(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "list 1", checks[0].Name)
+	assert.Equal(t, 1, lists)
+
+	// Other queries are cached separately.
+	_, err = client.Checks.List(ctx, map[string]string{"tags": "prod"})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, lists)
+
+	_, err = client.Checks.Read(ctx, 12345)
+	assert.NoError(t, err)
+	_, err = client.Contacts.List(ctx)
+	assert.NoError(t, err)
+
+	// Writing a check invalidates every cached response about checks only.
+	_, err = client.Checks.Update(ctx, 12345, &HttpCheck{Name: "Test", Hostname: "example.com"})
+	assert.NoError(t, err)
+	assert.Len(t, client.cache.entries, 1)
+
+	checks, err = client.Checks.List(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "list 3", checks[0].Name)
+	_, err = client.Checks.Read(ctx, 12345)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, reads)
+
+	client.ClearCache()
+	assert.Empty(t, client.cache.entries)
+}
+
+func TestResponseCacheInvalidatedByFailedWrite(t *testing.T) {
+	setup()
+	defer teardown()
+	client.cache = newResponseCache(time.Minute)
+
+	mux.HandleFunc("/maintenance", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"maintenance": []}`)
+	})
+	mux.HandleFunc("/maintenance.occurrences", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"occurrences": []}`)
+	})
+	mux.HandleFunc("/maintenance/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprint(w, `{"error": {"statuscode": 500, "statusdesc": "Internal Server Error", "errormessage": "failed"}}`)
+	})
+
+	ctx := context.Background()
+	_, err := client.Maintenances.List(ctx)
+	assert.NoError(t, err)
+	_, err = client.Occurrences.List(ctx, ListOccurrenceQuery{})
+	assert.NoError(t, err)
+	assert.Len(t, client.cache.entries, 2)
+
+	_, err = client.Maintenances.Delete(ctx, 1)
+	assert.Error(t, err)
+	assert.Empty(t, client.cache.entries)
+}
+
+func TestResponseCacheExpires(t *testing.T) {
+	setup()
+	defer teardown()
+	client.cache = newResponseCache(time.Nanosecond)
+
+	lists := 0
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		lists++
+		fmt.Fprint(w, `{"checks": []}`)
+	})
+
+	for i := 0; i < 2; i++ {
+		time.Sleep(time.Millisecond)
+		_, err := client.Checks.List(context.Background())
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 2, lists)
+}
+
+func TestResponseCacheDisabled(t *testing.T) {
+	c, err := NewClientWithConfig(ClientConfig{APIToken: "token"})
+	assert.NoError(t, err)
+	assert.Nil(t, c.cache)
+	c.ClearCache()
+
+	c, err = NewClientWithConfig(ClientConfig{APIToken: "token", CacheTTL: time.Minute})
+	assert.NoError(t, err)
+	assert.NotNil(t, c.cache)
+}
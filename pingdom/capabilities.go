@@ -0,0 +1,120 @@
+package pingdom
+
+import (
+	"context"
+	"sort"
+)
+
+// The endpoints of the Pingdom API the client has services for, relative to
+// its BaseURL.
+var supportedEndpoints = []string{
+	"actions",
+	"alerting/contacts",
+	"alerting/teams",
+	"analysis",
+	"checks",
+	"credits",
+	"maintenance",
+	"maintenance.occurrences",
+	"probes",
+	"reference",
+	"results",
+	"single",
+	"summary.hoursofday",
+	"summary.outage",
+	"summary.performance",
+	"tms/check",
+	"traceroute",
+}
+
+// The check types CheckService.Create can create.
+var supportedCheckTypes = []string{"dns", "http", "httpcustom", "imap", "ping", "pop3", "smtp", "tcp", "udp"}
+
+// The endpoints which also require an experimental feature.
+var endpointFeatures = map[string]string{
+	"tms/check": FeatureTMS,
+}
+
+// Capabilities describes what the client supports, so that orchestration
+// layers can skip what is not available instead of failing half way.
+type Capabilities struct {
+	// Endpoints are the API endpoints the client has services for, e.g.
+	// "maintenance.occurrences", and CheckTypes the types of the checks it
+	// can create, e.g. "http".
+	Endpoints  []string
+	CheckTypes []string
+
+	// Features tells for every experimental feature whether it is enabled.
+	Features map[string]bool
+
+	// ReadOnly is set when mutating calls fail, see ClientConfig.ReadOnly.
+	ReadOnly bool
+
+	// Plan is what the plan of the account allows, nil when it could not be
+	// detected, PlanError telling why.
+	Plan      *PlanCapabilities
+	PlanError error
+}
+
+// PlanCapabilities is what the plan of an account allows, as far as its
+// credits tell.
+type PlanCapabilities struct {
+	CheckLimit      int
+	AvailableChecks int
+	SMS             bool
+	RUM             bool
+}
+
+// SupportsEndpoint reports whether the client has a usable service for the
+// endpoint, e.g. "tms/check" only once FeatureTMS is enabled.
+func (c *Capabilities) SupportsEndpoint(endpoint string) bool {
+	for _, e := range c.Endpoints {
+		if e == endpoint {
+			feature, ok := endpointFeatures[endpoint]
+			return !ok || c.Features[feature]
+		}
+	}
+	return false
+}
+
+// SupportsCheckType reports whether the client can create checks of the type,
+// e.g. "dns".
+func (c *Capabilities) SupportsCheckType(checkType string) bool {
+	for _, t := range c.CheckTypes {
+		if t == checkType {
+			return true
+		}
+	}
+	return false
+}
+
+// Capabilities returns what this version of the client supports and, when
+// the credits of the account can be read, what its plan allows.  It does not
+// fail: a plan which cannot be detected, e.g. offline or with a token lacking
+// the permission, is reported in PlanError.
+func (pc *Client) Capabilities(ctx context.Context) *Capabilities {
+	c := &Capabilities{
+		Endpoints:  append([]string(nil), supportedEndpoints...),
+		CheckTypes: append([]string(nil), supportedCheckTypes...),
+		Features:   map[string]bool{},
+		ReadOnly:   pc.readOnly,
+	}
+	sort.Strings(c.Endpoints)
+	sort.Strings(c.CheckTypes)
+	for _, feature := range []string{FeatureTMS, FeatureStatusPages} {
+		c.Features[feature] = pc.FeatureEnabled(feature)
+	}
+
+	credits, err := pc.Account.Credits(ctx)
+	if err != nil {
+		c.PlanError = err
+		return c
+	}
+	c.Plan = &PlanCapabilities{
+		CheckLimit:      credits.CheckLimit,
+		AvailableChecks: credits.AvailableChecks,
+		SMS:             credits.AvailableSMS > 0 || credits.AutoFillSMS,
+		RUM:             credits.AvailableRUMSites+credits.UsedRUMSites > 0,
+	}
+	return c
+}
@@ -0,0 +1,52 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientCapabilities(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/credits", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, creditsResponse)
+	})
+
+	c := client.Capabilities(context.Background())
+	assert.Contains(t, c.Endpoints, "maintenance.occurrences")
+	assert.Equal(t, []string{"dns", "http", "httpcustom", "imap", "ping", "pop3", "smtp", "tcp", "udp"}, c.CheckTypes)
+	assert.Equal(t, map[string]bool{FeatureTMS: false, FeatureStatusPages: false}, c.Features)
+	assert.False(t, c.ReadOnly)
+	assert.NoError(t, c.PlanError)
+	assert.Equal(t, &PlanCapabilities{CheckLimit: 50, AvailableChecks: 2, SMS: true, RUM: true}, c.Plan)
+
+	assert.True(t, c.SupportsEndpoint("checks"))
+	assert.False(t, c.SupportsEndpoint("tms/check"))
+	assert.False(t, c.SupportsEndpoint("statuspages"))
+	assert.True(t, c.SupportsCheckType("dns"))
+	assert.False(t, c.SupportsCheckType("transaction"))
+
+	enableTMS()
+	assert.True(t, client.Capabilities(context.Background()).SupportsEndpoint("tms/check"))
+}
+
+func TestClientCapabilitiesWithoutPlan(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/credits", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error":{"statuscode":403,"statusdesc":"Forbidden","errormessage":"Missing permission"}}`)
+	})
+
+	c := client.Capabilities(context.Background())
+	assert.Nil(t, c.Plan)
+	assert.EqualError(t, c.PlanError, "403 Forbidden: Missing permission")
+	assert.True(t, c.SupportsEndpoint("checks"))
+}
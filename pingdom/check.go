@@ -1,8 +1,7 @@
 package pingdom
 
 import (
-	"encoding/json"
-	"io/ioutil"
+	"context"
 	"strconv"
 )
 
@@ -22,7 +21,7 @@ type Check interface {
 // List returns a list of checks from Pingdom.
 // This returns type CheckResponse rather than Check since the
 // Pingdom API does not return a complete representation of a check.
-func (cs *CheckService) List(params ...map[string]string) ([]CheckResponse, error) {
+func (cs *CheckService) List(ctx context.Context, params ...map[string]string) ([]CheckResponse, error) {
 	param := map[string]string{}
 	if len(params) == 1 {
 		param = params[0]
@@ -32,20 +31,14 @@ func (cs *CheckService) List(params ...map[string]string) ([]CheckResponse, erro
 		return nil, err
 	}
 
-	resp, err := cs.client.client.Do(req)
+	resp, err := cs.client.exec(req.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if err := validateResponse(resp); err != nil {
-		return nil, err
-	}
-
-	bodyBytes, _ := ioutil.ReadAll(resp.Body)
-	bodyString := string(bodyBytes)
 	m := &listChecksJSONResponse{}
-	err = json.Unmarshal([]byte(bodyString), &m)
+	err = cs.client.decodeResponse(resp, m)
 
 	return m.Checks, err
 }
@@ -54,19 +47,22 @@ func (cs *CheckService) List(params ...map[string]string) ([]CheckResponse, erro
 // to ensure that it contains correct values before submitting the request
 // Returns a CheckResponse object representing the response from Pingdom.
 // Note that Pingdom does not return a full check object so in the returned
-// object you should only use the ID field.
-func (cs *CheckService) Create(check Check) (*CheckResponse, error) {
+// object you should only use the ID field.  The check is tagged with the
+// CreationMetadata of the client, if any.
+func (cs *CheckService) Create(ctx context.Context, check Check) (*CheckResponse, error) {
 	if err := check.Valid(); err != nil {
 		return nil, err
 	}
 
-	req, err := cs.client.NewRequest("POST", "/checks", check.PostParams())
+	params := check.PostParams()
+	cs.client.metadata.annotate(params)
+	req, err := cs.client.NewRequest("POST", "/checks", params)
 	if err != nil {
 		return nil, err
 	}
 
 	m := &checkDetailsJSONResponse{}
-	_, err = cs.client.Do(req, m)
+	_, err = cs.client.Do(req.WithContext(ctx), m)
 	if err != nil {
 		return nil, err
 	}
@@ -76,14 +72,14 @@ func (cs *CheckService) Create(check Check) (*CheckResponse, error) {
 // ReadCheck returns detailed information about a pingdom check given its ID.
 // This returns type CheckResponse rather than Check since the
 // pingdom API does not return a complete representation of a check.
-func (cs *CheckService) Read(id int) (*CheckResponse, error) {
+func (cs *CheckService) Read(ctx context.Context, id int) (*CheckResponse, error) {
 	req, err := cs.client.NewRequest("GET", "/checks/"+strconv.Itoa(id)+"?include_teams=true", nil)
 	if err != nil {
 		return nil, err
 	}
 
 	m := &checkDetailsJSONResponse{}
-	_, err = cs.client.Do(req, m)
+	_, err = cs.client.Do(req.WithContext(ctx), m)
 	if err != nil {
 		return nil, err
 	}
@@ -97,19 +93,25 @@ func (cs *CheckService) Read(id int) (*CheckResponse, error) {
 
 // Update will update the check represented by the given ID with the values
 // in the given check.  You should submit the complete list of values in
-// the given check parameter, not just those that have changed.
-func (cs *CheckService) Update(id int, check Check) (*PingdomResponse, error) {
+// the given check parameter, not just those that have changed.  As the tags
+// replace those of the check, a client with CreationMetadata keeps the
+// creation metadata tags the check already has when the update sets tags.
+func (cs *CheckService) Update(ctx context.Context, id int, check Check) (*PingdomResponse, error) {
 	if err := check.Valid(); err != nil {
 		return nil, err
 	}
 
-	req, err := cs.client.NewRequest("PUT", "/checks/"+strconv.Itoa(id), check.PutParams())
+	params := check.PutParams()
+	if err := cs.keepCreationTags(ctx, id, params); err != nil {
+		return nil, err
+	}
+	req, err := cs.client.NewRequest("PUT", "/checks/"+strconv.Itoa(id), params)
 	if err != nil {
 		return nil, err
 	}
 
 	m := &PingdomResponse{}
-	_, err = cs.client.Do(req, m)
+	_, err = cs.client.Do(req.WithContext(ctx), m)
 	if err != nil {
 		return nil, err
 	}
@@ -117,22 +119,64 @@ func (cs *CheckService) Update(id int, check Check) (*PingdomResponse, error) {
 }
 
 // Delete will delete the check for the given ID.
-func (cs *CheckService) Delete(id int) (*PingdomResponse, error) {
+func (cs *CheckService) Delete(ctx context.Context, id int) (*PingdomResponse, error) {
 	req, err := cs.client.NewRequest("DELETE", "/checks/"+strconv.Itoa(id), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	m := &PingdomResponse{}
-	_, err = cs.client.Do(req, m)
+	_, err = cs.client.Do(req.WithContext(ctx), m)
 	if err != nil {
 		return nil, err
 	}
 	return m, err
 }
 
+// CheckUpdate pairs a check with the ID of the check it should update.
+type CheckUpdate struct {
+	ID    int
+	Check Check
+}
+
+// CreateMany creates all the given checks concurrently, see RunBulk. The
+// responses and errors are returned at the index of their check.
+func (cs *CheckService) CreateMany(ctx context.Context, checks []Check, config BulkConfig) ([]*CheckResponse, []error) {
+	responses := make([]*CheckResponse, len(checks))
+	errs := RunBulk(ctx, config, len(checks), func(i int) error {
+		var err error
+		responses[i], err = cs.Create(ctx, checks[i])
+		return Creation(err)
+	})
+	return responses, errs
+}
+
+// UpdateMany applies all the given updates concurrently, see RunBulk. The
+// responses and errors are returned at the index of their update.
+func (cs *CheckService) UpdateMany(ctx context.Context, updates []CheckUpdate, config BulkConfig) ([]*PingdomResponse, []error) {
+	responses := make([]*PingdomResponse, len(updates))
+	errs := RunBulk(ctx, config, len(updates), func(i int) error {
+		var err error
+		responses[i], err = cs.Update(ctx, updates[i].ID, updates[i].Check)
+		return err
+	})
+	return responses, errs
+}
+
+// DeleteMany deletes the checks with the given IDs concurrently, see RunBulk.
+// The responses and errors are returned at the index of their ID.
+func (cs *CheckService) DeleteMany(ctx context.Context, ids []int, config BulkConfig) ([]*PingdomResponse, []error) {
+	responses := make([]*PingdomResponse, len(ids))
+	errs := RunBulk(ctx, config, len(ids), func(i int) error {
+		var err error
+		responses[i], err = cs.Delete(ctx, ids[i])
+		return err
+	})
+	return responses, errs
+}
+
 // SummaryPerformance returns a performance summary from Pingdom.
-func (cs *CheckService) SummaryPerformance(request SummaryPerformanceRequest) (*SummaryPerformanceResponse, error) {
+func (cs *CheckService) SummaryPerformance(ctx context.Context, request SummaryPerformanceRequest) (*SummaryPerformanceResponse, error) {
 	if err := request.Valid(); err != nil {
 		return nil, err
 	}
@@ -142,7 +186,47 @@ func (cs *CheckService) SummaryPerformance(request SummaryPerformanceRequest) (*
 		return nil, err
 	}
 	m := &SummaryPerformanceResponse{}
-	_, err = cs.client.Do(req, m)
+	_, err = cs.client.Do(req.WithContext(ctx), m)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// SummaryOutage returns the list of states of a check, i.e. the intervals
+// during which it was up or down, from Pingdom.
+func (cs *CheckService) SummaryOutage(ctx context.Context, request SummaryOutageRequest) (*SummaryOutageResponse, error) {
+	if err := request.Valid(); err != nil {
+		return nil, err
+	}
+
+	req, err := cs.client.NewRequest("GET", "/summary.outage/"+strconv.Itoa(request.Id), request.GetParams())
+	if err != nil {
+		return nil, err
+	}
+	m := &SummaryOutageResponse{}
+	_, err = cs.client.Do(req.WithContext(ctx), m)
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// SummaryHoursOfDay returns the average response times of a check by hour of
+// the day from Pingdom, e.g. for capacity planning.
+func (cs *CheckService) SummaryHoursOfDay(ctx context.Context, request SummaryHoursOfDayRequest) (*SummaryHoursOfDayResponse, error) {
+	if err := request.Valid(); err != nil {
+		return nil, err
+	}
+
+	req, err := cs.client.NewRequest("GET", "/summary.hoursofday/"+strconv.Itoa(request.Id), request.GetParams())
+	if err != nil {
+		return nil, err
+	}
+	m := &SummaryHoursOfDayResponse{}
+	_, err = cs.client.Do(req.WithContext(ctx), m)
 	if err != nil {
 		return nil, err
 	}
@@ -151,7 +235,7 @@ func (cs *CheckService) SummaryPerformance(request SummaryPerformanceRequest) (*
 }
 
 // Results returns raw check results and the list of associated probe IDs used from Pingdom.
-func (cs *CheckService) Results(id int, params ...map[string]string) (*ResultsResponse, error) {
+func (cs *CheckService) Results(ctx context.Context, id int, params ...map[string]string) (*ResultsResponse, error) {
 	param := map[string]string{}
 	if len(params) == 1 {
 		param = params[0]
@@ -161,20 +245,39 @@ func (cs *CheckService) Results(id int, params ...map[string]string) (*ResultsRe
 		return nil, err
 	}
 
-	resp, err := cs.client.client.Do(req)
+	resp, err := cs.client.exec(req.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if err := validateResponse(resp); err != nil {
-		return nil, err
-	}
-
-	bodyBytes, _ := ioutil.ReadAll(resp.Body)
-	bodyString := string(bodyBytes)
 	m := &ResultsResponse{}
-	err = json.Unmarshal([]byte(bodyString), &m)
+	err = cs.client.decodeResponse(resp, m)
 
 	return m, err
 }
+
+// ResultsWithProbes returns the same as Results, with the probe of each result
+// resolved from the cached probe list, see ProbeService.Get. Results of probes
+// unknown to Pingdom are left without probe.
+func (cs *CheckService) ResultsWithProbes(ctx context.Context, id int, params ...map[string]string) (*ResultsResponse, error) {
+	results, err := cs.Results(ctx, id, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, 0, len(results.Results))
+	for _, result := range results.Results {
+		ids = append(ids, result.ProbeID)
+	}
+	probes, err := cs.client.Probes.lookup(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range results.Results {
+		if probe, ok := probes[results.Results[i].ProbeID]; ok {
+			results.Results[i].Probe = &probe
+		}
+	}
+	return results, nil
+}
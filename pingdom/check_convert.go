@@ -0,0 +1,279 @@
+package pingdom
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ToCheck converts a CheckResponse, as returned by CheckService.Read, into the
+// Check implementation matching its type, so that a fetched check can be sent
+// back through Create or Update without losing any of its settings.  Tags that
+// Pingdom generated automatically (type "a") are not carried over.
+func (cr *CheckResponse) ToCheck() (Check, error) {
+	switch cr.Type.Name {
+	case "http":
+		return cr.toHttpCheck(), nil
+	case "httpcustom":
+		return cr.toHttpCustomCheck(), nil
+	case "ping":
+		return cr.toPingCheck(), nil
+	case "tcp":
+		return cr.toTCPCheck(), nil
+	case "udp":
+		return cr.toUDPCheck(), nil
+	case "smtp":
+		return cr.toSMTPCheck(), nil
+	case "pop3":
+		return cr.toPOP3Check(), nil
+	case "imap":
+		return cr.toIMAPCheck(), nil
+	case "dns":
+		return cr.toDNSCheck(), nil
+	default:
+		return nil, fmt.Errorf("unsupported check type %q", cr.Type.Name)
+	}
+}
+
+func (cr *CheckResponse) toHttpCheck() *HttpCheck {
+	ck := &HttpCheck{
+		Name:                     cr.Name,
+		Hostname:                 cr.Hostname,
+		Resolution:               cr.Resolution,
+		Paused:                   cr.Paused,
+		SendNotificationWhenDown: cr.SendNotificationWhenDown,
+		NotifyAgainEvery:         cr.NotifyAgainEvery,
+		NotifyWhenBackup:         cr.NotifyWhenBackup,
+		IntegrationIds:           cr.IntegrationIds,
+		ResponseTimeThreshold:    cr.ResponseTimeThreshold,
+		Tags:                     cr.tagString(),
+		ProbeFilters:             strings.Join(cr.ProbeFilters, ","),
+		UserIds:                  cr.UserIds,
+		TeamIds:                  cr.TeamIds,
+	}
+
+	if d := cr.Type.HTTP; d != nil {
+		verifyCertificate := d.VerifyCertificate
+		sslDownDaysBefore := d.SSLDownDaysBefore
+
+		ck.Url = d.Url
+		ck.Encryption = d.Encryption
+		ck.Port = d.Port
+		ck.Username = d.Username
+		ck.Password = d.Password
+		ck.ShouldContain = d.ShouldContain
+		ck.ShouldNotContain = d.ShouldNotContain
+		ck.PostData = d.PostData
+		ck.RequestHeaders = d.RequestHeaders
+		ck.VerifyCertificate = &verifyCertificate
+		ck.SSLDownDaysBefore = &sslDownDaysBefore
+	}
+	return ck
+}
+
+func (cr *CheckResponse) toHttpCustomCheck() *HttpCustomCheck {
+	ck := &HttpCustomCheck{
+		Name:                     cr.Name,
+		Hostname:                 cr.Hostname,
+		Resolution:               cr.Resolution,
+		Paused:                   cr.Paused,
+		SendNotificationWhenDown: cr.SendNotificationWhenDown,
+		NotifyAgainEvery:         cr.NotifyAgainEvery,
+		NotifyWhenBackup:         cr.NotifyWhenBackup,
+		IntegrationIds:           cr.IntegrationIds,
+		Tags:                     cr.tagString(),
+		ProbeFilters:             strings.Join(cr.ProbeFilters, ","),
+		UserIds:                  cr.UserIds,
+		TeamIds:                  cr.TeamIds,
+		ResponseTimeThreshold:    cr.ResponseTimeThreshold,
+	}
+
+	if d := cr.Type.HTTPCustom; d != nil {
+		ck.Url = d.Url
+		ck.Encryption = d.Encryption
+		ck.Port = d.Port
+		ck.Username = d.Username
+		ck.Password = d.Password
+		ck.AdditionalUrls = d.AdditionalUrls
+	}
+	return ck
+}
+
+func (cr *CheckResponse) toPingCheck() *PingCheck {
+	return &PingCheck{
+		Name:                     cr.Name,
+		Hostname:                 cr.Hostname,
+		Resolution:               cr.Resolution,
+		Paused:                   cr.Paused,
+		SendNotificationWhenDown: cr.SendNotificationWhenDown,
+		NotifyAgainEvery:         cr.NotifyAgainEvery,
+		NotifyWhenBackup:         cr.NotifyWhenBackup,
+		IntegrationIds:           cr.IntegrationIds,
+		Tags:                     cr.tagString(),
+		ResponseTimeThreshold:    cr.ResponseTimeThreshold,
+		ProbeFilters:             strings.Join(cr.ProbeFilters, ","),
+		UserIds:                  cr.UserIds,
+		TeamIds:                  cr.TeamIds,
+	}
+}
+
+func (cr *CheckResponse) toTCPCheck() *TCPCheck {
+	ck := &TCPCheck{
+		Name:                     cr.Name,
+		Hostname:                 cr.Hostname,
+		Resolution:               cr.Resolution,
+		Paused:                   cr.Paused,
+		SendNotificationWhenDown: cr.SendNotificationWhenDown,
+		NotifyAgainEvery:         cr.NotifyAgainEvery,
+		NotifyWhenBackup:         cr.NotifyWhenBackup,
+		IntegrationIds:           cr.IntegrationIds,
+		Tags:                     cr.tagString(),
+		ProbeFilters:             strings.Join(cr.ProbeFilters, ","),
+		UserIds:                  cr.UserIds,
+		TeamIds:                  cr.TeamIds,
+		ResponseTimeThreshold:    cr.ResponseTimeThreshold,
+	}
+
+	if d := cr.Type.TCP; d != nil {
+		ck.Port = d.Port
+		ck.StringToSend = d.StringToSend
+		ck.StringToExpect = d.StringToExpect
+	}
+	return ck
+}
+
+func (cr *CheckResponse) toUDPCheck() *UDPCheck {
+	ck := &UDPCheck{
+		Name:                     cr.Name,
+		Hostname:                 cr.Hostname,
+		Resolution:               cr.Resolution,
+		Paused:                   cr.Paused,
+		SendNotificationWhenDown: cr.SendNotificationWhenDown,
+		NotifyAgainEvery:         cr.NotifyAgainEvery,
+		NotifyWhenBackup:         cr.NotifyWhenBackup,
+		IntegrationIds:           cr.IntegrationIds,
+		Tags:                     cr.tagString(),
+		ProbeFilters:             strings.Join(cr.ProbeFilters, ","),
+		UserIds:                  cr.UserIds,
+		TeamIds:                  cr.TeamIds,
+		ResponseTimeThreshold:    cr.ResponseTimeThreshold,
+	}
+
+	if d := cr.Type.UDP; d != nil {
+		ck.Port = d.Port
+		ck.StringToSend = d.StringToSend
+		ck.StringToExpect = d.StringToExpect
+	}
+	return ck
+}
+
+func (cr *CheckResponse) toSMTPCheck() *SMTPCheck {
+	ck := &SMTPCheck{
+		Name:                     cr.Name,
+		Hostname:                 cr.Hostname,
+		Resolution:               cr.Resolution,
+		Paused:                   cr.Paused,
+		SendNotificationWhenDown: cr.SendNotificationWhenDown,
+		NotifyAgainEvery:         cr.NotifyAgainEvery,
+		NotifyWhenBackup:         cr.NotifyWhenBackup,
+		IntegrationIds:           cr.IntegrationIds,
+		Tags:                     cr.tagString(),
+		ProbeFilters:             strings.Join(cr.ProbeFilters, ","),
+		UserIds:                  cr.UserIds,
+		TeamIds:                  cr.TeamIds,
+		ResponseTimeThreshold:    cr.ResponseTimeThreshold,
+	}
+
+	if d := cr.Type.SMTP; d != nil {
+		ck.Port = d.Port
+		ck.Auth = d.Auth
+		ck.Encryption = d.Encryption
+		ck.StringToExpect = d.StringToExpect
+	}
+	return ck
+}
+
+func (cr *CheckResponse) toPOP3Check() *POP3Check {
+	ck := &POP3Check{
+		Name:                     cr.Name,
+		Hostname:                 cr.Hostname,
+		Resolution:               cr.Resolution,
+		Paused:                   cr.Paused,
+		SendNotificationWhenDown: cr.SendNotificationWhenDown,
+		NotifyAgainEvery:         cr.NotifyAgainEvery,
+		NotifyWhenBackup:         cr.NotifyWhenBackup,
+		IntegrationIds:           cr.IntegrationIds,
+		Tags:                     cr.tagString(),
+		ProbeFilters:             strings.Join(cr.ProbeFilters, ","),
+		UserIds:                  cr.UserIds,
+		TeamIds:                  cr.TeamIds,
+		ResponseTimeThreshold:    cr.ResponseTimeThreshold,
+	}
+
+	if d := cr.Type.POP3; d != nil {
+		ck.Port = d.Port
+		ck.Encryption = d.Encryption
+		ck.StringToExpect = d.StringToExpect
+	}
+	return ck
+}
+
+func (cr *CheckResponse) toIMAPCheck() *IMAPCheck {
+	ck := &IMAPCheck{
+		Name:                     cr.Name,
+		Hostname:                 cr.Hostname,
+		Resolution:               cr.Resolution,
+		Paused:                   cr.Paused,
+		SendNotificationWhenDown: cr.SendNotificationWhenDown,
+		NotifyAgainEvery:         cr.NotifyAgainEvery,
+		NotifyWhenBackup:         cr.NotifyWhenBackup,
+		IntegrationIds:           cr.IntegrationIds,
+		Tags:                     cr.tagString(),
+		ProbeFilters:             strings.Join(cr.ProbeFilters, ","),
+		UserIds:                  cr.UserIds,
+		TeamIds:                  cr.TeamIds,
+		ResponseTimeThreshold:    cr.ResponseTimeThreshold,
+	}
+
+	if d := cr.Type.IMAP; d != nil {
+		ck.Port = d.Port
+		ck.Encryption = d.Encryption
+		ck.StringToExpect = d.StringToExpect
+	}
+	return ck
+}
+
+func (cr *CheckResponse) toDNSCheck() *DNSCheck {
+	ck := &DNSCheck{
+		Name:                     cr.Name,
+		Hostname:                 cr.Hostname,
+		Resolution:               cr.Resolution,
+		Paused:                   cr.Paused,
+		SendNotificationWhenDown: cr.SendNotificationWhenDown,
+		NotifyAgainEvery:         cr.NotifyAgainEvery,
+		NotifyWhenBackup:         cr.NotifyWhenBackup,
+		IntegrationIds:           cr.IntegrationIds,
+		Tags:                     cr.tagString(),
+		ProbeFilters:             strings.Join(cr.ProbeFilters, ","),
+		UserIds:                  cr.UserIds,
+		TeamIds:                  cr.TeamIds,
+		ResponseTimeThreshold:    cr.ResponseTimeThreshold,
+	}
+
+	if d := cr.Type.DNS; d != nil {
+		ck.ExpectedIP = d.ExpectedIP
+		ck.NameServer = d.NameServer
+	}
+	return ck
+}
+
+// tagString returns the user defined tags as the comma separated list
+// expected by the check parameters.
+func (cr *CheckResponse) tagString() string {
+	var tags []string
+	for _, tag := range cr.Tags {
+		if tag.Type != "a" {
+			tags = append(tags, tag.Name)
+		}
+	}
+	return strings.Join(tags, ",")
+}
@@ -0,0 +1,301 @@
+package pingdom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckResponseToCheck(t *testing.T) {
+	verifyCertificate := true
+	sslDownDaysBefore := 10
+
+	common := CheckResponse{
+		ID:                       1,
+		Name:                     "fake check",
+		Hostname:                 "example.com",
+		Resolution:               5,
+		SendNotificationWhenDown: 2,
+		NotifyAgainEvery:         3,
+		NotifyWhenBackup:         true,
+		Paused:                   true,
+		IntegrationIds:           []int{33333333},
+		Tags: []CheckResponseTag{
+			{Name: "apache", Type: "u"},
+			{Name: "generated", Type: "a"},
+			{Name: "prod", Type: "u"},
+		},
+		UserIds:               []int{123},
+		TeamIds:               []int{789},
+		ProbeFilters:          []string{"region: NA", "region: EU"},
+		ResponseTimeThreshold: 3000,
+	}
+
+	tests := []struct {
+		name      string
+		giveType  CheckResponseType
+		wantCheck Check
+	}{
+		{
+			name: "http",
+			giveType: CheckResponseType{
+				Name: "http",
+				HTTP: &CheckResponseHTTPDetails{
+					Url:               "/foo",
+					Encryption:        true,
+					Port:              443,
+					ShouldContain:     "ok",
+					PostData:          "a=b",
+					RequestHeaders:    map[string]string{"Pragma": "no-cache"},
+					VerifyCertificate: true,
+					SSLDownDaysBefore: 10,
+				},
+			},
+			wantCheck: &HttpCheck{
+				Name:                     "fake check",
+				Hostname:                 "example.com",
+				Resolution:               5,
+				SendNotificationWhenDown: 2,
+				NotifyAgainEvery:         3,
+				NotifyWhenBackup:         true,
+				Paused:                   true,
+				IntegrationIds:           []int{33333333},
+				Tags:                     "apache,prod",
+				UserIds:                  []int{123},
+				TeamIds:                  []int{789},
+				ProbeFilters:             "region: NA,region: EU",
+				ResponseTimeThreshold:    3000,
+				Url:                      "/foo",
+				Encryption:               true,
+				Port:                     443,
+				ShouldContain:            "ok",
+				PostData:                 "a=b",
+				RequestHeaders:           map[string]string{"Pragma": "no-cache"},
+				VerifyCertificate:        &verifyCertificate,
+				SSLDownDaysBefore:        &sslDownDaysBefore,
+			},
+		},
+		{
+			name:     "ping",
+			giveType: CheckResponseType{Name: "ping"},
+			wantCheck: &PingCheck{
+				Name:                     "fake check",
+				Hostname:                 "example.com",
+				Resolution:               5,
+				SendNotificationWhenDown: 2,
+				NotifyAgainEvery:         3,
+				NotifyWhenBackup:         true,
+				Paused:                   true,
+				IntegrationIds:           []int{33333333},
+				Tags:                     "apache,prod",
+				UserIds:                  []int{123},
+				TeamIds:                  []int{789},
+				ProbeFilters:             "region: NA,region: EU",
+				ResponseTimeThreshold:    3000,
+			},
+		},
+		{
+			name: "tcp",
+			giveType: CheckResponseType{
+				Name: "tcp",
+				TCP:  &CheckResponseTCPDetails{Port: 25, StringToSend: "HELO", StringToExpect: "250"},
+			},
+			wantCheck: &TCPCheck{
+				Name:                     "fake check",
+				Hostname:                 "example.com",
+				Resolution:               5,
+				SendNotificationWhenDown: 2,
+				NotifyAgainEvery:         3,
+				NotifyWhenBackup:         true,
+				Paused:                   true,
+				IntegrationIds:           []int{33333333},
+				Tags:                     "apache,prod",
+				UserIds:                  []int{123},
+				TeamIds:                  []int{789},
+				ProbeFilters:             "region: NA,region: EU",
+				ResponseTimeThreshold:    3000,
+				Port:                     25,
+				StringToSend:             "HELO",
+				StringToExpect:           "250",
+			},
+		},
+		{
+			name: "udp",
+			giveType: CheckResponseType{
+				Name: "udp",
+				UDP:  &CheckResponseUDPDetails{Port: 53, StringToSend: "ping", StringToExpect: "pong"},
+			},
+			wantCheck: &UDPCheck{
+				Name:                     "fake check",
+				Hostname:                 "example.com",
+				Resolution:               5,
+				SendNotificationWhenDown: 2,
+				NotifyAgainEvery:         3,
+				NotifyWhenBackup:         true,
+				Paused:                   true,
+				IntegrationIds:           []int{33333333},
+				Tags:                     "apache,prod",
+				UserIds:                  []int{123},
+				TeamIds:                  []int{789},
+				ProbeFilters:             "region: NA,region: EU",
+				ResponseTimeThreshold:    3000,
+				Port:                     53,
+				StringToSend:             "ping",
+				StringToExpect:           "pong",
+			},
+		},
+		{
+			name: "httpcustom",
+			giveType: CheckResponseType{
+				Name: "httpcustom",
+				HTTPCustom: &CheckResponseHTTPCustomDetails{
+					Url:            "/status.xml",
+					Encryption:     true,
+					Port:           8443,
+					Username:       "user",
+					Password:       "secret",
+					AdditionalUrls: []string{"https://a.example.com/status.xml"},
+				},
+			},
+			wantCheck: &HttpCustomCheck{
+				Name:                     "fake check",
+				Hostname:                 "example.com",
+				Resolution:               5,
+				SendNotificationWhenDown: 2,
+				NotifyAgainEvery:         3,
+				NotifyWhenBackup:         true,
+				Paused:                   true,
+				IntegrationIds:           []int{33333333},
+				Tags:                     "apache,prod",
+				UserIds:                  []int{123},
+				TeamIds:                  []int{789},
+				ProbeFilters:             "region: NA,region: EU",
+				ResponseTimeThreshold:    3000,
+				Url:                      "/status.xml",
+				Encryption:               true,
+				Port:                     8443,
+				Username:                 "user",
+				Password:                 "secret",
+				AdditionalUrls:           []string{"https://a.example.com/status.xml"},
+			},
+		},
+		{
+			name: "pop3",
+			giveType: CheckResponseType{
+				Name: "pop3",
+				POP3: &CheckResponseMailDetails{Port: 995, Encryption: true, StringToExpect: "+OK"},
+			},
+			wantCheck: &POP3Check{
+				Name:                     "fake check",
+				Hostname:                 "example.com",
+				Resolution:               5,
+				SendNotificationWhenDown: 2,
+				NotifyAgainEvery:         3,
+				NotifyWhenBackup:         true,
+				Paused:                   true,
+				IntegrationIds:           []int{33333333},
+				Tags:                     "apache,prod",
+				UserIds:                  []int{123},
+				TeamIds:                  []int{789},
+				ProbeFilters:             "region: NA,region: EU",
+				ResponseTimeThreshold:    3000,
+				Port:                     995,
+				Encryption:               true,
+				StringToExpect:           "+OK",
+			},
+		},
+		{
+			name: "imap",
+			giveType: CheckResponseType{
+				Name: "imap",
+				IMAP: &CheckResponseMailDetails{Port: 993, Encryption: true, StringToExpect: "* OK"},
+			},
+			wantCheck: &IMAPCheck{
+				Name:                     "fake check",
+				Hostname:                 "example.com",
+				Resolution:               5,
+				SendNotificationWhenDown: 2,
+				NotifyAgainEvery:         3,
+				NotifyWhenBackup:         true,
+				Paused:                   true,
+				IntegrationIds:           []int{33333333},
+				Tags:                     "apache,prod",
+				UserIds:                  []int{123},
+				TeamIds:                  []int{789},
+				ProbeFilters:             "region: NA,region: EU",
+				ResponseTimeThreshold:    3000,
+				Port:                     993,
+				Encryption:               true,
+				StringToExpect:           "* OK",
+			},
+		},
+		{
+			name: "smtp",
+			giveType: CheckResponseType{
+				Name: "smtp",
+				SMTP: &CheckResponseSMTPDetails{Port: 587, Auth: "postmaster:secret", Encryption: true, StringToExpect: "ESMTP"},
+			},
+			wantCheck: &SMTPCheck{
+				Name:                     "fake check",
+				Hostname:                 "example.com",
+				Resolution:               5,
+				SendNotificationWhenDown: 2,
+				NotifyAgainEvery:         3,
+				NotifyWhenBackup:         true,
+				Paused:                   true,
+				IntegrationIds:           []int{33333333},
+				Tags:                     "apache,prod",
+				UserIds:                  []int{123},
+				TeamIds:                  []int{789},
+				ProbeFilters:             "region: NA,region: EU",
+				ResponseTimeThreshold:    3000,
+				Port:                     587,
+				Auth:                     "postmaster:secret",
+				Encryption:               true,
+				StringToExpect:           "ESMTP",
+			},
+		},
+		{
+			name: "dns",
+			giveType: CheckResponseType{
+				Name: "dns",
+				DNS:  &CheckResponseDNSDetails{ExpectedIP: "192.168.1.1", NameServer: "8.8.8.8"},
+			},
+			wantCheck: &DNSCheck{
+				Name:                     "fake check",
+				Hostname:                 "example.com",
+				Resolution:               5,
+				SendNotificationWhenDown: 2,
+				NotifyAgainEvery:         3,
+				NotifyWhenBackup:         true,
+				Paused:                   true,
+				IntegrationIds:           []int{33333333},
+				Tags:                     "apache,prod",
+				UserIds:                  []int{123},
+				TeamIds:                  []int{789},
+				ProbeFilters:             "region: NA,region: EU",
+				ResponseTimeThreshold:    3000,
+				ExpectedIP:               "192.168.1.1",
+				NameServer:               "8.8.8.8",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := common
+			cr.Type = tt.giveType
+
+			check, err := cr.ToCheck()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCheck, check)
+			assert.NoError(t, check.Valid())
+		})
+	}
+}
+
+func TestCheckResponseToCheckUnsupportedType(t *testing.T) {
+	cr := CheckResponse{Type: CheckResponseType{Name: "unknown"}}
+	_, err := cr.ToCheck()
+	assert.Error(t, err)
+}
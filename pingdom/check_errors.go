@@ -7,3 +7,30 @@ var ErrMissingId = errors.New("required field 'Id' missing")
 
 // ErrBadResolution is an error for when an invalid resolution is specified.
 var ErrBadResolution = errors.New("resolution must be either 'hour', 'day' or 'week'")
+
+// ErrBadOrder is an error for when an invalid order is specified.
+var ErrBadOrder = errors.New("order must be either 'asc' or 'desc'")
+
+// ErrBadResultStatus is an error for when an invalid result status is specified.
+var ErrBadResultStatus = errors.New("status must be 'up', 'down', 'unconfirmed' or 'unknown'")
+
+// ErrBadLimit is an error for when a limit out of range is specified.
+var ErrBadLimit = errors.New("limit must be between 0 and 1000")
+
+// ErrBadOffset is an error for when an offset out of range is specified.
+var ErrBadOffset = errors.New("offset must be between 0 and 43200")
+
+// ErrMissingHost is an error for when a required Host field is missing.
+var ErrMissingHost = errors.New("required field 'Host' missing")
+
+// ErrMissingType is an error for when a required Type field is missing.
+var ErrMissingType = errors.New("required field 'Type' missing")
+
+// ErrBadActionStatus is an error for when an invalid alert status is specified.
+var ErrBadActionStatus = errors.New("status must be 'sent', 'delivered', 'error', 'not_delivered' or 'no_credits'")
+
+// ErrBadActionVia is an error for when an invalid alert medium is specified.
+var ErrBadActionVia = errors.New("via must be 'email', 'sms', 'twitter', 'iphone' or 'android'")
+
+// ErrBadActionLimit is an error for when an alert limit out of range is specified.
+var ErrBadActionLimit = errors.New("limit must be between 0 and 300")
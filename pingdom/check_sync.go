@@ -0,0 +1,84 @@
+package pingdom
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+)
+
+// CheckSyncState is the hash of the settings of each check listed by
+// ListChanged, keyed by check ID. It can be saved between the runs of a sync
+// loop, e.g. as JSON.
+type CheckSyncState map[int]string
+
+// CheckChanges are the checks added, updated and removed since the state a
+// ListChanged call started from, sorted by ID. State is the state to pass to
+// the next call.
+type CheckChanges struct {
+	Added   []CheckResponse
+	Updated []CheckResponse
+	Removed []int
+	State   CheckSyncState
+}
+
+// Empty reports whether no check changed.
+func (c *CheckChanges) Empty() bool {
+	return len(c.Added) == 0 && len(c.Updated) == 0 && len(c.Removed) == 0
+}
+
+// ListChanged returns the checks which changed since the given state, nil
+// for the first call.  The checks API has no modification time to filter
+// on, and the last test time changes with every test, so the checks are
+// still listed in full but compared with the state by the hash of their
+// settings: the status and the times of the last test, error and response
+// are left out.  The same params must be passed to List on every call, or
+// the checks they filter out are reported as removed.
+func (cs *CheckService) ListChanged(ctx context.Context, state CheckSyncState, params ...map[string]string) (*CheckChanges, error) {
+	checks, err := cs.List(ctx, params...)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := &CheckChanges{State: make(CheckSyncState, len(checks))}
+	for _, check := range checks {
+		hash, err := checkSettingsHash(check)
+		if err != nil {
+			return nil, err
+		}
+		changes.State[check.ID] = hash
+		previous, seen := state[check.ID]
+		switch {
+		case !seen:
+			changes.Added = append(changes.Added, check)
+		case previous != hash:
+			changes.Updated = append(changes.Updated, check)
+		}
+	}
+	for id := range state {
+		if _, ok := changes.State[id]; !ok {
+			changes.Removed = append(changes.Removed, id)
+		}
+	}
+
+	sort.Slice(changes.Added, func(i, j int) bool { return changes.Added[i].ID < changes.Added[j].ID })
+	sort.Slice(changes.Updated, func(i, j int) bool { return changes.Updated[i].ID < changes.Updated[j].ID })
+	sort.Ints(changes.Removed)
+	return changes, nil
+}
+
+// checkSettingsHash hashes the check without the fields which change as it
+// runs.
+func checkSettingsHash(check CheckResponse) (string, error) {
+	check.Status = ""
+	check.LastErrorTime = 0
+	check.LastTestTime = 0
+	check.LastResponseTime = 0
+	b, err := json.Marshal(check)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
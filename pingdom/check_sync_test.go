@@ -0,0 +1,60 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckServiceListChanged(t *testing.T) {
+	setup()
+	defer teardown()
+
+	responses := []string{
+		`{"checks": [
+			{"id": 1, "name": "API", "hostname": "api.example.com", "status": "up", "lasttesttime": 1000},
+			{"id": 2, "name": "Web", "hostname": "www.example.com", "status": "up", "lasttesttime": 1000}
+		]}`,
+		`{"checks": [
+			{"id": 1, "name": "API", "hostname": "api.example.com", "status": "down", "lasttesttime": 1060, "lasterrortime": 1060},
+			{"id": 2, "name": "Web", "hostname": "www.example.com", "status": "up", "lasttesttime": 1060}
+		]}`,
+		`{"checks": [
+			{"id": 2, "name": "Web", "hostname": "www2.example.com", "status": "up", "lasttesttime": 1120},
+			{"id": 3, "name": "CDN", "hostname": "cdn.example.com", "status": "unknown"}
+		]}`,
+	}
+	calls := 0
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "include_tags=true", r.URL.RawQuery)
+		fmt.Fprint(w, responses[calls])
+		calls++
+	})
+	params := map[string]string{"include_tags": "true"}
+
+	changes, err := client.Checks.ListChanged(context.Background(), nil, params)
+	assert.NoError(t, err)
+	assert.Len(t, changes.Added, 2)
+	assert.Empty(t, changes.Updated)
+	assert.Empty(t, changes.Removed)
+	assert.Len(t, changes.State, 2)
+
+	// Only the status and test times changed.
+	changes, err = client.Checks.ListChanged(context.Background(), changes.State, params)
+	assert.NoError(t, err)
+	assert.True(t, changes.Empty())
+
+	changes, err = client.Checks.ListChanged(context.Background(), changes.State, params)
+	assert.NoError(t, err)
+	if assert.Len(t, changes.Added, 1) && assert.Len(t, changes.Updated, 1) {
+		assert.Equal(t, 3, changes.Added[0].ID)
+		assert.Equal(t, "www2.example.com", changes.Updated[0].Hostname)
+	}
+	assert.Equal(t, []int{1}, changes.Removed)
+	assert.Len(t, changes.State, 2)
+	assert.False(t, changes.Empty())
+}
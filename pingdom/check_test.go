@@ -1,6 +1,7 @@
 package pingdom
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"testing"
@@ -141,7 +142,7 @@ func TestCheckServiceList(t *testing.T) {
 		},
 	}
 
-	checks, err := client.Checks.List()
+	checks, err := client.Checks.List(context.Background())
 	assert.NoError(t, err)
 	assert.Equal(t, want, checks)
 }
@@ -168,7 +169,7 @@ func TestCheckServiceCreate(t *testing.T) {
 	}
 	want := &CheckResponse{ID: 138631, Name: "My new HTTP check"}
 
-	check, err := client.Checks.Create(&newCheck)
+	check, err := client.Checks.Create(context.Background(), &newCheck)
 	assert.NoError(t, err)
 	assert.Equal(t, want, check)
 }
@@ -261,7 +262,7 @@ func TestCheckServiceRead(t *testing.T) {
 		ProbeFilters:   []string{},
 	}
 
-	check, err := client.Checks.Read(85975)
+	check, err := client.Checks.Read(context.Background(), 85975)
 	assert.NoError(t, err)
 	assert.Equal(t, want, check)
 }
@@ -278,7 +279,7 @@ func TestCheckServiceUpdate(t *testing.T) {
 	updateCheck := HttpCheck{Name: "Updated Check", Hostname: "example2.com", Resolution: 5}
 	want := &PingdomResponse{Message: "Modification of check was successful!"}
 
-	msg, err := client.Checks.Update(12345, &updateCheck)
+	msg, err := client.Checks.Update(context.Background(), 12345, &updateCheck)
 	assert.NoError(t, err)
 	assert.Equal(t, want, msg)
 }
@@ -294,7 +295,7 @@ func TestCheckServiceDelete(t *testing.T) {
 
 	want := &PingdomResponse{Message: "Deletion of check was successful!"}
 
-	msg, err := client.Checks.Delete(12345)
+	msg, err := client.Checks.Delete(context.Background(), 12345)
 	assert.NoError(t, err)
 	assert.Equal(t, want, msg)
 }
@@ -315,7 +316,7 @@ func TestCheckServiceSummaryPerformance(t *testing.T) {
 			fmt.Fprint(w, errorMsg)
 		})
 
-		_, err := client.Checks.SummaryPerformance(request)
+		_, err := client.Checks.SummaryPerformance(context.Background(), request)
 
 		assert.Equal(t, &PingdomError{
 			StatusCode: 401,
@@ -377,13 +378,81 @@ func TestCheckServiceSummaryPerformance(t *testing.T) {
 }`)
 		})
 
-		resp, err := client.Checks.SummaryPerformance(request)
+		resp, err := client.Checks.SummaryPerformance(context.Background(), request)
 
 		assert.NoError(t, err)
 		assert.Equal(t, expectedResponse, *resp)
 	})
 }
 
+func TestCheckServiceSummaryOutage(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/summary.outage/12345", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "1536926400", r.URL.Query().Get("from"))
+		fmt.Fprint(w, `{
+	"summary": {
+		"states": [
+			{"status": "up", "timefrom": 1536926400, "timeto": 1536927000},
+			{"status": "down", "timefrom": 1536927000, "timeto": 1536927300},
+			{"status": "up", "timefrom": 1536927300, "timeto": 1536930000}
+		]
+	}
+}`)
+	})
+
+	want := &SummaryOutageResponse{
+		Summary: SummaryOutageStates{
+			States: []SummaryOutageState{
+				{Status: "up", TimeFrom: 1536926400, TimeTo: 1536927000},
+				{Status: "down", TimeFrom: 1536927000, TimeTo: 1536927300},
+				{Status: "up", TimeFrom: 1536927300, TimeTo: 1536930000},
+			},
+		},
+	}
+
+	resp, err := client.Checks.SummaryOutage(context.Background(), SummaryOutageRequest{Id: 12345, From: 1536926400})
+	assert.NoError(t, err)
+	assert.Equal(t, want, resp)
+
+	_, err = client.Checks.SummaryOutage(context.Background(), SummaryOutageRequest{})
+	assert.Equal(t, ErrMissingId, err)
+}
+
+func TestCheckServiceSummaryHoursOfDay(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/summary.hoursofday/12345", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "true", r.URL.Query().Get("uselocaltime"))
+		fmt.Fprint(w, `{
+	"hoursofday": [
+		{"hour": 0, "avgresponse": 215},
+		{"hour": 1, "avgresponse": 198},
+		{"hour": 2, "avgresponse": 240}
+	]
+}`)
+	})
+
+	want := &SummaryHoursOfDayResponse{
+		HoursOfDay: []SummaryHourOfDay{
+			{Hour: 0, AvgResponse: 215},
+			{Hour: 1, AvgResponse: 198},
+			{Hour: 2, AvgResponse: 240},
+		},
+	}
+
+	resp, err := client.Checks.SummaryHoursOfDay(context.Background(), SummaryHoursOfDayRequest{Id: 12345, UseLocalTime: true})
+	assert.NoError(t, err)
+	assert.Equal(t, want, resp)
+
+	_, err = client.Checks.SummaryHoursOfDay(context.Background(), SummaryHoursOfDayRequest{})
+	assert.Equal(t, ErrMissingId, err)
+}
+
 func TestCheckServiceResults(t *testing.T) {
 	setup()
 	defer teardown()
@@ -454,7 +523,76 @@ func TestCheckServiceResults(t *testing.T) {
 		},
 	}
 
-	results, err := client.Checks.Results(12345)
+	results, err := client.Checks.Results(context.Background(), 12345)
 	assert.NoError(t, err)
 	assert.Equal(t, want, results)
 }
+
+func TestCheckServiceBulk(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprintf(w, `{"check":{"id":1,"name":"%v"}}`, r.URL.Query().Get("name"))
+	})
+	mux.HandleFunc("/checks/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			fmt.Fprint(w, `{"message":"Modification of check was successful!"}`)
+			return
+		}
+		testMethod(t, r, "DELETE")
+		fmt.Fprint(w, `{"message":"Deletion of check was successful!"}`)
+	})
+	mux.HandleFunc("/checks/2", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":{"statuscode":404,"statusdesc":"Not Found","errormessage":"Check not found"}}`)
+	})
+
+	created, errs := client.Checks.CreateMany(context.Background(), []Check{
+		&HttpCheck{Name: "first", Hostname: "example.com", Resolution: 5},
+		&HttpCheck{Name: "second", Hostname: "example.com", Resolution: 5},
+	}, BulkConfig{})
+	assert.Equal(t, []error{nil, nil}, errs)
+	assert.Equal(t, "first", created[0].Name)
+	assert.Equal(t, "second", created[1].Name)
+
+	updated, errs := client.Checks.UpdateMany(context.Background(), []CheckUpdate{
+		{ID: 1, Check: &HttpCheck{Name: "first", Hostname: "example.org", Resolution: 5}},
+	}, BulkConfig{})
+	assert.Equal(t, []error{nil}, errs)
+	assert.Equal(t, "Modification of check was successful!", updated[0].Message)
+
+	deleted, errs := client.Checks.DeleteMany(context.Background(), []int{1, 2}, BulkConfig{})
+	assert.NoError(t, errs[0])
+	assert.Equal(t, "Deletion of check was successful!", deleted[0].Message)
+	assert.Error(t, errs[1])
+	assert.Nil(t, deleted[1])
+}
+
+func TestCheckServiceResultsWithProbes(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/results/12345", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{
+	"activeprobes": [32, 87],
+	"results": [
+		{"probeid": 87, "time": 1563370611, "status": "up", "responsetime": 145, "statusdesc": "OK", "statusdesclong": "OK"},
+		{"probeid": 99, "time": 1563370551, "status": "up", "responsetime": 56, "statusdesc": "OK", "statusdesclong": "OK"}
+	]
+}`)
+	})
+	mux.HandleFunc("/probes", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, probeListJSON)
+	})
+
+	results, err := client.Checks.ResultsWithProbes(context.Background(), 12345)
+	assert.NoError(t, err)
+	if assert.NotNil(t, results.Results[0].Probe) {
+		assert.Equal(t, "Frankfurt, Germany", results.Results[0].Probe.Name)
+		assert.Equal(t, "DE", results.Results[0].Probe.CountryISO)
+	}
+	assert.Nil(t, results.Results[1].Probe)
+}
@@ -4,9 +4,15 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"strings"
 )
 
-// HttpCheck represents a Pingdom HTTP check.
+// HttpCheck represents a Pingdom HTTP check.  The API has no setting for the
+// expected status codes: a response with a 4xx or 5xx status is down, any
+// other one is up, see preflight.Checker.ExpectedStatus to verify a status
+// before creating a check.  VerifyCertificate and SSLDownDaysBefore monitor
+// the certificate of an encrypted check, which is down for an invalid one or
+// one expiring within that many days; nil leaves them unchanged on update.
 type HttpCheck struct {
 	Name                     string            `json:"name"`
 	Hostname                 string            `json:"hostname,omitempty"`
@@ -34,6 +40,32 @@ type HttpCheck struct {
 	SSLDownDaysBefore        *int              `json:"ssl_down_days_before,omitempty"`
 }
 
+// HttpCustomCheck represents a Pingdom HTTP custom check, which reads the
+// status and response time from an XML document served at Url, see the
+// Pingdom documentation for its format.  AdditionalUrls are the full URLs of
+// further servers to read the document from.
+type HttpCustomCheck struct {
+	Name                     string   `json:"name"`
+	Hostname                 string   `json:"hostname,omitempty"`
+	Resolution               int      `json:"resolution,omitempty"`
+	Paused                   bool     `json:"paused,omitempty"`
+	SendNotificationWhenDown int      `json:"sendnotificationwhendown,omitempty"`
+	NotifyAgainEvery         int      `json:"notifyagainevery,omitempty"`
+	NotifyWhenBackup         bool     `json:"notifywhenbackup,omitempty"`
+	Url                      string   `json:"url"`
+	Encryption               bool     `json:"encryption,omitempty"`
+	Port                     int      `json:"port,omitempty"`
+	Username                 string   `json:"username,omitempty"`
+	Password                 string   `json:"password,omitempty"`
+	AdditionalUrls           []string `json:"additionalurls,omitempty"`
+	IntegrationIds           []int    `json:"integrationids,omitempty"`
+	Tags                     string   `json:"tags,omitempty"`
+	ResponseTimeThreshold    int      `json:"responsetime_threshold,omitempty"`
+	ProbeFilters             string   `json:"probe_filters,omitempty"`
+	UserIds                  []int    `json:"userids,omitempty"`
+	TeamIds                  []int    `json:"teamids,omitempty"`
+}
+
 // PingCheck represents a Pingdom ping check.
 type PingCheck struct {
 	Name                     string `json:"name"`
@@ -62,6 +94,7 @@ type TCPCheck struct {
 	NotifyWhenBackup         bool   `json:"notifywhenbackup,omitempty"`
 	IntegrationIds           []int  `json:"integrationids,omitempty"`
 	Tags                     string `json:"tags,omitempty"`
+	ResponseTimeThreshold    int    `json:"responsetime_threshold,omitempty"`
 	ProbeFilters             string `json:"probe_filters,omitempty"`
 	UserIds                  []int  `json:"userids,omitempty"`
 	TeamIds                  []int  `json:"teamids,omitempty"`
@@ -70,6 +103,92 @@ type TCPCheck struct {
 	StringToExpect           string `json:"stringtoexpect,omitempty"`
 }
 
+// UDPCheck represents a Pingdom UDP check, which sends StringToSend to Port
+// and expects StringToExpect in the answer.
+type UDPCheck struct {
+	Name                     string `json:"name"`
+	Hostname                 string `json:"hostname,omitempty"`
+	Resolution               int    `json:"resolution,omitempty"`
+	Paused                   bool   `json:"paused,omitempty"`
+	SendNotificationWhenDown int    `json:"sendnotificationwhendown,omitempty"`
+	NotifyAgainEvery         int    `json:"notifyagainevery,omitempty"`
+	NotifyWhenBackup         bool   `json:"notifywhenbackup,omitempty"`
+	IntegrationIds           []int  `json:"integrationids,omitempty"`
+	Tags                     string `json:"tags,omitempty"`
+	ResponseTimeThreshold    int    `json:"responsetime_threshold,omitempty"`
+	ProbeFilters             string `json:"probe_filters,omitempty"`
+	UserIds                  []int  `json:"userids,omitempty"`
+	TeamIds                  []int  `json:"teamids,omitempty"`
+	Port                     int    `json:"port"`
+	StringToSend             string `json:"stringtosend,omitempty"`
+	StringToExpect           string `json:"stringtoexpect,omitempty"`
+}
+
+// SMTPCheck represents a Pingdom SMTP check of a mail server.  Auth is the
+// username and password to log in with, colon separated, and Encryption
+// connects with TLS.
+type SMTPCheck struct {
+	Name                     string `json:"name"`
+	Hostname                 string `json:"hostname,omitempty"`
+	Resolution               int    `json:"resolution,omitempty"`
+	Paused                   bool   `json:"paused,omitempty"`
+	SendNotificationWhenDown int    `json:"sendnotificationwhendown,omitempty"`
+	NotifyAgainEvery         int    `json:"notifyagainevery,omitempty"`
+	NotifyWhenBackup         bool   `json:"notifywhenbackup,omitempty"`
+	IntegrationIds           []int  `json:"integrationids,omitempty"`
+	Tags                     string `json:"tags,omitempty"`
+	ResponseTimeThreshold    int    `json:"responsetime_threshold,omitempty"`
+	ProbeFilters             string `json:"probe_filters,omitempty"`
+	UserIds                  []int  `json:"userids,omitempty"`
+	TeamIds                  []int  `json:"teamids,omitempty"`
+	Port                     int    `json:"port,omitempty"`
+	Auth                     string `json:"auth,omitempty"`
+	Encryption               bool   `json:"encryption,omitempty"`
+	StringToExpect           string `json:"stringtoexpect,omitempty"`
+}
+
+// POP3Check represents a Pingdom POP3 check of a mail server.  Encryption
+// connects with TLS, and StringToExpect is looked for in the greeting.
+type POP3Check struct {
+	Name                     string `json:"name"`
+	Hostname                 string `json:"hostname,omitempty"`
+	Resolution               int    `json:"resolution,omitempty"`
+	Paused                   bool   `json:"paused,omitempty"`
+	SendNotificationWhenDown int    `json:"sendnotificationwhendown,omitempty"`
+	NotifyAgainEvery         int    `json:"notifyagainevery,omitempty"`
+	NotifyWhenBackup         bool   `json:"notifywhenbackup,omitempty"`
+	IntegrationIds           []int  `json:"integrationids,omitempty"`
+	Tags                     string `json:"tags,omitempty"`
+	ResponseTimeThreshold    int    `json:"responsetime_threshold,omitempty"`
+	ProbeFilters             string `json:"probe_filters,omitempty"`
+	UserIds                  []int  `json:"userids,omitempty"`
+	TeamIds                  []int  `json:"teamids,omitempty"`
+	Port                     int    `json:"port,omitempty"`
+	Encryption               bool   `json:"encryption,omitempty"`
+	StringToExpect           string `json:"stringtoexpect,omitempty"`
+}
+
+// IMAPCheck represents a Pingdom IMAP check of a mail server.  Encryption
+// connects with TLS, and StringToExpect is looked for in the greeting.
+type IMAPCheck struct {
+	Name                     string `json:"name"`
+	Hostname                 string `json:"hostname,omitempty"`
+	Resolution               int    `json:"resolution,omitempty"`
+	Paused                   bool   `json:"paused,omitempty"`
+	SendNotificationWhenDown int    `json:"sendnotificationwhendown,omitempty"`
+	NotifyAgainEvery         int    `json:"notifyagainevery,omitempty"`
+	NotifyWhenBackup         bool   `json:"notifywhenbackup,omitempty"`
+	IntegrationIds           []int  `json:"integrationids,omitempty"`
+	Tags                     string `json:"tags,omitempty"`
+	ResponseTimeThreshold    int    `json:"responsetime_threshold,omitempty"`
+	ProbeFilters             string `json:"probe_filters,omitempty"`
+	UserIds                  []int  `json:"userids,omitempty"`
+	TeamIds                  []int  `json:"teamids,omitempty"`
+	Port                     int    `json:"port,omitempty"`
+	Encryption               bool   `json:"encryption,omitempty"`
+	StringToExpect           string `json:"stringtoexpect,omitempty"`
+}
+
 // DNSCheck represents a Pingdom DNS check.
 type DNSCheck struct {
 	Name                     string `json:"name"`
@@ -83,6 +202,7 @@ type DNSCheck struct {
 	NotifyWhenBackup         bool   `json:"notifywhenbackup,omitempty"`
 	IntegrationIds           []int  `json:"integrationids,omitempty"`
 	Tags                     string `json:"tags,omitempty"`
+	ResponseTimeThreshold    int    `json:"responsetime_threshold,omitempty"`
 	ProbeFilters             string `json:"probe_filters,omitempty"`
 	UserIds                  []int  `json:"userids,omitempty"`
 	TeamIds                  []int  `json:"teamids,omitempty"`
@@ -99,6 +219,27 @@ type SummaryPerformanceRequest struct {
 	Order         string
 }
 
+// SummaryOutageRequest is the API request to Pingdom for a SummaryOutage.
+// From and To are unix timestamps, Order is either "asc" or "desc".
+type SummaryOutageRequest struct {
+	Id    int
+	From  int
+	To    int
+	Order string
+}
+
+// SummaryHoursOfDayRequest is the API request to Pingdom for a
+// SummaryHoursOfDay.  From and To are unix timestamps, Probes a comma
+// separated list of probe IDs.  UseLocalTime groups the hours in the time zone
+// of the account instead of UTC.
+type SummaryHoursOfDayRequest struct {
+	Id           int
+	From         int
+	To           int
+	Probes       string
+	UseLocalTime bool
+}
+
 // PutParams returns a map of parameters for an HttpCheck that can be sent along
 // with an HTTP PUT request.
 func (ck *HttpCheck) PutParams() map[string]string {
@@ -200,6 +341,108 @@ func (ck *HttpCheck) Valid() error {
 		return fmt.Errorf("`ShouldContain` and `ShouldNotContain` must not be declared at the same time")
 	}
 
+	if ck.SSLDownDaysBefore != nil && *ck.SSLDownDaysBefore < 0 {
+		return fmt.Errorf("invalid value %d for `SSLDownDaysBefore`, must not be negative", *ck.SSLDownDaysBefore)
+	}
+
+	names := make([]string, 0, len(ck.RequestHeaders))
+	for name := range ck.RequestHeaders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if name == "" || strings.ContainsAny(name, ": \t\r\n") {
+			return fmt.Errorf("invalid request header name %q, must be a non-empty token without colons or white space", name)
+		}
+		if strings.ContainsAny(ck.RequestHeaders[name], "\r\n") {
+			return fmt.Errorf("invalid value of request header %s, must not contain line breaks", name)
+		}
+	}
+
+	return nil
+}
+
+// PutParams returns a map of parameters for an HttpCustomCheck that can be
+// sent along with an HTTP PUT request.
+func (ck *HttpCustomCheck) PutParams() map[string]string {
+	m := map[string]string{
+		"name":             ck.Name,
+		"host":             ck.Hostname,
+		"paused":           strconv.FormatBool(ck.Paused),
+		"notifyagainevery": strconv.Itoa(ck.NotifyAgainEvery),
+		"notifywhenbackup": strconv.FormatBool(ck.NotifyWhenBackup),
+		"url":              ck.Url,
+		"encryption":       strconv.FormatBool(ck.Encryption),
+		"additionalurls":   strings.Join(ck.AdditionalUrls, ";"),
+		"integrationids":   intListToCDString(ck.IntegrationIds),
+		"tags":             ck.Tags,
+		"probe_filters":    ck.ProbeFilters,
+		"userids":          intListToCDString(ck.UserIds),
+		"teamids":          intListToCDString(ck.TeamIds),
+	}
+
+	if ck.Resolution != 0 {
+		m["resolution"] = strconv.Itoa(ck.Resolution)
+	}
+
+	if ck.SendNotificationWhenDown != 0 {
+		m["sendnotificationwhendown"] = strconv.Itoa(ck.SendNotificationWhenDown)
+	}
+
+	// Ignore zero values
+	if ck.Port != 0 {
+		m["port"] = strconv.Itoa(ck.Port)
+	}
+
+	// Convert auth
+	if ck.Username != "" {
+		m["auth"] = fmt.Sprintf("%s:%s", ck.Username, ck.Password)
+	}
+
+	if ck.ResponseTimeThreshold != 0 {
+		m["responsetime_threshold"] = strconv.Itoa(ck.ResponseTimeThreshold)
+	}
+
+	return m
+}
+
+// PostParams returns a map of parameters for an HttpCustomCheck that can be
+// sent along with an HTTP POST request. Same as PUT, with empty strings
+// cleared out.
+func (ck *HttpCustomCheck) PostParams() map[string]string {
+	params := ck.PutParams()
+
+	for k, v := range params {
+		if v == "" {
+			delete(params, k)
+		}
+	}
+	params["type"] = "httpcustom"
+
+	return params
+}
+
+// Valid determines whether the HttpCustomCheck contains valid fields.  This
+// can be used to guard against sending illegal values to the Pingdom API.
+func (ck *HttpCustomCheck) Valid() error {
+	if err := validCommonParameters(ck.Name, ck.Hostname, ck.Resolution); err != nil {
+		return err
+	}
+
+	if ck.Url == "" {
+		return fmt.Errorf("Invalid value for `Url`.  Must contain the path of the XML document")
+	}
+
+	if ck.Port < 0 || ck.Port > 65535 {
+		return fmt.Errorf("Invalid value for `Port`.  Must contain an integer >= 1 and <= 65535")
+	}
+
+	for _, u := range ck.AdditionalUrls {
+		if u == "" || strings.Contains(u, ";") {
+			return fmt.Errorf("invalid additional URL %q, must be a full URL without \";\"", u)
+		}
+	}
+
 	return nil
 }
 
@@ -291,6 +534,10 @@ func (ck *TCPCheck) PutParams() map[string]string {
 		m["stringtoexpect"] = ck.StringToExpect
 	}
 
+	if ck.ResponseTimeThreshold != 0 {
+		m["responsetime_threshold"] = strconv.Itoa(ck.ResponseTimeThreshold)
+	}
+
 	return m
 }
 
@@ -323,6 +570,283 @@ func (ck *TCPCheck) Valid() error {
 	return nil
 }
 
+// PutParams returns a map of parameters for a UDPCheck that can be sent along
+// with an HTTP PUT request.
+func (ck *UDPCheck) PutParams() map[string]string {
+	m := map[string]string{
+		"name":             ck.Name,
+		"host":             ck.Hostname,
+		"paused":           strconv.FormatBool(ck.Paused),
+		"notifyagainevery": strconv.Itoa(ck.NotifyAgainEvery),
+		"notifywhenbackup": strconv.FormatBool(ck.NotifyWhenBackup),
+		"integrationids":   intListToCDString(ck.IntegrationIds),
+		"probe_filters":    ck.ProbeFilters,
+		"tags":             ck.Tags,
+		"userids":          intListToCDString(ck.UserIds),
+		"teamids":          intListToCDString(ck.TeamIds),
+		"port":             strconv.Itoa(ck.Port),
+		"stringtosend":     ck.StringToSend,
+		"stringtoexpect":   ck.StringToExpect,
+	}
+
+	if ck.Resolution != 0 {
+		m["resolution"] = strconv.Itoa(ck.Resolution)
+	}
+
+	if ck.SendNotificationWhenDown != 0 {
+		m["sendnotificationwhendown"] = strconv.Itoa(ck.SendNotificationWhenDown)
+	}
+
+	if ck.ResponseTimeThreshold != 0 {
+		m["responsetime_threshold"] = strconv.Itoa(ck.ResponseTimeThreshold)
+	}
+
+	return m
+}
+
+// PostParams returns a map of parameters for a UDPCheck that can be sent along
+// with an HTTP POST request. Same as PUT.
+func (ck *UDPCheck) PostParams() map[string]string {
+	params := ck.PutParams()
+
+	for k, v := range params {
+		if v == "" {
+			delete(params, k)
+		}
+	}
+
+	params["type"] = "udp"
+	return params
+}
+
+// Valid determines whether the UDPCheck contains valid fields.  This can be
+// used to guard against sending illegal values to the Pingdom API.
+func (ck *UDPCheck) Valid() error {
+	if err := validCommonParameters(ck.Name, ck.Hostname, ck.Resolution); err != nil {
+		return err
+	}
+
+	if ck.Port < 1 || ck.Port > 65535 {
+		return fmt.Errorf("Invalid value for `Port`.  Must contain an integer >= 1 and <= 65535")
+	}
+
+	if ck.StringToSend == "" {
+		return fmt.Errorf("invalid value for `StringToSend`, must contain non-empty string")
+	}
+
+	if ck.StringToExpect == "" {
+		return fmt.Errorf("invalid value for `StringToExpect`, must contain non-empty string")
+	}
+
+	return nil
+}
+
+// PutParams returns a map of parameters for an SMTPCheck that can be sent
+// along with an HTTP PUT request.
+func (ck *SMTPCheck) PutParams() map[string]string {
+	m := map[string]string{
+		"name":             ck.Name,
+		"host":             ck.Hostname,
+		"paused":           strconv.FormatBool(ck.Paused),
+		"notifyagainevery": strconv.Itoa(ck.NotifyAgainEvery),
+		"notifywhenbackup": strconv.FormatBool(ck.NotifyWhenBackup),
+		"integrationids":   intListToCDString(ck.IntegrationIds),
+		"probe_filters":    ck.ProbeFilters,
+		"tags":             ck.Tags,
+		"userids":          intListToCDString(ck.UserIds),
+		"teamids":          intListToCDString(ck.TeamIds),
+		"auth":             ck.Auth,
+		"encryption":       strconv.FormatBool(ck.Encryption),
+		"stringtoexpect":   ck.StringToExpect,
+	}
+
+	// Pingdom defaults to port 25.
+	if ck.Port != 0 {
+		m["port"] = strconv.Itoa(ck.Port)
+	}
+
+	if ck.Resolution != 0 {
+		m["resolution"] = strconv.Itoa(ck.Resolution)
+	}
+
+	if ck.SendNotificationWhenDown != 0 {
+		m["sendnotificationwhendown"] = strconv.Itoa(ck.SendNotificationWhenDown)
+	}
+
+	if ck.ResponseTimeThreshold != 0 {
+		m["responsetime_threshold"] = strconv.Itoa(ck.ResponseTimeThreshold)
+	}
+
+	return m
+}
+
+// PostParams returns a map of parameters for an SMTPCheck that can be sent
+// along with an HTTP POST request. Same as PUT.
+func (ck *SMTPCheck) PostParams() map[string]string {
+	params := ck.PutParams()
+
+	for k, v := range params {
+		if v == "" {
+			delete(params, k)
+		}
+	}
+
+	params["type"] = "smtp"
+	return params
+}
+
+// Valid determines whether the SMTPCheck contains valid fields.  This can be
+// used to guard against sending illegal values to the Pingdom API.
+func (ck *SMTPCheck) Valid() error {
+	if err := validCommonParameters(ck.Name, ck.Hostname, ck.Resolution); err != nil {
+		return err
+	}
+
+	if ck.Port < 0 || ck.Port > 65535 {
+		return fmt.Errorf("Invalid value for `Port`.  Must contain an integer >= 1 and <= 65535")
+	}
+
+	if ck.Auth != "" && !strings.Contains(ck.Auth, ":") {
+		return fmt.Errorf("invalid value for `Auth`, must be the username and password separated by a colon")
+	}
+
+	return nil
+}
+
+// PutParams returns a map of parameters for a POP3Check that can be sent
+// along with an HTTP PUT request.
+func (ck *POP3Check) PutParams() map[string]string {
+	m := map[string]string{
+		"name":             ck.Name,
+		"host":             ck.Hostname,
+		"paused":           strconv.FormatBool(ck.Paused),
+		"notifyagainevery": strconv.Itoa(ck.NotifyAgainEvery),
+		"notifywhenbackup": strconv.FormatBool(ck.NotifyWhenBackup),
+		"integrationids":   intListToCDString(ck.IntegrationIds),
+		"probe_filters":    ck.ProbeFilters,
+		"tags":             ck.Tags,
+		"userids":          intListToCDString(ck.UserIds),
+		"teamids":          intListToCDString(ck.TeamIds),
+		"encryption":       strconv.FormatBool(ck.Encryption),
+		"stringtoexpect":   ck.StringToExpect,
+	}
+
+	// Pingdom defaults to port 110.
+	if ck.Port != 0 {
+		m["port"] = strconv.Itoa(ck.Port)
+	}
+
+	if ck.Resolution != 0 {
+		m["resolution"] = strconv.Itoa(ck.Resolution)
+	}
+
+	if ck.SendNotificationWhenDown != 0 {
+		m["sendnotificationwhendown"] = strconv.Itoa(ck.SendNotificationWhenDown)
+	}
+
+	if ck.ResponseTimeThreshold != 0 {
+		m["responsetime_threshold"] = strconv.Itoa(ck.ResponseTimeThreshold)
+	}
+
+	return m
+}
+
+// PostParams returns a map of parameters for a POP3Check that can be sent
+// along with an HTTP POST request. Same as PUT.
+func (ck *POP3Check) PostParams() map[string]string {
+	params := ck.PutParams()
+
+	for k, v := range params {
+		if v == "" {
+			delete(params, k)
+		}
+	}
+
+	params["type"] = "pop3"
+	return params
+}
+
+// Valid determines whether the POP3Check contains valid fields.  This can be
+// used to guard against sending illegal values to the Pingdom API.
+func (ck *POP3Check) Valid() error {
+	if err := validCommonParameters(ck.Name, ck.Hostname, ck.Resolution); err != nil {
+		return err
+	}
+
+	if ck.Port < 0 || ck.Port > 65535 {
+		return fmt.Errorf("Invalid value for `Port`.  Must contain an integer >= 1 and <= 65535")
+	}
+
+	return nil
+}
+
+// PutParams returns a map of parameters for an IMAPCheck that can be sent
+// along with an HTTP PUT request.
+func (ck *IMAPCheck) PutParams() map[string]string {
+	m := map[string]string{
+		"name":             ck.Name,
+		"host":             ck.Hostname,
+		"paused":           strconv.FormatBool(ck.Paused),
+		"notifyagainevery": strconv.Itoa(ck.NotifyAgainEvery),
+		"notifywhenbackup": strconv.FormatBool(ck.NotifyWhenBackup),
+		"integrationids":   intListToCDString(ck.IntegrationIds),
+		"probe_filters":    ck.ProbeFilters,
+		"tags":             ck.Tags,
+		"userids":          intListToCDString(ck.UserIds),
+		"teamids":          intListToCDString(ck.TeamIds),
+		"encryption":       strconv.FormatBool(ck.Encryption),
+		"stringtoexpect":   ck.StringToExpect,
+	}
+
+	// Pingdom defaults to port 143.
+	if ck.Port != 0 {
+		m["port"] = strconv.Itoa(ck.Port)
+	}
+
+	if ck.Resolution != 0 {
+		m["resolution"] = strconv.Itoa(ck.Resolution)
+	}
+
+	if ck.SendNotificationWhenDown != 0 {
+		m["sendnotificationwhendown"] = strconv.Itoa(ck.SendNotificationWhenDown)
+	}
+
+	if ck.ResponseTimeThreshold != 0 {
+		m["responsetime_threshold"] = strconv.Itoa(ck.ResponseTimeThreshold)
+	}
+
+	return m
+}
+
+// PostParams returns a map of parameters for an IMAPCheck that can be sent
+// along with an HTTP POST request. Same as PUT.
+func (ck *IMAPCheck) PostParams() map[string]string {
+	params := ck.PutParams()
+
+	for k, v := range params {
+		if v == "" {
+			delete(params, k)
+		}
+	}
+
+	params["type"] = "imap"
+	return params
+}
+
+// Valid determines whether the IMAPCheck contains valid fields.  This can be
+// used to guard against sending illegal values to the Pingdom API.
+func (ck *IMAPCheck) Valid() error {
+	if err := validCommonParameters(ck.Name, ck.Hostname, ck.Resolution); err != nil {
+		return err
+	}
+
+	if ck.Port < 0 || ck.Port > 65535 {
+		return fmt.Errorf("Invalid value for `Port`.  Must contain an integer >= 1 and <= 65535")
+	}
+
+	return nil
+}
+
 // PutParams returns a map of parameters for a DNSCheck that can be sent along
 // with an HTTP PUT request.
 func (ck *DNSCheck) PutParams() map[string]string {
@@ -349,6 +873,10 @@ func (ck *DNSCheck) PutParams() map[string]string {
 		m["sendnotificationwhendown"] = strconv.Itoa(ck.SendNotificationWhenDown)
 	}
 
+	if ck.ResponseTimeThreshold != 0 {
+		m["responsetime_threshold"] = strconv.Itoa(ck.ResponseTimeThreshold)
+	}
+
 	return m
 }
 
@@ -407,9 +935,10 @@ func validCommonParameters(name string, hostname string, resolution int) error {
 	}
 
 	// if resolution value is 0, it will be set to default value which is 5.
-	if resolution != 0 && resolution != 1 && resolution != 5 && resolution != 15 &&
-		resolution != 30 && resolution != 60 {
-		return fmt.Errorf("invalid value %v for `Resolution`, allowed values are [1,5,15,30,60]", resolution)
+	if resolution != 0 {
+		if err := Resolution(resolution).Valid(); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -439,5 +968,83 @@ func (csr SummaryPerformanceRequest) GetParams() (params map[string]string) {
 		params["includeuptime"] = "true"
 	}
 
+	if csr.From != 0 {
+		params["from"] = strconv.Itoa(csr.From)
+	}
+
+	if csr.To != 0 {
+		params["to"] = strconv.Itoa(csr.To)
+	}
+
+	if csr.Probes != "" {
+		params["probes"] = csr.Probes
+	}
+
+	if csr.Order != "" {
+		params["order"] = csr.Order
+	}
+
+	return
+}
+
+// Valid determines whether a SummaryOutageRequest contains valid fields for the Pingdom API.
+func (sor SummaryOutageRequest) Valid() error {
+	if sor.Id == 0 {
+		return ErrMissingId
+	}
+
+	if sor.Order != "" && sor.Order != "asc" && sor.Order != "desc" {
+		return ErrBadOrder
+	}
+	return nil
+}
+
+// GetParams returns a map of params for a Pingdom SummaryOutageRequest.
+func (sor SummaryOutageRequest) GetParams() (params map[string]string) {
+	params = make(map[string]string)
+
+	if sor.From != 0 {
+		params["from"] = strconv.Itoa(sor.From)
+	}
+
+	if sor.To != 0 {
+		params["to"] = strconv.Itoa(sor.To)
+	}
+
+	if sor.Order != "" {
+		params["order"] = sor.Order
+	}
+
+	return
+}
+
+// Valid determines whether a SummaryHoursOfDayRequest contains valid fields for the Pingdom API.
+func (shr SummaryHoursOfDayRequest) Valid() error {
+	if shr.Id == 0 {
+		return ErrMissingId
+	}
+	return nil
+}
+
+// GetParams returns a map of params for a Pingdom SummaryHoursOfDayRequest.
+func (shr SummaryHoursOfDayRequest) GetParams() (params map[string]string) {
+	params = make(map[string]string)
+
+	if shr.From != 0 {
+		params["from"] = strconv.Itoa(shr.From)
+	}
+
+	if shr.To != 0 {
+		params["to"] = strconv.Itoa(shr.To)
+	}
+
+	if shr.Probes != "" {
+		params["probes"] = shr.Probes
+	}
+
+	if shr.UseLocalTime {
+		params["uselocaltime"] = "true"
+	}
+
 	return
 }
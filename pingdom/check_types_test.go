@@ -1,6 +1,7 @@
 package pingdom
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -169,6 +170,18 @@ func TestHttpCheckValid(t *testing.T) {
 		ShouldNotContain: "bar",
 	}
 	assert.Error(t, badContainsCheck.Valid())
+
+	headers := map[string]string{"Authorization": "Bearer token"}
+	headerCheck := HttpCheck{Name: "fake check", Hostname: "example.com", PostData: "a=1", RequestHeaders: headers}
+	assert.NoError(t, headerCheck.Valid())
+	headerCheck.RequestHeaders = map[string]string{"X Bad": "value"}
+	assert.EqualError(t, headerCheck.Valid(), `invalid request header name "X Bad", must be a non-empty token without colons or white space`)
+	headerCheck.RequestHeaders = map[string]string{"X-Injected": "a\r\nHost: evil"}
+	assert.EqualError(t, headerCheck.Valid(), "invalid value of request header X-Injected, must not contain line breaks")
+
+	sslDownDaysBefore := -1
+	badSSLCheck := HttpCheck{Name: "fake check", Hostname: "example.com", SSLDownDaysBefore: &sslDownDaysBefore}
+	assert.EqualError(t, badSSLCheck.Valid(), "invalid value -1 for `SSLDownDaysBefore`, must not be negative")
 }
 
 func TestPingCheckPostParams(t *testing.T) {
@@ -272,6 +285,186 @@ func TestTCPCheckValid(t *testing.T) {
 	assert.Error(t, badPortCheck.Valid())
 }
 
+func TestUDPCheckPostParams(t *testing.T) {
+	check := UDPCheck{
+		Name:           "fake check",
+		Hostname:       "example.com",
+		Resolution:     5,
+		TeamIds:        []int{789},
+		Port:           53,
+		StringToSend:   "ping",
+		StringToExpect: "pong",
+	}
+	want := map[string]string{
+		"name":             "fake check",
+		"host":             "example.com",
+		"resolution":       "5",
+		"paused":           "false",
+		"notifyagainevery": "0",
+		"notifywhenbackup": "false",
+		"type":             "udp",
+		"teamids":          "789",
+		"port":             "53",
+		"stringtosend":     "ping",
+		"stringtoexpect":   "pong",
+	}
+
+	assert.Equal(t, want, check.PostParams())
+}
+
+func TestUDPCheckValid(t *testing.T) {
+	check := UDPCheck{Name: "fake check", Hostname: "example.com", Port: 53, StringToSend: "ping", StringToExpect: "pong"}
+	assert.NoError(t, check.Valid())
+
+	badCheck := check
+	badCheck.Port = 0
+	assert.Error(t, badCheck.Valid())
+
+	badCheck = check
+	badCheck.StringToSend = ""
+	assert.EqualError(t, badCheck.Valid(), "invalid value for `StringToSend`, must contain non-empty string")
+
+	badCheck = check
+	badCheck.StringToExpect = ""
+	assert.EqualError(t, badCheck.Valid(), "invalid value for `StringToExpect`, must contain non-empty string")
+}
+
+func TestHttpCustomCheckPostParams(t *testing.T) {
+	check := HttpCustomCheck{
+		Name:           "fake check",
+		Hostname:       "example.com",
+		Url:            "/status.xml",
+		Encryption:     true,
+		Port:           8443,
+		Username:       "user",
+		Password:       "secret",
+		AdditionalUrls: []string{"https://a.example.com/status.xml", "https://b.example.com/status.xml"},
+		UserIds:        []int{123},
+	}
+	want := map[string]string{
+		"name":             "fake check",
+		"host":             "example.com",
+		"paused":           "false",
+		"notifyagainevery": "0",
+		"notifywhenbackup": "false",
+		"type":             "httpcustom",
+		"url":              "/status.xml",
+		"encryption":       "true",
+		"port":             "8443",
+		"auth":             "user:secret",
+		"additionalurls":   "https://a.example.com/status.xml;https://b.example.com/status.xml",
+		"userids":          "123",
+	}
+	assert.Equal(t, want, check.PostParams())
+
+	// The additional URLs can be emptied by an update.
+	check.AdditionalUrls = nil
+	assert.Equal(t, "", check.PutParams()["additionalurls"])
+}
+
+func TestHttpCustomCheckValid(t *testing.T) {
+	check := HttpCustomCheck{Name: "fake check", Hostname: "example.com", Url: "/status.xml"}
+	assert.NoError(t, check.Valid())
+
+	check.AdditionalUrls = []string{"https://a.example.com/status.xml;https://b.example.com/status.xml"}
+	assert.Error(t, check.Valid())
+
+	check.AdditionalUrls = nil
+	check.Url = ""
+	assert.EqualError(t, check.Valid(), "Invalid value for `Url`.  Must contain the path of the XML document")
+}
+
+func TestSMTPCheckPostParams(t *testing.T) {
+	check := SMTPCheck{
+		Name:           "fake check",
+		Hostname:       "mail.example.com",
+		UserIds:        []int{123},
+		Port:           587,
+		Auth:           "postmaster:secret",
+		Encryption:     true,
+		StringToExpect: "ESMTP",
+	}
+	want := map[string]string{
+		"name":             "fake check",
+		"host":             "mail.example.com",
+		"paused":           "false",
+		"notifyagainevery": "0",
+		"notifywhenbackup": "false",
+		"type":             "smtp",
+		"userids":          "123",
+		"port":             "587",
+		"auth":             "postmaster:secret",
+		"encryption":       "true",
+		"stringtoexpect":   "ESMTP",
+	}
+	assert.Equal(t, want, check.PostParams())
+
+	// Pingdom defaults to port 25.
+	check = SMTPCheck{Name: "fake check", Hostname: "mail.example.com"}
+	_, ok := check.PostParams()["port"]
+	assert.False(t, ok)
+	assert.Equal(t, "false", check.PutParams()["encryption"])
+}
+
+func TestSMTPCheckValid(t *testing.T) {
+	check := SMTPCheck{Name: "fake check", Hostname: "mail.example.com"}
+	assert.NoError(t, check.Valid())
+
+	check.Auth = "postmaster"
+	assert.EqualError(t, check.Valid(), "invalid value for `Auth`, must be the username and password separated by a colon")
+
+	check.Auth = ""
+	check.Port = 70000
+	assert.Error(t, check.Valid())
+
+	assert.Error(t, (&SMTPCheck{Name: "fake check"}).Valid())
+}
+
+func TestMailCheckPostParams(t *testing.T) {
+	pop3 := POP3Check{
+		Name:           "fake check",
+		Hostname:       "mail.example.com",
+		Port:           995,
+		Encryption:     true,
+		StringToExpect: "+OK",
+	}
+	want := map[string]string{
+		"name":             "fake check",
+		"host":             "mail.example.com",
+		"paused":           "false",
+		"notifyagainevery": "0",
+		"notifywhenbackup": "false",
+		"type":             "pop3",
+		"port":             "995",
+		"encryption":       "true",
+		"stringtoexpect":   "+OK",
+	}
+	assert.Equal(t, want, pop3.PostParams())
+
+	imap := IMAPCheck(pop3)
+	imap.StringToExpect = "* OK"
+	want["type"] = "imap"
+	want["stringtoexpect"] = "* OK"
+	assert.Equal(t, want, imap.PostParams())
+
+	// Pingdom defaults to the well-known port.
+	imap.Port = 0
+	_, ok := imap.PostParams()["port"]
+	assert.False(t, ok)
+}
+
+func TestMailCheckValid(t *testing.T) {
+	pop3 := POP3Check{Name: "fake check", Hostname: "mail.example.com"}
+	assert.NoError(t, pop3.Valid())
+	pop3.Port = -1
+	assert.Error(t, pop3.Valid())
+
+	imap := IMAPCheck{Name: "fake check", Hostname: "mail.example.com", Port: 993}
+	assert.NoError(t, imap.Valid())
+	imap.Hostname = ""
+	assert.Error(t, imap.Valid())
+}
+
 func TestDNSCheckPutParams(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -385,6 +578,20 @@ func TestDNSCheckValid(t *testing.T) {
 	assert.Error(t, badNameServerCheck.Valid())
 }
 
+func TestCheckIntegrationIdsCleared(t *testing.T) {
+	for _, check := range []Check{
+		&HttpCheck{Name: "fake check", Hostname: "example.com"},
+		&PingCheck{Name: "fake check", Hostname: "example.com"},
+		&TCPCheck{Name: "fake check", Hostname: "example.com", Port: 25},
+		&DNSCheck{Name: "fake check", Hostname: "example.com", ExpectedIP: "192.168.1.1", NameServer: "8.8.8.8"},
+	} {
+		// Updates detach the integrations, creations leave them out.
+		assert.Equal(t, "", check.PutParams()["integrationids"])
+		assert.Contains(t, check.PutParams(), "integrationids")
+		assert.NotContains(t, check.PostParams(), "integrationids")
+	}
+}
+
 func TestValidCommonParameters(t *testing.T) {
 	assert.Error(t, validCommonParameters("", "example.com", 5))
 	assert.Error(t, validCommonParameters("Test Name", "", 5))
@@ -444,4 +651,175 @@ func TestSummaryPerformanceRequestGetParams(t *testing.T) {
 
 		assert.Equal(t, want, params)
 	})
+
+	t.Run("with period", func(t *testing.T) {
+		want := map[string]string{
+			"resolution":    "day",
+			"includeuptime": "true",
+			"from":          "1536926400",
+			"to":            "1537012800",
+			"probes":        "1,2",
+			"order":         "asc",
+		}
+
+		params := SummaryPerformanceRequest{
+			Id:            id,
+			From:          1536926400,
+			To:            1537012800,
+			Resolution:    "day",
+			IncludeUptime: true,
+			Probes:        "1,2",
+			Order:         "asc",
+		}.GetParams()
+
+		assert.Equal(t, want, params)
+	})
+}
+
+func TestSummaryOutageRequestValid(t *testing.T) {
+	assert.Equal(t, ErrMissingId, SummaryOutageRequest{}.Valid())
+	assert.Nil(t, SummaryOutageRequest{Id: 123}.Valid())
+	assert.Nil(t, SummaryOutageRequest{Id: 123, Order: "desc"}.Valid())
+	assert.Equal(t, ErrBadOrder, SummaryOutageRequest{Id: 123, Order: "random"}.Valid())
+}
+
+func TestSummaryOutageRequestGetParams(t *testing.T) {
+	assert.Equal(t, map[string]string{}, SummaryOutageRequest{Id: 1337}.GetParams())
+	assert.Equal(t, map[string]string{
+		"from":  "1536926400",
+		"to":    "1536930000",
+		"order": "asc",
+	}, SummaryOutageRequest{Id: 1337, From: 1536926400, To: 1536930000, Order: "asc"}.GetParams())
+}
+
+func TestSummaryHoursOfDayRequestValid(t *testing.T) {
+	assert.Equal(t, ErrMissingId, SummaryHoursOfDayRequest{}.Valid())
+	assert.Nil(t, SummaryHoursOfDayRequest{Id: 123}.Valid())
+}
+
+func TestSummaryHoursOfDayRequestGetParams(t *testing.T) {
+	assert.Equal(t, map[string]string{}, SummaryHoursOfDayRequest{Id: 1337}.GetParams())
+	assert.Equal(t, map[string]string{
+		"from":         "1536926400",
+		"to":           "1536930000",
+		"probes":       "1,2",
+		"uselocaltime": "true",
+	}, SummaryHoursOfDayRequest{Id: 1337, From: 1536926400, To: 1536930000, Probes: "1,2", UseLocalTime: true}.GetParams())
+}
+
+func TestChecksJSONRoundTrip(t *testing.T) {
+	verify, days := true, 10
+	tests := []struct {
+		check Check
+		empty Check
+	}{
+		{
+			check: &HttpCheck{
+				Name:                     "fake check",
+				Hostname:                 "example.com",
+				Resolution:               5,
+				Paused:                   true,
+				SendNotificationWhenDown: 2,
+				NotifyAgainEvery:         3,
+				NotifyWhenBackup:         true,
+				Url:                      "/health",
+				Encryption:               true,
+				Port:                     8443,
+				Username:                 "user",
+				Password:                 "secret",
+				ShouldContain:            "ok",
+				PostData:                 "a=b",
+				RequestHeaders:           map[string]string{"User-Agent": "go-pingdom"},
+				IntegrationIds:           []int{1, 2},
+				ResponseTimeThreshold:    2300,
+				Tags:                     "a,b",
+				ProbeFilters:             "region: EU",
+				UserIds:                  []int{3},
+				TeamIds:                  []int{4},
+				VerifyCertificate:        &verify,
+				SSLDownDaysBefore:        &days,
+			},
+			empty: &HttpCheck{},
+		},
+		{
+			check: &PingCheck{
+				Name:                  "fake check",
+				Hostname:              "example.com",
+				Resolution:            1,
+				IntegrationIds:        []int{1},
+				Tags:                  "a",
+				ResponseTimeThreshold: 100,
+				ProbeFilters:          "region: NA",
+				UserIds:               []int{3},
+				TeamIds:               []int{4},
+			},
+			empty: &PingCheck{},
+		},
+		{
+			check: &TCPCheck{
+				Name:           "fake check",
+				Hostname:       "example.com",
+				Resolution:     1,
+				Port:           25,
+				StringToSend:   "HELO",
+				StringToExpect: "250",
+				UserIds:        []int{3},
+			},
+			empty: &TCPCheck{},
+		},
+		{
+			check: &DNSCheck{
+				Name:       "fake check",
+				Hostname:   "example.com",
+				ExpectedIP: "93.184.216.34",
+				NameServer: "a.iana-servers.net",
+				Resolution: 1,
+				TeamIds:    []int{4},
+			},
+			empty: &DNSCheck{},
+		},
+	}
+	for _, tt := range tests {
+		b, err := json.Marshal(tt.check)
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(b, tt.empty))
+		assert.Equal(t, tt.check, tt.empty, string(b))
+	}
+}
+
+func TestCheckJSONOmitsEmptyStrings(t *testing.T) {
+	tests := []struct {
+		check Check
+		want  string
+	}{
+		{&TCPCheck{Name: "fake check", Hostname: "example.com", Port: 25}, `{"name":"fake check","hostname":"example.com","port":25}`},
+		{&UDPCheck{Name: "fake check", Hostname: "example.com", Port: 53}, `{"name":"fake check","hostname":"example.com","port":53}`},
+	}
+	for _, tt := range tests {
+		b, err := json.Marshal(tt.check)
+		assert.NoError(t, err)
+		assert.JSONEq(t, tt.want, string(b))
+	}
+}
+
+func TestResponseTimeThresholdParams(t *testing.T) {
+	checks := []Check{
+		&HttpCheck{ResponseTimeThreshold: 3000},
+		&HttpCustomCheck{ResponseTimeThreshold: 3000},
+		&PingCheck{ResponseTimeThreshold: 3000},
+		&TCPCheck{ResponseTimeThreshold: 3000},
+		&UDPCheck{ResponseTimeThreshold: 3000},
+		&SMTPCheck{ResponseTimeThreshold: 3000},
+		&POP3Check{ResponseTimeThreshold: 3000},
+		&IMAPCheck{ResponseTimeThreshold: 3000},
+		&DNSCheck{ResponseTimeThreshold: 3000},
+	}
+	for _, check := range checks {
+		assert.Equal(t, "3000", check.PostParams()["responsetime_threshold"], "%T", check)
+		assert.Equal(t, "3000", check.PutParams()["responsetime_threshold"], "%T", check)
+	}
+
+	// Zero leaves the threshold of Pingdom, i.e. none or unchanged.
+	_, ok := (&TCPCheck{}).PostParams()["responsetime_threshold"]
+	assert.False(t, ok)
 }
@@ -0,0 +1,79 @@
+package pingdom
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	defaultWaitInterval    = 10 * time.Second
+	defaultWaitMaxInterval = time.Minute
+)
+
+// WaitConfig controls how WaitForFirstResult polls the results of a check: it
+// waits Interval before the first poll, then doubles the interval after each
+// poll without result, up to MaxInterval.  Zero values are replaced by the
+// defaults.
+type WaitConfig struct {
+	Interval    time.Duration
+	MaxInterval time.Duration
+}
+
+func (wc WaitConfig) withDefaults() WaitConfig {
+	if wc.Interval <= 0 {
+		wc.Interval = defaultWaitInterval
+	}
+	if wc.MaxInterval <= 0 {
+		wc.MaxInterval = defaultWaitMaxInterval
+	}
+	if wc.MaxInterval < wc.Interval {
+		wc.MaxInterval = wc.Interval
+	}
+	return wc
+}
+
+// WaitForFirstResult polls the results of a check until one is available and
+// returns it, e.g. to confirm that a newly created check is actually up before
+// declaring a deployment successful.  Pingdom tests a new check within its
+// resolution, so the context should allow for at least that long; once it is
+// done the polling stops with its error.
+func (cs *CheckService) WaitForFirstResult(ctx context.Context, id int, config WaitConfig) (*Result, error) {
+	config = config.withDefaults()
+	interval := config.Interval
+	for {
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		results, err := cs.Results(ctx, id, map[string]string{"limit": "1"})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, err
+		}
+		if len(results.Results) > 0 {
+			return &results.Results[0], nil
+		}
+
+		if interval *= 2; interval > config.MaxInterval {
+			interval = config.MaxInterval
+		}
+	}
+}
+
+// CreateAndWait creates a check, then waits for its first result with
+// WaitForFirstResult.  When waiting fails the created check is still returned
+// along with the error, so that it can be deleted or inspected.
+func (cs *CheckService) CreateAndWait(ctx context.Context, check Check, config WaitConfig) (*CheckResponse, *Result, error) {
+	created, err := cs.Create(ctx, check)
+	if err != nil {
+		return nil, nil, err
+	}
+	result, err := cs.WaitForFirstResult(ctx, created.ID, config)
+	return created, result, err
+}
@@ -0,0 +1,71 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitConfigWithDefaults(t *testing.T) {
+	assert.Equal(t, WaitConfig{Interval: defaultWaitInterval, MaxInterval: defaultWaitMaxInterval}, WaitConfig{}.withDefaults())
+	assert.Equal(t, WaitConfig{Interval: 2 * time.Minute, MaxInterval: 2 * time.Minute}, WaitConfig{Interval: 2 * time.Minute}.withDefaults())
+}
+
+func TestCheckServiceWaitForFirstResult(t *testing.T) {
+	setup()
+	defer teardown()
+
+	polls := 0
+	mux.HandleFunc("/results/12345", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "1", r.URL.Query().Get("limit"))
+		polls++
+		if polls < 3 {
+			fmt.Fprint(w, `{"activeprobes":[],"results":[]}`)
+			return
+		}
+		fmt.Fprint(w, `{"activeprobes":[259],"results":[{"probeid":259,"time":1563370611,"status":"up","responsetime":145,"statusdesc":"OK"}]}`)
+	})
+
+	result, err := client.Checks.WaitForFirstResult(context.Background(), 12345, WaitConfig{Interval: time.Millisecond, MaxInterval: 2 * time.Millisecond})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, polls)
+	assert.Equal(t, &Result{ProbeID: 259, Time: 1563370611, Status: "up", ResponseTime: 145, StatusDesc: "OK"}, result)
+}
+
+func TestCheckServiceWaitForFirstResultTimeout(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/results/12345", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"activeprobes":[],"results":[]}`)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.Checks.WaitForFirstResult(ctx, 12345, WaitConfig{Interval: time.Millisecond})
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestCheckServiceCreateAndWait(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"check":{"id":12345,"name":"My new HTTP check"}}`)
+	})
+	mux.HandleFunc("/results/12345", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":{"statuscode":404,"statusdesc":"Not Found","errormessage":"unknown check"}}`)
+	})
+
+	check, result, err := client.Checks.CreateAndWait(context.Background(), &HttpCheck{Name: "My new HTTP check", Hostname: "example.com", Resolution: 5}, WaitConfig{Interval: time.Millisecond})
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, &CheckResponse{ID: 12345, Name: "My new HTTP check"}, check)
+}
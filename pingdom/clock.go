@@ -0,0 +1,159 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const defaultClockSkewTolerance = time.Minute
+
+// ClockSkewPolicy decides how maintenance windows are sent when the local
+// clock is off from the clock of Pingdom by more than the tolerance, as on
+// misconfigured CI runners: windows computed from the local time could then
+// be already over for Pingdom.
+type ClockSkewPolicy string
+
+// Clock skew policies.
+const (
+	// ClockSkewIgnore sends the windows as is, the default.
+	ClockSkewIgnore ClockSkewPolicy = ""
+
+	// ClockSkewReject returns a *ClockSkewError instead of sending windows
+	// which are in the future locally but already over for Pingdom.
+	ClockSkewReject ClockSkewPolicy = "reject"
+
+	// ClockSkewAdjust shifts the times of windows which are in the future
+	// locally by the skew, so that they are as far in the future for
+	// Pingdom.  Times in the past, e.g. a To of 1 ending a window, are kept.
+	ClockSkewAdjust ClockSkewPolicy = "adjust"
+)
+
+// ClockSkewError is returned under ClockSkewReject for a window which would
+// be over for Pingdom.  Skew is the time the clock of Pingdom is ahead of the
+// local clock.
+type ClockSkewError struct {
+	Skew  time.Duration
+	Field string // To or EffectiveTo
+	Time  time.Time
+}
+
+// Error returns the string representation of the ClockSkewError.
+func (e *ClockSkewError) Error() string {
+	return fmt.Sprintf("maintenance window %s %s is already past for Pingdom, whose clock is %s ahead of the local clock", e.Field, e.Time.UTC().Format(time.RFC3339), e.Skew)
+}
+
+// ClockSkewWarning reports a maintenance window sent while the clocks are
+// skewed.  Adjusted reports whether its times were shifted.
+type ClockSkewWarning struct {
+	Skew     time.Duration
+	Window   *MaintenanceWindow
+	Adjusted bool
+}
+
+// clock tracks the skew of the local clock from the Date header of the
+// responses of Pingdom.
+type clock struct {
+	mu    sync.Mutex
+	skew  time.Duration
+	known bool
+	now   func() time.Time
+}
+
+func (c *clock) localNow() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+	return time.Now()
+}
+
+// observe records the skew from the Date header of the response.
+func (c *clock) observe(resp *http.Response) {
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return
+	}
+	// The header has a resolution of a second.
+	skew := date.Sub(c.localNow()).Round(time.Second)
+	c.mu.Lock()
+	c.skew, c.known = skew, true
+	c.mu.Unlock()
+}
+
+// ClockSkew returns the time the clock of Pingdom was ahead of the local
+// clock (negative when behind) at the last response, with a precision of
+// about a second, and whether any response had a Date header.
+func (pc *Client) ClockSkew() (time.Duration, bool) {
+	pc.clock.mu.Lock()
+	defer pc.clock.mu.Unlock()
+	return pc.clock.skew, pc.clock.known
+}
+
+// SyncClock measures the clock skew with a request to Pingdom, which the
+// maintenance service does before its first window under ClockSkewReject and
+// ClockSkewAdjust.
+func (pc *Client) SyncClock(ctx context.Context) (time.Duration, error) {
+	req, err := pc.NewRequest("GET", "/probes", map[string]string{"limit": "1"})
+	if err != nil {
+		return 0, err
+	}
+	resp, err := pc.exec(req.WithContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	skew, _ := pc.ClockSkew()
+	return skew, nil
+}
+
+// checkClockSkew applies the clock skew policy to a maintenance window,
+// returning the window to send.
+func (pc *Client) checkClockSkew(ctx context.Context, maintenance Maintenance) (Maintenance, error) {
+	window, ok := maintenance.(*MaintenanceWindow)
+	if !ok || pc.clockSkewPolicy == ClockSkewIgnore && pc.onClockSkew == nil {
+		return maintenance, nil
+	}
+	skew, known := pc.ClockSkew()
+	if !known && pc.clockSkewPolicy != ClockSkewIgnore {
+		var err error
+		if skew, err = pc.SyncClock(ctx); err != nil {
+			return nil, err
+		}
+	}
+	tolerance := pc.clockSkewTolerance
+	if tolerance <= 0 {
+		tolerance = defaultClockSkewTolerance
+	}
+	if skew <= tolerance && skew >= -tolerance {
+		return maintenance, nil
+	}
+
+	now := pc.clock.localNow()
+	warning := ClockSkewWarning{Skew: skew, Window: window}
+	switch pc.clockSkewPolicy {
+	case ClockSkewReject:
+		for _, t := range []struct {
+			field string
+			unix  int64
+		}{{"To", window.To}, {"EffectiveTo", window.EffectiveTo}} {
+			at := time.Unix(t.unix, 0)
+			if t.unix != 0 && at.After(now) && !at.After(now.Add(skew)) {
+				return nil, &ClockSkewError{Skew: skew, Field: t.field, Time: at}
+			}
+		}
+	case ClockSkewAdjust:
+		adjusted := *window
+		for _, t := range []*int64{&adjusted.From, &adjusted.To, &adjusted.EffectiveTo} {
+			if *t > now.Unix() {
+				*t += int64(skew / time.Second)
+			}
+		}
+		warning.Window, warning.Adjusted = &adjusted, true
+	}
+	if pc.onClockSkew != nil {
+		pc.onClockSkew(warning)
+	}
+	return warning.Window, nil
+}
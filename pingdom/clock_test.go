@@ -0,0 +1,101 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var localNow = time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// setupSkewedClock serves probes and maintenance windows with a clock ten
+// minutes ahead of the local one.
+func setupSkewedClock(policy ClockSkewPolicy) *[]string {
+	setup()
+	client.clock.now = func() time.Time { return localNow }
+	client.clockSkewPolicy = policy
+
+	date := localNow.Add(10 * time.Minute).Format(http.TimeFormat)
+	var sent []string
+	mux.HandleFunc("/probes", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", date)
+		fmt.Fprint(w, `{"probes":[]}`)
+	})
+	maintenance := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", date)
+		sent = append(sent, r.URL.Query().Get("from")+"-"+r.URL.Query().Get("to"))
+		fmt.Fprint(w, `{"maintenance":{"id":1}}`)
+	}
+	mux.HandleFunc("/maintenance", maintenance)
+	mux.HandleFunc("/maintenance/", maintenance)
+	return &sent
+}
+
+func TestClientClockSkew(t *testing.T) {
+	setupSkewedClock(ClockSkewIgnore)
+	defer teardown()
+
+	_, known := client.ClockSkew()
+	assert.False(t, known)
+
+	skew, err := client.SyncClock(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, skew)
+	skew, known = client.ClockSkew()
+	assert.True(t, known)
+	assert.Equal(t, 10*time.Minute, skew)
+}
+
+func TestMaintenanceClockSkewReject(t *testing.T) {
+	sent := setupSkewedClock(ClockSkewReject)
+	defer teardown()
+
+	// Over for Pingdom, whose clock says 12:10.
+	window := &MaintenanceWindow{Description: "deploy", From: localNow.Unix(), To: localNow.Add(5 * time.Minute).Unix()}
+	_, err := client.Maintenances.Create(context.Background(), window)
+	assert.EqualError(t, err, "maintenance window To 2021-03-01T12:05:00Z is already past for Pingdom, whose clock is 10m0s ahead of the local clock")
+	assert.Empty(t, *sent)
+
+	window.To = localNow.Add(time.Hour).Unix()
+	_, err = client.Maintenances.Create(context.Background(), window)
+	assert.NoError(t, err)
+
+	// Ending a window with a To in the past is fine.
+	_, err = client.Maintenances.Update(context.Background(), 1, &MaintenanceWindow{Description: "deploy", From: 1, To: 1, EffectiveTo: 1})
+	assert.NoError(t, err)
+	assert.Len(t, *sent, 2)
+}
+
+func TestMaintenanceClockSkewAdjust(t *testing.T) {
+	sent := setupSkewedClock(ClockSkewAdjust)
+	defer teardown()
+	var warnings []ClockSkewWarning
+	client.onClockSkew = func(w ClockSkewWarning) { warnings = append(warnings, w) }
+
+	window := &MaintenanceWindow{Description: "deploy", From: localNow.Add(time.Minute).Unix(), To: localNow.Add(5 * time.Minute).Unix()}
+	_, err := client.Maintenances.Create(context.Background(), window)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		fmt.Sprintf("%d-%d", localNow.Add(11*time.Minute).Unix(), localNow.Add(15*time.Minute).Unix()),
+	}, *sent)
+	assert.Equal(t, localNow.Add(time.Minute).Unix(), window.From, "the window of the caller is not modified")
+	if assert.Len(t, warnings, 1) {
+		assert.True(t, warnings[0].Adjusted)
+		assert.Equal(t, 10*time.Minute, warnings[0].Skew)
+	}
+}
+
+func TestMaintenanceClockSkewTolerance(t *testing.T) {
+	sent := setupSkewedClock(ClockSkewAdjust)
+	defer teardown()
+	client.clockSkewTolerance = 15 * time.Minute
+
+	window := &MaintenanceWindow{Description: "deploy", From: localNow.Unix(), To: localNow.Add(5 * time.Minute).Unix()}
+	_, err := client.Maintenances.Create(context.Background(), window)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{fmt.Sprintf("%d-%d", localNow.Unix(), localNow.Add(5*time.Minute).Unix())}, *sent)
+}
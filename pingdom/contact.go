@@ -1,9 +1,8 @@
 package pingdom
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 	"strconv"
 )
 
@@ -19,41 +18,34 @@ type ContactAPI interface {
 }
 
 // List returns a list of all contacts and their contact details.
-func (cs *ContactService) List() ([]Contact, error) {
+func (cs *ContactService) List(ctx context.Context) ([]Contact, error) {
 
 	req, err := cs.client.NewRequest("GET", "/alerting/contacts", nil)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := cs.client.client.Do(req)
+	resp, err := cs.client.exec(req.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if err := validateResponse(resp); err != nil {
-		return nil, err
-	}
-
-	bodyBytes, _ := ioutil.ReadAll(resp.Body)
-	bodyString := string(bodyBytes)
-
 	u := &listContactsJSONResponse{}
-	err = json.Unmarshal([]byte(bodyString), &u)
+	err = cs.client.decodeResponse(resp, u)
 
 	return u.Contacts, err
 }
 
 // Read return a contact object from Pingdom.
-func (cs *ContactService) Read(contactID int) (*Contact, error) {
+func (cs *ContactService) Read(ctx context.Context, contactID int) (*Contact, error) {
 	req, err := cs.client.NewRequest("GET", "/alerting/contacts/"+strconv.Itoa(contactID), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	c := &contactDetailsJSONResponse{}
-	_, err = cs.client.Do(req, c)
+	_, err = cs.client.Do(req.WithContext(ctx), c)
 	if err != nil {
 		return nil, err
 	}
@@ -62,7 +54,7 @@ func (cs *ContactService) Read(contactID int) (*Contact, error) {
 }
 
 // Create adds a new contact.
-func (cs *ContactService) Create(contact ContactAPI) (*Contact, error) {
+func (cs *ContactService) Create(ctx context.Context, contact ContactAPI) (*Contact, error) {
 	if err := contact.ValidContact(); err != nil {
 		return nil, err
 	}
@@ -73,7 +65,7 @@ func (cs *ContactService) Create(contact ContactAPI) (*Contact, error) {
 	}
 
 	m := &createContactJSONResponse{}
-	_, err = cs.client.Do(req, m)
+	_, err = cs.client.Do(req.WithContext(ctx), m)
 	if err != nil {
 		fmt.Println(err)
 		return nil, err
@@ -82,7 +74,7 @@ func (cs *ContactService) Create(contact ContactAPI) (*Contact, error) {
 }
 
 // Update a contact's core properties not contact targets.
-func (cs *ContactService) Update(id int, contact ContactAPI) (*PingdomResponse, error) {
+func (cs *ContactService) Update(ctx context.Context, id int, contact ContactAPI) (*PingdomResponse, error) {
 	if err := contact.ValidContact(); err != nil {
 		return nil, err
 	}
@@ -93,7 +85,7 @@ func (cs *ContactService) Update(id int, contact ContactAPI) (*PingdomResponse,
 	}
 
 	m := &PingdomResponse{}
-	_, err = cs.client.Do(req, m)
+	_, err = cs.client.Do(req.WithContext(ctx), m)
 	if err != nil {
 		return nil, err
 	}
@@ -101,14 +93,14 @@ func (cs *ContactService) Update(id int, contact ContactAPI) (*PingdomResponse,
 }
 
 // Delete removes a contact from Pingdom.
-func (cs *ContactService) Delete(id int) (*PingdomResponse, error) {
+func (cs *ContactService) Delete(ctx context.Context, id int) (*PingdomResponse, error) {
 	req, err := cs.client.NewRequest("DELETE", "/alerting/contacts/"+strconv.Itoa(id), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	m := &PingdomResponse{}
-	_, err = cs.client.Do(req, m)
+	_, err = cs.client.Do(req.WithContext(ctx), m)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package pingdom
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -127,7 +128,7 @@ func TestContactService_List(t *testing.T) {
 		},
 	}
 
-	contacts, err := client.Contacts.List()
+	contacts, err := client.Contacts.List(context.Background())
 	assert.NoError(t, err)
 	assert.Equal(t, want, contacts, "Contacts.List() should return correct result")
 }
@@ -200,7 +201,7 @@ func TestContactService_Read(t *testing.T) {
 		},
 	}
 
-	contacts, err := client.Contacts.Read(123456)
+	contacts, err := client.Contacts.Read(context.Background(), 123456)
 	assert.NoError(t, err)
 	assert.Equal(t, &want, contacts, "Contacts.Read(123456) should return a contact")
 }
@@ -226,7 +227,7 @@ func TestContactService_Create(t *testing.T) {
 		Name: "testContact",
 	}
 
-	contact, err := client.Contacts.Create(&u)
+	contact, err := client.Contacts.Create(context.Background(), &u)
 	assert.NoError(t, err)
 	assert.Equal(t, want, contact, "Contacts.Create() should return correct result")
 }
@@ -248,7 +249,7 @@ func TestContactService_Delete(t *testing.T) {
 		Message: "Deletion of contact was successful!",
 	}
 
-	response, err := client.Contacts.Delete(contactID)
+	response, err := client.Contacts.Delete(context.Background(), contactID)
 	assert.NoError(t, err)
 	assert.Equal(t, want, response, "Contacts.Delete() should return PingdomResponse with message")
 
@@ -274,7 +275,7 @@ func TestContactService_Update(t *testing.T) {
 		Message: "Modification of contact was successful!",
 	}
 
-	response, err := client.Contacts.Update(contactID, &contact)
+	response, err := client.Contacts.Update(context.Background(), contactID, &contact)
 	assert.NoError(t, err)
 	assert.Equal(t, want, response, "Contacts.Update() should return PingdomResponse with message")
 
@@ -1,6 +1,7 @@
 package pingdom
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -29,3 +30,26 @@ func TestContact_ValidContact_Negative(t *testing.T) {
 
 	assert.Equal(t, want, err, "Contact.ValidContact() should return error")
 }
+
+func TestContactJSONRoundTrip(t *testing.T) {
+	want := Contact{
+		ID:   1,
+		Name: "John Doe",
+		NotificationTargets: NotificationTargets{
+			SMS:   []SMSNotification{{CountryCode: "46", Number: "701234567", Provider: "nexmo", Severity: "HIGH"}},
+			Email: []EmailNotification{{Address: "johndoe@example.com", Severity: "LOW"}},
+			APNS:  []APNSNotification{{Device: "device", Name: "phone", Severity: "HIGH"}},
+			AGCM:  []AGCMNotification{{AGCMID: "agcm", Severity: "HIGH"}},
+		},
+		Owner:  true,
+		Paused: true,
+		Teams:  []ContactTeam{{ID: 2, Name: "The Dream Team"}},
+		Type:   "user",
+	}
+	b, err := json.Marshal(want)
+	assert.NoError(t, err)
+
+	var got Contact
+	assert.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, want, got)
+}
@@ -12,7 +12,7 @@ Construct a new Pingdom client:
 
 Using a Pingdom client, you can access supported services.
 
-CheckService
+# CheckService
 
 This service manages pingdom Checks which are represented by the `Check` struct.
 When creating or updating Checks you must specify at a minimum the `Name`, `Hostname`
@@ -23,39 +23,38 @@ More information on Checks from Pingdom: https://www.pingdom.com/features/api/do
 
 Get a list of all checks:
 
-	checks, err := client.Checks.List()
+	checks, err := client.Checks.List(ctx)
 	fmt.Println("Checks:", checks) // [{ID Name} ...]
 
 Create a new HTTP check:
 
 	newCheck := pingdom.Check{Name: "Test Check", Hostname: "example.com", Resolution: 5}
-	check, err := client.Checks.Create(&newCheck)
+	check, err := client.Checks.Create(ctx, &newCheck)
 	fmt.Println("Created check:", check) // {ID, Name}
 
 Create a new HTTP check with alerts for specified users:
 
 	newCheck := pingdom.Check{Name: "Test Check", Hostname: "example.com", Resolution: 5, UserIds: []int{12345}}
-	check, err := client.Checks.Create(&newCheck)
+	check, err := client.Checks.Create(ctx, &newCheck)
 	fmt.Println("Created check:", check) // {ID, Name}
 
 Create a new Ping check:
 
 	newCheck := pingdom.PingCheck{Name: "Test Check", Hostname: "example.com", Resolution: 5}
-	check, err := client.Checks.Create(&newCheck)
+	check, err := client.Checks.Create(ctx, &newCheck)
 	fmt.Println("Created check:", check) // {ID, Name}
 
 Get details for a specific check:
 
-	checkDetails, err := client.Checks.Read(12345)
+	checkDetails, err := client.Checks.Read(ctx, 12345)
 
 Update a check:
 
 	updatedCheck := pingdom.Check{Name: "Updated Check", Hostname: "example2.com", Resolution: 5}
-	msg, err := client.Checks.Update(12345, &updatedCheck)
+	msg, err := client.Checks.Update(ctx, 12345, &updatedCheck)
 
 Delete a check:
 
-	msg, err := client.Checks.Delete(12345)
-
+	msg, err := client.Checks.Delete(ctx, 12345)
 */
 package pingdom
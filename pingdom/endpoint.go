@@ -0,0 +1,74 @@
+package pingdom
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/internal/transport"
+)
+
+// EndpointClass groups the endpoints of the API which share a retry and
+// timeout policy, see ClientConfig.Endpoints.
+type EndpointClass string
+
+// Classes of endpoints.  Summaries are the slow, read only, summary.*
+// endpoints; the other GET requests are reads and any other method a write.
+const (
+	EndpointRead    EndpointClass = "read"
+	EndpointWrite   EndpointClass = "write"
+	EndpointSummary EndpointClass = "summary"
+)
+
+// EndpointPolicy overrides the retry policy and timeout of the client for a
+// class of endpoints.  A nil Retry keeps ClientConfig.Retry, a Retry with no
+// MaxRetries disables retries.  Timeout limits each attempt as a whole, like
+// Timeouts.Overall, and is not applied when zero.
+type EndpointPolicy struct {
+	Retry   *RetryPolicy
+	Timeout time.Duration
+}
+
+// endpoint is the resolved policy of a class of endpoints.
+type endpoint struct {
+	client *http.Client
+	retry  *RetryPolicy
+}
+
+// classify returns the class of endpoint the request is sent to.
+func classify(req *http.Request) EndpointClass {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return EndpointWrite
+	}
+	if strings.Contains(req.URL.Path, "/summary.") {
+		return EndpointSummary
+	}
+	return EndpointRead
+}
+
+// newEndpoints resolves the policies, the HTTP client of classes with a
+// timeout wrapping the transport of the given one.
+func newEndpoints(policies map[EndpointClass]EndpointPolicy, client *http.Client, retry *RetryPolicy) map[EndpointClass]endpoint {
+	endpoints := map[EndpointClass]endpoint{}
+	for class, policy := range policies {
+		e := endpoint{client: client, retry: retry}
+		if policy.Retry != nil {
+			e.retry = policy.Retry
+		}
+		if policy.Timeout > 0 {
+			withTimeout := *client
+			withTimeout.Transport = transport.WithTimeouts(client.Transport, Timeouts{Overall: policy.Timeout})
+			e.client = &withTimeout
+		}
+		endpoints[class] = e
+	}
+	return endpoints
+}
+
+// endpoint returns the HTTP client and retry policy to send the request with.
+func (pc *Client) endpoint(req *http.Request) (*http.Client, *RetryPolicy) {
+	if e, ok := pc.endpoints[classify(req)]; ok {
+		return e.client, e.retry
+	}
+	return pc.client, pc.retry
+}
@@ -0,0 +1,78 @@
+package pingdom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		want   EndpointClass
+	}{
+		{http.MethodGet, "/api/3.1/checks", EndpointRead},
+		{http.MethodGet, "/api/3.1/summary.performance/1", EndpointSummary},
+		{http.MethodGet, "/api/3.1/summary.outage/1", EndpointSummary},
+		{http.MethodPost, "/api/3.1/checks", EndpointWrite},
+		{http.MethodPut, "/api/3.1/checks/1", EndpointWrite},
+		{http.MethodDelete, "/api/3.1/checks/1", EndpointWrite},
+	}
+	for _, tt := range tests {
+		req := &http.Request{Method: tt.method, URL: &url.URL{Path: tt.path}}
+		assert.Equal(t, tt.want, classify(req), tt.method+" "+tt.path)
+	}
+}
+
+func TestEndpointPolicies(t *testing.T) {
+	setup()
+	defer teardown()
+
+	reads, writes := 0, 0
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			reads++
+		} else {
+			writes++
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, `{"error":{"statuscode":503,"statusdesc":"Service Unavailable","errormessage":"down"}}`)
+	})
+	mux.HandleFunc("/summary.outage/1", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		fmt.Fprint(w, `{"summary":{}}`)
+	})
+
+	c, err := NewClientWithConfig(ClientConfig{
+		APIToken: "token",
+		BaseURL:  server.URL,
+		Retry:    &RetryPolicy{MaxRetries: 2, Wait: time.Millisecond},
+		Endpoints: map[EndpointClass]EndpointPolicy{
+			EndpointWrite:   {Retry: &RetryPolicy{}},
+			EndpointSummary: {Timeout: 20 * time.Millisecond},
+		},
+	})
+	assert.NoError(t, err)
+
+	_, err = c.Checks.List(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 3, reads)
+
+	_, err = c.Checks.Create(context.Background(), &HttpCheck{Name: "test", Hostname: "example.com", Resolution: 5})
+	assert.Error(t, err)
+	assert.Equal(t, 1, writes)
+
+	_, err = c.Checks.SummaryOutage(context.Background(), SummaryOutageRequest{Id: 1})
+	var timeoutErr *TimeoutError
+	if assert.True(t, errors.As(err, &timeoutErr)) {
+		assert.Equal(t, TimeoutPhaseOverall, timeoutErr.Phase)
+		assert.Equal(t, 20*time.Millisecond, timeoutErr.Limit)
+	}
+}
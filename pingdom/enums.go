@@ -0,0 +1,170 @@
+package pingdom
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CheckStatus is the status of a check, as in CheckResponse.Status.
+type CheckStatus string
+
+// The statuses of a check.
+const (
+	CheckStatusUp              CheckStatus = "up"
+	CheckStatusDown            CheckStatus = "down"
+	CheckStatusUnconfirmedDown CheckStatus = "unconfirmed_down"
+	CheckStatusUnknown         CheckStatus = "unknown"
+	CheckStatusPaused          CheckStatus = "paused"
+)
+
+// CheckStatuses lists the statuses of a check.
+var CheckStatuses = []CheckStatus{
+	CheckStatusUp, CheckStatusDown, CheckStatusUnconfirmedDown, CheckStatusUnknown, CheckStatusPaused,
+}
+
+// String returns the status as sent by Pingdom.
+func (s CheckStatus) String() string {
+	return string(s)
+}
+
+// Valid determines whether the status is one of CheckStatuses.
+func (s CheckStatus) Valid() error {
+	for _, status := range CheckStatuses {
+		if s == status {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid check status %q, must be one of up, down, unconfirmed_down, unknown or paused", string(s))
+}
+
+// ParseCheckStatus returns the status spelt in any case, with spaces or
+// dashes for underscores, e.g. "Unconfirmed down".
+func ParseCheckStatus(value string) (CheckStatus, error) {
+	s := CheckStatus(strings.NewReplacer(" ", "_", "-", "_").Replace(strings.ToLower(strings.TrimSpace(value))))
+	if err := s.Valid(); err != nil {
+		return "", err
+	}
+	return s, nil
+}
+
+// Resolution is the interval between the tests of a check, in minutes, as in
+// the Resolution of an HttpCheck.
+type Resolution int
+
+// The resolutions Pingdom supports.
+const (
+	Resolution1Minute   Resolution = 1
+	Resolution5Minutes  Resolution = 5
+	Resolution15Minutes Resolution = 15
+	Resolution30Minutes Resolution = 30
+	Resolution1Hour     Resolution = 60
+)
+
+// Resolutions lists the resolutions Pingdom supports, from the shortest.
+var Resolutions = []Resolution{
+	Resolution1Minute, Resolution5Minutes, Resolution15Minutes, Resolution30Minutes, Resolution1Hour,
+}
+
+// String returns the resolution as a duration, e.g. "5m" or "1h".
+func (r Resolution) String() string {
+	if r > 0 && r%60 == 0 {
+		return strconv.Itoa(int(r)/60) + "h"
+	}
+	return strconv.Itoa(int(r)) + "m"
+}
+
+// Duration returns the interval between the tests.
+func (r Resolution) Duration() time.Duration {
+	return time.Duration(r) * time.Minute
+}
+
+// Valid determines whether the resolution is one of Resolutions.  The zero
+// Resolution, i.e. the default of 5 minutes, is not.
+func (r Resolution) Valid() error {
+	for _, resolution := range Resolutions {
+		if r == resolution {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid value %v for `Resolution`, allowed values are [1,5,15,30,60]", int(r))
+}
+
+// ParseResolution returns the resolution of a number of minutes, e.g. "15",
+// or of a duration, e.g. "15m" or "1h".
+func ParseResolution(value string) (Resolution, error) {
+	value = strings.TrimSpace(value)
+	minutes, err := strconv.Atoi(value)
+	if err != nil {
+		d, derr := time.ParseDuration(value)
+		if derr != nil || d%time.Minute != 0 {
+			return 0, fmt.Errorf("invalid resolution %q, must be a number of minutes or a duration such as 5m", value)
+		}
+		minutes = int(d / time.Minute)
+	}
+	r := Resolution(minutes)
+	if err := r.Valid(); err != nil {
+		return 0, err
+	}
+	return r, nil
+}
+
+// Region is a region of the Pingdom probes, as in ProbeResponse.Region.
+type Region string
+
+// The regions of the probes.
+const (
+	RegionNorthAmerica Region = "NA"
+	RegionEurope       Region = "EU"
+	RegionAsiaPacific  Region = "APAC"
+	RegionLatinAmerica Region = "LATAM"
+)
+
+// Regions lists the regions of the probes.
+var Regions = []Region{RegionNorthAmerica, RegionEurope, RegionAsiaPacific, RegionLatinAmerica}
+
+// String returns the region as sent by Pingdom, e.g. "EU".
+func (r Region) String() string {
+	return string(r)
+}
+
+// Valid determines whether the region is one of Regions.
+func (r Region) Valid() error {
+	for _, region := range Regions {
+		if r == region {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid region %q, must be one of NA, EU, APAC or LATAM", string(r))
+}
+
+// ProbeFilter returns the probe filter restricting the probes of a check to
+// the region, e.g. "region: EU".
+func (r Region) ProbeFilter() string {
+	return "region: " + string(r)
+}
+
+// ParseRegion returns the region spelt in any case, also as a probe filter,
+// e.g. "eu" or "region: EU".
+func ParseRegion(value string) (Region, error) {
+	value = strings.TrimSpace(value)
+	if i := strings.Index(value, ":"); i >= 0 && strings.EqualFold(strings.TrimSpace(value[:i]), "region") {
+		value = strings.TrimSpace(value[i+1:])
+	}
+	r := Region(strings.ToUpper(value))
+	if err := r.Valid(); err != nil {
+		return "", err
+	}
+	return r, nil
+}
+
+// RegionProbeFilters returns the ProbeFilters of a check restricting its
+// probes to the regions, e.g. "region: NA,region: EU".
+func RegionProbeFilters(regions ...Region) string {
+	filters := make([]string, len(regions))
+	for i, r := range regions {
+		filters[i] = r.ProbeFilter()
+	}
+	return strings.Join(filters, ",")
+}
@@ -0,0 +1,95 @@
+package pingdom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCheckStatus(t *testing.T) {
+	tests := []struct {
+		give string
+		want CheckStatus
+	}{
+		{"up", CheckStatusUp},
+		{" Down ", CheckStatusDown},
+		{"Unconfirmed down", CheckStatusUnconfirmedDown},
+		{"unconfirmed-down", CheckStatusUnconfirmedDown},
+		{"PAUSED", CheckStatusPaused},
+	}
+	for _, tt := range tests {
+		got, err := ParseCheckStatus(tt.give)
+		assert.NoError(t, err, tt.give)
+		assert.Equal(t, tt.want, got)
+	}
+
+	_, err := ParseCheckStatus("dwon")
+	assert.EqualError(t, err, `invalid check status "dwon", must be one of up, down, unconfirmed_down, unknown or paused`)
+	assert.Equal(t, "unconfirmed_down", CheckStatusUnconfirmedDown.String())
+}
+
+func TestParseResolution(t *testing.T) {
+	tests := []struct {
+		give string
+		want Resolution
+	}{
+		{"1", Resolution1Minute},
+		{"5m", Resolution5Minutes},
+		{" 15 ", Resolution15Minutes},
+		{"30m0s", Resolution30Minutes},
+		{"1h", Resolution1Hour},
+		{"60", Resolution1Hour},
+	}
+	for _, tt := range tests {
+		got, err := ParseResolution(tt.give)
+		assert.NoError(t, err, tt.give)
+		assert.Equal(t, tt.want, got)
+	}
+
+	for _, give := range []string{"10", "2h", "90s", "often", ""} {
+		_, err := ParseResolution(give)
+		assert.Error(t, err, give)
+	}
+	_, err := ParseResolution("10")
+	assert.EqualError(t, err, "invalid value 10 for `Resolution`, allowed values are [1,5,15,30,60]")
+}
+
+func TestResolution(t *testing.T) {
+	assert.Equal(t, "5m", Resolution5Minutes.String())
+	assert.Equal(t, "1h", Resolution1Hour.String())
+	assert.Equal(t, 15*time.Minute, Resolution15Minutes.Duration())
+	assert.Error(t, Resolution(0).Valid())
+
+	// Checks accept the zero Resolution as the default.
+	assert.NoError(t, validCommonParameters("name", "example.com", 0))
+	assert.Error(t, validCommonParameters("name", "example.com", 2))
+}
+
+func TestParseRegion(t *testing.T) {
+	tests := []struct {
+		give string
+		want Region
+	}{
+		{"EU", RegionEurope},
+		{"na", RegionNorthAmerica},
+		{"region: APAC", RegionAsiaPacific},
+		{"Region:latam", RegionLatinAmerica},
+	}
+	for _, tt := range tests {
+		got, err := ParseRegion(tt.give)
+		assert.NoError(t, err, tt.give)
+		assert.Equal(t, tt.want, got)
+	}
+
+	_, err := ParseRegion("europe")
+	assert.EqualError(t, err, `invalid region "EUROPE", must be one of NA, EU, APAC or LATAM`)
+	_, err = ParseRegion("country: EU")
+	assert.Error(t, err)
+}
+
+func TestRegionProbeFilters(t *testing.T) {
+	assert.Equal(t, "region: EU", RegionEurope.ProbeFilter())
+	assert.Equal(t, "region: NA,region: EU", RegionProbeFilters(RegionNorthAmerica, RegionEurope))
+	assert.Equal(t, "", RegionProbeFilters())
+}
@@ -0,0 +1,122 @@
+package pingdom
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// PermissionReadWrite is the access level an API token needs for any call
+// that modifies the account.
+const PermissionReadWrite = "read-write"
+
+// ErrInsufficientScope matches, through errors.Is, every
+// InsufficientScopeError.
+var ErrInsufficientScope = errors.New("insufficient token scope")
+
+// ErrFeatureDisabled matches, through errors.Is, every FeatureDisabledError.
+var ErrFeatureDisabled = errors.New("experimental feature disabled")
+
+// ErrReadOnly matches, through errors.Is, every ReadOnlyError.
+var ErrReadOnly = errors.New("read-only client")
+
+// ErrCheckLimit matches, through errors.Is, every CheckLimitError.
+var ErrCheckLimit = errors.New("check limit reached")
+
+// InsufficientScopeError is returned when Pingdom rejects a mutating call with
+// a 403, which almost always means the API token is read-only.  The original
+// error returned by Pingdom is available with errors.As.
+type InsufficientScopeError struct {
+	Method             string
+	Path               string
+	RequiredPermission string
+	Err                error
+}
+
+// Error returns the string representation of the InsufficientScopeError.
+func (e *InsufficientScopeError) Error() string {
+	return fmt.Sprintf("%s %s requires a token with %s access: %v", e.Method, e.Path, e.RequiredPermission, e.Err)
+}
+
+// Unwrap returns the error returned by Pingdom.
+func (e *InsufficientScopeError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrInsufficientScope.
+func (e *InsufficientScopeError) Is(target error) bool {
+	return target == ErrInsufficientScope
+}
+
+// scopeError turns the error of a forbidden mutating request into an
+// InsufficientScopeError, any other error is returned unchanged.
+func scopeError(req *http.Request, resp *http.Response, err error) error {
+	if resp.StatusCode != http.StatusForbidden || !isMutating(req.Method) {
+		return err
+	}
+	return &InsufficientScopeError{
+		Method:             req.Method,
+		Path:               req.URL.Path,
+		RequiredPermission: PermissionReadWrite,
+		Err:                err,
+	}
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// FeatureDisabledError is returned by beta endpoints whose experimental
+// feature has not been enabled in ClientConfig.ExperimentalFeatures.
+type FeatureDisabledError struct {
+	Feature string
+}
+
+// Error returns the string representation of the FeatureDisabledError.
+func (e *FeatureDisabledError) Error() string {
+	return fmt.Sprintf("experimental feature %q is not enabled, add it to ClientConfig.ExperimentalFeatures", e.Feature)
+}
+
+// Is reports whether target is ErrFeatureDisabled.
+func (e *FeatureDisabledError) Is(target error) bool {
+	return target == ErrFeatureDisabled
+}
+
+// ReadOnlyError is returned by the mutating calls of a client configured with
+// ClientConfig.ReadOnly.
+type ReadOnlyError struct {
+	Method string
+	Path   string
+}
+
+// Error returns the string representation of the ReadOnlyError.
+func (e *ReadOnlyError) Error() string {
+	return fmt.Sprintf("%s %s not sent: the client is read-only", e.Method, e.Path)
+}
+
+// Is reports whether target is ErrReadOnly.
+func (e *ReadOnlyError) Is(target error) bool {
+	return target == ErrReadOnly
+}
+
+// CheckLimitError is returned by AccountService.EnsureCheckCredits when the
+// plan of the account does not allow for the checks requested.
+type CheckLimitError struct {
+	Requested int
+	Available int
+	Limit     int
+}
+
+// Error returns the string representation of the CheckLimitError.
+func (e *CheckLimitError) Error() string {
+	return fmt.Sprintf("cannot add %d checks: %d of %d available", e.Requested, e.Available, e.Limit)
+}
+
+// Is reports whether target is ErrCheckLimit.
+func (e *CheckLimitError) Is(target error) bool {
+	return target == ErrCheckLimit
+}
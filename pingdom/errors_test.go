@@ -0,0 +1,97 @@
+package pingdom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/nordcloud/go-pingdom/apierror"
+	"github.com/stretchr/testify/assert"
+)
+
+const forbiddenBody = `{"error":{"statuscode":403,"statusdesc":"Forbidden","errormessage":"Access denied"}}`
+
+func TestInsufficientScopeOnMutatingCall(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks/12345", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, forbiddenBody)
+	})
+
+	_, err := client.Checks.Delete(context.Background(), 12345)
+	assert.True(t, errors.Is(err, ErrInsufficientScope))
+
+	var scopeErr *InsufficientScopeError
+	if assert.True(t, errors.As(err, &scopeErr)) {
+		assert.Equal(t, "DELETE", scopeErr.Method)
+		assert.Equal(t, "/checks/12345", scopeErr.Path)
+		assert.Equal(t, PermissionReadWrite, scopeErr.RequiredPermission)
+	}
+
+	var pingdomErr *PingdomError
+	if assert.True(t, errors.As(err, &pingdomErr)) {
+		assert.Equal(t, 403, pingdomErr.StatusCode)
+	}
+	assert.Equal(t, 403, apierror.Status(err))
+	assert.Equal(t, "DELETE /checks/12345 requires a token with read-write access: 403 Forbidden: Access denied", err.Error())
+}
+
+func TestForbiddenReadIsNotScopeError(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks/12345", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, forbiddenBody)
+	})
+
+	_, err := client.Checks.Read(context.Background(), 12345)
+	assert.False(t, errors.Is(err, ErrInsufficientScope))
+	assert.Equal(t, &PingdomError{StatusCode: 403, StatusDesc: "Forbidden", Message: "Access denied"}, err)
+}
+
+func TestInsufficientScopeWithRetries(t *testing.T) {
+	setup()
+	defer teardown()
+	client.retry = &RetryPolicy{MaxRetries: 2}
+
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, forbiddenBody)
+	})
+
+	_, err := client.Checks.Create(context.Background(), &HttpCheck{Name: "check", Hostname: "example.com"})
+	assert.True(t, errors.Is(err, ErrInsufficientScope))
+
+	var retryErr *RetryError
+	if assert.True(t, errors.As(err, &retryErr)) {
+		assert.Equal(t, 1, retryErr.Attempts)
+	}
+}
+
+func TestReadOnlyClient(t *testing.T) {
+	setup()
+	defer teardown()
+	client.readOnly = true
+
+	deleted := false
+	mux.HandleFunc("/checks/12345", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			deleted = true
+		}
+		fmt.Fprint(w, `{"check": {"id": 12345, "name": "Test"}}`)
+	})
+
+	_, err := client.Checks.Delete(context.Background(), 12345)
+	assert.True(t, errors.Is(err, ErrReadOnly))
+	assert.EqualError(t, err, "DELETE /checks/12345 not sent: the client is read-only")
+	assert.False(t, deleted)
+
+	check, err := client.Checks.Read(context.Background(), 12345)
+	assert.NoError(t, err)
+	assert.Equal(t, "Test", check.Name)
+}
@@ -0,0 +1,46 @@
+package pingdom
+
+import (
+	"os"
+	"strings"
+)
+
+// Experimental features which must be enabled through
+// ClientConfig.ExperimentalFeatures before the corresponding beta endpoints
+// can be used.
+const (
+	FeatureTMS         = "tms"
+	FeatureStatusPages = "statuspages"
+)
+
+// newFeatureSet combines the configured features with the comma separated
+// list in PINGDOM_EXPERIMENTAL_FEATURES.
+func newFeatureSet(features []string) map[string]bool {
+	set := map[string]bool{}
+	// Appending to the slice of the config could overwrite its backing array.
+	features = append([]string(nil), features...)
+	if env, ok := os.LookupEnv("PINGDOM_EXPERIMENTAL_FEATURES"); ok {
+		features = append(features, strings.Split(env, ",")...)
+	}
+	for _, feature := range features {
+		if feature = strings.ToLower(strings.TrimSpace(feature)); feature != "" {
+			set[feature] = true
+		}
+	}
+	return set
+}
+
+// FeatureEnabled reports whether the experimental feature has been enabled.
+// Feature names are case insensitive.
+func (pc *Client) FeatureEnabled(feature string) bool {
+	return pc.features[strings.ToLower(strings.TrimSpace(feature))]
+}
+
+// requireFeature returns a FeatureDisabledError unless the experimental
+// feature has been enabled.
+func (pc *Client) requireFeature(feature string) error {
+	if pc.FeatureEnabled(feature) {
+		return nil
+	}
+	return &FeatureDisabledError{Feature: feature}
+}
@@ -0,0 +1,60 @@
+package pingdom
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExperimentalFeatures(t *testing.T) {
+	client, err := NewClientWithConfig(ClientConfig{APIToken: "token"})
+	assert.NoError(t, err)
+	assert.False(t, client.FeatureEnabled(FeatureTMS))
+
+	err = client.requireFeature(FeatureTMS)
+	assert.True(t, errors.Is(err, ErrFeatureDisabled))
+	assert.Equal(t, &FeatureDisabledError{Feature: FeatureTMS}, err)
+
+	client, err = NewClientWithConfig(ClientConfig{
+		APIToken:             "token",
+		ExperimentalFeatures: []string{FeatureTMS},
+	})
+	assert.NoError(t, err)
+	assert.True(t, client.FeatureEnabled(FeatureTMS))
+	assert.False(t, client.FeatureEnabled(FeatureStatusPages))
+	assert.NoError(t, client.requireFeature(FeatureTMS))
+}
+
+func TestExperimentalFeaturesFromEnv(t *testing.T) {
+	assert.NoError(t, os.Setenv("PINGDOM_EXPERIMENTAL_FEATURES", " TMS, statuspages,"))
+	defer os.Unsetenv("PINGDOM_EXPERIMENTAL_FEATURES")
+
+	client, err := NewClientWithConfig(ClientConfig{APIToken: "token"})
+	assert.NoError(t, err)
+	assert.True(t, client.FeatureEnabled(FeatureTMS))
+	assert.True(t, client.FeatureEnabled(FeatureStatusPages))
+}
+
+func TestExperimentalFeaturesCase(t *testing.T) {
+	client, err := NewClientWithConfig(ClientConfig{
+		APIToken:             "token",
+		ExperimentalFeatures: []string{"Foo"},
+	})
+	assert.NoError(t, err)
+	assert.True(t, client.FeatureEnabled("Foo"))
+	assert.True(t, client.FeatureEnabled("foo"))
+	assert.True(t, client.FeatureEnabled("FOO"))
+}
+
+func TestExperimentalFeaturesDoNotAliasConfig(t *testing.T) {
+	assert.NoError(t, os.Setenv("PINGDOM_EXPERIMENTAL_FEATURES", FeatureStatusPages))
+	defer os.Unsetenv("PINGDOM_EXPERIMENTAL_FEATURES")
+
+	configured := make([]string, 1, 2)
+	configured[0] = FeatureTMS
+	_, err := NewClientWithConfig(ClientConfig{APIToken: "token", ExperimentalFeatures: configured})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{FeatureTMS, ""}, configured[:2])
+}
@@ -0,0 +1,153 @@
+package pingdom
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// decodeResponse decodes the JSON response into v like the decodeResponse
+// function, accepting variants of the field names too when the client is
+// configured with TolerantFieldNames.
+func (pc *Client) decodeResponse(r *http.Response, v interface{}) error {
+	if !pc.tolerantFieldNames {
+		return decodeResponse(r, v)
+	}
+	if v == nil {
+		return fmt.Errorf("nil interface provided to decodeResponse")
+	}
+
+	body, err := readBody(r)
+	if err != nil {
+		return err
+	}
+	return decodeTolerant(body, v)
+}
+
+// decodeTolerant decodes the JSON data into v like json.Unmarshal, but also
+// accepts the names of the fields of v spelt with other underscores, e.g.
+// "probeFilters" or "probefilters" for "probe_filters".  encoding/json
+// already ignores the case of the names.  A name spelt exactly as in v wins
+// over its variants.
+func decodeTolerant(data []byte, v interface{}) error {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	var value interface{}
+	if err := d.Decode(&value); err != nil {
+		return err
+	}
+	canonical, err := json.Marshal(canonicalFieldNames(value, reflect.TypeOf(v)))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(canonical, v)
+}
+
+// canonicalFieldNames renames the keys of the JSON objects of the generic
+// value to the names of the fields of the type they are decoded into, going
+// down its structs, slices and maps.
+func canonicalFieldNames(value interface{}, t reflect.Type) interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return value
+		}
+		names, normalized := jsonFields(t)
+		renamed := make(map[string]interface{}, len(object))
+		for key, v := range object {
+			field, ok := names[key]
+			if !ok {
+				if field, ok = normalized[normalizeFieldName(key)]; ok {
+					if _, exact := object[field.name]; exact {
+						continue
+					}
+					key = field.name
+				}
+			}
+			if ok {
+				v = canonicalFieldNames(v, field.typ)
+			}
+			renamed[key] = v
+		}
+		return renamed
+	case reflect.Slice, reflect.Array:
+		list, ok := value.([]interface{})
+		if !ok {
+			return value
+		}
+		for i, v := range list {
+			list[i] = canonicalFieldNames(v, t.Elem())
+		}
+		return list
+	case reflect.Map:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return value
+		}
+		for k, v := range object {
+			object[k] = canonicalFieldNames(v, t.Elem())
+		}
+		return object
+	}
+	return value
+}
+
+type jsonField struct {
+	name string
+	typ  reflect.Type
+}
+
+// jsonFields returns the JSON fields of the struct type by their name and by
+// their normalized name, including those of embedded structs.
+func jsonFields(t reflect.Type) (names map[string]jsonField, normalized map[string]jsonField) {
+	names, normalized = map[string]jsonField{}, map[string]jsonField{}
+	var add func(t reflect.Type)
+	add = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name := strings.Split(tag, ",")[0]
+			if f.Anonymous && name == "" {
+				ft := f.Type
+				if ft.Kind() == reflect.Ptr {
+					ft = ft.Elem()
+				}
+				if ft.Kind() == reflect.Struct {
+					add(ft)
+					continue
+				}
+			}
+			if f.PkgPath != "" {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			field := jsonField{name: name, typ: f.Type}
+			if _, ok := names[name]; !ok {
+				names[name] = field
+			}
+			if _, ok := normalized[normalizeFieldName(name)]; !ok {
+				normalized[normalizeFieldName(name)] = field
+			}
+		}
+	}
+	add(t)
+	return names, normalized
+}
+
+// normalizeFieldName returns the name in lower case without underscores and
+// dashes, e.g. "probefilters" for "probe_filters" and "probeFilters".
+func normalizeFieldName(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+}
@@ -0,0 +1,137 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeTolerant(t *testing.T) {
+	tests := []struct {
+		name string
+		give string
+		into func() interface{}
+		want interface{}
+	}{
+		{
+			name: "check",
+			give: `{"check": {"id": 1, "probeFilters": ["region: EU"], "responseTimeThreshold": 3000,
+				"customMessage": "down", "severityLevel": "HIGH",
+				"type": {"http": {"verifyCertificate": true, "sslDownDaysBefore": 10}}}}`,
+			into: func() interface{} { return &checkDetailsJSONResponse{} },
+			want: &checkDetailsJSONResponse{Check: &CheckResponse{
+				ID:                    1,
+				ProbeFilters:          []string{"region: EU"},
+				ResponseTimeThreshold: 3000,
+				CustomMessage:         "down",
+				SeverityLevel:         "HIGH",
+				Type: CheckResponseType{
+					Name: "http",
+					HTTP: &CheckResponseHTTPDetails{VerifyCertificate: true, SSLDownDaysBefore: 10},
+				},
+			}},
+		},
+		{
+			name: "contacts",
+			give: `{"contacts": [{"id": 1, "notificationTargets": {"sms": [{"countryCode": "46", "number": "5551234"}],
+				"apns": [{"apnsDevice": "device", "deviceName": "phone"}], "agcm": [{"agcmId": "agcm"}]}}]}`,
+			into: func() interface{} { return &listContactsJSONResponse{} },
+			want: &listContactsJSONResponse{Contacts: []Contact{{
+				ID: 1,
+				NotificationTargets: NotificationTargets{
+					SMS:  []SMSNotification{{CountryCode: "46", Number: "5551234"}},
+					APNS: []APNSNotification{{Device: "device", Name: "phone"}},
+					AGCM: []AGCMNotification{{AGCMID: "agcm"}},
+				},
+			}}},
+		},
+		{
+			name: "alerts",
+			give: `{"actions": {"alerts": [{"user_id": 1, "user_name": "ops", "check_id": 2}]}}`,
+			into: func() interface{} { return &listActionsJSONResponse{} },
+			want: func() interface{} {
+				m := &listActionsJSONResponse{}
+				m.Actions.Alerts = []AlertResponse{{UserID: 1, UserName: "ops", CheckID: 2}}
+				return m
+			}(),
+		},
+		{
+			name: "credits",
+			give: `{"credits": {"checklimit": 10, "autoFillSmsAmount": 5, "autoFillSmsWhenLeft": 2, "maxSmsOverage": 1}}`,
+			into: func() interface{} { return &creditsJSONResponse{} },
+			want: &creditsJSONResponse{Credits: Credits{CheckLimit: 10, AutoFillSMSAmount: 5, AutoFillSMSWhenLeft: 2, MaxSMSOverage: 1}},
+		},
+		{
+			name: "maintenance",
+			give: `{"maintenance": [{"id": 1, "recurrence_type": "week", "repeat_every": 2, "effective_to": 1600000000}]}`,
+			into: func() interface{} { return &listMaintenanceJSONResponse{} },
+			want: &listMaintenanceJSONResponse{Maintenances: []MaintenanceResponse{
+				{ID: 1, RecurrenceType: "week", RepeatEvery: 2, EffectiveTo: 1600000000},
+			}},
+		},
+		{
+			name: "occurrences",
+			give: `{"occurrences": [{"id": 1, "maintenance_id": 2, "duration_unit": "minute"}]}`,
+			into: func() interface{} { return &listOccurrenceResponse{} },
+			want: &listOccurrenceResponse{Occurrences: []Occurrence{{Id: 1, MaintenanceId: 2, DurationUnit: "minute"}}},
+		},
+		{
+			name: "analysis",
+			give: `{"analysis": [{"id": 1, "time_first_test": 100, "time_confirm_test": 200}]}`,
+			into: func() interface{} { return &listAnalysisJSONResponse{} },
+			want: &listAnalysisJSONResponse{Analysis: []AnalysisResponse{{ID: 1, TimeFirstTest: 100, TimeConfirmTest: 200}}},
+		},
+		{
+			name: "probes",
+			give: `{"probes": [{"id": 1, "country_iso": "SE", "IPv6": "::1"}]}`,
+			into: func() interface{} { return &listProbesJSONResponse{} },
+			want: &listProbesJSONResponse{Probes: []ProbeResponse{{ID: 1, CountryISO: "SE", IPv6: "::1"}}},
+		},
+		{
+			name: "exact name wins",
+			give: `{"credits": {"autofillsms_amount": 5, "autoFillSmsAmount": 6}}`,
+			into: func() interface{} { return &creditsJSONResponse{} },
+			want: &creditsJSONResponse{Credits: Credits{AutoFillSMSAmount: 5}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.into()
+			assert.NoError(t, decodeTolerant([]byte(tt.give), got))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDecodeTolerantKeepsLargeNumbers(t *testing.T) {
+	var got struct {
+		CreatedAt int64 `json:"created_at"`
+	}
+	assert.NoError(t, decodeTolerant([]byte(`{"createdAt": 9007199254740993}`), &got))
+	assert.Equal(t, int64(9007199254740993), got.CreatedAt)
+}
+
+func TestTolerantFieldNames(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks/12345", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"check": {"id": 12345, "name": "Test", "probeFilters": ["region: EU"]}}`)
+	})
+
+	check, err := client.Checks.Read(context.Background(), 12345)
+	assert.NoError(t, err)
+	assert.Empty(t, check.ProbeFilters)
+
+	client.tolerantFieldNames = true
+	check, err = client.Checks.Read(context.Background(), 12345)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"region: EU"}, check.ProbeFilters)
+
+	c, err := NewClientWithConfig(ClientConfig{APIToken: "token", TolerantFieldNames: true})
+	assert.NoError(t, err)
+	assert.True(t, c.tolerantFieldNames)
+}
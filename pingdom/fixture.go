@@ -0,0 +1,61 @@
+package pingdom
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// FixtureTransport is an http.RoundTripper serving responses from files
+// instead of the Pingdom API, which allows running demos and documentation
+// without credentials or network access.
+//
+// The response body for a request is read from
+// Dir/<METHOD>/<resource>.json, where resource is the request path relative
+// to BasePath. A GET of /api/3.1/checks/85975 is for example served from
+// Dir/GET/checks/85975.json. Requests without a fixture get a 404 in the
+// format of a Pingdom error.
+type FixtureTransport struct {
+	Dir      string
+	BasePath string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (ft *FixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+	rsc := strings.TrimPrefix(path.Clean("/"+req.URL.Path), path.Clean("/"+ft.BasePath))
+	rsc = strings.Trim(rsc, "/")
+	file := filepath.Join(ft.Dir, req.Method, filepath.FromSlash(rsc)+".json")
+
+	body, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		msg := fmt.Sprintf("no fixture for %s /%s", req.Method, rsc)
+		return fixtureResponse(req, http.StatusNotFound,
+			[]byte(fmt.Sprintf(`{"error":{"statuscode":404,"statusdesc":"Not Found","errormessage":%q}}`, msg))), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return fixtureResponse(req, http.StatusOK, body), nil
+}
+
+func fixtureResponse(req *http.Request, status int, body []byte) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
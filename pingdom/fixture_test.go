@@ -0,0 +1,47 @@
+package pingdom
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFixtureMode(t *testing.T) {
+	client, err := NewClientWithConfig(ClientConfig{FixtureDir: "testdata/fixtures"})
+	assert.NoError(t, err)
+
+	checks, err := client.Checks.List(context.Background())
+	assert.NoError(t, err)
+	if assert.Equal(t, 1, len(checks)) {
+		assert.Equal(t, 85975, checks[0].ID)
+	}
+
+	check, err := client.Checks.Read(context.Background(), 85975)
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com", check.Hostname)
+	assert.Equal(t, "http", check.Type.Name)
+
+	msg, err := client.Checks.Delete(context.Background(), 85975)
+	assert.NoError(t, err)
+	assert.Equal(t, "Deletion of check was successful!", msg.Message)
+
+	_, err = client.Checks.Read(context.Background(), 1)
+	assert.Equal(t, &PingdomError{
+		StatusCode: 404,
+		StatusDesc: "Not Found",
+		Message:    "no fixture for GET /checks/1",
+	}, err)
+}
+
+func TestFixtureTransportBasePath(t *testing.T) {
+	client, err := NewClientWithConfig(ClientConfig{
+		FixtureDir: "testdata/fixtures",
+		BaseURL:    "http://localhost/some/prefix/",
+	})
+	assert.NoError(t, err)
+
+	checks, err := client.Checks.List(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(checks))
+}
@@ -0,0 +1,92 @@
+package pingdom
+
+import (
+	"fmt"
+	"time"
+)
+
+// The layouts of the local times accepted by ParseLocalTime.
+var localTimeLayouts = []string{
+	"2006-01-02 15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+// ParseLocalTime returns the time of the wall clock time value, e.g.
+// "2021-03-28 02:30", in the IANA time zone, e.g. "Europe/Stockholm", taking
+// daylight saving time into account.  An empty time zone is UTC, and a value
+// in RFC 3339, with its own offset, is accepted as well.
+//
+// Local times which do not exist, skipped when the clocks go forward, or
+// which are ambiguous, repeated when they go back, are rejected rather than
+// silently shifted by an hour.
+func ParseLocalTime(value string, timezone string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time zone %q: %w", timezone, err)
+	}
+	for _, layout := range localTimeLayouts {
+		wall, err := time.Parse(layout, value)
+		if err != nil {
+			continue
+		}
+		t := time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(), 0, loc)
+		if !sameWallClock(t, wall) {
+			return time.Time{}, fmt.Errorf("local time %q does not exist in %s, the clocks go forward", value, loc)
+		}
+		// Offsets change by at most an hour, in steps of half an hour.
+		for _, d := range []time.Duration{-time.Hour, -30 * time.Minute, 30 * time.Minute, time.Hour} {
+			if sameWallClock(t.Add(d), wall) {
+				return time.Time{}, fmt.Errorf("local time %q is ambiguous in %s, the clocks go back, use RFC 3339 with an offset", value, loc)
+			}
+		}
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid local time %q, must be formatted as 2006-01-02 15:04 or RFC 3339", value)
+}
+
+// sameWallClock reports whether the times read the same on a wall clock, in
+// their own locations.
+func sameWallClock(t time.Time, u time.Time) bool {
+	return t.Format("2006-01-02 15:04:05") == u.Format("2006-01-02 15:04:05")
+}
+
+// NewLocalMaintenanceWindow returns a one-shot maintenance window from and to
+// the local times in the IANA time zone, see ParseLocalTime.  Windows
+// repeated at the same local time across daylight saving changes must be
+// split, as the schedule package does, because Pingdom repeats windows in
+// absolute time.
+func NewLocalMaintenanceWindow(description string, from string, to string, timezone string) (*MaintenanceWindow, error) {
+	start, err := ParseLocalTime(from, timezone)
+	if err != nil {
+		return nil, err
+	}
+	end, err := ParseLocalTime(to, timezone)
+	if err != nil {
+		return nil, err
+	}
+	if !end.After(start) {
+		return nil, fmt.Errorf("invalid maintenance window from %s to %s, must end after it starts", from, to)
+	}
+	return &MaintenanceWindow{
+		Description:    description,
+		From:           start.Unix(),
+		To:             end.Unix(),
+		RecurrenceType: RecurrenceNone,
+	}, nil
+}
+
+// LocalTimes returns the start and end of the maintenance in the location.
+func (mr *MaintenanceResponse) LocalTimes(loc *time.Location) (from time.Time, to time.Time) {
+	return time.Unix(mr.From, 0).In(loc), time.Unix(mr.To, 0).In(loc)
+}
+
+// LocalTimes returns the start and end of the occurrence in the location.
+func (o *Occurrence) LocalTimes(loc *time.Location) (from time.Time, to time.Time) {
+	return time.Unix(o.From, 0).In(loc), time.Unix(o.To, 0).In(loc)
+}
@@ -0,0 +1,73 @@
+package pingdom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLocalTime(t *testing.T) {
+	// CET is UTC+1 and CEST UTC+2, the clocks go forward on 2021-03-28 at
+	// 2:00 and back on 2021-10-31 at 3:00.
+	cases := []struct {
+		value string
+		want  string
+	}{
+		{"2021-03-28 01:30", "2021-03-28T00:30:00Z"},
+		{"2021-03-28 03:30", "2021-03-28T01:30:00Z"},
+		{"2021-07-01 12:00:30", "2021-07-01T10:00:30Z"},
+		{"2021-07-01T12:00", "2021-07-01T10:00:00Z"},
+		{"2021-12-24", "2021-12-23T23:00:00Z"},
+		{"2021-07-01T12:00:00-04:00", "2021-07-01T16:00:00Z"},
+	}
+	for _, c := range cases {
+		got, err := ParseLocalTime(c.value, "Europe/Stockholm")
+		if assert.NoError(t, err, c.value) {
+			assert.Equal(t, c.want, got.UTC().Format(time.RFC3339), c.value)
+		}
+	}
+
+	got, err := ParseLocalTime("2021-07-01 12:00", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "2021-07-01T12:00:00Z", got.Format(time.RFC3339))
+
+	_, err = ParseLocalTime("2021-03-28 02:30", "Europe/Stockholm")
+	assert.EqualError(t, err, `local time "2021-03-28 02:30" does not exist in Europe/Stockholm, the clocks go forward`)
+	_, err = ParseLocalTime("2021-10-31 02:30", "Europe/Stockholm")
+	assert.EqualError(t, err, `local time "2021-10-31 02:30" is ambiguous in Europe/Stockholm, the clocks go back, use RFC 3339 with an offset`)
+	_, err = ParseLocalTime("2021-07-01 12:00", "Mars/Olympus_Mons")
+	assert.Error(t, err)
+	_, err = ParseLocalTime("July 1st", "Europe/Stockholm")
+	assert.EqualError(t, err, `invalid local time "July 1st", must be formatted as 2006-01-02 15:04 or RFC 3339`)
+}
+
+func TestNewLocalMaintenanceWindow(t *testing.T) {
+	// The night the clocks go forward is an hour shorter.
+	window, err := NewLocalMaintenanceWindow("upgrade", "2021-03-28 00:00", "2021-03-28 06:00", "Europe/Stockholm")
+	assert.NoError(t, err)
+	assert.Equal(t, &MaintenanceWindow{
+		Description:    "upgrade",
+		From:           1616886000,
+		To:             1616904000,
+		RecurrenceType: RecurrenceNone,
+	}, window)
+	assert.NoError(t, window.Valid())
+
+	_, err = NewLocalMaintenanceWindow("upgrade", "2021-03-28 06:00", "2021-03-28 00:00", "Europe/Stockholm")
+	assert.EqualError(t, err, "invalid maintenance window from 2021-03-28 06:00 to 2021-03-28 00:00, must end after it starts")
+	_, err = NewLocalMaintenanceWindow("upgrade", "2021-03-28 02:00", "2021-03-28 06:00", "Europe/Stockholm")
+	assert.Error(t, err)
+}
+
+func TestLocalTimes(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	assert.NoError(t, err)
+
+	from, to := (&MaintenanceResponse{From: 1616886000, To: 1616904000}).LocalTimes(loc)
+	assert.Equal(t, "2021-03-27 19:00 EDT", from.Format("2006-01-02 15:04 MST"))
+	assert.Equal(t, "2021-03-28 00:00 EDT", to.Format("2006-01-02 15:04 MST"))
+
+	from, _ = (&Occurrence{From: 1609459200, To: 1609462800}).LocalTimes(loc)
+	assert.Equal(t, "2020-12-31 19:00 EST", from.Format("2006-01-02 15:04 MST"))
+}
@@ -1,11 +1,16 @@
 package pingdom
 
 import (
-	"encoding/json"
-	"io/ioutil"
+	"context"
+	"errors"
 	"strconv"
+	"strings"
 )
 
+// maintenanceDeleteBatchSize bounds the IDs of a multi-id DELETE, keeping the
+// URL short.
+const maintenanceDeleteBatchSize = 100
+
 // MaintenanceService provides an interface to Pingdom maintenance windows.
 type MaintenanceService struct {
 	client *Client
@@ -25,7 +30,7 @@ type MaintenanceDelete interface {
 }
 
 // List returns the response holding a list of Maintenance windows.
-func (cs *MaintenanceService) List(params ...map[string]string) ([]MaintenanceResponse, error) {
+func (cs *MaintenanceService) List(ctx context.Context, params ...map[string]string) ([]MaintenanceResponse, error) {
 	param := map[string]string{}
 	if len(params) != 0 {
 		for _, m := range params {
@@ -39,34 +44,27 @@ func (cs *MaintenanceService) List(params ...map[string]string) ([]MaintenanceRe
 		return nil, err
 	}
 
-	resp, err := cs.client.client.Do(req)
+	resp, err := cs.client.exec(req.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if err := validateResponse(resp); err != nil {
-		return nil, err
-	}
-
-	bodyBytes, _ := ioutil.ReadAll(resp.Body)
-	bodyString := string(bodyBytes)
-
 	m := &listMaintenanceJSONResponse{}
-	err = json.Unmarshal([]byte(bodyString), &m)
+	err = cs.client.decodeResponse(resp, m)
 
 	return m.Maintenances, err
 }
 
 // Read returns a Maintenance for a given ID.
-func (cs *MaintenanceService) Read(id int) (*MaintenanceResponse, error) {
+func (cs *MaintenanceService) Read(ctx context.Context, id int) (*MaintenanceResponse, error) {
 	req, err := cs.client.NewRequest("GET", "/maintenance/"+strconv.Itoa(id), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	m := &maintenanceDetailsJSONResponse{}
-	_, err = cs.client.Do(req, m)
+	_, err = cs.client.Do(req.WithContext(ctx), m)
 	if err != nil {
 		return nil, err
 	}
@@ -74,11 +72,16 @@ func (cs *MaintenanceService) Read(id int) (*MaintenanceResponse, error) {
 	return m.Maintenance, err
 }
 
-// Create creates a new Maintenance.
-func (cs *MaintenanceService) Create(maintenance Maintenance) (*MaintenanceResponse, error) {
+// Create creates a new Maintenance.  The ClockSkewPolicy of the client
+// applies to MaintenanceWindow.
+func (cs *MaintenanceService) Create(ctx context.Context, maintenance Maintenance) (*MaintenanceResponse, error) {
 	if err := maintenance.Valid(); err != nil {
 		return nil, err
 	}
+	maintenance, err := cs.client.checkClockSkew(ctx, maintenance)
+	if err != nil {
+		return nil, err
+	}
 
 	req, err := cs.client.NewRequest("POST", "/maintenance", maintenance.PostParams())
 	if err != nil {
@@ -86,7 +89,7 @@ func (cs *MaintenanceService) Create(maintenance Maintenance) (*MaintenanceRespo
 	}
 
 	m := &maintenanceDetailsJSONResponse{}
-	_, err = cs.client.Do(req, m)
+	_, err = cs.client.Do(req.WithContext(ctx), m)
 	if err != nil {
 		return nil, err
 	}
@@ -94,11 +97,16 @@ func (cs *MaintenanceService) Create(maintenance Maintenance) (*MaintenanceRespo
 }
 
 // Update is used to update an existing Maintenance. Only the 'Description',
-// and 'To' fields can be updated.
-func (cs *MaintenanceService) Update(id int, maintenance Maintenance) (*PingdomResponse, error) {
+// and 'To' fields can be updated.  The ClockSkewPolicy of the client applies
+// to MaintenanceWindow.
+func (cs *MaintenanceService) Update(ctx context.Context, id int, maintenance Maintenance) (*PingdomResponse, error) {
 	if err := maintenance.Valid(); err != nil {
 		return nil, err
 	}
+	maintenance, err := cs.client.checkClockSkew(ctx, maintenance)
+	if err != nil {
+		return nil, err
+	}
 
 	req, err := cs.client.NewRequest("PUT", "/maintenance/"+strconv.Itoa(id), maintenance.PutParams())
 	if err != nil {
@@ -106,15 +114,15 @@ func (cs *MaintenanceService) Update(id int, maintenance Maintenance) (*PingdomR
 	}
 
 	m := &PingdomResponse{}
-	_, err = cs.client.Do(req, m)
+	_, err = cs.client.Do(req.WithContext(ctx), m)
 	if err != nil {
 		return nil, err
 	}
 	return m, err
 }
 
-// MultiDelete will delete the Maintenance for the given ID.
-func (cs *MaintenanceService) MultiDelete(maintenance MaintenanceDelete) (*PingdomResponse, error) {
+// MultiDelete will delete the Maintenances for the given IDs in one request.
+func (cs *MaintenanceService) MultiDelete(ctx context.Context, maintenance MaintenanceDelete) (*PingdomResponse, error) {
 	if err := maintenance.ValidDelete(); err != nil {
 		return nil, err
 	}
@@ -125,7 +133,7 @@ func (cs *MaintenanceService) MultiDelete(maintenance MaintenanceDelete) (*Pingd
 	}
 
 	m := &PingdomResponse{}
-	_, err = cs.client.Do(req, m)
+	_, err = cs.client.Do(req.WithContext(ctx), m)
 	if err != nil {
 		return nil, err
 	}
@@ -133,16 +141,61 @@ func (cs *MaintenanceService) MultiDelete(maintenance MaintenanceDelete) (*Pingd
 }
 
 // Delete will delete the Maintenance for the given ID.
-func (cs *MaintenanceService) Delete(id int) (*PingdomResponse, error) {
+func (cs *MaintenanceService) Delete(ctx context.Context, id int) (*PingdomResponse, error) {
 	req, err := cs.client.NewRequest("DELETE", "/maintenance/"+strconv.Itoa(id), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	m := &PingdomResponse{}
-	_, err = cs.client.Do(req, m)
+	_, err = cs.client.Do(req.WithContext(ctx), m)
 	if err != nil {
 		return nil, err
 	}
 	return m, err
 }
+
+// DeleteMany deletes the maintenances with the given IDs with multi-id
+// DELETE requests of up to 100 IDs.  Pingdom rejects a request as a whole,
+// so when it rejects one the IDs of the request are deleted one by one
+// instead, see RunBulk, to find out which fail.  The responses and errors are
+// returned at the index of their ID, and the progress is reported once the
+// request of an ID is done.
+func (cs *MaintenanceService) DeleteMany(ctx context.Context, ids []int, config BulkConfig) ([]*PingdomResponse, []error) {
+	responses := make([]*PingdomResponse, len(ids))
+	errs := make([]error, len(ids))
+	tracker := &progress{fn: config.Progress, total: len(ids)}
+	single := config
+	single.Progress = nil
+	for start := 0; start < len(ids); start += maintenanceDeleteBatchSize {
+		end := start + maintenanceDeleteBatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		batch := ids[start:end]
+
+		strIds := make([]string, 0, len(batch))
+		for _, id := range batch {
+			strIds = append(strIds, strconv.Itoa(id))
+		}
+		resp, err := cs.MultiDelete(ctx, &MaintenanceWindowDelete{MaintenanceIDs: strings.Join(strIds, ",")})
+
+		var pingdomErr *PingdomError
+		if err != nil && errors.As(err, &pingdomErr) && len(batch) > 1 {
+			batchErrs := RunBulk(ctx, single, len(batch), func(i int) error {
+				var err error
+				responses[start+i], err = cs.Delete(ctx, batch[i])
+				return err
+			})
+			copy(errs[start:end], batchErrs)
+		} else {
+			for i := start; i < end; i++ {
+				responses[i], errs[i] = resp, err
+			}
+		}
+		for i := start; i < end; i++ {
+			tracker.report(i, strconv.Itoa(ids[i]), errs[i])
+		}
+	}
+	return responses, errs
+}
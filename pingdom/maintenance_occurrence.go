@@ -1,50 +1,49 @@
 package pingdom
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
 	"strconv"
 )
 
+// OccurrenceService provides an interface to Pingdom maintenance occurrences,
+// the individual instances of a maintenance window.  Updating or deleting an
+// occurrence reschedules or skips it without changing the other instances of
+// a recurring maintenance.
 type OccurrenceService struct {
 	client *Client
 }
 
-func (os *OccurrenceService) List(query ListOccurrenceQuery) ([]Occurrence, error) {
+// List returns the occurrences matching the query, e.g. those of a
+// maintenance window between two times.
+func (os *OccurrenceService) List(ctx context.Context, query ListOccurrenceQuery) ([]Occurrence, error) {
 	params := query.toParams()
 	req, err := os.client.NewRequest("GET", "/maintenance.occurrences", params)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := os.client.client.Do(req)
+	resp, err := os.client.exec(req.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if err := validateResponse(resp); err != nil {
-		return nil, err
-	}
-
-	bodyBytes, _ := ioutil.ReadAll(resp.Body)
-	bodyString := string(bodyBytes)
-
 	m := &listOccurrenceResponse{}
-	err = json.Unmarshal([]byte(bodyString), &m)
+	err = os.client.decodeResponse(resp, m)
 
 	return m.Occurrences, err
 }
 
-func (os *OccurrenceService) Read(id int64) (*Occurrence, error) {
+// Read returns the Occurrence for the given ID.
+func (os *OccurrenceService) Read(ctx context.Context, id int64) (*Occurrence, error) {
 	req, err := os.client.NewRequest("GET", "/maintenance.occurrences/"+strconv.FormatInt(id, 10), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	t := &readOccurrenceResponse{}
-	_, err = os.client.Do(req, t)
+	_, err = os.client.Do(req.WithContext(ctx), t)
 	if err != nil {
 		return nil, err
 	}
@@ -53,8 +52,8 @@ func (os *OccurrenceService) Read(id int64) (*Occurrence, error) {
 }
 
 // Update is used to update an existing Occurrence. Only the 'From',
-// and 'To' fields can be updated.
-func (os *OccurrenceService) Update(id int64, occurrence Occurrence) (*PingdomResponse, error) {
+// and 'To' fields can be updated, which reschedules the occurrence.
+func (os *OccurrenceService) Update(ctx context.Context, id int64, occurrence Occurrence) (*PingdomResponse, error) {
 	if err := occurrence.Valid(); err != nil {
 		return nil, err
 	}
@@ -65,15 +64,15 @@ func (os *OccurrenceService) Update(id int64, occurrence Occurrence) (*PingdomRe
 	}
 
 	m := &PingdomResponse{}
-	_, err = os.client.Do(req, m)
+	_, err = os.client.Do(req.WithContext(ctx), m)
 	if err != nil {
 		return nil, err
 	}
 	return m, err
 }
 
-// MultiDelete will delete the Occurrence for the given ID.
-func (os *OccurrenceService) MultiDelete(ids []int64) (*PingdomResponse, error) {
+// MultiDelete will delete the Occurrences for the given IDs.
+func (os *OccurrenceService) MultiDelete(ctx context.Context, ids []int64) (*PingdomResponse, error) {
 	if len(ids) == 0 {
 		return nil, fmt.Errorf("empty id list for multiple occurrence delete")
 	}
@@ -89,22 +88,23 @@ func (os *OccurrenceService) MultiDelete(ids []int64) (*PingdomResponse, error)
 	}
 
 	m := &PingdomResponse{}
-	_, err = os.client.Do(req, m)
+	_, err = os.client.Do(req.WithContext(ctx), m)
 	if err != nil {
 		return nil, err
 	}
 	return m, err
 }
 
-// Delete will delete the Occurrence for the given ID.
-func (os *OccurrenceService) Delete(id int64) (*PingdomResponse, error) {
+// Delete will delete the Occurrence for the given ID, skipping that instance
+// of its maintenance window.
+func (os *OccurrenceService) Delete(ctx context.Context, id int64) (*PingdomResponse, error) {
 	req, err := os.client.NewRequest("DELETE", "/maintenance.occurrences/"+strconv.FormatInt(id, 10), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	m := &PingdomResponse{}
-	_, err = os.client.Do(req, m)
+	_, err = os.client.Do(req.WithContext(ctx), m)
 	if err != nil {
 		return nil, err
 	}
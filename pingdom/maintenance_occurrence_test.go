@@ -1,6 +1,7 @@
 package pingdom
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/stretchr/testify/assert"
@@ -41,7 +42,7 @@ func TestOccurrenceServiceList(t *testing.T) {
 	err := json.Unmarshal([]byte(respStr), &want)
 	assert.NoError(t, err)
 
-	occurrences, err := client.Occurrences.List(ListOccurrenceQuery{})
+	occurrences, err := client.Occurrences.List(context.Background(), ListOccurrenceQuery{})
 	assert.NoError(t, err)
 	assert.Equal(t, want.Occurrences, occurrences, "Occurrence.List() should return correct result")
 }
@@ -71,7 +72,7 @@ func TestOccurrenceServiceRead(t *testing.T) {
 	err := json.Unmarshal([]byte(respStr), &want)
 	assert.NoError(t, err)
 
-	occurrence, err := client.Occurrences.Read(6110986)
+	occurrence, err := client.Occurrences.Read(context.Background(), 6110986)
 	assert.NoError(t, err)
 	assert.Equal(t, want.Occurrence, *occurrence, "Occurrence.Read() should return correct result")
 }
@@ -100,7 +101,7 @@ func TestOccurrenceServiceUpdate(t *testing.T) {
 		From: 1,
 		To:   2,
 	}
-	msg, err := client.Occurrences.Update(12345, update)
+	msg, err := client.Occurrences.Update(context.Background(), 12345, update)
 	assert.NoError(t, err)
 	assert.Equal(t, want, msg, "Occurrence.Update() should return correct result")
 }
@@ -124,7 +125,7 @@ func TestOccurrenceServiceDelete(t *testing.T) {
 	err := json.Unmarshal([]byte(respStr), want)
 	assert.NoError(t, err)
 
-	msg, err := client.Occurrences.Delete(1234)
+	msg, err := client.Occurrences.Delete(context.Background(), 1234)
 	assert.NoError(t, err)
 	assert.Equal(t, want, msg, "Occurrence.Delete() should return correct result")
 }
@@ -152,7 +153,7 @@ func TestOccurrenceServiceMultiDelete(t *testing.T) {
 	err := json.Unmarshal([]byte(respStr), want)
 	assert.NoError(t, err)
 
-	msg, err := client.Occurrences.MultiDelete(idsToDelete)
+	msg, err := client.Occurrences.MultiDelete(context.Background(), idsToDelete)
 	assert.NoError(t, err)
 	assert.Equal(t, want, msg, "Occurrence.MultiDelete() should return correct result")
 }
@@ -6,6 +6,8 @@ import (
 	"strconv"
 )
 
+// Occurrence represents a single instance of a maintenance window, in Unix
+// time.
 type Occurrence struct {
 	Id            int64  `json:"id"`
 	MaintenanceId int64  `json:"maintenanceid"`
@@ -15,6 +17,8 @@ type Occurrence struct {
 	DurationUnit  string `json:"durationunit"`
 }
 
+// ListOccurrenceQuery filters the occurrences returned by
+// OccurrenceService.List.  Zero fields are not sent.
 type ListOccurrenceQuery struct {
 	From          int64 `json:"from"`
 	To            int64 `json:"to"`
@@ -43,6 +47,8 @@ func (q *ListOccurrenceQuery) toParams() map[string]string {
 	return m
 }
 
+// Valid determines whether the Occurrence contains valid fields for an
+// update.
 func (o *Occurrence) Valid() error {
 	if o.From == 0 {
 		return fmt.Errorf("Invalid value for `From`.  Must contain time")
@@ -52,9 +58,15 @@ func (o *Occurrence) Valid() error {
 		return fmt.Errorf("Invalid value for `To`.  Must contain time")
 	}
 
+	if o.To <= o.From {
+		return fmt.Errorf("Invalid value for `To`.  Must be after `From`")
+	}
+
 	return nil
 }
 
+// RenderForJSONAPI returns the JSON formatted version of the updatable
+// fields of the Occurrence.
 func (o *Occurrence) RenderForJSONAPI() string {
 	b := map[string]interface{}{
 		"from": o.From,
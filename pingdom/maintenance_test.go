@@ -1,9 +1,13 @@
 package pingdom
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -54,7 +58,7 @@ func TestMaintenanceServiceList(t *testing.T) {
 		},
 	}
 
-	maintenances, err := client.Maintenances.List()
+	maintenances, err := client.Maintenances.List(context.Background())
 	assert.NoError(t, err)
 	assert.Equal(t, want, maintenances, "Maintenances.List() should return correct result")
 }
@@ -82,7 +86,7 @@ func TestMaintenanceServiceCreate(t *testing.T) {
 		ID: 85975,
 	}
 
-	maintenances, err := client.Maintenances.Create(&m)
+	maintenances, err := client.Maintenances.Create(context.Background(), &m)
 	assert.NoError(t, err)
 	assert.Equal(t, want, maintenances, "Maintenances.Create() should return correct result")
 }
@@ -124,7 +128,7 @@ func TestMaintenanceServiceRead(t *testing.T) {
 		},
 	}
 
-	maintenance, err := client.Maintenances.Read(456)
+	maintenance, err := client.Maintenances.Read(context.Background(), 456)
 	assert.NoError(t, err)
 	assert.Equal(t, want, maintenance, "Maintenances.Read() should return correct result")
 }
@@ -145,7 +149,7 @@ func TestMaintenanceServiceUpdate(t *testing.T) {
 	}
 	want := &PingdomResponse{Message: "Maintenance window successfully modified!"}
 
-	msg, err := client.Maintenances.Update(12345, &updateMaintenance)
+	msg, err := client.Maintenances.Update(context.Background(), 12345, &updateMaintenance)
 	assert.NoError(t, err)
 	assert.Equal(t, want, msg, "Maintenances.Update() should return correct result")
 }
@@ -160,7 +164,83 @@ func TestMaintenanceServiceDelete(t *testing.T) {
 	})
 	want := &PingdomResponse{Message: "Maintenance window successfully deleted!"}
 
-	msg, err := client.Maintenances.Delete(12345)
+	msg, err := client.Maintenances.Delete(context.Background(), 12345)
 	assert.NoError(t, err)
 	assert.Equal(t, want, msg, "Maintenances.Delete() should return correct result")
 }
+
+func TestMaintenanceServiceMultiDelete(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/maintenance/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		assert.Equal(t, "1,2,3", r.URL.Query().Get("maintenanceids"))
+		fmt.Fprint(w, `{"message":"Maintenance windows successfully deleted!"}`)
+	})
+
+	msg, err := client.Maintenances.MultiDelete(context.Background(), &MaintenanceWindowDelete{MaintenanceIDs: "1,2,3"})
+	assert.NoError(t, err)
+	assert.Equal(t, &PingdomResponse{Message: "Maintenance windows successfully deleted!"}, msg)
+
+	_, err = client.Maintenances.MultiDelete(context.Background(), &MaintenanceWindowDelete{})
+	assert.Error(t, err)
+}
+
+func TestMaintenanceServiceDeleteMany(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var batches []string
+	mux.HandleFunc("/maintenance/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		switch r.URL.Path {
+		case "/maintenance/":
+			ids := r.URL.Query().Get("maintenanceids")
+			batches = append(batches, ids)
+			if strings.Contains(ids, ",150,") {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"error":{"statuscode":400,"statusdesc":"Bad Request","errormessage":"Maintenance 150 has started"}}`)
+				return
+			}
+			fmt.Fprint(w, `{"message":"Maintenance windows successfully deleted!"}`)
+		case "/maintenance/150":
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":{"statuscode":400,"statusdesc":"Bad Request","errormessage":"Maintenance 150 has started"}}`)
+		default:
+			fmt.Fprint(w, `{"message":"Maintenance window successfully deleted!"}`)
+		}
+	})
+
+	ids := make([]int, 0, 210)
+	for id := 1; id <= 210; id++ {
+		ids = append(ids, id)
+	}
+	var last Progress
+	progressed := 0
+	config := BulkConfig{Wait: time.Millisecond, Progress: func(p Progress) {
+		assert.Equal(t, strconv.Itoa(ids[p.Index]), p.Item)
+		progressed++
+		last = p
+	}}
+	responses, errs := client.Maintenances.DeleteMany(context.Background(), ids, config)
+	assert.Equal(t, 210, progressed)
+	assert.Equal(t, Progress{Done: 210, Total: 210, Index: 209, Item: "210", Errors: 1}, last)
+	assert.Len(t, batches, 3)
+	assert.True(t, strings.HasPrefix(batches[0], "1,2,"))
+	assert.True(t, strings.HasSuffix(batches[2], ",209,210"))
+
+	for i, id := range ids {
+		if id == 150 {
+			assert.EqualError(t, errs[i], "400 Bad Request: Maintenance 150 has started")
+			assert.Nil(t, responses[i])
+			continue
+		}
+		assert.NoError(t, errs[i], id)
+		if id > 100 && id <= 200 {
+			assert.Equal(t, "Maintenance window successfully deleted!", responses[i].Message)
+		} else {
+			assert.Equal(t, "Maintenance windows successfully deleted!", responses[i].Message)
+		}
+	}
+}
@@ -17,6 +17,21 @@ type MaintenanceWindow struct {
 	TmsIDs         string `json:"tmsids,omitempty"`
 }
 
+// The recurrence types of a MaintenanceWindow.  A recurring window repeats
+// every RepeatEvery days, weeks or months until EffectiveTo, which defaults
+// to To.
+const (
+	RecurrenceNone  = "none"
+	RecurrenceDay   = "day"
+	RecurrenceWeek  = "week"
+	RecurrenceMonth = "month"
+)
+
+// Recurring reports whether the MaintenanceWindow repeats.
+func (ck *MaintenanceWindow) Recurring() bool {
+	return ck.RecurrenceType != "" && ck.RecurrenceType != RecurrenceNone
+}
+
 // MaintenanceWindowDelete represents delete request parameters.
 type MaintenanceWindowDelete struct {
 	MaintenanceIDs string `json:"maintenanceids"`
@@ -84,6 +99,24 @@ func (ck *MaintenanceWindow) Valid() error {
 		return fmt.Errorf("Invalid value for `To`.  Must contain time")
 	}
 
+	switch ck.RecurrenceType {
+	case "", RecurrenceNone, RecurrenceDay, RecurrenceWeek, RecurrenceMonth:
+	default:
+		return fmt.Errorf("Invalid value %q for `RecurrenceType`.  Must be 'none', 'day', 'week' or 'month'", ck.RecurrenceType)
+	}
+
+	if ck.RepeatEvery < 0 {
+		return fmt.Errorf("Invalid value %d for `RepeatEvery`.  Must be positive", ck.RepeatEvery)
+	}
+
+	if ck.RepeatEvery != 0 && !ck.Recurring() {
+		return fmt.Errorf("Invalid value for `RepeatEvery`.  Requires a `RecurrenceType` of 'day', 'week' or 'month'")
+	}
+
+	if ck.Recurring() && ck.EffectiveTo != 0 && ck.EffectiveTo < ck.To {
+		return fmt.Errorf("Invalid value for `EffectiveTo`.  Must not be before `To`")
+	}
+
 	return nil
 }
 
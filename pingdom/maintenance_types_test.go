@@ -1,6 +1,7 @@
 package pingdom
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -70,3 +71,53 @@ func TestMaintenanceNotValid(t *testing.T) {
 
 	assert.NotEqual(t, nil, params, "Maintenance.Valid() should return not nil if not valid")
 }
+
+func TestMaintenanceRecurrenceValid(t *testing.T) {
+	maintenance := MaintenanceWindow{
+		Description:    "weekly deploy",
+		From:           1524040922,
+		To:             1524044522,
+		RecurrenceType: RecurrenceWeek,
+		RepeatEvery:    2,
+		EffectiveTo:    1555576922,
+	}
+	assert.True(t, maintenance.Recurring())
+	assert.NoError(t, maintenance.Valid())
+
+	maintenance.EffectiveTo = 0
+	assert.NoError(t, maintenance.Valid())
+
+	maintenance.EffectiveTo = 1524040922
+	assert.EqualError(t, maintenance.Valid(), "Invalid value for `EffectiveTo`.  Must not be before `To`")
+
+	maintenance.EffectiveTo = 0
+	maintenance.RecurrenceType = "year"
+	assert.EqualError(t, maintenance.Valid(), "Invalid value \"year\" for `RecurrenceType`.  Must be 'none', 'day', 'week' or 'month'")
+
+	maintenance.RecurrenceType = RecurrenceNone
+	assert.False(t, maintenance.Recurring())
+	assert.EqualError(t, maintenance.Valid(), "Invalid value for `RepeatEvery`.  Requires a `RecurrenceType` of 'day', 'week' or 'month'")
+
+	maintenance.RecurrenceType = RecurrenceMonth
+	maintenance.RepeatEvery = -1
+	assert.Error(t, maintenance.Valid())
+}
+
+func TestMaintenanceWindowJSONRoundTrip(t *testing.T) {
+	want := MaintenanceWindow{
+		Description:    "fake maintenance",
+		From:           1524040922,
+		To:             1524044522,
+		RecurrenceType: "week",
+		RepeatEvery:    2,
+		EffectiveTo:    1555576922,
+		UptimeIDs:      "12345,67890",
+		TmsIDs:         "09876",
+	}
+	b, err := json.Marshal(want)
+	assert.NoError(t, err)
+
+	var got MaintenanceWindow
+	assert.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, want, got)
+}
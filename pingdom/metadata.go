@@ -0,0 +1,142 @@
+package pingdom
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+const (
+	defaultMetadataTagPrefix = "created-"
+	creatorTag               = "by-"
+	purposeTag               = "for-"
+)
+
+// CreationMetadata identifies the automation creating checks through a
+// client, e.g. a Terraform workspace or a CI job, so that the checks it
+// created can be listed, and cleaned up, later on.
+//
+// Pingdom has no annotations, so the metadata is recorded in the tags of the
+// checks: "<TagPrefix>by-<Creator>" and "<TagPrefix>for-<Purpose>", e.g.
+// "created-by-ci-smoke-tests".  Tags are lower cased and characters other
+// than letters, digits, "-" and "_" are replaced by "-".  Other resources
+// have no tags and are not annotated.
+type CreationMetadata struct {
+	Creator string
+	Purpose string // Optional
+
+	// TagPrefix defaults to "created-".
+	TagPrefix string
+}
+
+var invalidTagChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// tagValue returns the value as it can be used in a tag.
+func tagValue(value string) string {
+	return strings.Trim(invalidTagChars.ReplaceAllString(strings.ToLower(value), "-"), "-")
+}
+
+func (m *CreationMetadata) prefix() string {
+	if m == nil || m.TagPrefix == "" {
+		return defaultMetadataTagPrefix
+	}
+	return m.TagPrefix
+}
+
+// CreatorTag returns the tag of the checks created by the creator.
+func (m *CreationMetadata) CreatorTag() string {
+	return m.prefix() + creatorTag + tagValue(m.Creator)
+}
+
+// Tags returns the tags recording the metadata.
+func (m *CreationMetadata) Tags() []string {
+	tags := []string{m.CreatorTag()}
+	if purpose := tagValue(m.Purpose); purpose != "" {
+		tags = append(tags, m.prefix()+purposeTag+purpose)
+	}
+	return tags
+}
+
+// annotate adds the tags of the metadata to the parameters of a check,
+// unless they already have them.
+func (m *CreationMetadata) annotate(params map[string]string) {
+	if m == nil || tagValue(m.Creator) == "" {
+		return
+	}
+	var tags []string
+	present := map[string]bool{}
+	if params["tags"] != "" {
+		tags = []string{params["tags"]}
+		for _, tag := range strings.Split(params["tags"], ",") {
+			present[strings.TrimSpace(tag)] = true
+		}
+	}
+	for _, tag := range m.Tags() {
+		if !present[tag] {
+			tags = append(tags, tag)
+		}
+	}
+	params["tags"] = strings.Join(tags, ",")
+}
+
+// keepCreationTags adds the creation metadata tags of the check with the
+// given ID, whatever their creator, to the tags of an update, which would
+// replace them otherwise.  The check is only read when the client has
+// CreationMetadata and the update sets tags; the client does not add its own
+// tags, as it did not necessarily create the check.
+func (cs *CheckService) keepCreationTags(ctx context.Context, id int, params map[string]string) error {
+	m := cs.client.metadata
+	if m == nil || params["tags"] == "" {
+		return nil
+	}
+	current, err := cs.Read(ctx, id)
+	if err != nil {
+		return err
+	}
+	tags := []string{params["tags"]}
+	present := map[string]bool{}
+	for _, tag := range strings.Split(params["tags"], ",") {
+		present[strings.TrimSpace(tag)] = true
+	}
+	for _, tag := range current.Tags {
+		if strings.HasPrefix(tag.Name, m.prefix()) && !present[tag.Name] {
+			tags = append(tags, tag.Name)
+		}
+	}
+	params["tags"] = strings.Join(tags, ",")
+	return nil
+}
+
+// CreationMetadata returns the creation metadata recorded in the tags of the
+// check with the given prefix, which defaults to "created-", or nil when it
+// has none.  Tags are only listed when the include_tags parameter is set.
+func (cr *CheckResponse) CreationMetadata(prefix string) *CreationMetadata {
+	m := &CreationMetadata{TagPrefix: prefix}
+	for _, tag := range cr.Tags {
+		switch {
+		case strings.HasPrefix(tag.Name, m.prefix()+creatorTag):
+			m.Creator = strings.TrimPrefix(tag.Name, m.prefix()+creatorTag)
+		case strings.HasPrefix(tag.Name, m.prefix()+purposeTag):
+			m.Purpose = strings.TrimPrefix(tag.Name, m.prefix()+purposeTag)
+		}
+	}
+	if m.Creator == "" {
+		return nil
+	}
+	return m
+}
+
+// ListCreatedBy returns the checks created by the given creator, recorded
+// with the tag prefix of the client.  The params are passed to List along
+// with the tag filter.
+func (cs *CheckService) ListCreatedBy(ctx context.Context, creator string, params ...map[string]string) ([]CheckResponse, error) {
+	if tagValue(creator) == "" {
+		return nil, errors.New("creator must not be empty")
+	}
+	m := &CreationMetadata{Creator: creator}
+	if cs.client.metadata != nil {
+		m.TagPrefix = cs.client.metadata.TagPrefix
+	}
+	return cs.listByTags(ctx, []string{m.CreatorTag()}, params...)
+}
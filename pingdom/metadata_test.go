@@ -0,0 +1,152 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreationMetadataTags(t *testing.T) {
+	m := &CreationMetadata{Creator: "CI Smoke Tests", Purpose: "PR #42"}
+	assert.Equal(t, "created-by-ci-smoke-tests", m.CreatorTag())
+	assert.Equal(t, []string{"created-by-ci-smoke-tests", "created-for-pr-42"}, m.Tags())
+
+	m = &CreationMetadata{Creator: "terraform", TagPrefix: "acme-"}
+	assert.Equal(t, []string{"acme-by-terraform"}, m.Tags())
+}
+
+func TestCreationMetadataAnnotate(t *testing.T) {
+	params := map[string]string{"tags": "web"}
+	(&CreationMetadata{Creator: "terraform", Purpose: "prod"}).annotate(params)
+	assert.Equal(t, "web,created-by-terraform,created-for-prod", params["tags"])
+
+	params = map[string]string{}
+	(&CreationMetadata{Creator: "terraform"}).annotate(params)
+	assert.Equal(t, "created-by-terraform", params["tags"])
+
+	params = map[string]string{"tags": "web,created-by-terraform"}
+	(&CreationMetadata{Creator: "terraform"}).annotate(params)
+	assert.Equal(t, "web,created-by-terraform", params["tags"])
+
+	params = map[string]string{"tags": "web"}
+	var m *CreationMetadata
+	m.annotate(params)
+	(&CreationMetadata{Creator: " !"}).annotate(params)
+	assert.Equal(t, "web", params["tags"])
+}
+
+func TestCheckResponseCreationMetadata(t *testing.T) {
+	check := &CheckResponse{Tags: []CheckResponseTag{
+		{Name: "web", Type: "u"},
+		{Name: "created-by-terraform", Type: "u"},
+		{Name: "created-for-prod", Type: "u"},
+	}}
+	assert.Equal(t, &CreationMetadata{Creator: "terraform", Purpose: "prod"}, check.CreationMetadata(""))
+	assert.Nil(t, check.CreationMetadata("acme-"))
+	assert.Nil(t, (&CheckResponse{}).CreationMetadata(""))
+}
+
+func TestCheckServiceCreateWithCreationMetadata(t *testing.T) {
+	setup()
+	defer teardown()
+	client.metadata = &CreationMetadata{Creator: "terraform", Purpose: "prod"}
+
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		assert.Equal(t, "web,created-by-terraform,created-for-prod", r.URL.Query().Get("tags"))
+		fmt.Fprint(w, `{"check":{"id":138631,"name":"My new HTTP check"}}`)
+	})
+
+	check, err := client.Checks.Create(context.Background(), &HttpCheck{Name: "My new HTTP check", Hostname: "example.com", Resolution: 5, Tags: "web"})
+	assert.NoError(t, err)
+	assert.Equal(t, 138631, check.ID)
+}
+
+func TestCheckServiceUpdateWithCreationMetadata(t *testing.T) {
+	setup()
+	defer teardown()
+	client.metadata = &CreationMetadata{Creator: "terraform", Purpose: "prod"}
+
+	mux.HandleFunc("/checks/138631", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			fmt.Fprint(w, `{"check":{"id":138631,"tags":[{"name":"web","type":"u"},{"name":"created-by-terraform","type":"u"},{"name":"created-for-prod","type":"u"}]}}`)
+			return
+		}
+		testMethod(t, r, "PUT")
+		assert.Equal(t, "api,created-by-terraform,created-for-prod", r.URL.Query().Get("tags"))
+		fmt.Fprint(w, `{"message":"Modification of check was successful!"}`)
+	})
+
+	_, err := client.Checks.Update(context.Background(), 138631, &HttpCheck{Name: "My HTTP check", Hostname: "example.com", Resolution: 5, Tags: "api"})
+	assert.NoError(t, err)
+}
+
+func TestCheckServiceUpdateOfCheckCreatedElsewhere(t *testing.T) {
+	setup()
+	defer teardown()
+	client.metadata = &CreationMetadata{Creator: "terraform", Purpose: "prod"}
+
+	mux.HandleFunc("/checks/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			fmt.Fprint(w, `{"check":{"id":1,"tags":[{"name":"web","type":"u"}]}}`)
+			return
+		}
+		assert.Equal(t, "api", r.URL.Query().Get("tags"), "a check made by hand is not labeled")
+		fmt.Fprint(w, `{"message":"Modification of check was successful!"}`)
+	})
+	mux.HandleFunc("/checks/2", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			fmt.Fprint(w, `{"check":{"id":2,"tags":[{"name":"created-by-ci","type":"u"}]}}`)
+			return
+		}
+		assert.Equal(t, "api,created-by-ci", r.URL.Query().Get("tags"), "a check of another creator keeps its creator")
+		fmt.Fprint(w, `{"message":"Modification of check was successful!"}`)
+	})
+
+	for _, id := range []int{1, 2} {
+		_, err := client.Checks.Update(context.Background(), id, &HttpCheck{Name: "My HTTP check", Hostname: "example.com", Resolution: 5, Tags: "api"})
+		assert.NoError(t, err)
+	}
+}
+
+func TestCheckServiceUpdateWithoutTags(t *testing.T) {
+	setup()
+	defer teardown()
+	client.metadata = &CreationMetadata{Creator: "terraform", Purpose: "prod"}
+
+	mux.HandleFunc("/checks/138631", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		assert.Equal(t, "", r.URL.Query().Get("tags"))
+		fmt.Fprint(w, `{"message":"Modification of check was successful!"}`)
+	})
+
+	_, err := client.Checks.Update(context.Background(), 138631, &HttpCheck{Name: "My HTTP check", Hostname: "example.com", Resolution: 5})
+	assert.NoError(t, err)
+}
+
+func TestCheckServiceListCreatedBy(t *testing.T) {
+	setup()
+	defer teardown()
+	client.metadata = &CreationMetadata{Creator: "terraform", TagPrefix: "acme-"}
+
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "acme-by-ci", r.URL.Query().Get("tags"))
+		assert.Equal(t, "true", r.URL.Query().Get("include_tags"))
+		assert.Equal(t, "10", r.URL.Query().Get("limit"))
+		fmt.Fprint(w, `{"checks":[{"id":1,"name":"a","tags":[{"name":"acme-by-ci","type":"u","count":1}]}]}`)
+	})
+
+	params := map[string]string{"limit": "10"}
+	checks, err := client.Checks.ListCreatedBy(context.Background(), "CI", params)
+	assert.NoError(t, err)
+	assert.Len(t, checks, 1)
+	assert.Equal(t, "ci", checks[0].CreationMetadata("acme-").Creator)
+	assert.Equal(t, map[string]string{"limit": "10"}, params)
+
+	_, err = client.Checks.ListCreatedBy(context.Background(), "")
+	assert.EqualError(t, err, "creator must not be empty")
+}
@@ -1,13 +1,17 @@
 package pingdom
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"net/url"
 	"os"
 	"strings"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/internal/redact"
+	"github.com/nordcloud/go-pingdom/internal/transport"
 )
 
 const (
@@ -16,22 +20,114 @@ const (
 
 // Client represents a client to the Pingdom API.
 type Client struct {
-	APIToken     string
-	BaseURL      *url.URL
-	client       *http.Client
+	APIToken  string
+	BaseURL   *url.URL
+	client    *http.Client
+	auth      Authenticator
+	retry     *RetryPolicy
+	endpoints map[EndpointClass]endpoint
+	features  map[string]bool
+	metadata  *CreationMetadata
+	clock     clock
+	activity  activity
+	readOnly  bool
+	cache     *responseCache
+
+	clockSkewPolicy    ClockSkewPolicy
+	clockSkewTolerance time.Duration
+	onClockSkew        func(ClockSkewWarning)
+	tolerantFieldNames bool
+
+	Account      *AccountService
+	Actions      *ActionsService
+	Analysis     *AnalysisService
 	Checks       *CheckService
 	Contacts     *ContactService
 	Maintenances *MaintenanceService
 	Occurrences  *OccurrenceService
 	Probes       *ProbeService
+	Reference    *ReferenceService
+	Results      *ResultsService
+	Single       *SingleService
+	TMSChecks    *TMSCheckService
 	Teams        *TeamService
+	Traceroute   *TracerouteService
 }
 
 // ClientConfig represents a configuration for a pingdom client.
+//
+// When APIToken is set (directly or through PINGDOM_API_TOKEN) requests are
+// authenticated with a Bearer token as required by the 3.1 API. Otherwise, if
+// Username and AppKey are given, the legacy 2.x basic auth + App-Key scheme is
+// used; in that case BaseURL should point at the API version the account uses.
+// Auth takes precedence over both when set.  APITokenSecret and
+// PasswordSecret read the API token and the password from a SecretProvider
+// when each request is sent instead, the plain APIToken taking precedence.
+//
+// Retry enables retrying of failed requests, see RetryPolicy. Endpoints
+// overrides it, along with the timeout of requests, per class of endpoints:
+// e.g. summaries are slow but safe to retry while writes should not be.
+//
+// FixtureDir switches the client to offline mode: responses are served from
+// the files in that directory instead of the API, see FixtureTransport.
+// HTTPClient is ignored in that case.
+//
+// Timeouts sets the timeout of each phase of a request, see Timeouts. TLS
+// sets the minimum TLS version and the pinned keys or certificates, see
+// TLSConfig. Proxy sets an authenticated proxy, see ProxyConfig. They are
+// ignored when HTTPClient is set.
+//
+// ExperimentalFeatures opts in to beta endpoints, such as FeatureTMS, whose
+// API may still change. They can also be enabled with a comma separated list
+// in PINGDOM_EXPERIMENTAL_FEATURES.
+//
+// CreationMetadata tags the checks created through the client with the
+// identity and purpose of their creator, see CheckService.ListCreatedBy.
+//
+// ClockSkewPolicy protects maintenance windows from a skewed local clock,
+// measured from the Date header of the responses of Pingdom, when it is off
+// by more than ClockSkewTolerance (one minute by default).  OnClockSkew is
+// called for each window sent while the clocks are skewed, e.g. to log a
+// warning.
+//
+// ReadOnly makes every mutating call, e.g. CheckService.Create, fail with a
+// ReadOnlyError without sending the request, for services which must not
+// modify the account.
+//
+// CacheTTL caches the successful responses of GET requests for that long,
+// e.g. for dashboards polling the same lists; zero disables caching.
+// Creating, updating or deleting a resource invalidates its cached responses,
+// e.g. those of the check list after CheckService.Update, see ClearCache for
+// changes made by other means.
+//
+// TolerantFieldNames decodes the fields of responses whatever their
+// underscores, e.g. "probeFilters" as well as "probe_filters", for API
+// versions whose casing drifted.  Decoding is slower then.
 type ClientConfig struct {
-	APIToken   string
-	BaseURL    string
-	HTTPClient *http.Client
+	APIToken             string
+	APITokenSecret       SecretProvider
+	Username             string
+	Password             string
+	PasswordSecret       SecretProvider
+	AppKey               string
+	AccountEmail         string
+	Auth                 Authenticator
+	BaseURL              string
+	HTTPClient           *http.Client
+	Retry                *RetryPolicy
+	Endpoints            map[EndpointClass]EndpointPolicy
+	FixtureDir           string
+	Timeouts             *Timeouts
+	TLS                  *TLSConfig
+	Proxy                *ProxyConfig
+	ExperimentalFeatures []string
+	CreationMetadata     *CreationMetadata
+	ClockSkewPolicy      ClockSkewPolicy
+	ClockSkewTolerance   time.Duration
+	OnClockSkew          func(ClockSkewWarning)
+	ReadOnly             bool
+	CacheTTL             time.Duration
+	TolerantFieldNames   bool
 }
 
 // NewClientWithConfig returns a Pingdom client.
@@ -59,18 +155,78 @@ func NewClientWithConfig(config ClientConfig) (*Client, error) {
 		c.APIToken = config.APIToken
 	}
 
-	if config.HTTPClient != nil {
+	if config.Auth != nil {
+		c.auth = config.Auth
+	} else if c.APIToken == "" && config.APITokenSecret != nil {
+		c.auth = &SecretTokenAuth{APIToken: config.APITokenSecret}
+	} else if c.APIToken == "" {
+		legacy := &LegacyAuth{
+			Username:     config.Username,
+			Password:     config.Password,
+			AppKey:       config.AppKey,
+			AccountEmail: config.AccountEmail,
+		}
+		if legacy.Username == "" {
+			legacy.Username = os.Getenv("PINGDOM_USER")
+		}
+		if legacy.Password == "" {
+			legacy.Password = os.Getenv("PINGDOM_PASSWORD")
+		}
+		if legacy.AppKey == "" {
+			legacy.AppKey = os.Getenv("PINGDOM_APP_KEY")
+		}
+		if legacy.Username != "" && legacy.AppKey != "" && config.PasswordSecret != nil {
+			c.auth = &SecretLegacyAuth{
+				Username:     legacy.Username,
+				Password:     config.PasswordSecret,
+				AppKey:       legacy.AppKey,
+				AccountEmail: legacy.AccountEmail,
+			}
+		} else if legacy.Username != "" && legacy.AppKey != "" {
+			c.auth = legacy
+		}
+	}
+
+	if config.FixtureDir != "" {
+		c.client = &http.Client{
+			Transport: &FixtureTransport{Dir: config.FixtureDir, BasePath: baseURL.Path},
+		}
+	} else if config.HTTPClient != nil {
 		c.client = config.HTTPClient
+	} else if config.Timeouts != nil || config.TLS != nil || config.Proxy != nil {
+		rt, err := transport.Build(transport.Options{Timeouts: config.Timeouts, TLS: config.TLS, Proxy: config.Proxy})
+		if err != nil {
+			return nil, err
+		}
+		c.client = &http.Client{Transport: rt}
 	} else {
 		c.client = http.DefaultClient
 	}
+	c.retry = config.Retry
+	c.endpoints = newEndpoints(config.Endpoints, c.client, c.retry)
+	c.features = newFeatureSet(config.ExperimentalFeatures)
+	c.metadata = config.CreationMetadata
+	c.clockSkewPolicy = config.ClockSkewPolicy
+	c.clockSkewTolerance = config.ClockSkewTolerance
+	c.onClockSkew = config.OnClockSkew
+	c.readOnly = config.ReadOnly
+	c.cache = newResponseCache(config.CacheTTL)
+	c.tolerantFieldNames = config.TolerantFieldNames
 
+	c.Account = &AccountService{client: c}
+	c.Actions = &ActionsService{client: c}
+	c.Analysis = &AnalysisService{client: c}
 	c.Checks = &CheckService{client: c}
 	c.Contacts = &ContactService{client: c}
 	c.Maintenances = &MaintenanceService{client: c}
 	c.Occurrences = &OccurrenceService{client: c}
 	c.Probes = &ProbeService{client: c}
+	c.Reference = &ReferenceService{client: c}
+	c.Results = &ResultsService{client: c}
+	c.Single = &SingleService{client: c}
+	c.TMSChecks = &TMSCheckService{client: c}
 	c.Teams = &TeamService{client: c}
+	c.Traceroute = &TracerouteService{client: c}
 	return c, nil
 }
 
@@ -95,7 +251,10 @@ func (pc *Client) NewRequest(method string, rsc string, params map[string]string
 	}
 
 	req, err := http.NewRequest(method, baseURL.String(), nil)
-	req.Header.Add("Authorization", "Bearer "+pc.APIToken)
+	if err != nil {
+		return nil, err
+	}
+	pc.authenticate(req)
 	return req, err
 }
 
@@ -116,7 +275,10 @@ func (pc *Client) NewRequestMultiParamValue(method string, rsc string, params ma
 	}
 
 	req, err := http.NewRequest(method, baseURL.String(), nil)
-	req.Header.Add("Authorization", "Bearer "+pc.APIToken)
+	if err != nil {
+		return nil, err
+	}
+	pc.authenticate(req)
 	return req, err
 }
 
@@ -132,38 +294,151 @@ func (pc *Client) NewJSONRequest(method string, rsc string, params string) (*htt
 	reqBody := strings.NewReader(params)
 
 	req, err := http.NewRequest(method, baseURL.String(), reqBody)
-	req.Header.Add("Authorization", "Bearer "+pc.APIToken)
+	if err != nil {
+		return nil, err
+	}
+	pc.authenticate(req)
 	req.Header.Add("Content-Type", "application/json")
 	return req, err
 }
 
+// authenticate applies the configured Authenticator, falling back to the
+// Bearer token in APIToken.
+func (pc *Client) authenticate(req *http.Request) {
+	if pc.auth != nil {
+		pc.auth.Authenticate(req)
+		return
+	}
+	(&TokenAuth{APIToken: pc.APIToken}).Authenticate(req)
+}
+
 // Do makes an HTTP request and will unmarshal the JSON response in to the
 // passed in interface.  If the HTTP response is outside of the 2xx range the
 // response will be returned along with the error.
 func (pc *Client) Do(req *http.Request, v interface{}) (*http.Response, error) {
-	resp, err := pc.client.Do(req)
+	resp, err := pc.exec(req)
 	if err != nil {
-		return nil, err
+		return resp, err
 	}
 	defer resp.Body.Close()
 
-	if err := validateResponse(resp); err != nil {
-		return resp, err
+	err = pc.decodeResponse(resp, v)
+	return resp, err
+}
+
+// exec sends the request, retrying it according to the configured
+// RetryPolicy until the context of the request is done, and validates the
+// response.  The body of the returned response must be closed by the caller
+// when no error is returned.  Mutating requests of a read-only client are not
+// sent, and GET requests are answered from the cache when enabled.  A
+// ContextAuthenticator authenticates the request first.
+func (pc *Client) exec(req *http.Request) (*http.Response, error) {
+	if pc.readOnly && isMutating(req.Method) {
+		return nil, &ReadOnlyError{Method: req.Method, Path: req.URL.Path}
+	}
+	if resp := pc.cache.get(req); resp != nil {
+		return resp, nil
+	}
+	if auth, ok := pc.auth.(ContextAuthenticator); ok {
+		if err := auth.AuthenticateContext(req); err != nil {
+			pc.activity.sessionFailed(err)
+			return nil, err
+		}
 	}
 
-	err = decodeResponse(resp, v)
+	resp, err := pc.sendRetrying(req)
+	if cerr := pc.cache.update(req, pc.resource(req), resp, err); cerr != nil {
+		return nil, cerr
+	}
 	return resp, err
 }
 
+// sendRetrying sends the request, retrying it according to the RetryPolicy
+// of its endpoint.
+func (pc *Client) sendRetrying(req *http.Request) (*http.Response, error) {
+	client, retry := pc.endpoint(req)
+	if !retry.enabled() {
+		return pc.send(client, req)
+	}
+
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		resp, err := pc.send(client, req)
+		if err == nil {
+			return resp, nil
+		}
+		if attempt > retry.MaxRetries || !retry.shouldRetry(req, resp, err) {
+			return resp, &RetryError{Attempts: attempt, Elapsed: time.Since(start), Err: err}
+		}
+
+		timer := time.NewTimer(retry.backoff(attempt, resp))
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return resp, &RetryError{Attempts: attempt, Elapsed: time.Since(start), Err: req.Context().Err()}
+		case <-timer.C:
+		}
+		if rerr := rewind(req); rerr != nil {
+			return resp, &RetryError{Attempts: attempt, Elapsed: time.Since(start), Err: err}
+		}
+	}
+}
+
+// send performs a single attempt of the request with the given client.  When
+// the response is not successful its body is closed and it is returned along
+// with the error.
+func (pc *Client) send(client *http.Client, req *http.Request) (*http.Response, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		// The query string may carry credentials, e.g. the auth of an HTTP check.
+		err = redact.Error(err)
+		pc.activity.observe(nil, err)
+		return nil, err
+	}
+	pc.clock.observe(resp)
+
+	if err := validateResponse(resp); err != nil {
+		resp.Body.Close()
+		err = scopeError(req, resp, err)
+		pc.activity.observe(resp, err)
+		return resp, err
+	}
+	pc.activity.observe(resp, nil)
+	return resp, nil
+}
+
 func decodeResponse(r *http.Response, v interface{}) error {
 	if v == nil {
 		return fmt.Errorf("nil interface provided to decodeResponse")
 	}
 
-	bodyBytes, _ := ioutil.ReadAll(r.Body)
-	bodyString := string(bodyBytes)
-	err := json.Unmarshal([]byte(bodyString), &v)
-	return err
+	body, err := readBody(r)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, v)
+}
+
+// maxPreallocatedBody caps the buffer preallocated from the Content-Length of
+// a response, which is not trusted beyond it.
+const maxPreallocatedBody = 64 << 20
+
+// readBody reads the body of the response into a buffer preallocated from its
+// Content-Length, so that large lists are not copied over and over while
+// they are read.  Read errors, e.g. of a truncated or reset body, name the
+// request of the response.
+func readBody(r *http.Response) ([]byte, error) {
+	var buf bytes.Buffer
+	if n := r.ContentLength; n > 0 && n <= maxPreallocatedBody {
+		buf.Grow(int(n) + bytes.MinRead)
+	}
+	if _, err := buf.ReadFrom(r.Body); err != nil {
+		if r.Request != nil && r.Request.URL != nil {
+			return nil, fmt.Errorf("reading the response to %s %s: %w", r.Request.Method, r.Request.URL.Path, err)
+		}
+		return nil, fmt.Errorf("reading the response: %w", err)
+	}
+	return buf.Bytes(), nil
 }
 
 // Takes an HTTP response and determines whether it was successful.
@@ -174,10 +449,12 @@ func validateResponse(r *http.Response) error {
 		return nil
 	}
 
-	bodyBytes, _ := ioutil.ReadAll(r.Body)
-	bodyString := string(bodyBytes)
+	body, err := readBody(r)
+	if err != nil {
+		return err
+	}
 	m := &errorJSONResponse{}
-	err := json.Unmarshal([]byte(bodyString), &m)
+	err = json.Unmarshal(body, m)
 	if err != nil {
 		return err
 	}
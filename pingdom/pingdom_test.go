@@ -1,7 +1,10 @@
 package pingdom
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -134,3 +137,48 @@ func TestValidateResponse(t *testing.T) {
 	want := &PingdomError{400, "Bad Request", "This is an error"}
 	assert.Equal(t, want, validateResponse(invalid))
 }
+
+func TestTruncatedResponse(t *testing.T) {
+	setup()
+	defer teardown()
+
+	truncated := func(status int) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Length", "100")
+			w.WriteHeader(status)
+			fmt.Fprint(w, `{"checks":[`)
+		}
+	}
+	mux.HandleFunc("/checks", truncated(http.StatusOK))
+	mux.HandleFunc("/checks/1", truncated(http.StatusBadRequest))
+
+	_, err := client.Checks.List(context.Background())
+	assert.EqualError(t, err, "reading the response to GET /checks: unexpected EOF")
+	assert.True(t, errors.Is(err, io.ErrUnexpectedEOF))
+
+	_, err = client.Checks.Delete(context.Background(), 1)
+	assert.EqualError(t, err, "reading the response to DELETE /checks/1: unexpected EOF")
+
+	client.tolerantFieldNames = true
+	_, err = client.Checks.List(context.Background())
+	assert.True(t, errors.Is(err, io.ErrUnexpectedEOF))
+}
+
+func TestNetworkErrorsAreRedacted(t *testing.T) {
+	setup()
+	server.Close()
+
+	check := &HttpCheck{
+		Name:           "check",
+		Hostname:       "example.com",
+		Resolution:     5,
+		Username:       "user",
+		Password:       "hunter2",
+		RequestHeaders: map[string]string{"Authorization": "Bearer s3cr3t"},
+	}
+	_, err := client.Checks.Create(context.Background(), check)
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "hunter2")
+	assert.NotContains(t, err.Error(), "s3cr3t")
+	assert.Contains(t, err.Error(), "example.com")
+}
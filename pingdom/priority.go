@@ -0,0 +1,222 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	severityTagPrefix = "severity-"
+	priorityTagPrefix = "priority-p"
+)
+
+// Severity is the severity of the failure of a check, recorded in its tags as
+// "severity-<Severity>", e.g. "severity-critical", so that alert routing can
+// rely on consistent semantics.  Pingdom tags cannot contain ":", hence the
+// "-".  It is unrelated to the SeverityLevel of the alerts of a check.
+type Severity string
+
+// The severities, from the most to the least severe.
+const (
+	SeverityCritical Severity = "critical"
+	SeverityHigh     Severity = "high"
+	SeverityMedium   Severity = "medium"
+	SeverityLow      Severity = "low"
+	SeverityInfo     Severity = "info"
+)
+
+// Severities lists the severities, from the most to the least severe.
+var Severities = []Severity{SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow, SeverityInfo}
+
+// rank returns the index of the severity in Severities, or -1 when it is
+// unknown.
+func (s Severity) rank() int {
+	for i, severity := range Severities {
+		if s == severity {
+			return i
+		}
+	}
+	return -1
+}
+
+// Valid determines whether the severity is one of Severities.
+func (s Severity) Valid() error {
+	if s.rank() < 0 {
+		return fmt.Errorf("invalid severity %q, must be one of critical, high, medium, low or info", string(s))
+	}
+	return nil
+}
+
+// AtLeast reports whether the severity is known and at least as severe as
+// min.
+func (s Severity) AtLeast(min Severity) bool {
+	return s.rank() >= 0 && min.rank() >= 0 && s.rank() <= min.rank()
+}
+
+// Tag returns the tag recording the severity.
+func (s Severity) Tag() string {
+	return severityTagPrefix + string(s)
+}
+
+// Priority is the priority of the response to the failure of a check, from
+// 1, the highest, to 5, recorded in its tags as "priority-p<Priority>", e.g.
+// "priority-p1".  The zero Priority means none.
+type Priority int
+
+// The range of the priorities.
+const (
+	HighestPriority Priority = 1
+	LowestPriority  Priority = 5
+)
+
+// Valid determines whether the priority is between HighestPriority and
+// LowestPriority.
+func (p Priority) Valid() error {
+	if p < HighestPriority || p > LowestPriority {
+		return fmt.Errorf("invalid priority %d, must be between 1 and 5", int(p))
+	}
+	return nil
+}
+
+// String returns the priority as in "P1".
+func (p Priority) String() string {
+	return "P" + strconv.Itoa(int(p))
+}
+
+// Tag returns the tag recording the priority.
+func (p Priority) Tag() string {
+	return priorityTagPrefix + strconv.Itoa(int(p))
+}
+
+// Severity returns the severity recorded in the tags of the check, or "" when
+// it has none.  Tags are only listed when the include_tags parameter is set.
+func (cr *CheckResponse) Severity() Severity {
+	for _, tag := range cr.Tags {
+		if !strings.HasPrefix(tag.Name, severityTagPrefix) {
+			continue
+		}
+		if s := Severity(strings.TrimPrefix(tag.Name, severityTagPrefix)); s.Valid() == nil {
+			return s
+		}
+	}
+	return ""
+}
+
+// Priority returns the priority recorded in the tags of the check, or 0 when
+// it has none.  Tags are only listed when the include_tags parameter is set.
+func (cr *CheckResponse) Priority() Priority {
+	for _, tag := range cr.Tags {
+		if !strings.HasPrefix(tag.Name, priorityTagPrefix) {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimPrefix(tag.Name, priorityTagPrefix))
+		if p := Priority(n); err == nil && p.Valid() == nil {
+			return p
+		}
+	}
+	return 0
+}
+
+// SetSeverity returns the comma separated tags of a check, e.g. the Tags of
+// an HttpCheck, with the severity replacing any recorded before.  An empty
+// severity removes it.
+func SetSeverity(tags string, s Severity) (string, error) {
+	if s != "" {
+		if err := s.Valid(); err != nil {
+			return "", err
+		}
+	}
+	return replaceTag(tags, severityTagPrefix, s.Tag(), s != ""), nil
+}
+
+// SetPriority returns the comma separated tags of a check, e.g. the Tags of
+// an HttpCheck, with the priority replacing any recorded before.  A zero
+// priority removes it.
+func SetPriority(tags string, p Priority) (string, error) {
+	if p != 0 {
+		if err := p.Valid(); err != nil {
+			return "", err
+		}
+	}
+	return replaceTag(tags, priorityTagPrefix, p.Tag(), p != 0), nil
+}
+
+// replaceTag removes the tags with the prefix from the comma separated tags
+// and appends tag when add is set.
+func replaceTag(tags string, prefix string, tag string, add bool) string {
+	var kept []string
+	for _, t := range strings.Split(tags, ",") {
+		if t = strings.TrimSpace(t); t != "" && !strings.HasPrefix(t, prefix) {
+			kept = append(kept, t)
+		}
+	}
+	if add {
+		kept = append(kept, tag)
+	}
+	return strings.Join(kept, ",")
+}
+
+// FilterBySeverity returns the checks with a severity at least as severe as
+// min.
+func FilterBySeverity(checks []CheckResponse, min Severity) []CheckResponse {
+	var filtered []CheckResponse
+	for _, check := range checks {
+		if check.Severity().AtLeast(min) {
+			filtered = append(filtered, check)
+		}
+	}
+	return filtered
+}
+
+// FilterByPriority returns the checks with a priority at least as high as
+// min, e.g. P1 and P2 for 2.
+func FilterByPriority(checks []CheckResponse, min Priority) []CheckResponse {
+	var filtered []CheckResponse
+	for _, check := range checks {
+		if p := check.Priority(); p != 0 && p <= min {
+			filtered = append(filtered, check)
+		}
+	}
+	return filtered
+}
+
+// ListBySeverity returns the checks with a severity at least as severe as
+// min.  The params are passed to List along with the tag filter.
+func (cs *CheckService) ListBySeverity(ctx context.Context, min Severity, params ...map[string]string) ([]CheckResponse, error) {
+	if err := min.Valid(); err != nil {
+		return nil, err
+	}
+	var tags []string
+	for _, s := range Severities[:min.rank()+1] {
+		tags = append(tags, s.Tag())
+	}
+	return cs.listByTags(ctx, tags, params...)
+}
+
+// ListByPriority returns the checks with a priority at least as high as min.
+// The params are passed to List along with the tag filter.
+func (cs *CheckService) ListByPriority(ctx context.Context, min Priority, params ...map[string]string) ([]CheckResponse, error) {
+	if err := min.Valid(); err != nil {
+		return nil, err
+	}
+	var tags []string
+	for p := HighestPriority; p <= min; p++ {
+		tags = append(tags, p.Tag())
+	}
+	return cs.listByTags(ctx, tags, params...)
+}
+
+// listByTags lists the checks with any of the tags, including their tags.
+func (cs *CheckService) listByTags(ctx context.Context, tags []string, params ...map[string]string) ([]CheckResponse, error) {
+	param := map[string]string{}
+	if len(params) == 1 {
+		for k, v := range params[0] {
+			param[k] = v
+		}
+	}
+	param["tags"] = strings.Join(tags, ",")
+	param["include_tags"] = "true"
+	return cs.List(ctx, param)
+}
@@ -0,0 +1,129 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeverity(t *testing.T) {
+	assert.NoError(t, SeverityCritical.Valid())
+	assert.EqualError(t, Severity("urgent").Valid(), `invalid severity "urgent", must be one of critical, high, medium, low or info`)
+	assert.Equal(t, "severity-critical", SeverityCritical.Tag())
+
+	assert.True(t, SeverityCritical.AtLeast(SeverityHigh))
+	assert.True(t, SeverityHigh.AtLeast(SeverityHigh))
+	assert.False(t, SeverityLow.AtLeast(SeverityHigh))
+	assert.False(t, Severity("").AtLeast(SeverityInfo))
+	assert.False(t, SeverityHigh.AtLeast("urgent"))
+}
+
+func TestPriority(t *testing.T) {
+	assert.NoError(t, Priority(1).Valid())
+	assert.EqualError(t, Priority(6).Valid(), "invalid priority 6, must be between 1 and 5")
+	assert.Error(t, Priority(0).Valid())
+	assert.Equal(t, "P2", Priority(2).String())
+	assert.Equal(t, "priority-p2", Priority(2).Tag())
+}
+
+func TestCheckResponseSeverityAndPriority(t *testing.T) {
+	check := &CheckResponse{Tags: []CheckResponseTag{
+		{Name: "web", Type: "u"},
+		{Name: "severity-urgent", Type: "u"},
+		{Name: "severity-high", Type: "u"},
+		{Name: "priority-px", Type: "u"},
+		{Name: "priority-p2", Type: "u"},
+	}}
+	assert.Equal(t, SeverityHigh, check.Severity())
+	assert.Equal(t, Priority(2), check.Priority())
+
+	check = &CheckResponse{Tags: []CheckResponseTag{{Name: "priority-p9", Type: "u"}}}
+	assert.Equal(t, Severity(""), check.Severity())
+	assert.Equal(t, Priority(0), check.Priority())
+}
+
+func TestSetSeverityAndPriority(t *testing.T) {
+	tags, err := SetSeverity("web, severity-low,api", SeverityCritical)
+	assert.NoError(t, err)
+	assert.Equal(t, "web,api,severity-critical", tags)
+
+	tags, err = SetSeverity(tags, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "web,api", tags)
+
+	_, err = SetSeverity(tags, "urgent")
+	assert.Error(t, err)
+
+	tags, err = SetPriority("", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "priority-p1", tags)
+
+	tags, err = SetPriority("priority-p1,web", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "web,priority-p3", tags)
+
+	tags, err = SetPriority(tags, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "web", tags)
+
+	_, err = SetPriority(tags, 6)
+	assert.Error(t, err)
+}
+
+func TestFilterBySeverityAndPriority(t *testing.T) {
+	checks := []CheckResponse{
+		{ID: 1, Tags: []CheckResponseTag{{Name: "severity-critical"}, {Name: "priority-p3"}}},
+		{ID: 2, Tags: []CheckResponseTag{{Name: "severity-low"}, {Name: "priority-p1"}}},
+		{ID: 3, Tags: []CheckResponseTag{{Name: "web"}}},
+	}
+	assert.Equal(t, checks[:1], FilterBySeverity(checks, SeverityHigh))
+	assert.Equal(t, checks[:2], FilterBySeverity(checks, SeverityInfo))
+	assert.Equal(t, checks[1:2], FilterByPriority(checks, 2))
+	assert.Equal(t, checks[:2], FilterByPriority(checks, LowestPriority))
+}
+
+func TestCheckServiceListBySeverity(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "severity-critical,severity-high", r.URL.Query().Get("tags"))
+		assert.Equal(t, "true", r.URL.Query().Get("include_tags"))
+		assert.Equal(t, "10", r.URL.Query().Get("limit"))
+		fmt.Fprint(w, `{"checks":[{"id":1,"name":"a","tags":[{"name":"severity-high","type":"u","count":1}]}]}`)
+	})
+
+	checks, err := client.Checks.ListBySeverity(context.Background(), SeverityHigh, map[string]string{"limit": "10"})
+	assert.NoError(t, err)
+	if assert.Len(t, checks, 1) {
+		assert.Equal(t, SeverityHigh, checks[0].Severity())
+	}
+
+	_, err = client.Checks.ListBySeverity(context.Background(), "urgent")
+	assert.Error(t, err)
+}
+
+func TestCheckServiceListByPriority(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "priority-p1,priority-p2", r.URL.Query().Get("tags"))
+		assert.Equal(t, "true", r.URL.Query().Get("include_tags"))
+		fmt.Fprint(w, `{"checks":[{"id":1,"name":"a","tags":[{"name":"priority-p2","type":"u","count":1}]}]}`)
+	})
+
+	checks, err := client.Checks.ListByPriority(context.Background(), 2)
+	assert.NoError(t, err)
+	if assert.Len(t, checks, 1) {
+		assert.Equal(t, Priority(2), checks[0].Priority())
+	}
+
+	_, err = client.Checks.ListByPriority(context.Background(), 0)
+	assert.Error(t, err)
+}
@@ -1,17 +1,27 @@
 package pingdom
 
 import (
-	"encoding/json"
-	"io/ioutil"
+	"context"
+	"sync"
+	"time"
 )
 
+// ProbeCacheTTL is how long the probe list fetched by Get is reused before
+// being fetched again.
+const ProbeCacheTTL = time.Hour
+
 // ProbeService provides an interface to Pingdom probes.
 type ProbeService struct {
 	client *Client
+
+	mu       sync.Mutex
+	cache    map[int]ProbeResponse
+	missing  map[int]bool // IDs not in the cache when it was last refreshed
+	cachedAt time.Time
 }
 
 // List return a list of probes from Pingdom.
-func (cs *ProbeService) List(params ...map[string]string) ([]ProbeResponse, error) {
+func (cs *ProbeService) List(ctx context.Context, params ...map[string]string) ([]ProbeResponse, error) {
 	param := map[string]string{}
 	if len(params) == 1 {
 		param = params[0]
@@ -21,21 +31,65 @@ func (cs *ProbeService) List(params ...map[string]string) ([]ProbeResponse, erro
 		return nil, err
 	}
 
-	resp, err := cs.client.client.Do(req)
+	resp, err := cs.client.exec(req.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if err := validateResponse(resp); err != nil {
+	p := &listProbesJSONResponse{}
+	err = cs.client.decodeResponse(resp, p)
+
+	return p.Probes, err
+}
+
+// Get returns the probe with the given ID, or nil if Pingdom does not know it.
+// The probe list is cached for ProbeCacheTTL, an unknown ID causes it to be
+// fetched again once, and is then known to be unknown until the next refresh.
+func (cs *ProbeService) Get(ctx context.Context, id int) (*ProbeResponse, error) {
+	probes, err := cs.lookup(ctx, []int{id})
+	if err != nil {
 		return nil, err
 	}
+	if probe, ok := probes[id]; ok {
+		return &probe, nil
+	}
+	return nil, nil
+}
 
-	bodyBytes, _ := ioutil.ReadAll(resp.Body)
-	bodyString := string(bodyBytes)
+// lookup returns the cached probes with the given IDs, refreshing the cache
+// once if it is stale or any of the IDs is missing and was not already
+// missing after the last refresh.
+func (cs *ProbeService) lookup(ctx context.Context, ids []int) (map[int]ProbeResponse, error) {
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
 
-	p := &listProbesJSONResponse{}
-	err = json.Unmarshal([]byte(bodyString), &p)
+	refresh := cs.cache == nil || time.Since(cs.cachedAt) > ProbeCacheTTL
+	for _, id := range ids {
+		if _, ok := cs.cache[id]; !ok && !cs.missing[id] {
+			refresh = true
+		}
+	}
+	if refresh {
+		probes, err := cs.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		cs.cache = make(map[int]ProbeResponse, len(probes))
+		for _, probe := range probes {
+			cs.cache[probe.ID] = probe
+		}
+		cs.missing = map[int]bool{}
+		cs.cachedAt = time.Now()
+	}
 
-	return p.Probes, err
+	found := make(map[int]ProbeResponse, len(ids))
+	for _, id := range ids {
+		if probe, ok := cs.cache[id]; ok {
+			found[id] = probe
+		} else {
+			cs.missing[id] = true
+		}
+	}
+	return found, nil
 }
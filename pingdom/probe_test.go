@@ -1,6 +1,7 @@
 package pingdom
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"testing"
@@ -72,7 +73,57 @@ func TestProbesServiceList(t *testing.T) {
 
 	params := make(map[string]string)
 
-	probes, err := client.Probes.List(params)
+	probes, err := client.Probes.List(context.Background(), params)
 	assert.NoError(t, err)
 	assert.Equal(t, want, probes, "Probes.List() should return correct result")
 }
+
+const probeListJSON = `{
+	"probes": [
+		{"id": 32, "country": "United States", "city": "Los Angeles", "name": "Los Angeles, CA", "active": true, "countryiso": "US", "region": "NA"},
+		{"id": 87, "country": "Germany", "city": "Frankfurt", "name": "Frankfurt, Germany", "active": true, "countryiso": "DE", "region": "EU"}
+	]
+}`
+
+func TestProbesServiceGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/probes", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		calls++
+		fmt.Fprint(w, probeListJSON)
+	})
+
+	probe, err := client.Probes.Get(context.Background(), 87)
+	assert.NoError(t, err)
+	assert.Equal(t, "Frankfurt, Germany", probe.Name)
+	assert.Equal(t, "EU", probe.Region)
+
+	probe, err = client.Probes.Get(context.Background(), 32)
+	assert.NoError(t, err)
+	assert.Equal(t, "US", probe.CountryISO)
+	assert.Equal(t, 1, calls, "probe list should be cached")
+
+	probe, err = client.Probes.Get(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Nil(t, probe)
+	assert.Equal(t, 2, calls, "unknown probe should refresh the cache")
+
+	probe, err = client.Probes.Get(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Nil(t, probe)
+	assert.Equal(t, 2, calls, "unknown probe should be cached until the next refresh")
+
+	probe, err = client.Probes.Get(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Nil(t, probe)
+	assert.Equal(t, 3, calls, "another unknown probe should refresh the cache")
+
+	client.Probes.cachedAt = client.Probes.cachedAt.Add(-2 * ProbeCacheTTL)
+	probe, err = client.Probes.Get(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Nil(t, probe)
+	assert.Equal(t, 4, calls, "a stale cache should be refreshed")
+}
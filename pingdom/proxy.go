@@ -0,0 +1,13 @@
+package pingdom
+
+import "github.com/nordcloud/go-pingdom/internal/transport"
+
+// ProxyConfig routes the requests to Pingdom through an authenticated proxy,
+// instead of the one of the environment. Set ClientConfig.Proxy to apply it.
+type ProxyConfig = transport.ProxyConfig
+
+// The authentication schemes of ProxyConfig.Auth.
+const (
+	ProxyAuthBasic = transport.ProxyAuthBasic
+	ProxyAuthNTLM  = transport.ProxyAuthNTLM
+)
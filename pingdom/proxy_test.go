@@ -0,0 +1,41 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientProxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, _ := parseProxyAuthorization(r)
+		proxied = append(proxied, fmt.Sprintf("%s %s %s:%s", r.Method, r.URL, user, password))
+		fmt.Fprint(w, `{"checks":[]}`)
+	}))
+	defer proxy.Close()
+
+	c, err := NewClientWithConfig(ClientConfig{
+		APIToken: "token",
+		BaseURL:  "http://api.pingdom.example/api/3.1",
+		Proxy:    &ProxyConfig{URL: proxy.URL, Username: "user", Password: "secret"},
+	})
+	assert.NoError(t, err)
+	_, err = c.Checks.List(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"GET http://api.pingdom.example/api/3.1/checks user:secret"}, proxied)
+
+	_, err = NewClientWithConfig(ClientConfig{APIToken: "token", Proxy: &ProxyConfig{URL: "proxy.example.com:3128"}})
+	assert.Error(t, err)
+}
+
+// parseProxyAuthorization reads the basic credentials of the proxy, which
+// http.Request.BasicAuth only reads from the Authorization header.
+func parseProxyAuthorization(r *http.Request) (string, string, bool) {
+	auth := &http.Request{Header: http.Header{"Authorization": r.Header["Proxy-Authorization"]}}
+	return auth.BasicAuth()
+}
@@ -0,0 +1,77 @@
+package pingdom
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ReferenceCacheTTL is how long the reference data fetched by Get is reused
+// before being fetched again.
+const ReferenceCacheTTL = 24 * time.Hour
+
+// ReferenceService provides an interface to the reference data of Pingdom:
+// regions, time zones, date, time and number formats, countries and phone
+// codes.
+type ReferenceService struct {
+	client *Client
+
+	mu       sync.Mutex
+	cache    *Reference
+	cachedAt time.Time
+}
+
+// Get returns the reference data.  It rarely changes, so it is cached for
+// ReferenceCacheTTL; every call returns its own copy of the cache.
+func (rs *ReferenceService) Get(ctx context.Context) (*Reference, error) {
+	rs.mu.Lock()
+	cache, cachedAt := rs.cache, rs.cachedAt
+	rs.mu.Unlock()
+	if cache != nil && time.Since(cachedAt) <= ReferenceCacheTTL {
+		return cache.clone(), nil
+	}
+
+	// The lock is not held during the request, so that callers of a fresh
+	// cache are not kept waiting by the refresh.
+	req, err := rs.client.NewRequest("GET", "/reference", nil)
+	if err != nil {
+		return nil, err
+	}
+	m := &Reference{}
+	_, err = rs.client.Do(req.WithContext(ctx), m)
+	if err != nil {
+		return nil, err
+	}
+
+	rs.mu.Lock()
+	rs.cache = m
+	rs.cachedAt = time.Now()
+	rs.mu.Unlock()
+	return m.clone(), nil
+}
+
+// Timezone returns the time zone with the given ID, or nil if Pingdom does
+// not know it, e.g. to validate a time zone before sending it.
+func (rs *ReferenceService) Timezone(ctx context.Context, id string) (*ReferenceTimezone, error) {
+	ref, err := rs.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range ref.Timezones {
+		if ref.Timezones[i].ID == id {
+			return &ref.Timezones[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *Reference) clone() *Reference {
+	return &Reference{
+		Regions:         append([]ReferenceRegion(nil), r.Regions...),
+		Timezones:       append([]ReferenceTimezone(nil), r.Timezones...),
+		DateTimeFormats: append([]ReferenceDateTimeFormat(nil), r.DateTimeFormats...),
+		NumberFormats:   append([]ReferenceNumberFormat(nil), r.NumberFormats...),
+		Countries:       append([]ReferenceCountry(nil), r.Countries...),
+		PhoneCodes:      append([]ReferencePhoneCode(nil), r.PhoneCodes...),
+	}
+}
@@ -0,0 +1,96 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const referenceResponse = `{
+	"regions": [
+		{"id": 1, "description": "United States (EST)", "countryid": 13, "datetimeformatid": 1, "numberformatid": 1, "timezoneid": "America/New_York"}
+	],
+	"timezones": [
+		{"id": "America/New_York", "description": "(GMT -5:00) Eastern Time (US & Canada)"},
+		{"id": "Europe/Stockholm", "description": "(GMT +1:00) Stockholm"}
+	],
+	"datetimeformats": [{"id": 1, "description": "m/d/y h:m:s a"}],
+	"numberformats": [{"id": 1, "description": "123,456.00"}],
+	"countries": [{"id": 13, "iso": "US"}],
+	"phonecodes": [{"countryid": 13, "name": "United States", "phonecode": "1"}]
+}`
+
+func TestReferenceServiceGet(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := 0
+	mux.HandleFunc("/reference", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		requests++
+		fmt.Fprint(w, referenceResponse)
+	})
+
+	ref, err := client.Reference.Get(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, &Reference{
+		Regions: []ReferenceRegion{
+			{ID: 1, Description: "United States (EST)", CountryID: 13, DateTimeFormatID: 1, NumberFormatID: 1, TimezoneID: "America/New_York"},
+		},
+		Timezones: []ReferenceTimezone{
+			{ID: "America/New_York", Description: "(GMT -5:00) Eastern Time (US & Canada)"},
+			{ID: "Europe/Stockholm", Description: "(GMT +1:00) Stockholm"},
+		},
+		DateTimeFormats: []ReferenceDateTimeFormat{{ID: 1, Description: "m/d/y h:m:s a"}},
+		NumberFormats:   []ReferenceNumberFormat{{ID: 1, Description: "123,456.00"}},
+		Countries:       []ReferenceCountry{{ID: 13, ISO: "US"}},
+		PhoneCodes:      []ReferencePhoneCode{{CountryID: 13, Name: "United States", PhoneCode: "1"}},
+	}, ref)
+
+	tz, err := client.Reference.Timezone(context.Background(), "Europe/Stockholm")
+	assert.NoError(t, err)
+	assert.Equal(t, &ReferenceTimezone{ID: "Europe/Stockholm", Description: "(GMT +1:00) Stockholm"}, tz)
+	tz, err = client.Reference.Timezone(context.Background(), "Mars/Olympus_Mons")
+	assert.NoError(t, err)
+	assert.Nil(t, tz)
+	assert.Equal(t, 1, requests)
+}
+
+func TestReferenceServiceGetReturnsCopies(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	requests := 0
+	mux.HandleFunc("/reference", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		fmt.Fprint(w, referenceResponse)
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ref, err := client.Reference.Get(context.Background())
+			assert.NoError(t, err)
+			ref.Timezones[0].ID = "Mars/Olympus_Mons"
+			ref.Countries = nil
+		}()
+	}
+	wg.Wait()
+
+	ref, err := client.Reference.Get(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "America/New_York", ref.Timezones[0].ID)
+	assert.Equal(t, []ReferenceCountry{{ID: 13, ISO: "US"}}, ref.Countries)
+	mu.Lock()
+	assert.True(t, requests >= 1)
+	mu.Unlock()
+}
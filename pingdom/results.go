@@ -0,0 +1,111 @@
+package pingdom
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// ResultMaxLimit is the largest number of results Pingdom returns at once.
+const ResultMaxLimit = 1000
+
+// ResultMaxOffset is the largest offset Pingdom accepts for results.
+const ResultMaxOffset = 43200
+
+// ResultsService provides an interface to the raw results of Pingdom checks.
+type ResultsService struct {
+	client *Client
+}
+
+// ResultsRequest is the API request to Pingdom for the raw results of a check.
+// From and To are unix timestamps, Status holds any of "up", "down",
+// "unconfirmed" and "unknown".  Results are returned newest first, Limit and
+// Offset page through them.
+type ResultsRequest struct {
+	Id              int
+	From            int
+	To              int
+	Probes          []int
+	Status          []string
+	Limit           int
+	Offset          int
+	IncludeAnalysis bool
+	MinResponse     int
+	MaxResponse     int
+}
+
+// Valid determines whether a ResultsRequest contains valid fields for the Pingdom API.
+func (rr ResultsRequest) Valid() error {
+	if rr.Id == 0 {
+		return ErrMissingId
+	}
+
+	for _, status := range rr.Status {
+		// Results are "unconfirmed" rather than "unconfirmed_down".
+		switch CheckStatus(status) {
+		case CheckStatusUp, CheckStatusDown, "unconfirmed", CheckStatusUnknown:
+		default:
+			return ErrBadResultStatus
+		}
+	}
+
+	if rr.Limit < 0 || rr.Limit > ResultMaxLimit {
+		return ErrBadLimit
+	}
+
+	if rr.Offset < 0 || rr.Offset > ResultMaxOffset {
+		return ErrBadOffset
+	}
+	return nil
+}
+
+// GetParams returns a map of params for a Pingdom ResultsRequest.
+func (rr ResultsRequest) GetParams() (params map[string]string) {
+	params = make(map[string]string)
+
+	if rr.From != 0 {
+		params["from"] = strconv.Itoa(rr.From)
+	}
+
+	if rr.To != 0 {
+		params["to"] = strconv.Itoa(rr.To)
+	}
+
+	if len(rr.Probes) > 0 {
+		params["probes"] = intListToCDString(rr.Probes)
+	}
+
+	if len(rr.Status) > 0 {
+		params["status"] = strings.Join(rr.Status, ",")
+	}
+
+	if rr.Limit != 0 {
+		params["limit"] = strconv.Itoa(rr.Limit)
+	}
+
+	if rr.Offset != 0 {
+		params["offset"] = strconv.Itoa(rr.Offset)
+	}
+
+	if rr.IncludeAnalysis {
+		params["includeanalysis"] = "true"
+	}
+
+	if rr.MinResponse != 0 {
+		params["minresponse"] = strconv.Itoa(rr.MinResponse)
+	}
+
+	if rr.MaxResponse != 0 {
+		params["maxresponse"] = strconv.Itoa(rr.MaxResponse)
+	}
+
+	return
+}
+
+// List returns the raw results of a check matching the request.
+func (rs *ResultsService) List(ctx context.Context, request ResultsRequest) (*ResultsResponse, error) {
+	if err := request.Valid(); err != nil {
+		return nil, err
+	}
+	return rs.client.Checks.Results(ctx, request.Id, request.GetParams())
+}
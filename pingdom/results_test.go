@@ -0,0 +1,100 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultsRequestValid(t *testing.T) {
+	assert.Equal(t, ErrMissingId, ResultsRequest{}.Valid())
+	assert.NoError(t, ResultsRequest{Id: 1, Status: []string{"up", "unconfirmed"}, Limit: 1000, Offset: 43200}.Valid())
+	assert.Equal(t, ErrBadResultStatus, ResultsRequest{Id: 1, Status: []string{"paused"}}.Valid())
+	assert.Equal(t, ErrBadLimit, ResultsRequest{Id: 1, Limit: 1001}.Valid())
+	assert.Equal(t, ErrBadLimit, ResultsRequest{Id: 1, Limit: -1}.Valid())
+	assert.Equal(t, ErrBadOffset, ResultsRequest{Id: 1, Offset: 43201}.Valid())
+}
+
+func TestResultsRequestGetParams(t *testing.T) {
+	assert.Equal(t, map[string]string{}, ResultsRequest{Id: 1}.GetParams())
+	assert.Equal(t, map[string]string{
+		"from":            "1563370000",
+		"to":              "1563380000",
+		"probes":          "87,259",
+		"status":          "down,unconfirmed",
+		"limit":           "100",
+		"offset":          "200",
+		"includeanalysis": "true",
+		"minresponse":     "500",
+		"maxresponse":     "2000",
+	}, ResultsRequest{
+		Id:              1,
+		From:            1563370000,
+		To:              1563380000,
+		Probes:          []int{87, 259},
+		Status:          []string{"down", "unconfirmed"},
+		Limit:           100,
+		Offset:          200,
+		IncludeAnalysis: true,
+		MinResponse:     500,
+		MaxResponse:     2000,
+	}.GetParams())
+}
+
+func TestResultsServiceList(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/results/12345", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "down", r.URL.Query().Get("status"))
+		assert.Equal(t, "87,93", r.URL.Query().Get("probes"))
+		assert.Equal(t, "true", r.URL.Query().Get("includeanalysis"))
+		assert.Equal(t, "10", r.URL.Query().Get("limit"))
+		fmt.Fprint(w, `{
+			"activeprobes": [87, 93],
+			"results": [
+				{
+					"probeid": 87,
+					"time": 1563370611,
+					"status": "down",
+					"responsetime": 30000,
+					"statusdesc": "Timeout",
+					"statusdesclong": "Timeout (> 30s)",
+					"analysisid": 987654
+				},
+				{
+					"probeid": 93,
+					"time": 1563370551,
+					"status": "down",
+					"responsetime": 30000,
+					"statusdesc": "Timeout",
+					"statusdesclong": "Timeout (> 30s)"
+				}
+			]
+		}`)
+	})
+	want := &ResultsResponse{
+		ActiveProbes: []int{87, 93},
+		Results: []Result{
+			{ProbeID: 87, Time: 1563370611, Status: "down", ResponseTime: 30000, StatusDesc: "Timeout", StatusDescLong: "Timeout (> 30s)", AnalysisID: 987654},
+			{ProbeID: 93, Time: 1563370551, Status: "down", ResponseTime: 30000, StatusDesc: "Timeout", StatusDescLong: "Timeout (> 30s)"},
+		},
+	}
+
+	results, err := client.Results.List(context.Background(), ResultsRequest{
+		Id:              12345,
+		Probes:          []int{87, 93},
+		Status:          []string{"down"},
+		Limit:           10,
+		IncludeAnalysis: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, want, results)
+
+	_, err = client.Results.List(context.Background(), ResultsRequest{Id: 12345, Status: []string{"paused"}})
+	assert.Equal(t, ErrBadResultStatus, err)
+}
@@ -0,0 +1,103 @@
+package pingdom
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultRetryWait    = 500 * time.Millisecond
+	defaultRetryMaxWait = 30 * time.Second
+)
+
+// RetryPolicy controls how failed requests are retried.  Requests failing with
+// a network error, a 429 or a 5xx response are retried up to MaxRetries times.
+// The first retry happens after Wait, doubling on each further attempt up to
+// MaxWait; a Retry-After header sent by Pingdom takes precedence.  POST
+// requests are only retried on 429 since Pingdom may already have created the
+// resource otherwise.
+type RetryPolicy struct {
+	MaxRetries int
+	Wait       time.Duration
+	MaxWait    time.Duration
+}
+
+// RetryError is returned by every failed request once retries are enabled.
+// It records the number of attempts made and the total time spent on them so
+// that callers can detect degradation before requests start failing
+// outright.  The error of the last attempt is available with errors.As.
+type RetryError struct {
+	Attempts int
+	Elapsed  time.Duration
+	Err      error
+}
+
+// Error returns the string representation of the RetryError.
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("request failed after %d attempt(s) in %v: %v", e.Attempts, e.Elapsed, e.Err)
+}
+
+// Unwrap returns the error of the last attempt.
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+func (p *RetryPolicy) enabled() bool {
+	return p != nil && p.MaxRetries > 0
+}
+
+// shouldRetry reports whether a request that ended with the given response
+// and error should be attempted again.
+func (p *RetryPolicy) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if resp == nil {
+		return err != nil && req.Method != "POST"
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return resp.StatusCode >= 500 && req.Method != "POST"
+}
+
+// backoff returns how long to wait before the given retry (starting at 1).
+func (p *RetryPolicy) backoff(retry int, resp *http.Response) time.Duration {
+	maxWait := p.MaxWait
+	if maxWait <= 0 {
+		maxWait = defaultRetryMaxWait
+	}
+
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			if wait := time.Duration(seconds) * time.Second; wait < maxWait {
+				return wait
+			}
+			return maxWait
+		}
+	}
+
+	wait := p.Wait
+	if wait <= 0 {
+		wait = defaultRetryWait
+	}
+	for i := 1; i < retry && wait < maxWait; i++ {
+		wait *= 2
+	}
+	if wait > maxWait {
+		return maxWait
+	}
+	return wait
+}
+
+// rewind resets the request body so the request can be sent again.
+func rewind(req *http.Request) error {
+	if req.Body == nil || req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	req.Body = body
+	return nil
+}
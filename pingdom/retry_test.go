@@ -0,0 +1,134 @@
+package pingdom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := &RetryPolicy{MaxRetries: 5, Wait: time.Second, MaxWait: 5 * time.Second}
+
+	assert.Equal(t, time.Second, p.backoff(1, nil))
+	assert.Equal(t, 2*time.Second, p.backoff(2, nil))
+	assert.Equal(t, 4*time.Second, p.backoff(3, nil))
+	assert.Equal(t, 5*time.Second, p.backoff(4, nil))
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+	assert.Equal(t, 3*time.Second, p.backoff(1, resp))
+	resp.Header.Set("Retry-After", "60")
+	assert.Equal(t, 5*time.Second, p.backoff(1, resp))
+}
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	p := &RetryPolicy{MaxRetries: 1}
+	get, _ := http.NewRequest("GET", "/", nil)
+	post, _ := http.NewRequest("POST", "/", nil)
+	networkErr := errors.New("connection reset")
+
+	assert.True(t, p.shouldRetry(get, nil, networkErr))
+	assert.False(t, p.shouldRetry(post, nil, networkErr))
+	assert.True(t, p.shouldRetry(get, &http.Response{StatusCode: 503}, networkErr))
+	assert.False(t, p.shouldRetry(post, &http.Response{StatusCode: 503}, networkErr))
+	assert.True(t, p.shouldRetry(post, &http.Response{StatusCode: 429}, networkErr))
+	assert.False(t, p.shouldRetry(get, &http.Response{StatusCode: 404}, networkErr))
+}
+
+func TestDoRetries(t *testing.T) {
+	setup()
+	defer teardown()
+	client.retry = &RetryPolicy{MaxRetries: 3, Wait: time.Millisecond}
+
+	attempts := 0
+	mux.HandleFunc("/checks/1", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprint(w, `{"error":{"statuscode":503,"statusdesc":"Service Unavailable","errormessage":"try again"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"message":"Modification of check was successful!"}`)
+	})
+
+	msg, err := client.Checks.Update(context.Background(), 1, &HttpCheck{Name: "check", Hostname: "example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Modification of check was successful!", msg.Message)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestDoRetryError(t *testing.T) {
+	setup()
+	defer teardown()
+	client.retry = &RetryPolicy{MaxRetries: 2, Wait: time.Millisecond}
+
+	attempts := 0
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"error":{"statuscode":429,"statusdesc":"Too Many Requests","errormessage":"slow down"}}`)
+	})
+
+	_, err := client.Checks.List(context.Background())
+	assert.Equal(t, 3, attempts)
+
+	var retryErr *RetryError
+	assert.True(t, errors.As(err, &retryErr))
+	assert.Equal(t, 3, retryErr.Attempts)
+	assert.True(t, retryErr.Elapsed > 0)
+
+	var pingdomErr *PingdomError
+	assert.True(t, errors.As(err, &pingdomErr))
+	assert.Equal(t, 429, pingdomErr.StatusCode)
+}
+
+func TestDoRetryErrorNotRetryable(t *testing.T) {
+	setup()
+	defer teardown()
+	client.retry = &RetryPolicy{MaxRetries: 2, Wait: time.Millisecond}
+
+	attempts := 0
+	mux.HandleFunc("/checks/1", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":{"statuscode":404,"statusdesc":"Not Found","errormessage":"no such check"}}`)
+	})
+
+	_, err := client.Checks.Delete(context.Background(), 1)
+	assert.Equal(t, 1, attempts)
+
+	var retryErr *RetryError
+	assert.True(t, errors.As(err, &retryErr))
+	assert.Equal(t, 1, retryErr.Attempts)
+}
+
+func TestDoRetryContextDone(t *testing.T) {
+	setup()
+	defer teardown()
+	client.retry = &RetryPolicy{MaxRetries: 5, Wait: time.Hour}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		cancel()
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, `{"error":{"statuscode":503,"statusdesc":"Service Unavailable","errormessage":"try again"}}`)
+	})
+
+	_, err := client.Checks.List(ctx)
+	assert.Equal(t, 1, attempts)
+	assert.True(t, errors.Is(err, context.Canceled))
+
+	var retryErr *RetryError
+	assert.True(t, errors.As(err, &retryErr))
+	assert.Equal(t, 1, retryErr.Attempts)
+
+	_, err = client.Checks.List(ctx)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, 1, attempts)
+}
@@ -0,0 +1,209 @@
+package pingdom
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SecretProvider supplies a secret, e.g. the API token, when a request is
+// sent rather than when the client is made, so that secrets need not be kept
+// in plain strings and can be rotated.  Implementations must be safe for
+// concurrent use.
+type SecretProvider interface {
+	Secret(ctx context.Context) (string, error)
+}
+
+// EnvSecret reads the secret from the named environment variable.
+type EnvSecret string
+
+// Secret returns the value of the environment variable.
+func (s EnvSecret) Secret(ctx context.Context) (string, error) {
+	value, ok := os.LookupEnv(string(s))
+	if !ok || value == "" {
+		return "", fmt.Errorf("environment variable %s is not set", string(s))
+	}
+	return value, nil
+}
+
+// FileSecret reads the secret from the file at the path, e.g. a mounted
+// Kubernetes secret.  Surrounding white space is trimmed.
+type FileSecret string
+
+// Secret returns the content of the file.
+func (s FileSecret) Secret(ctx context.Context) (string, error) {
+	b, err := ioutil.ReadFile(string(s))
+	if err != nil {
+		return "", err
+	}
+	return nonEmptySecret(string(b), string(s))
+}
+
+// CommandSecret runs a command, e.g. a password manager CLI, and reads the
+// secret from its output.  Surrounding white space is trimmed.
+type CommandSecret struct {
+	Name string
+	Args []string
+}
+
+// Secret runs the command and returns its output.
+func (s *CommandSecret) Secret(ctx context.Context) (string, error) {
+	out, err := exec.CommandContext(ctx, s.Name, s.Args...).Output()
+	if err != nil {
+		return "", fmt.Errorf("running %s: %w", s.Name, err)
+	}
+	return nonEmptySecret(string(out), s.Name)
+}
+
+// HTTPSecret fetches the secret from an HTTP endpoint, such as a Vault KV
+// engine.  Field is the dotted path of the secret in the JSON response, e.g.
+// "data.data.api_token" for Vault KV version 2; the whole body is the secret
+// when it is empty.  Header is sent along, e.g. X-Vault-Token, and Client
+// defaults to http.DefaultClient.
+type HTTPSecret struct {
+	URL    string
+	Header http.Header
+	Field  string
+	Client *http.Client
+}
+
+// Secret fetches the secret.
+func (s *HTTPSecret) Secret(ctx context.Context) (string, error) {
+	req, err := http.NewRequest("GET", s.URL, nil)
+	if err != nil {
+		return "", err
+	}
+	for k, v := range s.Header {
+		req.Header[k] = v
+	}
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("fetching secret: %s", resp.Status)
+	}
+	if s.Field == "" {
+		return nonEmptySecret(string(body), s.URL)
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return "", fmt.Errorf("decoding secret: %w", err)
+	}
+	for _, key := range strings.Split(s.Field, ".") {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("secret has no field %s", s.Field)
+		}
+		value = object[key]
+	}
+	secret, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("secret has no string field %s", s.Field)
+	}
+	return nonEmptySecret(secret, s.Field)
+}
+
+// CachedSecret keeps the secret of Provider for TTL, e.g. to run a command
+// once rather than for every request.  Failures are not cached.
+type CachedSecret struct {
+	Provider SecretProvider
+	TTL      time.Duration
+
+	mu      sync.Mutex
+	secret  string
+	expires time.Time
+}
+
+// Secret returns the cached secret, reading it from Provider when it expired.
+func (s *CachedSecret) Secret(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.secret != "" && time.Now().Before(s.expires) {
+		return s.secret, nil
+	}
+	secret, err := s.Provider.Secret(ctx)
+	if err != nil {
+		return "", err
+	}
+	s.secret, s.expires = secret, time.Now().Add(s.TTL)
+	return secret, nil
+}
+
+func nonEmptySecret(value string, source string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", fmt.Errorf("secret from %s is empty", source)
+	}
+	return value, nil
+}
+
+// ContextAuthenticator is an Authenticator whose credentials are resolved
+// when the request is sent, with its context, e.g. from a SecretProvider.
+// AuthenticateContext is called instead of Authenticate then, and its error is
+// returned by the call.
+type ContextAuthenticator interface {
+	Authenticator
+	AuthenticateContext(req *http.Request) error
+}
+
+// SecretTokenAuth is TokenAuth with the API token of a SecretProvider.
+type SecretTokenAuth struct {
+	APIToken SecretProvider
+}
+
+// Authenticate does nothing, the token is set by AuthenticateContext.
+func (a *SecretTokenAuth) Authenticate(req *http.Request) {}
+
+// AuthenticateContext sets the Bearer token on the request.
+func (a *SecretTokenAuth) AuthenticateContext(req *http.Request) error {
+	token, err := a.APIToken.Secret(req.Context())
+	if err != nil {
+		return fmt.Errorf("reading the Pingdom API token: %w", err)
+	}
+	(&TokenAuth{APIToken: token}).Authenticate(req)
+	return nil
+}
+
+// SecretLegacyAuth is LegacyAuth with the password of a SecretProvider.
+type SecretLegacyAuth struct {
+	Username     string
+	Password     SecretProvider
+	AppKey       string
+	AccountEmail string
+}
+
+// Authenticate does nothing, the credentials are set by AuthenticateContext.
+func (a *SecretLegacyAuth) Authenticate(req *http.Request) {}
+
+// AuthenticateContext sets basic auth and the App-Key header on the request.
+func (a *SecretLegacyAuth) AuthenticateContext(req *http.Request) error {
+	password, err := a.Password.Secret(req.Context())
+	if err != nil {
+		return fmt.Errorf("reading the Pingdom password: %w", err)
+	}
+	(&LegacyAuth{
+		Username:     a.Username,
+		Password:     password,
+		AppKey:       a.AppKey,
+		AccountEmail: a.AccountEmail,
+	}).Authenticate(req)
+	return nil
+}
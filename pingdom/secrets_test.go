@@ -0,0 +1,173 @@
+package pingdom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type countingSecret struct {
+	calls  int
+	secret string
+	err    error
+}
+
+func (s *countingSecret) Secret(ctx context.Context) (string, error) {
+	s.calls++
+	return s.secret, s.err
+}
+
+func TestEnvSecret(t *testing.T) {
+	os.Setenv("PINGDOM_TEST_SECRET", "token")
+	defer os.Unsetenv("PINGDOM_TEST_SECRET")
+
+	secret, err := EnvSecret("PINGDOM_TEST_SECRET").Secret(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "token", secret)
+
+	_, err = EnvSecret("PINGDOM_TEST_MISSING").Secret(context.Background())
+	assert.EqualError(t, err, "environment variable PINGDOM_TEST_MISSING is not set")
+}
+
+func TestFileSecret(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secret")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "token")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("token\n"), 0600))
+
+	secret, err := FileSecret(path).Secret(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "token", secret)
+
+	assert.NoError(t, ioutil.WriteFile(path, []byte("\n"), 0600))
+	_, err = FileSecret(path).Secret(context.Background())
+	assert.EqualError(t, err, "secret from "+path+" is empty")
+
+	_, err = FileSecret(filepath.Join(dir, "missing")).Secret(context.Background())
+	assert.Error(t, err)
+}
+
+func TestCommandSecret(t *testing.T) {
+	secret, err := (&CommandSecret{Name: "echo", Args: []string{"token"}}).Secret(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "token", secret)
+
+	_, err = (&CommandSecret{Name: "false"}).Secret(context.Background())
+	assert.EqualError(t, err, "running false: exit status 1")
+}
+
+func TestHTTPSecret(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "vault" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/pingdom":
+			fmt.Fprint(w, `{"data":{"data":{"api_token":"token"},"metadata":{"version":3}}}`)
+		default:
+			fmt.Fprint(w, "plain\n")
+		}
+	}))
+	defer server.Close()
+	header := http.Header{"X-Vault-Token": {"vault"}}
+
+	secret, err := (&HTTPSecret{URL: server.URL + "/v1/secret/data/pingdom", Header: header, Field: "data.data.api_token"}).Secret(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "token", secret)
+
+	secret, err = (&HTTPSecret{URL: server.URL + "/plain", Header: header}).Secret(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "plain", secret)
+
+	_, err = (&HTTPSecret{URL: server.URL + "/v1/secret/data/pingdom", Header: header, Field: "data.data.api_token.value"}).Secret(context.Background())
+	assert.EqualError(t, err, "secret has no field data.data.api_token.value")
+	_, err = (&HTTPSecret{URL: server.URL + "/v1/secret/data/pingdom", Header: header, Field: "data.metadata.version"}).Secret(context.Background())
+	assert.EqualError(t, err, "secret has no string field data.metadata.version")
+	_, err = (&HTTPSecret{URL: server.URL + "/plain"}).Secret(context.Background())
+	assert.EqualError(t, err, "fetching secret: 403 Forbidden")
+}
+
+func TestCachedSecret(t *testing.T) {
+	provider := &countingSecret{err: errors.New("boom")}
+	cached := &CachedSecret{Provider: provider, TTL: time.Hour}
+
+	_, err := cached.Secret(context.Background())
+	assert.EqualError(t, err, "boom")
+
+	provider.secret, provider.err = "token", nil
+	for i := 0; i < 3; i++ {
+		secret, err := cached.Secret(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "token", secret)
+	}
+	assert.Equal(t, 2, provider.calls)
+
+	cached.expires = time.Now()
+	_, err = cached.Secret(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 3, provider.calls)
+}
+
+func TestClientWithSecretToken(t *testing.T) {
+	setup()
+	defer teardown()
+
+	provider := &countingSecret{secret: "rotated"}
+	client, _ = NewClientWithConfig(ClientConfig{APITokenSecret: provider, BaseURL: server.URL})
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer rotated", r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"checks":[]}`)
+	})
+
+	// The token is not read until a request is sent.
+	req, err := client.NewRequest("GET", "/checks", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "", req.Header.Get("Authorization"))
+	assert.Equal(t, 0, provider.calls)
+
+	_, err = client.Checks.List(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, provider.calls)
+
+	provider.err = errors.New("vault sealed")
+	_, err = client.Checks.List(context.Background())
+	assert.EqualError(t, err, "reading the Pingdom API token: vault sealed")
+}
+
+func TestClientWithSecretPassword(t *testing.T) {
+	setup()
+	defer teardown()
+
+	client, _ = NewClientWithConfig(ClientConfig{
+		Username:       "user@example.com",
+		PasswordSecret: &countingSecret{secret: "secret"},
+		AppKey:         "appkey",
+		BaseURL:        server.URL,
+	})
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "user@example.com", username)
+		assert.Equal(t, "secret", password)
+		assert.Equal(t, "appkey", r.Header.Get("App-Key"))
+		fmt.Fprint(w, `{"checks":[]}`)
+	})
+
+	_, err := client.Checks.List(context.Background())
+	assert.NoError(t, err)
+
+	c, err := NewClientWithConfig(ClientConfig{APIToken: "token", APITokenSecret: &countingSecret{}})
+	assert.NoError(t, err)
+	assert.Nil(t, c.auth)
+}
@@ -0,0 +1,109 @@
+package pingdom
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// SingleService provides an interface to the single tests of Pingdom: a one
+// off test of a host from a probe, without creating a check.
+type SingleService struct {
+	client *Client
+}
+
+// SingleRequest is the API request to Pingdom for a single test of Host.
+// Type is one of "http", "httpcustom", "tcp", "ping", "dns", "udp", "smtp",
+// "pop3" and "imap".  ProbeID selects the probe running the test, Pingdom
+// picks one when 0.  Params holds the settings specific to the type, named as
+// for checks, e.g. "url", "encryption", "port" or "shouldcontain".
+type SingleRequest struct {
+	Type    string
+	Host    string
+	ProbeID int
+	IPv6    bool
+	Params  map[string]string
+}
+
+// Valid determines whether a SingleRequest contains valid fields for the Pingdom API.
+func (sr SingleRequest) Valid() error {
+	if sr.Host == "" {
+		return ErrMissingHost
+	}
+	if sr.Type == "" {
+		return ErrMissingType
+	}
+	return nil
+}
+
+// GetParams returns a map of params for a Pingdom SingleRequest.
+func (sr SingleRequest) GetParams() (params map[string]string) {
+	params = make(map[string]string, len(sr.Params)+4)
+	for k, v := range sr.Params {
+		params[k] = v
+	}
+	params["type"] = sr.Type
+	params["host"] = sr.Host
+
+	if sr.ProbeID != 0 {
+		params["probeid"] = strconv.Itoa(sr.ProbeID)
+	}
+
+	if sr.IPv6 {
+		params["ipv6"] = "true"
+	}
+
+	return
+}
+
+// Run runs a single test and returns its result.
+func (ss *SingleService) Run(ctx context.Context, request SingleRequest) (*SingleResult, error) {
+	if err := request.Valid(); err != nil {
+		return nil, err
+	}
+
+	req, err := ss.client.NewRequest("GET", "/single", request.GetParams())
+	if err != nil {
+		return nil, err
+	}
+	m := &singleJSONResponse{}
+	_, err = ss.client.Do(req.WithContext(ctx), m)
+	if err != nil {
+		return nil, err
+	}
+
+	return &m.Result, nil
+}
+
+// RunAndWait runs a single test until its status is known, i.e. "up" or
+// "down", e.g. for a smoke test before a deployment.  Tests whose status is
+// not known yet are run again after the intervals of the config, like
+// CheckService.WaitForFirstResult; once the context is done the polling stops
+// with its error.
+func (ss *SingleService) RunAndWait(ctx context.Context, request SingleRequest, config WaitConfig) (*SingleResult, error) {
+	config = config.withDefaults()
+	interval := config.Interval
+	for {
+		result, err := ss.Run(ctx, request)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, err
+		}
+		if s := CheckStatus(result.Status); s == CheckStatusUp || s == CheckStatusDown {
+			return result, nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		if interval *= 2; interval > config.MaxInterval {
+			interval = config.MaxInterval
+		}
+	}
+}
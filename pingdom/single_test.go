@@ -0,0 +1,79 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSingleRequestGetParams(t *testing.T) {
+	assert.Equal(t, ErrMissingHost, SingleRequest{Type: "http"}.Valid())
+	assert.Equal(t, ErrMissingType, SingleRequest{Host: "example.com"}.Valid())
+	assert.NoError(t, SingleRequest{Type: "http", Host: "example.com"}.Valid())
+
+	assert.Equal(t, map[string]string{
+		"type":       "http",
+		"host":       "example.com",
+		"probeid":    "33",
+		"ipv6":       "true",
+		"url":        "/health",
+		"encryption": "true",
+	}, SingleRequest{
+		Type:    "http",
+		Host:    "example.com",
+		ProbeID: 33,
+		IPv6:    true,
+		Params:  map[string]string{"url": "/health", "encryption": "true"},
+	}.GetParams())
+}
+
+func TestSingleServiceRun(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/single", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "tcp", r.URL.Query().Get("type"))
+		assert.Equal(t, "example.com", r.URL.Query().Get("host"))
+		assert.Equal(t, "443", r.URL.Query().Get("port"))
+		fmt.Fprint(w, `{"result": {"status": "up", "responsetime": 42, "statusdesc": "OK", "statusdesclong": "OK", "probeid": 33, "probedesc": "Stockholm, Sweden"}}`)
+	})
+
+	result, err := client.Single.Run(context.Background(), SingleRequest{Type: "tcp", Host: "example.com", Params: map[string]string{"port": "443"}})
+	assert.NoError(t, err)
+	assert.Equal(t, &SingleResult{Status: "up", ResponseTime: 42, StatusDesc: "OK", StatusDescLong: "OK", ProbeID: 33, ProbeDesc: "Stockholm, Sweden"}, result)
+
+	_, err = client.Single.Run(context.Background(), SingleRequest{Type: "tcp"})
+	assert.Equal(t, ErrMissingHost, err)
+}
+
+func TestSingleServiceRunAndWait(t *testing.T) {
+	setup()
+	defer teardown()
+
+	runs, knownAfter := 0, 3
+	mux.HandleFunc("/single", func(w http.ResponseWriter, r *http.Request) {
+		runs++
+		if runs < knownAfter {
+			fmt.Fprint(w, `{"result": {"status": "unknown", "probeid": 33}}`)
+			return
+		}
+		fmt.Fprint(w, `{"result": {"status": "down", "statusdesc": "Timeout", "probeid": 33}}`)
+	})
+
+	request := SingleRequest{Type: "http", Host: "example.com"}
+	result, err := client.Single.RunAndWait(context.Background(), request, WaitConfig{Interval: time.Millisecond, MaxInterval: 2 * time.Millisecond})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, runs)
+	assert.Equal(t, &SingleResult{Status: "down", StatusDesc: "Timeout", ProbeID: 33}, result)
+
+	knownAfter = 1000
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = client.Single.RunAndWait(ctx, request, WaitConfig{Interval: time.Millisecond})
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
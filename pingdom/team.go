@@ -1,8 +1,7 @@
 package pingdom
 
 import (
-	"encoding/json"
-	"io/ioutil"
+	"context"
 	"strconv"
 )
 
@@ -18,40 +17,33 @@ type TeamAPI interface {
 }
 
 // List return a list of teams from Pingdom.
-func (cs *TeamService) List() ([]TeamResponse, error) {
+func (cs *TeamService) List(ctx context.Context) ([]TeamResponse, error) {
 	req, err := cs.client.NewRequest("GET", "/alerting/teams", nil)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := cs.client.client.Do(req)
+	resp, err := cs.client.exec(req.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
-	if err := validateResponse(resp); err != nil {
-		return nil, err
-	}
-
-	bodyBytes, _ := ioutil.ReadAll(resp.Body)
-	bodyString := string(bodyBytes)
-
 	t := &listTeamsJSONResponse{}
-	err = json.Unmarshal([]byte(bodyString), &t)
+	err = cs.client.decodeResponse(resp, t)
 
 	return t.Teams, err
 }
 
 // Read return a team object from Pingdom.
-func (cs *TeamService) Read(id int) (*TeamResponse, error) {
+func (cs *TeamService) Read(ctx context.Context, id int) (*TeamResponse, error) {
 	req, err := cs.client.NewRequest("GET", "/alerting/teams/"+strconv.Itoa(id), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	t := &teamDetailsJSONResponse{}
-	_, err = cs.client.Do(req, t)
+	_, err = cs.client.Do(req.WithContext(ctx), t)
 	if err != nil {
 		return nil, err
 	}
@@ -60,7 +52,7 @@ func (cs *TeamService) Read(id int) (*TeamResponse, error) {
 }
 
 // Create is used to create a new team.
-func (cs *TeamService) Create(team TeamAPI) (*TeamResponse, error) {
+func (cs *TeamService) Create(ctx context.Context, team TeamAPI) (*TeamResponse, error) {
 	if err := team.Valid(); err != nil {
 		return nil, err
 	}
@@ -71,7 +63,7 @@ func (cs *TeamService) Create(team TeamAPI) (*TeamResponse, error) {
 	}
 
 	t := &teamDetailsJSONResponse{}
-	_, err = cs.client.Do(req, t)
+	_, err = cs.client.Do(req.WithContext(ctx), t)
 	if err != nil {
 		return nil, err
 	}
@@ -79,14 +71,14 @@ func (cs *TeamService) Create(team TeamAPI) (*TeamResponse, error) {
 }
 
 // Update is used to update existing team.
-func (cs *TeamService) Update(id int, team TeamAPI) (*TeamResponse, error) {
+func (cs *TeamService) Update(ctx context.Context, id int, team TeamAPI) (*TeamResponse, error) {
 	req, err := cs.client.NewJSONRequest("PUT", "/alerting/teams/"+strconv.Itoa(id), team.RenderForJSONAPI())
 	if err != nil {
 		return nil, err
 	}
 
 	t := &teamDetailsJSONResponse{}
-	_, err = cs.client.Do(req, t)
+	_, err = cs.client.Do(req.WithContext(ctx), t)
 	if err != nil {
 		return nil, err
 	}
@@ -94,14 +86,14 @@ func (cs *TeamService) Update(id int, team TeamAPI) (*TeamResponse, error) {
 }
 
 // Delete will delete the Team for the given ID.
-func (cs *TeamService) Delete(id int) (*TeamDeleteResponse, error) {
+func (cs *TeamService) Delete(ctx context.Context, id int) (*TeamDeleteResponse, error) {
 	req, err := cs.client.NewRequest("DELETE", "/alerting/teams/"+strconv.Itoa(id), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	t := &TeamDeleteResponse{}
-	_, err = cs.client.Do(req, t)
+	_, err = cs.client.Do(req.WithContext(ctx), t)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package pingdom
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"testing"
@@ -77,7 +78,7 @@ func TestMTeamServiceList(t *testing.T) {
 		},
 	}
 
-	teams, err := client.Teams.List()
+	teams, err := client.Teams.List(context.Background())
 	assert.NoError(t, err)
 	assert.Equal(t, want, teams, "Teams.List() should return correct result")
 }
@@ -104,7 +105,7 @@ func TestTeamServiceCreate(t *testing.T) {
 		ID: 12345678,
 	}
 
-	teams, err := client.Teams.Create(&team)
+	teams, err := client.Teams.Create(context.Background(), &team)
 	assert.NoError(t, err)
 	assert.Equal(t, want, teams, "Teams.Create() should return correct result")
 }
@@ -152,7 +153,7 @@ func TestTeamServiceRead(t *testing.T) {
 		},
 	}
 
-	team, err := client.Teams.Read(1)
+	team, err := client.Teams.Read(context.Background(), 1)
 	assert.NoError(t, err)
 	assert.Equal(t, want, team, "Teams.Read() should return correct result")
 }
@@ -205,7 +206,7 @@ func TestTeamServiceUpdate(t *testing.T) {
 		},
 	}
 
-	team, err := client.Teams.Update(65, &updateTeam)
+	team, err := client.Teams.Update(context.Background(), 65, &updateTeam)
 	assert.NoError(t, err)
 	assert.Equal(t, want, team, "Teams.Update() should return correct result")
 }
@@ -222,7 +223,7 @@ func TestTeamServiceDelete(t *testing.T) {
 	})
 	want := &TeamDeleteResponse{Message: "Deletion of team 1234 was successful"}
 
-	team, err := client.Teams.Delete(1234)
+	team, err := client.Teams.Delete(context.Background(), 1234)
 	assert.NoError(t, err)
 	assert.Equal(t, want, team, "Teams.Delete() should return correct result")
 }
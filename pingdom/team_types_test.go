@@ -1,6 +1,7 @@
 package pingdom
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -25,3 +26,13 @@ func TestTeamNotValid(t *testing.T) {
 
 	assert.NotEqual(t, nil, params, "Team.Valid() should return not nil if not valid")
 }
+
+func TestTeamJSONRoundTrip(t *testing.T) {
+	want := Team{ID: 1, Name: "fake team", MemberIDs: []int{1, 3}}
+	b, err := json.Marshal(want)
+	assert.NoError(t, err)
+
+	var got Team
+	assert.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, want, got)
+}
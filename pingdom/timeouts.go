@@ -0,0 +1,21 @@
+package pingdom
+
+import "github.com/nordcloud/go-pingdom/internal/transport"
+
+// Timeouts limits the time spent in each phase of a request: dialing, the TLS
+// handshake, waiting for the response headers, reading the body and the
+// request as a whole. Set ClientConfig.Timeouts to apply them.
+type Timeouts = transport.Timeouts
+
+// TimeoutError is returned, possibly wrapped, when a request times out. Its
+// Phase is one of the TimeoutPhase constants.
+type TimeoutError = transport.TimeoutError
+
+// Phases of a request reported by TimeoutError.
+const (
+	TimeoutPhaseDial    = transport.PhaseDial
+	TimeoutPhaseTLS     = transport.PhaseTLS
+	TimeoutPhaseHeader  = transport.PhaseHeader
+	TimeoutPhaseBody    = transport.PhaseBody
+	TimeoutPhaseOverall = transport.PhaseOverall
+)
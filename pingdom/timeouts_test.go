@@ -0,0 +1,36 @@
+package pingdom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientTimeouts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		fmt.Fprint(w, `{"checks":[]}`)
+	})
+
+	c, err := NewClientWithConfig(ClientConfig{
+		APIToken: "token",
+		BaseURL:  server.URL,
+		Timeouts: &Timeouts{ResponseHeader: 20 * time.Millisecond},
+	})
+	assert.NoError(t, err)
+
+	_, err = c.Checks.List(context.Background())
+	var timeoutErr *TimeoutError
+	if assert.True(t, errors.As(err, &timeoutErr)) {
+		assert.Equal(t, TimeoutPhaseHeader, timeoutErr.Phase)
+		assert.Equal(t, 20*time.Millisecond, timeoutErr.Limit)
+	}
+}
@@ -0,0 +1,12 @@
+package pingdom
+
+import "github.com/nordcloud/go-pingdom/internal/transport"
+
+// TLSConfig sets the minimum TLS version of the connections to Pingdom and
+// optionally pins the public keys or certificates of its endpoints. Set
+// ClientConfig.TLS to apply it.
+type TLSConfig = transport.TLSConfig
+
+// ErrCertificateNotPinned is wrapped by the errors of the requests to a server
+// whose certificate chain matches none of the pins of the TLSConfig.
+var ErrCertificateNotPinned = transport.ErrCertificateNotPinned
@@ -0,0 +1,43 @@
+package pingdom
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientTLS(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"checks":[]}`)
+	})
+
+	c, err := NewClientWithConfig(ClientConfig{
+		APIToken: "token",
+		BaseURL:  server.URL,
+		TLS: &TLSConfig{
+			MinVersion:       tls.VersionTLS12,
+			PinnedPublicKeys: []string{"47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU="},
+		},
+	})
+	assert.NoError(t, err)
+	rt := c.client.Transport.(*http.Transport)
+	assert.Equal(t, uint16(tls.VersionTLS12), rt.TLSClientConfig.MinVersion)
+	assert.NotNil(t, rt.TLSClientConfig.VerifyConnection)
+
+	// The pins only apply to TLS connections.
+	_, err = c.Checks.List(context.Background())
+	assert.NoError(t, err)
+
+	_, err = NewClientWithConfig(ClientConfig{
+		APIToken: "token",
+		TLS:      &TLSConfig{PinnedCertificates: []string{"not a pin"}},
+	})
+	assert.EqualError(t, err, `invalid pin "not a pin", must be a base64 encoded SHA-256 digest`)
+}
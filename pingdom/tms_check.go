@@ -0,0 +1,120 @@
+package pingdom
+
+import (
+	"context"
+	"strconv"
+)
+
+// TMSCheckService provides an interface to Pingdom transaction (TMS) checks.
+// The TMS endpoints are in beta: every method fails with a
+// FeatureDisabledError unless FeatureTMS is enabled.
+type TMSCheckService struct {
+	client *Client
+}
+
+// List returns the TMS checks matching the request, without their steps.
+func (cs *TMSCheckService) List(ctx context.Context, request TMSCheckListRequest) ([]TMSCheckResponse, error) {
+	if err := cs.client.requireFeature(FeatureTMS); err != nil {
+		return nil, err
+	}
+	if err := request.Valid(); err != nil {
+		return nil, err
+	}
+
+	req, err := cs.client.NewRequest("GET", "/tms/check", request.GetParams())
+	if err != nil {
+		return nil, err
+	}
+
+	m := &listTMSChecksJSONResponse{}
+	_, err = cs.client.Do(req.WithContext(ctx), m)
+	if err != nil {
+		return nil, err
+	}
+	return m.Checks, err
+}
+
+// Read returns the TMS check with the given ID, steps and metadata included.
+func (cs *TMSCheckService) Read(ctx context.Context, id int) (*TMSCheckResponse, error) {
+	if err := cs.client.requireFeature(FeatureTMS); err != nil {
+		return nil, err
+	}
+
+	req, err := cs.client.NewRequest("GET", "/tms/check/"+strconv.Itoa(id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &TMSCheckResponse{}
+	_, err = cs.client.Do(req.WithContext(ctx), m)
+	if err != nil {
+		return nil, err
+	}
+	return m, err
+}
+
+// Create a new TMS check. The check is validated before the request is sent,
+// as Pingdom has no validation endpoint.  Errors of Pingdom pointing at a
+// step are returned as a *TMSStepError.
+func (cs *TMSCheckService) Create(ctx context.Context, check *TMSCheck) (*TMSCheckResponse, error) {
+	if err := cs.client.requireFeature(FeatureTMS); err != nil {
+		return nil, err
+	}
+	if err := check.Valid(); err != nil {
+		return nil, err
+	}
+
+	req, err := cs.client.NewJSONRequest("POST", "/tms/check", check.RenderForJSONAPI())
+	if err != nil {
+		return nil, err
+	}
+
+	m := &TMSCheckResponse{}
+	_, err = cs.client.Do(req.WithContext(ctx), m)
+	if err != nil {
+		return nil, tmsStepError(err, check.Steps)
+	}
+	return m, err
+}
+
+// Update replaces the TMS check with the given ID, validated and with its
+// errors mapped to the steps like those of Create.
+func (cs *TMSCheckService) Update(ctx context.Context, id int, check *TMSCheck) (*TMSCheckResponse, error) {
+	if err := cs.client.requireFeature(FeatureTMS); err != nil {
+		return nil, err
+	}
+	if err := check.Valid(); err != nil {
+		return nil, err
+	}
+
+	req, err := cs.client.NewJSONRequest("PUT", "/tms/check/"+strconv.Itoa(id), check.RenderForJSONAPI())
+	if err != nil {
+		return nil, err
+	}
+
+	m := &TMSCheckResponse{}
+	_, err = cs.client.Do(req.WithContext(ctx), m)
+	if err != nil {
+		return nil, tmsStepError(err, check.Steps)
+	}
+	return m, err
+}
+
+// Delete will delete the TMS check with the given ID.
+func (cs *TMSCheckService) Delete(ctx context.Context, id int) (*PingdomResponse, error) {
+	if err := cs.client.requireFeature(FeatureTMS); err != nil {
+		return nil, err
+	}
+
+	req, err := cs.client.NewRequest("DELETE", "/tms/check/"+strconv.Itoa(id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &PingdomResponse{}
+	_, err = cs.client.Do(req.WithContext(ctx), m)
+	if err != nil {
+		return nil, err
+	}
+	return m, err
+}
@@ -0,0 +1,200 @@
+package pingdom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const tmsCheckDetails = `{
+	"id": 42,
+	"name": "Login",
+	"active": true,
+	"type": "script",
+	"status": "successful",
+	"interval": 10,
+	"region": "eu",
+	"steps": [
+		{"fn": "go_to", "args": {"url": "https://www.example.com"}},
+		{"fn": "click", "args": {"element": "#login"}}
+	],
+	"metadata": {"width": 1280, "height": 720, "disableWebSecurity": false},
+	"contact_ids": [1],
+	"severity_level": "high",
+	"tags": ["web"],
+	"created_at": 1553070682,
+	"modified_at": 1553070968
+}`
+
+// enableTMS opts the test client in to the TMS endpoints.
+func enableTMS() {
+	client.features[FeatureTMS] = true
+}
+
+func TestTMSCheckServiceFeatureDisabled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	_, err := client.TMSChecks.List(context.Background(), TMSCheckListRequest{})
+	assert.Equal(t, &FeatureDisabledError{Feature: FeatureTMS}, err)
+	_, err = client.TMSChecks.Create(context.Background(), &TMSCheck{})
+	assert.Equal(t, &FeatureDisabledError{Feature: FeatureTMS}, err)
+	_, err = client.TMSChecks.Delete(context.Background(), 42)
+	assert.Equal(t, &FeatureDisabledError{Feature: FeatureTMS}, err)
+}
+
+func TestTMSCheckServiceList(t *testing.T) {
+	setup()
+	defer teardown()
+	enableTMS()
+
+	mux.HandleFunc("/tms/check", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "limit=2&tags=web%2Capi&type=script", r.URL.RawQuery)
+		fmt.Fprint(w, `{"checks": [{"id": 42, "name": "Login", "active": true, "type": "script", "interval": 10, "region": "eu", "tags": ["web"]}], "limit": 2}`)
+	})
+
+	checks, err := client.TMSChecks.List(context.Background(), TMSCheckListRequest{Limit: 2, Type: "script", Tags: []string{"web", "api"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []TMSCheckResponse{
+		{ID: 42, Name: "Login", Active: true, Type: "script", Interval: 10, Region: "eu", Tags: []string{"web"}},
+	}, checks)
+
+	_, err = client.TMSChecks.List(context.Background(), TMSCheckListRequest{Type: "browser"})
+	assert.EqualError(t, err, "invalid value \"browser\" for `Type`, must be 'script' or 'recording'")
+}
+
+func TestTMSCheckServiceRead(t *testing.T) {
+	setup()
+	defer teardown()
+	enableTMS()
+
+	mux.HandleFunc("/tms/check/42", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, tmsCheckDetails)
+	})
+
+	check, err := client.TMSChecks.Read(context.Background(), 42)
+	assert.NoError(t, err)
+	assert.Equal(t, &TMSCheckResponse{
+		ID:       42,
+		Name:     "Login",
+		Active:   true,
+		Type:     "script",
+		Status:   "successful",
+		Interval: 10,
+		Region:   "eu",
+		Steps: []TMSCheckStep{
+			{Fn: "go_to", Args: map[string]string{"url": "https://www.example.com"}},
+			{Fn: "click", Args: map[string]string{"element": "#login"}},
+		},
+		Metadata:      &TMSCheckMetadata{Width: 1280, Height: 720},
+		ContactIDs:    []int{1},
+		SeverityLevel: "high",
+		Tags:          []string{"web"},
+		CreatedAt:     1553070682,
+		ModifiedAt:    1553070968,
+	}, check)
+}
+
+func TestTMSCheckServiceCreate(t *testing.T) {
+	setup()
+	defer teardown()
+	enableTMS()
+
+	mux.HandleFunc("/tms/check", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{
+			"name": "Login",
+			"active": true,
+			"interval": 10,
+			"region": "eu",
+			"steps": [{"fn": "go_to", "args": {"url": "https://www.example.com"}}],
+			"metadata": {"width": 1280, "height": 720},
+			"contact_ids": [1],
+			"tags": ["web"]
+		}`, string(body))
+		fmt.Fprint(w, tmsCheckDetails)
+	})
+
+	check, err := client.TMSChecks.Create(context.Background(), &TMSCheck{
+		Name:       "Login",
+		Interval:   10,
+		Region:     "eu",
+		Steps:      []TMSCheckStep{{Fn: "go_to", Args: map[string]string{"url": "https://www.example.com"}}},
+		Metadata:   &TMSCheckMetadata{Width: 1280, Height: 720},
+		ContactIDs: []int{1},
+		Tags:       []string{"web"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 42, check.ID)
+
+	_, err = client.TMSChecks.Create(context.Background(), &TMSCheck{Name: "Login"})
+	assert.EqualError(t, err, "invalid value for `Steps`, must contain at least one step")
+}
+
+func TestTMSCheckServiceUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+	enableTMS()
+
+	mux.HandleFunc("/tms/check/42", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{"name": "Login", "active": false, "steps": [{"fn": "go_to", "args": {"url": "https://www.example.com"}}]}`, string(body))
+		fmt.Fprint(w, `{"id": 42, "name": "Login", "active": false}`)
+	})
+
+	check, err := client.TMSChecks.Update(context.Background(), 42, &TMSCheck{
+		Name:   "Login",
+		Paused: true,
+		Steps:  []TMSCheckStep{{Fn: "go_to", Args: map[string]string{"url": "https://www.example.com"}}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &TMSCheckResponse{ID: 42, Name: "Login"}, check)
+}
+
+func TestTMSCheckServiceDelete(t *testing.T) {
+	setup()
+	defer teardown()
+	enableTMS()
+
+	mux.HandleFunc("/tms/check/42", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		fmt.Fprint(w, `{"message": "Deletion of check 42 was successful"}`)
+	})
+
+	msg, err := client.TMSChecks.Delete(context.Background(), 42)
+	assert.NoError(t, err)
+	assert.Equal(t, &PingdomResponse{Message: "Deletion of check 42 was successful"}, msg)
+}
+
+func TestTMSCheckServiceCreateStepError(t *testing.T) {
+	setup()
+	defer teardown()
+	enableTMS()
+
+	mux.HandleFunc("/tms/check", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error": {"statuscode": 400, "statusdesc": "Bad Request", "errormessage": "steps[1].args.element: invalid selector"}}`)
+	})
+
+	steps, err := NewTMSStepBuilder().GoTo("https://www.example.com").Click("#login[").Build()
+	assert.NoError(t, err)
+	_, err = client.TMSChecks.Create(context.Background(), &TMSCheck{Name: "Login", Steps: steps})
+	assert.EqualError(t, err, "step 1: 400 Bad Request: steps[1].args.element: invalid selector")
+
+	var stepErr *TMSStepError
+	assert.True(t, errors.As(err, &stepErr))
+	assert.Equal(t, 1, stepErr.Step)
+	assert.Equal(t, "click", stepErr.Fn)
+	var pe *PingdomError
+	assert.True(t, errors.As(err, &pe))
+}
@@ -0,0 +1,245 @@
+package pingdom
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TMSCheck represents a Pingdom transaction (TMS) check: a sequence of
+// browser steps run from one region at a fixed interval.
+type TMSCheck struct {
+	Name                     string
+	Steps                    []TMSCheckStep
+	Paused                   bool
+	Interval                 int    // Minutes, defaults to 10
+	Region                   string // Defaults to "us-east"
+	Metadata                 *TMSCheckMetadata
+	ContactIDs               []int
+	TeamIDs                  []int
+	IntegrationIDs           []int
+	SendNotificationWhenDown int
+	SeverityLevel            string
+	CustomMessage            string
+	Tags                     []string
+}
+
+// TMSCheckStep is a step of a TMS check: the function to run, e.g. "go_to",
+// and its arguments, e.g. {"url": "https://www.example.com"}.
+type TMSCheckStep struct {
+	Fn   string            `json:"fn"`
+	Args map[string]string `json:"args"`
+}
+
+// TMSCheckMetadata is the browser a TMS check runs in: the size of its
+// viewport in pixels, the User-Agent it sends instead of the default one of
+// Pingdom, e.g. to emulate a mobile browser, whether web security is
+// disabled and the credentials of the sites asking for HTTP authentication.
+type TMSCheckMetadata struct {
+	Width              int                      `json:"width,omitempty"`
+	Height             int                      `json:"height,omitempty"`
+	UserAgent          string                   `json:"userAgent,omitempty"`
+	DisableWebSecurity bool                     `json:"disableWebSecurity,omitempty"`
+	Authentications    *TMSCheckAuthentications `json:"authentications,omitempty"`
+}
+
+// TMSCheckAuthentications are the HTTP authentications of a TMS check.
+type TMSCheckAuthentications struct {
+	HTTPAuthentications []TMSCheckHTTPAuthentication `json:"httpAuthentications"`
+}
+
+// TMSCheckHTTPAuthentication is the credentials of a host asking for HTTP
+// authentication.
+type TMSCheckHTTPAuthentication struct {
+	Host     string `json:"host"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// RenderForJSONAPI returns the JSON formatted version of this object that may be submitted to Pingdom
+func (ck *TMSCheck) RenderForJSONAPI() string {
+	b := map[string]interface{}{
+		"name":   ck.Name,
+		"steps":  ck.Steps,
+		"active": !ck.Paused,
+	}
+	if ck.Interval != 0 {
+		b["interval"] = ck.Interval
+	}
+	if ck.Region != "" {
+		b["region"] = ck.Region
+	}
+	if ck.Metadata != nil {
+		b["metadata"] = ck.Metadata
+	}
+	if ck.ContactIDs != nil {
+		b["contact_ids"] = ck.ContactIDs
+	}
+	if ck.TeamIDs != nil {
+		b["team_ids"] = ck.TeamIDs
+	}
+	if ck.IntegrationIDs != nil {
+		b["integration_ids"] = ck.IntegrationIDs
+	}
+	if ck.SendNotificationWhenDown != 0 {
+		b["send_notification_when_down"] = ck.SendNotificationWhenDown
+	}
+	if ck.SeverityLevel != "" {
+		b["severity_level"] = ck.SeverityLevel
+	}
+	if ck.CustomMessage != "" {
+		b["custom_message"] = ck.CustomMessage
+	}
+	if ck.Tags != nil {
+		b["tags"] = ck.Tags
+	}
+	jsonBody, _ := json.Marshal(b)
+	return string(jsonBody)
+}
+
+// Valid determines whether the TMSCheck contains valid fields.  This can be
+// used to guard against sending illegal values to the Pingdom API.
+func (ck *TMSCheck) Valid() error {
+	if ck.Name == "" {
+		return fmt.Errorf("invalid value for `Name`, must contain non-empty string")
+	}
+	if len(ck.Steps) == 0 {
+		return fmt.Errorf("invalid value for `Steps`, must contain at least one step")
+	}
+	if errs := ValidateTMSSteps(ck.Steps); len(errs) > 0 {
+		return fmt.Errorf("invalid value for `Steps`, %w", errs[0])
+	}
+	switch ck.Interval {
+	case 0, 5, 10, 20, 60, 720, 1440:
+	default:
+		return fmt.Errorf("invalid value %d for `Interval`, must be 5, 10, 20, 60, 720 or 1440 minutes", ck.Interval)
+	}
+	switch ck.Region {
+	case "", "us-east", "us-west", "eu", "au":
+	default:
+		return fmt.Errorf("invalid value %q for `Region`, must be 'us-east', 'us-west', 'eu' or 'au'", ck.Region)
+	}
+	if ck.Metadata != nil && (ck.Metadata.Width < 0 || ck.Metadata.Height < 0) {
+		return fmt.Errorf("invalid viewport %dx%d for `Metadata`, must not be negative", ck.Metadata.Width, ck.Metadata.Height)
+	}
+	if ck.Metadata != nil && strings.ContainsAny(ck.Metadata.UserAgent, "\r\n") {
+		return fmt.Errorf("invalid value %q for `Metadata.UserAgent`, must be a single line", ck.Metadata.UserAgent)
+	}
+	if ck.SeverityLevel != "" && ck.SeverityLevel != "high" && ck.SeverityLevel != "low" {
+		return fmt.Errorf("invalid value %q for `SeverityLevel`, must be 'high' or 'low'", ck.SeverityLevel)
+	}
+	return nil
+}
+
+// TMSCheckListRequest narrows down the TMS checks returned by
+// TMSCheckService.List.
+type TMSCheckListRequest struct {
+	Limit  int
+	Offset int
+	Type   string   // "script" or "recording"
+	Tags   []string // Checks with any of the tags
+}
+
+// Valid determines whether the TMSCheckListRequest contains valid fields.
+func (req TMSCheckListRequest) Valid() error {
+	if req.Type != "" && req.Type != "script" && req.Type != "recording" {
+		return fmt.Errorf("invalid value %q for `Type`, must be 'script' or 'recording'", req.Type)
+	}
+	if req.Limit < 0 || req.Offset < 0 {
+		return fmt.Errorf("`Limit` and `Offset` must not be negative")
+	}
+	return nil
+}
+
+// GetParams returns a map of params for a Pingdom TMSCheckListRequest.
+func (req TMSCheckListRequest) GetParams() (params map[string]string) {
+	params = make(map[string]string)
+	if req.Limit != 0 {
+		params["limit"] = strconv.Itoa(req.Limit)
+	}
+	if req.Offset != 0 {
+		params["offset"] = strconv.Itoa(req.Offset)
+	}
+	if req.Type != "" {
+		params["type"] = req.Type
+	}
+	if len(req.Tags) > 0 {
+		params["tags"] = strings.Join(req.Tags, ",")
+	}
+	return params
+}
+
+// TMSStatusReportRequest is the API request to Pingdom for the status report
+// of a TMS check.  From and To are unix timestamps.
+type TMSStatusReportRequest struct {
+	Id    int
+	From  int
+	To    int
+	Order string
+}
+
+// Valid determines whether a TMSStatusReportRequest contains valid fields for the Pingdom API.
+func (req TMSStatusReportRequest) Valid() error {
+	if req.Id == 0 {
+		return ErrMissingId
+	}
+	if req.Order != "" && req.Order != "asc" && req.Order != "desc" {
+		return ErrBadOrder
+	}
+	return nil
+}
+
+// GetParams returns a map of params for a Pingdom TMSStatusReportRequest.
+func (req TMSStatusReportRequest) GetParams() (params map[string]string) {
+	params = make(map[string]string)
+	if req.From != 0 {
+		params["from"] = strconv.Itoa(req.From)
+	}
+	if req.To != 0 {
+		params["to"] = strconv.Itoa(req.To)
+	}
+	if req.Order != "" {
+		params["order"] = req.Order
+	}
+	return params
+}
+
+// TMSPerformanceReportRequest is the API request to Pingdom for the
+// performance report of a TMS check.  From and To are unix timestamps,
+// Resolution is "hour", "day" or "week".  IncludeUptime adds the uptime,
+// downtime and unmonitored time of each interval.
+type TMSPerformanceReportRequest struct {
+	Id            int
+	From          int
+	To            int
+	Order         string
+	Resolution    string
+	IncludeUptime bool
+}
+
+// Valid determines whether a TMSPerformanceReportRequest contains valid fields for the Pingdom API.
+func (req TMSPerformanceReportRequest) Valid() error {
+	if req.Id == 0 {
+		return ErrMissingId
+	}
+	if req.Resolution != "" && req.Resolution != "hour" && req.Resolution != "day" && req.Resolution != "week" {
+		return ErrBadResolution
+	}
+	if req.Order != "" && req.Order != "asc" && req.Order != "desc" {
+		return ErrBadOrder
+	}
+	return nil
+}
+
+// GetParams returns a map of params for a Pingdom TMSPerformanceReportRequest.
+func (req TMSPerformanceReportRequest) GetParams() (params map[string]string) {
+	params = TMSStatusReportRequest{From: req.From, To: req.To, Order: req.Order}.GetParams()
+	if req.Resolution != "" {
+		params["resolution"] = req.Resolution
+	}
+	if req.IncludeUptime {
+		params["include_uptime"] = "true"
+	}
+	return params
+}
@@ -0,0 +1,74 @@
+package pingdom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTMSCheckValid(t *testing.T) {
+	steps := []TMSCheckStep{{Fn: "go_to", Args: map[string]string{"url": "https://www.example.com"}}}
+	tests := []struct {
+		check TMSCheck
+		err   string
+	}{
+		{check: TMSCheck{Name: "Login", Steps: steps}},
+		{check: TMSCheck{Name: "Login", Steps: steps, Interval: 1440, Region: "au", SeverityLevel: "low"}},
+		{check: TMSCheck{Steps: steps}, err: "invalid value for `Name`, must contain non-empty string"},
+		{check: TMSCheck{Name: "Login"}, err: "invalid value for `Steps`, must contain at least one step"},
+		{check: TMSCheck{Name: "Login", Steps: []TMSCheckStep{steps[0], {}}}, err: "invalid value for `Steps`, step 1: unknown step function \"\""},
+		{check: TMSCheck{Name: "Login", Steps: []TMSCheckStep{{Fn: "fill", Args: map[string]string{"input": "#user"}}}}, err: "invalid value for `Steps`, step 0: step \"fill\" requires the argument \"value\""},
+		{check: TMSCheck{Name: "Login", Steps: steps, Interval: 15}, err: "invalid value 15 for `Interval`, must be 5, 10, 20, 60, 720 or 1440 minutes"},
+		{check: TMSCheck{Name: "Login", Steps: steps, Region: "asia"}, err: "invalid value \"asia\" for `Region`, must be 'us-east', 'us-west', 'eu' or 'au'"},
+		{check: TMSCheck{Name: "Login", Steps: steps, SeverityLevel: "medium"}, err: "invalid value \"medium\" for `SeverityLevel`, must be 'high' or 'low'"},
+		{check: TMSCheck{Name: "Login", Steps: steps, Metadata: &TMSCheckMetadata{Width: 390, Height: 844, UserAgent: "Mozilla/5.0 (iPhone)"}}},
+		{check: TMSCheck{Name: "Login", Steps: steps, Metadata: &TMSCheckMetadata{Width: -1}}, err: "invalid viewport -1x0 for `Metadata`, must not be negative"},
+		{check: TMSCheck{Name: "Login", Steps: steps, Metadata: &TMSCheckMetadata{UserAgent: "a\nb"}}, err: "invalid value \"a\\nb\" for `Metadata.UserAgent`, must be a single line"},
+	}
+	for _, tt := range tests {
+		err := tt.check.Valid()
+		if tt.err == "" {
+			assert.NoError(t, err)
+		} else {
+			assert.EqualError(t, err, tt.err)
+		}
+	}
+}
+
+func TestTMSCheckRenderForJSONAPI(t *testing.T) {
+	check := TMSCheck{
+		Name:                     "Login",
+		Steps:                    []TMSCheckStep{{Fn: "wait_for_element", Args: map[string]string{"element": "#menu"}}},
+		Metadata:                 &TMSCheckMetadata{UserAgent: "Mozilla/5.0 (iPhone)", Authentications: &TMSCheckAuthentications{HTTPAuthentications: []TMSCheckHTTPAuthentication{{Host: "www.example.com", Username: "user", Password: "secret"}}}},
+		TeamIDs:                  []int{},
+		IntegrationIDs:           []int{7},
+		SendNotificationWhenDown: 2,
+		SeverityLevel:            "low",
+		CustomMessage:            "Login broken",
+	}
+	assert.JSONEq(t, `{
+		"name": "Login",
+		"active": true,
+		"steps": [{"fn": "wait_for_element", "args": {"element": "#menu"}}],
+		"metadata": {"userAgent": "Mozilla/5.0 (iPhone)", "authentications": {"httpAuthentications": [{"host": "www.example.com", "username": "user", "password": "secret"}]}},
+		"team_ids": [],
+		"integration_ids": [7],
+		"send_notification_when_down": 2,
+		"severity_level": "low",
+		"custom_message": "Login broken"
+	}`, check.RenderForJSONAPI())
+}
+
+func TestTMSCheckListRequestGetParams(t *testing.T) {
+	assert.Equal(t, map[string]string{}, TMSCheckListRequest{}.GetParams())
+	assert.Equal(t, map[string]string{"limit": "10", "offset": "20", "type": "recording", "tags": "web"},
+		TMSCheckListRequest{Limit: 10, Offset: 20, Type: "recording", Tags: []string{"web"}}.GetParams())
+	assert.EqualError(t, TMSCheckListRequest{Limit: -1}.Valid(), "`Limit` and `Offset` must not be negative")
+}
+
+func TestTMSReportRequests(t *testing.T) {
+	assert.Equal(t, ErrBadOrder, TMSStatusReportRequest{Id: 1, Order: "up"}.Valid())
+	assert.Equal(t, ErrMissingId, TMSPerformanceReportRequest{}.Valid())
+	assert.Equal(t, map[string]string{"from": "1", "to": "2", "order": "desc", "resolution": "week"},
+		TMSPerformanceReportRequest{Id: 1, From: 1, To: 2, Order: "desc", Resolution: "week"}.GetParams())
+}
@@ -0,0 +1,51 @@
+package pingdom
+
+import (
+	"context"
+	"strconv"
+)
+
+// StatusReport returns the periods a TMS check was successful or failing.
+func (cs *TMSCheckService) StatusReport(ctx context.Context, request TMSStatusReportRequest) (*TMSStatusReport, error) {
+	if err := cs.client.requireFeature(FeatureTMS); err != nil {
+		return nil, err
+	}
+	if err := request.Valid(); err != nil {
+		return nil, err
+	}
+
+	req, err := cs.client.NewRequest("GET", "/tms/check/"+strconv.Itoa(request.Id)+"/report/status", request.GetParams())
+	if err != nil {
+		return nil, err
+	}
+
+	m := &tmsStatusReportJSONResponse{}
+	_, err = cs.client.Do(req.WithContext(ctx), m)
+	if err != nil {
+		return nil, err
+	}
+	return m.Report, err
+}
+
+// PerformanceReport returns the response times of a TMS check and of each of
+// its steps, per interval of the requested resolution.
+func (cs *TMSCheckService) PerformanceReport(ctx context.Context, request TMSPerformanceReportRequest) (*TMSPerformanceReport, error) {
+	if err := cs.client.requireFeature(FeatureTMS); err != nil {
+		return nil, err
+	}
+	if err := request.Valid(); err != nil {
+		return nil, err
+	}
+
+	req, err := cs.client.NewRequest("GET", "/tms/check/"+strconv.Itoa(request.Id)+"/report/performance", request.GetParams())
+	if err != nil {
+		return nil, err
+	}
+
+	m := &tmsPerformanceReportJSONResponse{}
+	_, err = cs.client.Do(req.WithContext(ctx), m)
+	if err != nil {
+		return nil, err
+	}
+	return m.Report, err
+}
@@ -0,0 +1,75 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTMSCheckServiceStatusReport(t *testing.T) {
+	setup()
+	defer teardown()
+	enableTMS()
+
+	mux.HandleFunc("/tms/check/42/report/status", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "from=1553040000&order=asc&to=1553126400", r.URL.RawQuery)
+		fmt.Fprint(w, `{"report": {"check_id": 42, "name": "Login", "states": [
+			{"status": "successful", "from": "2019-03-20T00:00:00Z", "to": "2019-03-20T06:00:00Z"},
+			{"status": "failing", "from": "2019-03-20T06:00:00Z", "to": "2019-03-20T06:30:00Z", "error_in_step": 1, "message": "element not found"}
+		]}}`)
+	})
+
+	report, err := client.TMSChecks.StatusReport(context.Background(), TMSStatusReportRequest{Id: 42, From: 1553040000, To: 1553126400, Order: "asc"})
+	assert.NoError(t, err)
+	assert.Equal(t, &TMSStatusReport{CheckID: 42, Name: "Login", States: []TMSStatusState{
+		{Status: "successful", From: time.Date(2019, 3, 20, 0, 0, 0, 0, time.UTC), To: time.Date(2019, 3, 20, 6, 0, 0, 0, time.UTC)},
+		{Status: "failing", From: time.Date(2019, 3, 20, 6, 0, 0, 0, time.UTC), To: time.Date(2019, 3, 20, 6, 30, 0, 0, time.UTC), ErrorInStep: 1, Message: "element not found"},
+	}}, report)
+	assert.Equal(t, 30*time.Minute, report.States[1].Duration())
+
+	_, err = client.TMSChecks.StatusReport(context.Background(), TMSStatusReportRequest{})
+	assert.Equal(t, ErrMissingId, err)
+}
+
+func TestTMSCheckServicePerformanceReport(t *testing.T) {
+	setup()
+	defer teardown()
+	enableTMS()
+
+	mux.HandleFunc("/tms/check/42/report/performance", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "include_uptime=true&resolution=hour", r.URL.RawQuery)
+		fmt.Fprint(w, `{"report": {"check_id": 42, "name": "Login", "resolution": "hour", "intervals": [
+			{"from": "2019-03-20T00:00:00Z", "average_response": 1500, "uptime": 3600, "downtime": 0, "unmonitored": 0, "steps": [
+				{"step": {"fn": "go_to", "args": {"url": "https://www.example.com"}}, "average_response": 1000},
+				{"step": {"fn": "click", "args": {"element": "#login"}}, "average_response": 500}
+			]},
+			{"from": "2019-03-20T01:00:00Z", "average_response": 1700, "uptime": 2700, "downtime": 900, "unmonitored": 0, "steps": []}
+		]}}`)
+	})
+
+	report, err := client.TMSChecks.PerformanceReport(context.Background(), TMSPerformanceReportRequest{Id: 42, Resolution: "hour", IncludeUptime: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "hour", report.Resolution)
+	if assert.Len(t, report.Intervals, 2) {
+		assert.Equal(t, TMSPerformanceInterval{
+			From:            time.Date(2019, 3, 20, 0, 0, 0, 0, time.UTC),
+			AverageResponse: 1500,
+			Uptime:          3600,
+			Steps: []TMSStepPerformance{
+				{Step: TMSCheckStep{Fn: "go_to", Args: map[string]string{"url": "https://www.example.com"}}, AverageResponse: 1000},
+				{Step: TMSCheckStep{Fn: "click", Args: map[string]string{"element": "#login"}}, AverageResponse: 500},
+			},
+		}, report.Intervals[0])
+	}
+	assert.InDelta(t, 0.875, report.Availability(), 1e-9)
+	assert.Equal(t, 1.0, (&TMSPerformanceReport{}).Availability())
+
+	_, err = client.TMSChecks.PerformanceReport(context.Background(), TMSPerformanceReportRequest{Id: 42, Resolution: "month"})
+	assert.Equal(t, ErrBadResolution, err)
+}
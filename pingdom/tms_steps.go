@@ -0,0 +1,231 @@
+package pingdom
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// tmsStepArgs are the arguments each function of a TMS step requires.
+var tmsStepArgs = map[string][]string{
+	"go_to":                 {"url"},
+	"url":                   {"url"},
+	"click":                 {"element"},
+	"fill":                  {"input", "value"},
+	"check":                 {"checkbox"},
+	"uncheck":               {"checkbox"},
+	"select_radio":          {"radio"},
+	"select":                {"select", "option"},
+	"submit":                {"form"},
+	"basic_auth":            {"username", "password"},
+	"sleep":                 {"seconds"},
+	"wait_for_element":      {"element"},
+	"wait_for_contains":     {"element", "value"},
+	"exists":                {"element"},
+	"not_exists":            {"element"},
+	"contains":              {"element", "value"},
+	"not_contains":          {"element", "value"},
+	"field_contains":        {"input", "value"},
+	"field_not_contains":    {"input", "value"},
+	"is_checked":            {"checkbox"},
+	"is_not_checked":        {"checkbox"},
+	"radio_is_selected":     {"radio"},
+	"dropdown_selected":     {"select", "option"},
+	"dropdown_not_selected": {"select", "option"},
+}
+
+// Valid determines whether the step has a known function and the arguments
+// it requires.
+func (s TMSCheckStep) Valid() error {
+	required, ok := tmsStepArgs[s.Fn]
+	if !ok {
+		return fmt.Errorf("unknown step function %q", s.Fn)
+	}
+	for _, arg := range required {
+		if s.Args[arg] == "" {
+			return fmt.Errorf("step %q requires the argument %q", s.Fn, arg)
+		}
+	}
+	return nil
+}
+
+// TMSStepError is an error of a step of a TMS check, found by Valid or
+// reported by Pingdom, Step being its index in the steps of the check.
+type TMSStepError struct {
+	Step int
+	Fn   string
+	Err  error
+}
+
+func (e *TMSStepError) Error() string {
+	return fmt.Sprintf("step %d: %v", e.Step, e.Err)
+}
+
+// Unwrap returns the error of the step, e.g. a *PingdomError.
+func (e *TMSStepError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateTMSSteps returns the errors of every invalid step, rather than
+// only the first one as Valid does, so that a long script can be fixed in
+// one go before it is sent.
+func ValidateTMSSteps(steps []TMSCheckStep) []*TMSStepError {
+	var errs []*TMSStepError
+	for i, step := range steps {
+		if err := step.Valid(); err != nil {
+			errs = append(errs, &TMSStepError{Step: i, Fn: step.Fn, Err: err})
+		}
+	}
+	return errs
+}
+
+// tmsStepPath matches the path of a step in the error messages of Pingdom,
+// e.g. "steps[12].args.element" or "steps.12".
+var tmsStepPath = regexp.MustCompile(`\bsteps(?:\[(\d+)\]|\.(\d+))`)
+
+// tmsStepError returns the error of Pingdom as a TMSStepError when its
+// message points at one of the steps, or the error unchanged otherwise.
+func tmsStepError(err error, steps []TMSCheckStep) error {
+	var pe *PingdomError
+	if !errors.As(err, &pe) {
+		return err
+	}
+	m := tmsStepPath.FindStringSubmatch(pe.Message)
+	if m == nil {
+		return err
+	}
+	i, _ := strconv.Atoi(m[1] + m[2])
+	if i >= len(steps) {
+		return err
+	}
+	return &TMSStepError{Step: i, Fn: steps[i].Fn, Err: err}
+}
+
+// TMSStepBuilder assembles the steps of a TMS check:
+//
+//	steps, err := pingdom.NewTMSStepBuilder().
+//		GoTo("https://www.example.com/login").
+//		Fill("#username", "monitoring").
+//		Click("#submit").
+//		WaitForElement("#dashboard").
+//		Build()
+//
+// The steps are validated by Build, which reports the first invalid one.
+type TMSStepBuilder struct {
+	steps []TMSCheckStep
+}
+
+// NewTMSStepBuilder returns an empty TMSStepBuilder.
+func NewTMSStepBuilder() *TMSStepBuilder {
+	return &TMSStepBuilder{}
+}
+
+// Step adds a step running fn with the given arguments, for functions
+// without a dedicated method.
+func (b *TMSStepBuilder) Step(fn string, args map[string]string) *TMSStepBuilder {
+	b.steps = append(b.steps, TMSCheckStep{Fn: fn, Args: args})
+	return b
+}
+
+// GoTo opens the URL.
+func (b *TMSStepBuilder) GoTo(url string) *TMSStepBuilder {
+	return b.Step("go_to", map[string]string{"url": url})
+}
+
+// Click clicks the element matching the CSS selector.
+func (b *TMSStepBuilder) Click(element string) *TMSStepBuilder {
+	return b.Step("click", map[string]string{"element": element})
+}
+
+// Fill types the value into the input.
+func (b *TMSStepBuilder) Fill(input, value string) *TMSStepBuilder {
+	return b.Step("fill", map[string]string{"input": input, "value": value})
+}
+
+// Check ticks the checkbox.
+func (b *TMSStepBuilder) Check(checkbox string) *TMSStepBuilder {
+	return b.Step("check", map[string]string{"checkbox": checkbox})
+}
+
+// Uncheck clears the checkbox.
+func (b *TMSStepBuilder) Uncheck(checkbox string) *TMSStepBuilder {
+	return b.Step("uncheck", map[string]string{"checkbox": checkbox})
+}
+
+// SelectRadio selects the radio button.
+func (b *TMSStepBuilder) SelectRadio(radio string) *TMSStepBuilder {
+	return b.Step("select_radio", map[string]string{"radio": radio})
+}
+
+// Select picks the option of the dropdown.
+func (b *TMSStepBuilder) Select(dropdown, option string) *TMSStepBuilder {
+	return b.Step("select", map[string]string{"select": dropdown, "option": option})
+}
+
+// Submit submits the form.
+func (b *TMSStepBuilder) Submit(form string) *TMSStepBuilder {
+	return b.Step("submit", map[string]string{"form": form})
+}
+
+// BasicAuth answers the HTTP basic authentication of the next pages.
+func (b *TMSStepBuilder) BasicAuth(username, password string) *TMSStepBuilder {
+	return b.Step("basic_auth", map[string]string{"username": username, "password": password})
+}
+
+// Sleep waits for the given number of seconds.
+func (b *TMSStepBuilder) Sleep(seconds int) *TMSStepBuilder {
+	args := map[string]string{}
+	if seconds > 0 {
+		args["seconds"] = strconv.Itoa(seconds)
+	}
+	return b.Step("sleep", args)
+}
+
+// WaitForElement waits until the element is on the page.
+func (b *TMSStepBuilder) WaitForElement(element string) *TMSStepBuilder {
+	return b.Step("wait_for_element", map[string]string{"element": element})
+}
+
+// WaitForContains waits until the element contains the value.
+func (b *TMSStepBuilder) WaitForContains(element, value string) *TMSStepBuilder {
+	return b.Step("wait_for_contains", map[string]string{"element": element, "value": value})
+}
+
+// Exists asserts that the element is on the page.
+func (b *TMSStepBuilder) Exists(element string) *TMSStepBuilder {
+	return b.Step("exists", map[string]string{"element": element})
+}
+
+// NotExists asserts that the element is not on the page.
+func (b *TMSStepBuilder) NotExists(element string) *TMSStepBuilder {
+	return b.Step("not_exists", map[string]string{"element": element})
+}
+
+// Contains asserts that the element contains the value.
+func (b *TMSStepBuilder) Contains(element, value string) *TMSStepBuilder {
+	return b.Step("contains", map[string]string{"element": element, "value": value})
+}
+
+// NotContains asserts that the element does not contain the value.
+func (b *TMSStepBuilder) NotContains(element, value string) *TMSStepBuilder {
+	return b.Step("not_contains", map[string]string{"element": element, "value": value})
+}
+
+// URL asserts that the current URL is the given one.
+func (b *TMSStepBuilder) URL(url string) *TMSStepBuilder {
+	return b.Step("url", map[string]string{"url": url})
+}
+
+// Build returns the steps, or an error naming the first invalid one.
+func (b *TMSStepBuilder) Build() ([]TMSCheckStep, error) {
+	if len(b.steps) == 0 {
+		return nil, fmt.Errorf("invalid value for `Steps`, must contain at least one step")
+	}
+	if errs := ValidateTMSSteps(b.steps); len(errs) > 0 {
+		return nil, errs[0]
+	}
+	steps := make([]TMSCheckStep, len(b.steps))
+	copy(steps, b.steps)
+	return steps, nil
+}
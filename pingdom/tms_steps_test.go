@@ -0,0 +1,91 @@
+package pingdom
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTMSStepBuilder(t *testing.T) {
+	steps, err := NewTMSStepBuilder().
+		GoTo("https://www.example.com/login").
+		BasicAuth("staging", "secret").
+		Fill("#username", "monitoring").
+		Check("#remember").
+		Select("#language", "en").
+		Click("#submit").
+		Sleep(2).
+		WaitForElement("#dashboard").
+		Contains("h1", "Welcome").
+		Step("dropdown_selected", map[string]string{"select": "#language", "option": "en"}).
+		URL("https://www.example.com/dashboard").
+		Build()
+	assert.NoError(t, err)
+	assert.Equal(t, []TMSCheckStep{
+		{Fn: "go_to", Args: map[string]string{"url": "https://www.example.com/login"}},
+		{Fn: "basic_auth", Args: map[string]string{"username": "staging", "password": "secret"}},
+		{Fn: "fill", Args: map[string]string{"input": "#username", "value": "monitoring"}},
+		{Fn: "check", Args: map[string]string{"checkbox": "#remember"}},
+		{Fn: "select", Args: map[string]string{"select": "#language", "option": "en"}},
+		{Fn: "click", Args: map[string]string{"element": "#submit"}},
+		{Fn: "sleep", Args: map[string]string{"seconds": "2"}},
+		{Fn: "wait_for_element", Args: map[string]string{"element": "#dashboard"}},
+		{Fn: "contains", Args: map[string]string{"element": "h1", "value": "Welcome"}},
+		{Fn: "dropdown_selected", Args: map[string]string{"select": "#language", "option": "en"}},
+		{Fn: "url", Args: map[string]string{"url": "https://www.example.com/dashboard"}},
+	}, steps)
+
+	_, err = NewTMSStepBuilder().Build()
+	assert.EqualError(t, err, "invalid value for `Steps`, must contain at least one step")
+	_, err = NewTMSStepBuilder().GoTo("https://www.example.com").Click("").Build()
+	assert.EqualError(t, err, `step 1: step "click" requires the argument "element"`)
+	_, err = NewTMSStepBuilder().Sleep(0).Build()
+	assert.EqualError(t, err, `step 0: step "sleep" requires the argument "seconds"`)
+	_, err = NewTMSStepBuilder().Step("scroll", nil).Build()
+	assert.EqualError(t, err, `step 0: unknown step function "scroll"`)
+}
+
+func TestValidateTMSSteps(t *testing.T) {
+	steps := []TMSCheckStep{
+		{Fn: "go_to", Args: map[string]string{"url": "https://www.example.com"}},
+		{Fn: "click"},
+		{Fn: "fill", Args: map[string]string{"input": "#user", "value": "monitoring"}},
+		{Fn: "scroll"},
+	}
+	errs := ValidateTMSSteps(steps)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, 1, errs[0].Step)
+	assert.Equal(t, "click", errs[0].Fn)
+	assert.EqualError(t, errs[0], `step 1: step "click" requires the argument "element"`)
+	assert.Equal(t, 3, errs[1].Step)
+	assert.EqualError(t, errs[1], `step 3: unknown step function "scroll"`)
+	assert.Empty(t, ValidateTMSSteps(steps[:1]))
+
+	var stepErr *TMSStepError
+	err := (&TMSCheck{Name: "Login", Steps: steps}).Valid()
+	assert.True(t, errors.As(err, &stepErr))
+	assert.Equal(t, 1, stepErr.Step)
+}
+
+func TestTMSStepErrorOfPingdom(t *testing.T) {
+	steps := []TMSCheckStep{{Fn: "go_to"}, {Fn: "click"}}
+	for message, step := range map[string]int{
+		"steps[1].args.element: invalid selector": 1,
+		"invalid value at steps.0":                0,
+		"steps[7]: out of range":                  -1,
+		"name is required":                        -1,
+	} {
+		err := tmsStepError(&PingdomError{StatusCode: 400, Message: message}, steps)
+		var stepErr *TMSStepError
+		if step < 0 {
+			assert.False(t, errors.As(err, &stepErr), message)
+			continue
+		}
+		assert.True(t, errors.As(err, &stepErr), message)
+		assert.Equal(t, step, stepErr.Step, message)
+		assert.Equal(t, steps[step].Fn, stepErr.Fn, message)
+	}
+	other := errors.New("connection reset")
+	assert.Equal(t, other, tmsStepError(other, steps))
+}
@@ -0,0 +1,117 @@
+package pingdom
+
+import (
+	"bufio"
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TracerouteService provides an interface to the traceroutes Pingdom runs
+// from its probes.
+type TracerouteService struct {
+	client *Client
+}
+
+// TracerouteRequest is the API request to Pingdom for a traceroute to Host.
+// ProbeID selects the probe running it, Pingdom picks one when 0.
+type TracerouteRequest struct {
+	Host    string
+	ProbeID int
+}
+
+// Valid determines whether a TracerouteRequest contains valid fields for the Pingdom API.
+func (tr TracerouteRequest) Valid() error {
+	if tr.Host == "" {
+		return ErrMissingHost
+	}
+	return nil
+}
+
+// GetParams returns a map of params for a Pingdom TracerouteRequest.
+func (tr TracerouteRequest) GetParams() (params map[string]string) {
+	params = map[string]string{"host": tr.Host}
+
+	if tr.ProbeID != 0 {
+		params["probeid"] = strconv.Itoa(tr.ProbeID)
+	}
+
+	return
+}
+
+// Run runs a traceroute and returns its output along with the parsed hops.
+func (ts *TracerouteService) Run(ctx context.Context, request TracerouteRequest) (*TracerouteResponse, error) {
+	if err := request.Valid(); err != nil {
+		return nil, err
+	}
+
+	req, err := ts.client.NewRequest("GET", "/traceroute", request.GetParams())
+	if err != nil {
+		return nil, err
+	}
+	m := &tracerouteJSONResponse{}
+	_, err = ts.client.Do(req.WithContext(ctx), m)
+	if err != nil {
+		return nil, err
+	}
+
+	m.Traceroute.Hops = ParseTraceroute(m.Traceroute.Result)
+	return &m.Traceroute, nil
+}
+
+// ParseTraceroute parses the output of traceroute into hops.  Lines which are
+// not hops, such as the header, are skipped.
+func ParseTraceroute(output string) []TracerouteHop {
+	var hops []TracerouteHop
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		if hop, ok := parseHop(scanner.Text()); ok {
+			hops = append(hops, hop)
+		}
+	}
+	return hops
+}
+
+// parseHop parses a line such as
+//
+//	3  r1.example.net (10.0.0.1)  1.234 ms  * r2.example.net (10.0.0.2)  1.5 ms !H
+//
+// where each latency is an attempt answered by the last host named, and each
+// star an attempt which timed out.
+func parseHop(line string) (TracerouteHop, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return TracerouteHop{}, false
+	}
+	number, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return TracerouteHop{}, false
+	}
+
+	hop := TracerouteHop{Number: number}
+	var host, ip string
+	for i := 1; i < len(fields); i++ {
+		field := fields[i]
+		switch {
+		case field == "*":
+			hop.Attempts = append(hop.Attempts, TracerouteAttempt{Timeout: true})
+		case field == "ms" || strings.HasPrefix(field, "!"):
+			// Unit of the previous latency, or annotation such as !H.
+		case strings.HasPrefix(field, "(") && strings.HasSuffix(field, ")"):
+			ip = strings.Trim(field, "()")
+		default:
+			ms, err := strconv.ParseFloat(strings.TrimSuffix(field, "ms"), 64)
+			if err != nil || host == "" {
+				host, ip = field, field
+				continue
+			}
+			hop.Attempts = append(hop.Attempts, TracerouteAttempt{
+				Host:    host,
+				IP:      ip,
+				Latency: time.Duration(ms * float64(time.Millisecond)),
+			})
+		}
+	}
+	return hop, true
+}
@@ -0,0 +1,63 @@
+package pingdom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const tracerouteOutput = `traceroute to example.com (93.184.216.34), 30 hops max, 60 byte packets
+ 1  gateway (192.168.1.1)  0.345 ms  0.312 ms  0.298 ms
+ 2  * * *
+ 3  r1.example.net (10.0.0.1)  1.234 ms * r2.example.net (10.0.0.2)  1.5 ms !H
+ 4  93.184.216.34  12.1 ms  12.0 ms  11.9 ms
+`
+
+func TestParseTraceroute(t *testing.T) {
+	ms := func(f float64) time.Duration { return time.Duration(f * float64(time.Millisecond)) }
+	assert.Equal(t, []TracerouteHop{
+		{Number: 1, Attempts: []TracerouteAttempt{
+			{Host: "gateway", IP: "192.168.1.1", Latency: ms(0.345)},
+			{Host: "gateway", IP: "192.168.1.1", Latency: ms(0.312)},
+			{Host: "gateway", IP: "192.168.1.1", Latency: ms(0.298)},
+		}},
+		{Number: 2, Attempts: []TracerouteAttempt{{Timeout: true}, {Timeout: true}, {Timeout: true}}},
+		{Number: 3, Attempts: []TracerouteAttempt{
+			{Host: "r1.example.net", IP: "10.0.0.1", Latency: ms(1.234)},
+			{Timeout: true},
+			{Host: "r2.example.net", IP: "10.0.0.2", Latency: ms(1.5)},
+		}},
+		{Number: 4, Attempts: []TracerouteAttempt{
+			{Host: "93.184.216.34", IP: "93.184.216.34", Latency: ms(12.1)},
+			{Host: "93.184.216.34", IP: "93.184.216.34", Latency: ms(12.0)},
+			{Host: "93.184.216.34", IP: "93.184.216.34", Latency: ms(11.9)},
+		}},
+	}, ParseTraceroute(tracerouteOutput))
+	assert.Empty(t, ParseTraceroute(""))
+}
+
+func TestTracerouteServiceRun(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/traceroute", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "example.com", r.URL.Query().Get("host"))
+		assert.Equal(t, "33", r.URL.Query().Get("probeid"))
+		fmt.Fprintf(w, `{"traceroute": {"result": %q, "probeid": 33, "probedescription": "Stockholm, Sweden"}}`, tracerouteOutput)
+	})
+
+	traceroute, err := client.Traceroute.Run(context.Background(), TracerouteRequest{Host: "example.com", ProbeID: 33})
+	assert.NoError(t, err)
+	assert.Equal(t, tracerouteOutput, traceroute.Result)
+	assert.Equal(t, 33, traceroute.ProbeID)
+	assert.Equal(t, "Stockholm, Sweden", traceroute.ProbeDescription)
+	assert.Len(t, traceroute.Hops, 4)
+
+	_, err = client.Traceroute.Run(context.Background(), TracerouteRequest{})
+	assert.Equal(t, ErrMissingHost, err)
+}
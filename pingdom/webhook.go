@@ -0,0 +1,164 @@
+package pingdom
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultWebhookSignatureHeader is the header checked for the signature
+	// of webhook payloads when WebhookHandler.Secret is set.
+	DefaultWebhookSignatureHeader = "X-Pingdom-Signature"
+	defaultWebhookMaxBodySize     = 1 << 20
+)
+
+// ErrWebhookSignature is returned when the signature of a webhook payload is
+// missing or does not match.
+var ErrWebhookSignature = errors.New("invalid webhook signature")
+
+// WebhookEvent is an alert sent by a Pingdom webhook integration when the
+// state of a check changes.
+type WebhookEvent struct {
+	CheckID               int                `json:"check_id"`
+	CheckName             string             `json:"check_name"`
+	CheckType             string             `json:"check_type"`
+	CheckParams           WebhookCheckParams `json:"check_params"`
+	Tags                  []string           `json:"tags"`
+	PreviousState         string             `json:"previous_state"`
+	CurrentState          string             `json:"current_state"`
+	ImportanceLevel       string             `json:"importance_level"`
+	StateChangedTimestamp int64              `json:"state_changed_timestamp"`
+	StateChangedUTCTime   string             `json:"state_changed_utc_time"`
+	LongDescription       string             `json:"long_description"`
+	Description           string             `json:"description"`
+	FirstProbe            WebhookProbe       `json:"first_probe"`
+	SecondProbe           WebhookProbe       `json:"second_probe"`
+}
+
+// WebhookCheckParams are the parameters of the check which triggered a
+// webhook event. Only the fields matching the type of the check are set.
+type WebhookCheckParams struct {
+	BasicAuth  bool   `json:"basic_auth"`
+	Encryption bool   `json:"encryption"`
+	FullURL    string `json:"full_url"`
+	Header     string `json:"header"`
+	Hostname   string `json:"hostname"`
+	IPv6       bool   `json:"ipv6"`
+	Port       int    `json:"port"`
+	Url        string `json:"url"`
+}
+
+// WebhookProbe is a probe which confirmed the state change of a check.
+type WebhookProbe struct {
+	IP       string `json:"ip"`
+	IPv6     string `json:"ipv6"`
+	Location string `json:"location"`
+	Version  int    `json:"version,omitempty"`
+}
+
+// IsDown reports whether the check went down.
+func (e *WebhookEvent) IsDown() bool {
+	return strings.EqualFold(e.CurrentState, string(CheckStatusDown))
+}
+
+// StateChangedAt returns the time at which the state of the check changed.
+func (e *WebhookEvent) StateChangedAt() time.Time {
+	return time.Unix(e.StateChangedTimestamp, 0).UTC()
+}
+
+// ParseWebhook decodes a webhook payload.
+func ParseWebhook(body []byte) (*WebhookEvent, error) {
+	event := &WebhookEvent{}
+	if err := json.Unmarshal(body, event); err != nil {
+		return nil, err
+	}
+	if event.CheckID == 0 {
+		return nil, errors.New("webhook payload has no check_id")
+	}
+	return event, nil
+}
+
+// SignWebhook returns the hex encoded HMAC-SHA256 of the payload, as expected
+// by WebhookHandler in the signature header. Pingdom does not sign payloads
+// itself, this is meant for proxies relaying them.
+func SignWebhook(secret string, body []byte) string {
+	return hex.EncodeToString(webhookMAC(secret, body))
+}
+
+func webhookMAC(secret string, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(body)
+	return mac.Sum(nil)
+}
+
+// WebhookHandler is an http.Handler receiving Pingdom webhook alerts and
+// passing them, decoded, to OnEvent. It answers 405 to anything but POST, 401
+// when Secret is set and the signature does not match, 400 to invalid
+// payloads and 500 when OnEvent returns an error.
+type WebhookHandler struct {
+	OnEvent         func(event *WebhookEvent, r *http.Request) error
+	Secret          string
+	SignatureHeader string // Defaults to DefaultWebhookSignatureHeader
+	MaxBodySize     int64  // Defaults to 1MB
+}
+
+// ServeHTTP implements http.Handler.
+func (wh *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	maxBodySize := wh.MaxBodySize
+	if maxBodySize <= 0 {
+		maxBodySize = defaultWebhookMaxBodySize
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, "could not read payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := wh.verify(r, body); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	event, err := ParseWebhook(body)
+	if err != nil {
+		http.Error(w, "invalid payload: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if wh.OnEvent != nil {
+		if err := wh.OnEvent(event, r); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (wh *WebhookHandler) verify(r *http.Request, body []byte) error {
+	if wh.Secret == "" {
+		return nil
+	}
+	header := wh.SignatureHeader
+	if header == "" {
+		header = DefaultWebhookSignatureHeader
+	}
+	// The hex is decoded, as senders may use either case.
+	signature, err := hex.DecodeString(strings.TrimPrefix(r.Header.Get(header), "sha256="))
+	if err != nil || len(signature) == 0 || !hmac.Equal(signature, webhookMAC(wh.Secret, body)) {
+		return ErrWebhookSignature
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package pingdom
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const webhookPayload = `{
+	"check_id": 12345,
+	"check_name": "Name of HTTP check",
+	"check_type": "HTTP",
+	"check_params": {
+		"basic_auth": false,
+		"encryption": true,
+		"full_url": "https://www.example.com/path",
+		"header": "User-Agent:Pingdom.com_bot",
+		"hostname": "www.example.com",
+		"ipv6": false,
+		"port": 443,
+		"url": "/path"
+	},
+	"tags": ["example_tag"],
+	"previous_state": "UP",
+	"current_state": "DOWN",
+	"importance_level": "HIGH",
+	"state_changed_timestamp": 1451610061,
+	"state_changed_utc_time": "2016-01-01T01:01:01",
+	"long_description": "Long error message",
+	"description": "Short error message",
+	"first_probe": {"ip": "123.4.5.6", "ipv6": "2001:4800:1020:209::5", "location": "Stockholm, Sweden"},
+	"second_probe": {"ip": "123.4.5.6", "ipv6": "2001:4800:1020:209::5", "location": "Austin, US", "version": 1}
+}`
+
+func TestParseWebhook(t *testing.T) {
+	event, err := ParseWebhook([]byte(webhookPayload))
+	assert.NoError(t, err)
+	assert.Equal(t, 12345, event.CheckID)
+	assert.Equal(t, "HTTP", event.CheckType)
+	assert.Equal(t, 443, event.CheckParams.Port)
+	assert.Equal(t, []string{"example_tag"}, event.Tags)
+	assert.True(t, event.IsDown())
+	assert.Equal(t, time.Date(2016, 1, 1, 1, 1, 1, 0, time.UTC), event.StateChangedAt())
+	assert.Equal(t, "Austin, US", event.SecondProbe.Location)
+	assert.Equal(t, 1, event.SecondProbe.Version)
+
+	_, err = ParseWebhook([]byte(`{"check_name": "no id"}`))
+	assert.Error(t, err)
+	_, err = ParseWebhook([]byte(`not json`))
+	assert.Error(t, err)
+}
+
+func TestWebhookHandler(t *testing.T) {
+	var received *WebhookEvent
+	handler := &WebhookHandler{
+		OnEvent: func(event *WebhookEvent, r *http.Request) error {
+			received = event
+			if event.CheckID == 1 {
+				return errors.New("cannot handle")
+			}
+			return nil
+		},
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/webhook", strings.NewReader(webhookPayload)))
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "Name of HTTP check", received.CheckName)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/webhook", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/webhook", strings.NewReader(`{}`)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/webhook", strings.NewReader(`{"check_id": 1}`)))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+	handler.MaxBodySize = 10
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/webhook", strings.NewReader(webhookPayload)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestWebhookHandlerSignature(t *testing.T) {
+	calls := 0
+	handler := &WebhookHandler{
+		Secret: "s3cr3t",
+		OnEvent: func(event *WebhookEvent, r *http.Request) error {
+			calls++
+			return nil
+		},
+	}
+
+	req := httptest.NewRequest("POST", "/webhook", strings.NewReader(webhookPayload))
+	req.Header.Set(DefaultWebhookSignatureHeader, "sha256="+SignWebhook("s3cr3t", []byte(webhookPayload)))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	req = httptest.NewRequest("POST", "/webhook", strings.NewReader(webhookPayload))
+	req.Header.Set(DefaultWebhookSignatureHeader, SignWebhook("other", []byte(webhookPayload)))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("POST", "/webhook", strings.NewReader(webhookPayload)))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req = httptest.NewRequest("POST", "/webhook", strings.NewReader(webhookPayload))
+	req.Header.Set(DefaultWebhookSignatureHeader, "sha256="+strings.ToUpper(SignWebhook("s3cr3t", []byte(webhookPayload))))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code, "uppercase hex should be accepted")
+
+	req = httptest.NewRequest("POST", "/webhook", strings.NewReader(webhookPayload))
+	req.Header.Set(DefaultWebhookSignatureHeader, "sha256=not-hex")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	handler.SignatureHeader = "X-Hub-Signature-256"
+	req = httptest.NewRequest("POST", "/webhook", strings.NewReader(webhookPayload))
+	req.Header.Set("X-Hub-Signature-256", SignWebhook("s3cr3t", []byte(webhookPayload)))
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, 3, calls)
+}
@@ -1,9 +1,12 @@
 package pingdomext
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"strconv"
+	"strings"
 )
 
 // IntegrationService provides an interface to Pingdom integration management.
@@ -19,13 +22,13 @@ type Integration interface {
 }
 
 // List returns the response holding a list of Integration.
-func (cs *IntegrationService) List() ([]IntegrationGetResponse, error) {
+func (cs *IntegrationService) List(ctx context.Context) ([]IntegrationGetResponse, error) {
 	req, err := cs.client.NewRequest("GET", "/data/v3/integration", nil)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := cs.client.client.Do(req)
+	resp, err := cs.client.client.Do(req.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -44,15 +47,41 @@ func (cs *IntegrationService) List() ([]IntegrationGetResponse, error) {
 	return m.Integrations, err
 }
 
+// ValidateIDs checks that every ID refers to a configured integration, e.g.
+// before setting the IntegrationIds of a check.
+func (cs *IntegrationService) ValidateIDs(ctx context.Context, ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	integrations, err := cs.List(ctx)
+	if err != nil {
+		return err
+	}
+	known := make(map[int]bool, len(integrations))
+	for _, integration := range integrations {
+		known[integration.ID] = true
+	}
+	var unknown []string
+	for _, id := range ids {
+		if !known[id] {
+			unknown = append(unknown, strconv.Itoa(id))
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown integration IDs: %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
 // Read returns a Integration for a given ID.
-func (cs *IntegrationService) Read(id int) (*IntegrationGetResponse, error) {
+func (cs *IntegrationService) Read(ctx context.Context, id int) (*IntegrationGetResponse, error) {
 	req, err := cs.client.NewRequest("GET", "/data/v3/integration/"+strconv.Itoa(id), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	m := &integrationDetailsJSONResponse{}
-	_, err = cs.client.Do(req, m)
+	_, err = cs.client.Do(req.WithContext(ctx), m)
 	if err != nil {
 		return nil, err
 	}
@@ -61,7 +90,7 @@ func (cs *IntegrationService) Read(id int) (*IntegrationGetResponse, error) {
 }
 
 // Create a new Integration.
-func (cs *IntegrationService) Create(integration Integration) (*IntegrationStatus, error) {
+func (cs *IntegrationService) Create(ctx context.Context, integration Integration) (*IntegrationStatus, error) {
 	if err := integration.Valid(); err != nil {
 		return nil, err
 	}
@@ -72,7 +101,7 @@ func (cs *IntegrationService) Create(integration Integration) (*IntegrationStatu
 	}
 
 	m := &integrationJSONResponse{}
-	_, err = cs.client.Do(req, m)
+	_, err = cs.client.Do(req.WithContext(ctx), m)
 	if err != nil {
 		return nil, err
 	}
@@ -80,7 +109,7 @@ func (cs *IntegrationService) Create(integration Integration) (*IntegrationStatu
 }
 
 // Update will update the Integration for the given ID.
-func (cs *IntegrationService) Update(id int, integration Integration) (*IntegrationStatus, error) {
+func (cs *IntegrationService) Update(ctx context.Context, id int, integration Integration) (*IntegrationStatus, error) {
 	if err := integration.Valid(); err != nil {
 		return nil, err
 	}
@@ -91,7 +120,7 @@ func (cs *IntegrationService) Update(id int, integration Integration) (*Integrat
 	}
 
 	m := &integrationJSONResponse{}
-	_, err = cs.client.Do(req, m)
+	_, err = cs.client.Do(req.WithContext(ctx), m)
 	if err != nil {
 		return nil, err
 	}
@@ -99,14 +128,14 @@ func (cs *IntegrationService) Update(id int, integration Integration) (*Integrat
 }
 
 // Delete will delete the Integration for the given ID.
-func (cs *IntegrationService) Delete(id int) (*IntegrationStatus, error) {
+func (cs *IntegrationService) Delete(ctx context.Context, id int) (*IntegrationStatus, error) {
 	req, err := cs.client.NewRequest("DELETE", "/data/v3/integration/"+strconv.Itoa(id), nil)
 	if err != nil {
 		return nil, err
 	}
 
 	m := &integrationJSONResponse{}
-	_, err = cs.client.Do(req, m)
+	_, err = cs.client.Do(req.WithContext(ctx), m)
 	if err != nil {
 		return nil, err
 	}
@@ -114,13 +143,13 @@ func (cs *IntegrationService) Delete(id int) (*IntegrationStatus, error) {
 }
 
 // ListProviders returns the response holding a list of Provider.
-func (cs *IntegrationService) ListProviders() ([]IntegrationProvider, error) {
+func (cs *IntegrationService) ListProviders(ctx context.Context) ([]IntegrationProvider, error) {
 	req, err := cs.client.NewRequest("GET", "/integrations/provider", nil)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := cs.client.client.Do(req)
+	resp, err := cs.client.client.Do(req.WithContext(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package pingdomext
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"reflect"
@@ -61,7 +62,7 @@ func TestIntegrationService_Create(t *testing.T) {
 			cs := &IntegrationService{
 				client: tt.client,
 			}
-			got, err := cs.Create(tt.integration)
+			got, err := cs.Create(context.Background(), tt.integration)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("IntegrationService.Create() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -205,7 +206,7 @@ func TestIntegrationService_List(t *testing.T) {
 			cs := &IntegrationService{
 				client: tt.client,
 			}
-			got, err := cs.List()
+			got, err := cs.List(context.Background())
 			if (err != nil) != tt.wantErr {
 				t.Errorf("IntegrationService.List() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -217,6 +218,24 @@ func TestIntegrationService_List(t *testing.T) {
 	}
 }
 
+func TestIntegrationService_ValidateIDs(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := 0
+	mux.HandleFunc("/data/v3/integration", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		requests++
+		fmt.Fprint(w, `{"integration": [{"id": 112107, "name": "webhook"}, {"id": 112108, "name": "webhook"}]}`)
+	})
+
+	assert.NoError(t, client.Integrations.ValidateIDs(context.Background(), nil))
+	assert.Equal(t, 0, requests)
+	assert.NoError(t, client.Integrations.ValidateIDs(context.Background(), []int{112108, 112107}))
+	assert.EqualError(t, client.Integrations.ValidateIDs(context.Background(), []int{1, 112107, 2}),
+		"unknown integration IDs: 1, 2")
+}
+
 func TestIntegrationService_Read(t *testing.T) {
 	setup()
 	defer teardown()
@@ -321,7 +340,7 @@ func TestIntegrationService_Read(t *testing.T) {
 			cs := &IntegrationService{
 				client: tt.client,
 			}
-			got, err := cs.Read(tt.args.id)
+			got, err := cs.Read(context.Background(), tt.args.id)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("IntegrationService.Read() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -390,7 +409,7 @@ func TestIntegrationService_Update(t *testing.T) {
 			cs := &IntegrationService{
 				client: tt.client,
 			}
-			got, err := cs.Update(tt.args.id, tt.args.integration)
+			got, err := cs.Update(context.Background(), tt.args.id, tt.args.integration)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("IntegrationService.Update() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -460,7 +479,7 @@ func TestIntegrationService_Delete(t *testing.T) {
 			cs := &IntegrationService{
 				client: tt.client,
 			}
-			got, err := cs.Delete(tt.args.id)
+			got, err := cs.Delete(context.Background(), tt.args.id)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("IntegrationService.Delete() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -577,7 +596,7 @@ func TestIntegrationService_ListProviders(t *testing.T) {
 			cs := &IntegrationService{
 				client: tt.client,
 			}
-			got, err := cs.ListProviders()
+			got, err := cs.ListProviders(context.Background())
 			if (err != nil) != tt.wantErr {
 				t.Errorf("IntegrationService.ListProviders() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -9,6 +9,9 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+
+	"github.com/nordcloud/go-pingdom/internal/redact"
+	"github.com/nordcloud/go-pingdom/internal/transport"
 )
 
 const (
@@ -24,7 +27,22 @@ type Client struct {
 	Integrations *IntegrationService
 }
 
+// Timeouts limits the time spent in each phase of a request, a request
+// exceeding one of them fails with a *TimeoutError naming the phase.
+type Timeouts = transport.Timeouts
+
+// TimeoutError is returned, possibly wrapped, when a request times out.
+type TimeoutError = transport.TimeoutError
+
+// TLSConfig sets the minimum TLS version and optionally pins the public keys
+// or certificates of the endpoints.
+type TLSConfig = transport.TLSConfig
+
+// ProxyConfig routes the requests through an authenticated proxy.
+type ProxyConfig = transport.ProxyConfig
+
 // ClientConfig represents a configuration for a pingdom client.
+// Timeouts, TLS and Proxy are ignored when HTTPClient is set.
 type ClientConfig struct {
 	Username   string
 	Password   string
@@ -32,6 +50,9 @@ type ClientConfig struct {
 	AuthURL    string
 	BaseURL    string
 	HTTPClient *http.Client
+	Timeouts   *Timeouts
+	TLS        *TLSConfig
+	Proxy      *ProxyConfig
 }
 
 type authPayload struct {
@@ -91,12 +112,22 @@ func NewClientWithConfig(config ClientConfig) (*Client, error) {
 				return http.ErrUseLastResponse
 			},
 		}
+		if config.Timeouts != nil || config.TLS != nil || config.Proxy != nil {
+			config.HTTPClient.Transport, err = transport.Build(transport.Options{
+				Timeouts: config.Timeouts,
+				TLS:      config.TLS,
+				Proxy:    config.Proxy,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	c.client = config.HTTPClient
 	jwtToken, err = obtainToken(config)
 	if err != nil {
-		return nil, err
+		return nil, redact.Secrets{config.Password}.Error(err)
 	}
 
 	c.JWTToken = *jwtToken
@@ -227,7 +258,7 @@ func (pc *Client) NewRequest(method string, rsc string, params map[string]string
 func (pc *Client) Do(req *http.Request, v interface{}) (*http.Response, error) {
 	resp, err := pc.client.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, redact.Error(err)
 	}
 	defer resp.Body.Close()
 
@@ -1,6 +1,7 @@
 package pingdomext
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -8,6 +9,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/nordcloud/go-pingdom/pingdom"
 	"github.com/stretchr/testify/assert"
@@ -276,3 +278,37 @@ func Test_getCookie(t *testing.T) {
 		})
 	}
 }
+
+func TestNewClientWithTimeouts(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/auth/login", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+	})
+
+	c, err := NewClientWithConfig(ClientConfig{
+		Username: "test_user",
+		Password: "test_pwd",
+		OrgID:    "test_org",
+		BaseURL:  server.URL,
+		AuthURL:  server.URL + "/v1/login",
+		Timeouts: &Timeouts{Overall: 20 * time.Millisecond},
+	})
+	assert.Nil(t, c)
+	var timeoutErr *TimeoutError
+	if assert.True(t, errors.As(err, &timeoutErr)) {
+		assert.Equal(t, "overall", timeoutErr.Phase)
+	}
+}
+
+func TestNewClientWithInvalidTLS(t *testing.T) {
+	c, err := NewClientWithConfig(ClientConfig{
+		Username: "test_user",
+		Password: "test_pwd",
+		OrgID:    "test_org",
+		TLS:      &TLSConfig{PinnedPublicKeys: []string{"c2hvcnQ="}},
+	})
+	assert.Nil(t, c)
+	assert.EqualError(t, err, `invalid pin "c2hvcnQ=", must be a base64 encoded SHA-256 digest`)
+}
@@ -0,0 +1,305 @@
+// Package preflight validates HTTP checks locally before they are created or
+// updated: it requests the URL of the check the way the Pingdom probes do and
+// verifies its shouldcontain or shouldnotcontain string against the response,
+// so that a typo does not make a new check fail right away.
+//
+// Like the probes, responses are decompressed (gzip and deflate) and decoded
+// to UTF-8 from the charset of their Content-Type header, byte order mark or
+// HTML meta tag, defaulting to windows-1252, before being searched.
+//
+// Pingdom HTTP checks cannot be configured with the status codes they expect:
+// any 4xx or 5xx status is down, anything else is up.  Checker.ExpectedStatus
+// narrows down the statuses accepted locally, e.g. to make sure an endpoint
+// still redirects, and rejects the ones the probes would report as down.
+package preflight
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"golang.org/x/net/html/charset"
+)
+
+const (
+	defaultTimeout     = 30 * time.Second
+	defaultMaxBodySize = 10 << 20
+)
+
+// Result is the response to the request of a check.
+type Result struct {
+	URL        string
+	StatusCode int
+	Encoding   string // Content encoding of the response, empty when not compressed
+	Charset    string // Charset the body was decoded from
+	Body       string // Decoded body, up to MaxBodySize
+}
+
+// StatusError is returned when the URL answers with an error status, which
+// the probes would report as down, or with a status other than the expected
+// ones.
+type StatusError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: unexpected status %d %s", e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// UnsupportedStatusError is returned when an expected status is one the
+// probes report as down whatever the check, such as 404.  Such endpoints
+// need a transaction check, or a shouldcontain string on an endpoint
+// answering with a 2xx status.
+type UnsupportedStatusError struct {
+	StatusCode int
+}
+
+func (e *UnsupportedStatusError) Error() string {
+	return fmt.Sprintf("status %d %s is always down for Pingdom HTTP checks, use a transaction check instead", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// ValidateExpectedStatus returns an *UnsupportedStatusError for the first
+// status which Pingdom HTTP checks cannot expect, i.e. 400 and above, or
+// which is not an HTTP status.
+func ValidateExpectedStatus(codes []int) error {
+	for _, code := range codes {
+		if code >= 400 || code < 100 {
+			return &UnsupportedStatusError{StatusCode: code}
+		}
+	}
+	return nil
+}
+
+// ContentError is returned when the response does not contain the
+// shouldcontain string of the check, or contains its shouldnotcontain string.
+type ContentError struct {
+	URL           string
+	ShouldContain bool
+	String        string
+}
+
+func (e *ContentError) Error() string {
+	if e.ShouldContain {
+		return fmt.Sprintf("%s: response does not contain %q", e.URL, e.String)
+	}
+	return fmt.Sprintf("%s: response contains %q", e.URL, e.String)
+}
+
+// Checker requests the URL of checks.
+type Checker struct {
+	// Client defaults to a client with a 30 seconds timeout, which verifies
+	// certificates unless the check disables it.
+	Client *http.Client
+
+	// MaxBodySize is the number of bytes of the decompressed body searched,
+	// defaults to 10MB.
+	MaxBodySize int64
+
+	// ExpectedStatus, when set, are the only statuses accepted, e.g.
+	// []int{301, 302}.  Redirects are not followed by the default client
+	// when a 3xx status is expected.
+	ExpectedStatus []int
+}
+
+// Check requests the URL of the check and verifies the response with a
+// default Checker.
+func Check(ctx context.Context, check *pingdom.HttpCheck) (*Result, error) {
+	return (&Checker{}).Check(ctx, check)
+}
+
+// Check requests the URL of the check with its method, credentials, headers
+// and body, and verifies the response.  The result is returned along with a
+// *StatusError or *ContentError when the verification fails.  An
+// *UnsupportedStatusError is returned without sending the request when
+// ExpectedStatus is invalid.
+func (c *Checker) Check(ctx context.Context, check *pingdom.HttpCheck) (*Result, error) {
+	if err := ValidateExpectedStatus(c.ExpectedStatus); err != nil {
+		return nil, err
+	}
+	req, err := NewRequest(ctx, check)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{URL: req.URL.String()}
+
+	resp, err := c.client(check).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	result.StatusCode = resp.StatusCode
+
+	body, err := c.decode(resp, result)
+	if err != nil {
+		return result, fmt.Errorf("%s: %w", result.URL, err)
+	}
+	result.Body = body
+
+	if !c.expected(resp.StatusCode) {
+		return result, &StatusError{URL: result.URL, StatusCode: resp.StatusCode}
+	}
+	if check.ShouldContain != "" && !strings.Contains(body, check.ShouldContain) {
+		return result, &ContentError{URL: result.URL, ShouldContain: true, String: check.ShouldContain}
+	}
+	if check.ShouldNotContain != "" && strings.Contains(body, check.ShouldNotContain) {
+		return result, &ContentError{URL: result.URL, String: check.ShouldNotContain}
+	}
+	return result, nil
+}
+
+// NewRequest returns the request the probes send for the check: a POST when
+// it has post data, a GET otherwise.
+func NewRequest(ctx context.Context, check *pingdom.HttpCheck) (*http.Request, error) {
+	if check.Hostname == "" {
+		return nil, fmt.Errorf("check %q has no hostname", check.Name)
+	}
+	scheme := "http"
+	if check.Encryption {
+		scheme = "https"
+	}
+	host := check.Hostname
+	if check.Port != 0 {
+		host += ":" + strconv.Itoa(check.Port)
+	}
+	path := check.Url
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	method := http.MethodGet
+	var body io.Reader
+	if check.PostData != "" {
+		method = http.MethodPost
+		body = strings.NewReader(check.PostData)
+	}
+	req, err := http.NewRequest(method, scheme+"://"+host+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if check.PostData != "" {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	// Set explicitly so that the response is not transparently decompressed,
+	// and deflate is accepted as well.
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
+	for name, value := range check.RequestHeaders {
+		if strings.EqualFold(name, "Host") {
+			req.Host = value
+		} else {
+			req.Header.Set(name, value)
+		}
+	}
+	if check.Username != "" {
+		req.SetBasicAuth(check.Username, check.Password)
+	}
+	return req, nil
+}
+
+func (c *Checker) client(check *pingdom.HttpCheck) *http.Client {
+	if c.Client != nil {
+		return c.Client
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if check.VerifyCertificate != nil && !*check.VerifyCertificate {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	client := &http.Client{Transport: transport, Timeout: defaultTimeout}
+	for _, code := range c.ExpectedStatus {
+		if code >= 300 && code < 400 {
+			client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+		}
+	}
+	return client
+}
+
+// expected reports whether the status is accepted: one of ExpectedStatus
+// when set, any status below 400 otherwise.
+func (c *Checker) expected(code int) bool {
+	if len(c.ExpectedStatus) == 0 {
+		return code < 400
+	}
+	for _, expected := range c.ExpectedStatus {
+		if code == expected {
+			return true
+		}
+	}
+	return false
+}
+
+// decode decompresses the body of the response and decodes it to UTF-8.
+func (c *Checker) decode(resp *http.Response, result *Result) (string, error) {
+	limit := c.MaxBodySize
+	if limit <= 0 {
+		limit = defaultMaxBodySize
+	}
+
+	var r io.Reader = resp.Body
+	switch encoding := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))); encoding {
+	case "", "identity":
+	case "gzip", "x-gzip":
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return "", fmt.Errorf("invalid gzip response: %w", err)
+		}
+		defer gz.Close()
+		r = gz
+		result.Encoding = "gzip"
+	case "deflate":
+		// Servers send either zlib wrapped or raw deflate data.
+		buffered := bufio.NewReader(r)
+		if header, err := buffered.Peek(2); err == nil && isZlibHeader(header) {
+			zr, err := zlib.NewReader(buffered)
+			if err != nil {
+				return "", fmt.Errorf("invalid deflate response: %w", err)
+			}
+			defer zr.Close()
+			r = zr
+		} else {
+			fr := flate.NewReader(buffered)
+			defer fr.Close()
+			r = fr
+		}
+		result.Encoding = "deflate"
+	default:
+		return "", fmt.Errorf("unsupported content encoding %q", encoding)
+	}
+
+	raw, err := ioutil.ReadAll(io.LimitReader(r, limit))
+	if err != nil {
+		return "", err
+	}
+	peek := raw
+	if len(peek) > 1024 {
+		peek = peek[:1024]
+	}
+	enc, name, _ := charset.DetermineEncoding(peek, resp.Header.Get("Content-Type"))
+	result.Charset = name
+	if name == "utf-8" {
+		return string(bytes.TrimPrefix(raw, []byte("\xef\xbb\xbf"))), nil
+	}
+	decoded, err := ioutil.ReadAll(enc.NewDecoder().Reader(bytes.NewReader(raw)))
+	if err != nil {
+		return "", fmt.Errorf("invalid %s response: %w", name, err)
+	}
+	return string(decoded), nil
+}
+
+// isZlibHeader reports whether the two bytes are a zlib header: deflate
+// compression and a valid checksum.
+func isZlibHeader(b []byte) bool {
+	return b[0]&0x0f == 8 && (uint16(b[0])<<8|uint16(b[1]))%31 == 0
+}
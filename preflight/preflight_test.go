@@ -0,0 +1,213 @@
+package preflight
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func compress(t *testing.T, encoding string, body []byte) []byte {
+	buf := &bytes.Buffer{}
+	var w io.WriteCloser
+	switch encoding {
+	case "gzip":
+		w = gzip.NewWriter(buf)
+	case "zlib":
+		w = zlib.NewWriter(buf)
+	case "flate":
+		var err error
+		w, err = flate.NewWriter(buf, flate.DefaultCompression)
+		assert.NoError(t, err)
+	}
+	_, err := w.Write(body)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+// newCheck returns a check of the given path of the server.
+func newCheck(t *testing.T, server *httptest.Server, path string) *pingdom.HttpCheck {
+	u, err := url.Parse(server.URL)
+	assert.NoError(t, err)
+	port, err := strconv.Atoi(u.Port())
+	assert.NoError(t, err)
+	return &pingdom.HttpCheck{Name: "test", Hostname: u.Hostname(), Port: port, Url: path}
+}
+
+func TestCheckDecoding(t *testing.T) {
+	latin1 := []byte("Bienvenue \xe0 la caf\xe9t\xe9ria")
+	responses := map[string]struct {
+		contentType string
+		encoding    string
+		body        []byte
+	}{
+		"/plain":    {contentType: "text/html; charset=utf-8", body: []byte("Bienvenue à la cafétéria")},
+		"/gzip":     {contentType: "text/html; charset=utf-8", encoding: "gzip", body: compress(t, "gzip", []byte("Bienvenue à la cafétéria"))},
+		"/zlib":     {contentType: "text/plain", encoding: "deflate", body: compress(t, "zlib", []byte("Bienvenue à la cafétéria"))},
+		"/flate":    {contentType: "text/plain", encoding: "deflate", body: compress(t, "flate", []byte("Bienvenue à la cafétéria"))},
+		"/latin1":   {contentType: "text/html; charset=ISO-8859-1", body: latin1},
+		"/meta":     {contentType: "text/html", body: append([]byte(`<html><head><meta charset="iso-8859-1"></head><body>`), latin1...)},
+		"/gzlatin1": {contentType: "text/html; charset=latin1", encoding: "gzip", body: compress(t, "gzip", latin1)},
+		"/utf16":    {contentType: "text/plain", body: []byte("\xff\xfeB\x00i\x00e\x00n\x00v\x00e\x00n\x00u\x00e\x00 \x00\xe0\x00 \x00l\x00a\x00 \x00c\x00a\x00f\x00\xe9\x00t\x00\xe9\x00r\x00i\x00a\x00")},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "gzip, deflate", r.Header.Get("Accept-Encoding"))
+		resp := responses[r.URL.Path]
+		w.Header().Set("Content-Type", resp.contentType)
+		if resp.encoding != "" {
+			w.Header().Set("Content-Encoding", resp.encoding)
+		}
+		w.Write(resp.body)
+	}))
+	defer server.Close()
+
+	tests := map[string]struct {
+		encoding string
+		charset  string
+	}{
+		"/plain":    {charset: "utf-8"},
+		"/gzip":     {encoding: "gzip", charset: "utf-8"},
+		"/zlib":     {encoding: "deflate", charset: "utf-8"},
+		"/flate":    {encoding: "deflate", charset: "utf-8"},
+		"/latin1":   {charset: "windows-1252"},
+		"/meta":     {charset: "windows-1252"},
+		"/gzlatin1": {encoding: "gzip", charset: "windows-1252"},
+		"/utf16":    {charset: "utf-16le"},
+	}
+	for path, want := range tests {
+		check := newCheck(t, server, path)
+		check.ShouldContain = "à la cafétéria"
+		result, err := Check(context.Background(), check)
+		assert.NoError(t, err, path)
+		if assert.NotNil(t, result, path) {
+			assert.Equal(t, want.encoding, result.Encoding, path)
+			assert.Equal(t, want.charset, result.Charset, path)
+		}
+	}
+}
+
+func TestCheckFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/brotli":
+			w.Header().Set("Content-Encoding", "br")
+			w.Write([]byte("garbage"))
+		case "/broken":
+			w.Header().Set("Content-Encoding", "gzip")
+			w.Write([]byte("not gzip"))
+		default:
+			w.Write([]byte("status: maintenance"))
+		}
+	}))
+	defer server.Close()
+
+	check := newCheck(t, server, "/missing")
+	_, err := Check(context.Background(), check)
+	var statusErr *StatusError
+	if assert.True(t, errors.As(err, &statusErr)) {
+		assert.Equal(t, http.StatusNotFound, statusErr.StatusCode)
+	}
+
+	check = newCheck(t, server, "/")
+	check.ShouldContain = "status: ok"
+	result, err := Check(context.Background(), check)
+	assert.EqualError(t, err, server.URL+`/: response does not contain "status: ok"`)
+	assert.Equal(t, "status: maintenance", result.Body)
+
+	check = newCheck(t, server, "/")
+	check.ShouldNotContain = "maintenance"
+	_, err = Check(context.Background(), check)
+	var contentErr *ContentError
+	if assert.True(t, errors.As(err, &contentErr)) {
+		assert.False(t, contentErr.ShouldContain)
+		assert.Equal(t, "maintenance", contentErr.String)
+	}
+
+	_, err = Check(context.Background(), newCheck(t, server, "/brotli"))
+	assert.EqualError(t, err, server.URL+`/brotli: unsupported content encoding "br"`)
+	_, err = Check(context.Background(), newCheck(t, server, "/broken"))
+	assert.Error(t, err)
+
+	_, err = Check(context.Background(), &pingdom.HttpCheck{Name: "test"})
+	assert.EqualError(t, err, `check "test" has no hostname`)
+}
+
+func TestCheckExpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/old":
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+		case "/empty":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.Write([]byte("new"))
+		}
+	}))
+	defer server.Close()
+
+	checker := &Checker{ExpectedStatus: []int{http.StatusMovedPermanently}}
+	result, err := checker.Check(context.Background(), newCheck(t, server, "/old"))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusMovedPermanently, result.StatusCode)
+
+	// Followed without a 3xx expected status.
+	result, err = Check(context.Background(), newCheck(t, server, "/old"))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, result.StatusCode)
+
+	checker = &Checker{ExpectedStatus: []int{http.StatusOK}}
+	_, err = checker.Check(context.Background(), newCheck(t, server, "/empty"))
+	var statusErr *StatusError
+	if assert.True(t, errors.As(err, &statusErr)) {
+		assert.Equal(t, http.StatusNoContent, statusErr.StatusCode)
+	}
+
+	checker = &Checker{ExpectedStatus: []int{http.StatusOK, http.StatusUnauthorized}}
+	_, err = checker.Check(context.Background(), newCheck(t, server, "/"))
+	assert.EqualError(t, err, "status 401 Unauthorized is always down for Pingdom HTTP checks, use a transaction check instead")
+	assert.NoError(t, ValidateExpectedStatus([]int{200, 204, 302}))
+	assert.Equal(t, &UnsupportedStatusError{StatusCode: 42}, ValidateExpectedStatus([]int{42}))
+}
+
+func TestNewRequest(t *testing.T) {
+	check := &pingdom.HttpCheck{
+		Hostname:       "example.com",
+		Encryption:     true,
+		Port:           8443,
+		Url:            "health?full=1",
+		Username:       "user",
+		Password:       "secret",
+		PostData:       "a=b",
+		RequestHeaders: map[string]string{"X-Test": "1", "Host": "vhost.example.com"},
+	}
+	req, err := NewRequest(context.Background(), check)
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPost, req.Method)
+	assert.Equal(t, "https://example.com:8443/health?full=1", req.URL.String())
+	assert.Equal(t, "vhost.example.com", req.Host)
+	assert.Equal(t, "1", req.Header.Get("X-Test"))
+	assert.Equal(t, "application/x-www-form-urlencoded", req.Header.Get("Content-Type"))
+	user, password, ok := req.BasicAuth()
+	assert.True(t, ok)
+	assert.Equal(t, "user", user)
+	assert.Equal(t, "secret", password)
+
+	req, err = NewRequest(context.Background(), &pingdom.HttpCheck{Hostname: "example.com"})
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodGet, req.Method)
+	assert.Equal(t, "http://example.com/", req.URL.String())
+}
@@ -0,0 +1,88 @@
+package reporting
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+)
+
+// BudgetForecast is the state of the downtime budget of an uptime target,
+// e.g. 99.9 percent, during a calendar month (UTC), such as "2021-03".
+type BudgetForecast struct {
+	Month   string
+	Target  float64 // Percent
+	Elapsed time.Duration
+	Left    time.Duration // Until the end of the month
+
+	// Downtime is the month-to-date downtime, Allowed the downtime the
+	// target allows over the whole month, and Remaining the downtime still
+	// allowed, negative once the budget is exceeded.
+	Downtime  time.Duration
+	Allowed   time.Duration
+	Remaining time.Duration
+
+	// Uptime is the month-to-date uptime in percent, and ProjectedUptime the
+	// uptime at the end of the month if there is no further downtime.
+	Uptime          float64
+	ProjectedUptime float64
+}
+
+// RemainingMinutes returns the downtime still allowed in minutes.
+func (f *BudgetForecast) RemainingMinutes() float64 {
+	return f.Remaining.Minutes()
+}
+
+// Exceeded returns whether the downtime already exceeds the budget.
+func (f *BudgetForecast) Exceeded() bool {
+	return f.Remaining < 0
+}
+
+// Forecast returns the budget forecast of the month of now given the
+// month-to-date downtime and the uptime target in percent.
+func Forecast(target float64, downtime time.Duration, now time.Time) (*BudgetForecast, error) {
+	if target <= 0 || target > 100 {
+		return nil, fmt.Errorf("invalid uptime target %v, must be a percentage between 0 and 100", target)
+	}
+	now = now.UTC()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	month := start.AddDate(0, 1, 0).Sub(start)
+
+	f := &BudgetForecast{
+		Month:    start.Format("2006-01"),
+		Target:   target,
+		Elapsed:  now.Sub(start),
+		Left:     month - now.Sub(start),
+		Downtime: downtime,
+		Allowed:  time.Duration(float64(month) * (100 - target) / 100),
+	}
+	f.Remaining = f.Allowed - downtime
+	f.Uptime = 100
+	if f.Elapsed > 0 {
+		f.Uptime = 100 * (1 - float64(downtime)/float64(f.Elapsed))
+	}
+	f.ProjectedUptime = 100 * (1 - float64(downtime)/float64(month))
+	return f, nil
+}
+
+// ForecastCheck fetches the outages of the check since the start of the month
+// of now and returns the budget forecast of the uptime target in percent.
+func ForecastCheck(ctx context.Context, source OutageSource, check pingdom.CheckResponse, target float64, now time.Time) (*BudgetForecast, error) {
+	now = now.UTC()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	resp, err := source.SummaryOutage(ctx, pingdom.SummaryOutageRequest{
+		Id:    check.ID,
+		From:  int(start.Unix()),
+		To:    int(now.Unix()),
+		Order: "asc",
+	})
+	if err != nil {
+		return nil, err
+	}
+	var downtime time.Duration
+	for _, outage := range Outages(check, resp.Summary.States, 0, start, now) {
+		downtime += outage.Duration
+	}
+	return Forecast(target, downtime, now)
+}
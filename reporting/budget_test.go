@@ -0,0 +1,66 @@
+package reporting
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForecast(t *testing.T) {
+	// Half of April, which has 30 days.
+	now := time.Date(2021, 4, 16, 0, 0, 0, 0, time.UTC)
+	f, err := Forecast(99.5, 108*time.Minute, now)
+	assert.NoError(t, err)
+	assert.Equal(t, "2021-04", f.Month)
+	assert.Equal(t, 15*24*time.Hour, f.Elapsed)
+	assert.Equal(t, 15*24*time.Hour, f.Left)
+	assert.Equal(t, 216*time.Minute, f.Allowed)
+	assert.Equal(t, 108*time.Minute, f.Remaining)
+	assert.Equal(t, 108.0, f.RemainingMinutes())
+	assert.False(t, f.Exceeded())
+	assert.InDelta(t, 99.5, f.Uptime, 1e-9)
+	assert.InDelta(t, 99.75, f.ProjectedUptime, 1e-9)
+
+	f, err = Forecast(99.5, 4*time.Hour, now)
+	assert.NoError(t, err)
+	assert.Equal(t, -24*time.Minute, f.Remaining)
+	assert.True(t, f.Exceeded())
+
+	f, err = Forecast(100, 0, time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), f.Allowed)
+	assert.Equal(t, 100.0, f.Uptime)
+	assert.Equal(t, 28*24*time.Hour, f.Left)
+	assert.False(t, f.Exceeded())
+
+	_, err = Forecast(0, 0, now)
+	assert.EqualError(t, err, "invalid uptime target 0, must be a percentage between 0 and 100")
+	_, err = Forecast(101, 0, now)
+	assert.Error(t, err)
+}
+
+func TestForecastCheck(t *testing.T) {
+	source := &fakeOutageSource{states: map[int][]pingdom.SummaryOutageState{
+		1: {
+			// Started during March, only April counts.
+			{Status: "down", TimeFrom: unix(2021, 3, 31, 23, 0), TimeTo: unix(2021, 4, 1, 0, 30)},
+			{Status: "up", TimeFrom: unix(2021, 4, 1, 0, 30), TimeTo: unix(2021, 4, 10, 12, 0)},
+			{Status: "down", TimeFrom: unix(2021, 4, 10, 12, 0), TimeTo: unix(2021, 4, 10, 12, 15)},
+		},
+	}}
+	now := time.Date(2021, 4, 16, 0, 0, 0, 0, time.UTC)
+	f, err := ForecastCheck(context.Background(), source, pingdom.CheckResponse{ID: 1, Name: "API"}, 99.9, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 45*time.Minute, f.Downtime)
+	assert.Equal(t, []pingdom.SummaryOutageRequest{
+		{Id: 1, From: unix(2021, 4, 1, 0, 0), To: unix(2021, 4, 16, 0, 0), Order: "asc"},
+	}, source.requests)
+
+	source.err = errors.New("boom")
+	_, err = ForecastCheck(context.Background(), source, pingdom.CheckResponse{ID: 1}, 99.9, now)
+	assert.EqualError(t, err, "boom")
+}
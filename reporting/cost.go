@@ -0,0 +1,179 @@
+// Package reporting computes reports, such as the estimated business impact of
+// outages, out of the data returned by the Pingdom API.
+package reporting
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+)
+
+// DefaultCostTagPrefix is the prefix of the check tag holding the cost of one
+// minute of downtime. Pingdom tags can not contain dots, so an underscore is
+// used as the decimal separator: "costperminute-12_50" stands for 12.50.
+const DefaultCostTagPrefix = "costperminute-"
+
+// OutageSource provides the outage history of checks, it is implemented by
+// pingdom.CheckService.
+type OutageSource interface {
+	SummaryOutage(ctx context.Context, request pingdom.SummaryOutageRequest) (*pingdom.SummaryOutageResponse, error)
+}
+
+// CostModel tells how much a minute of downtime of a check costs. Annotations,
+// keyed by check ID, take precedence over the cost tags of the checks.
+type CostModel struct {
+	TagPrefix   string // Defaults to DefaultCostTagPrefix
+	Annotations map[int]float64
+}
+
+// Outage is a single period of downtime of a check and its estimated cost.
+type Outage struct {
+	CheckID   int
+	CheckName string
+	Start     time.Time
+	End       time.Time
+	Duration  time.Duration
+	Cost      float64
+}
+
+// MonthlyCost is the downtime of a check during a calendar month (UTC), e.g.
+// "2021-03", and its estimated cost.
+type MonthlyCost struct {
+	Month     string
+	CheckID   int
+	CheckName string
+	Downtime  time.Duration
+	Cost      float64
+}
+
+// ImpactReport is the estimated business impact of the outages of checks.
+type ImpactReport struct {
+	Outages []Outage
+	Monthly []MonthlyCost
+	Total   float64
+}
+
+// CostPerMinute returns the cost of a minute of downtime of the check, and
+// whether any cost is attached to it at all.
+func (m CostModel) CostPerMinute(check pingdom.CheckResponse) (float64, bool) {
+	if cost, ok := m.Annotations[check.ID]; ok {
+		return cost, true
+	}
+	prefix := m.TagPrefix
+	if prefix == "" {
+		prefix = DefaultCostTagPrefix
+	}
+	for _, tag := range check.Tags {
+		if !strings.HasPrefix(tag.Name, prefix) {
+			continue
+		}
+		value := strings.Replace(strings.TrimPrefix(tag.Name, prefix), "_", ".", 1)
+		if cost, err := strconv.ParseFloat(value, 64); err == nil {
+			return cost, true
+		}
+	}
+	return 0, false
+}
+
+// Impact fetches the outages between from and to of every check with a cost
+// attached and estimates their cost, per outage and per month. Checks without
+// cost are skipped.
+func (m CostModel) Impact(ctx context.Context, source OutageSource, checks []pingdom.CheckResponse, from, to time.Time) (*ImpactReport, error) {
+	report := &ImpactReport{
+		Outages: []Outage{},
+		Monthly: []MonthlyCost{},
+	}
+	for _, check := range checks {
+		costPerMinute, ok := m.CostPerMinute(check)
+		if !ok {
+			continue
+		}
+		resp, err := source.SummaryOutage(ctx, pingdom.SummaryOutageRequest{
+			Id:    check.ID,
+			From:  int(from.Unix()),
+			To:    int(to.Unix()),
+			Order: "asc",
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, outage := range Outages(check, resp.Summary.States, costPerMinute, from, to) {
+			report.add(outage, costPerMinute)
+		}
+	}
+	sort.SliceStable(report.Monthly, func(i, j int) bool {
+		if report.Monthly[i].Month != report.Monthly[j].Month {
+			return report.Monthly[i].Month < report.Monthly[j].Month
+		}
+		return report.Monthly[i].CheckID < report.Monthly[j].CheckID
+	})
+	return report, nil
+}
+
+// Outages turns the "down" states of a check into outages clipped to the
+// period between from and to, costed at costPerMinute.
+func Outages(check pingdom.CheckResponse, states []pingdom.SummaryOutageState, costPerMinute float64, from, to time.Time) []Outage {
+	var outages []Outage
+	for _, state := range states {
+		if pingdom.CheckStatus(state.Status) != pingdom.CheckStatusDown {
+			continue
+		}
+		start := time.Unix(int64(state.TimeFrom), 0).UTC()
+		end := time.Unix(int64(state.TimeTo), 0).UTC()
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if !end.After(start) {
+			continue
+		}
+		duration := end.Sub(start)
+		outages = append(outages, Outage{
+			CheckID:   check.ID,
+			CheckName: check.Name,
+			Start:     start,
+			End:       end,
+			Duration:  duration,
+			Cost:      duration.Minutes() * costPerMinute,
+		})
+	}
+	return outages
+}
+
+// add records the outage, splitting it over the months it spans.
+func (r *ImpactReport) add(outage Outage, costPerMinute float64) {
+	r.Outages = append(r.Outages, outage)
+	r.Total += outage.Cost
+
+	for start := outage.Start; start.Before(outage.End); {
+		monthStart := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC)
+		end := monthStart.AddDate(0, 1, 0)
+		if end.After(outage.End) {
+			end = outage.End
+		}
+		monthly := r.month(monthStart.Format("2006-01"), outage)
+		monthly.Downtime += end.Sub(start)
+		monthly.Cost += end.Sub(start).Minutes() * costPerMinute
+		start = end
+	}
+}
+
+func (r *ImpactReport) month(month string, outage Outage) *MonthlyCost {
+	for i := range r.Monthly {
+		if r.Monthly[i].Month == month && r.Monthly[i].CheckID == outage.CheckID {
+			return &r.Monthly[i]
+		}
+	}
+	r.Monthly = append(r.Monthly, MonthlyCost{
+		Month:     month,
+		CheckID:   outage.CheckID,
+		CheckName: outage.CheckName,
+	})
+	return &r.Monthly[len(r.Monthly)-1]
+}
@@ -0,0 +1,112 @@
+package reporting
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeOutageSource struct {
+	states   map[int][]pingdom.SummaryOutageState
+	requests []pingdom.SummaryOutageRequest
+	err      error
+}
+
+func (f *fakeOutageSource) SummaryOutage(ctx context.Context, request pingdom.SummaryOutageRequest) (*pingdom.SummaryOutageResponse, error) {
+	f.requests = append(f.requests, request)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &pingdom.SummaryOutageResponse{
+		Summary: pingdom.SummaryOutageStates{States: f.states[request.Id]},
+	}, nil
+}
+
+func unix(year int, month time.Month, day, hour, min int) int {
+	return int(time.Date(year, month, day, hour, min, 0, 0, time.UTC).Unix())
+}
+
+func TestCostPerMinute(t *testing.T) {
+	model := CostModel{Annotations: map[int]float64{2: 3}}
+
+	cost, ok := model.CostPerMinute(pingdom.CheckResponse{
+		ID:   1,
+		Tags: []pingdom.CheckResponseTag{{Name: "web"}, {Name: "costperminute-12_50"}},
+	})
+	assert.True(t, ok)
+	assert.Equal(t, 12.5, cost)
+
+	cost, ok = model.CostPerMinute(pingdom.CheckResponse{
+		ID:   2,
+		Tags: []pingdom.CheckResponseTag{{Name: "costperminute-12"}},
+	})
+	assert.True(t, ok)
+	assert.Equal(t, 3.0, cost)
+
+	_, ok = model.CostPerMinute(pingdom.CheckResponse{
+		ID:   3,
+		Tags: []pingdom.CheckResponseTag{{Name: "costperminute-abc"}},
+	})
+	assert.False(t, ok)
+
+	cost, ok = CostModel{TagPrefix: "cpm_"}.CostPerMinute(pingdom.CheckResponse{
+		Tags: []pingdom.CheckResponseTag{{Name: "cpm_7"}},
+	})
+	assert.True(t, ok)
+	assert.Equal(t, 7.0, cost)
+}
+
+func TestImpact(t *testing.T) {
+	from := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)
+	source := &fakeOutageSource{
+		states: map[int][]pingdom.SummaryOutageState{
+			1: {
+				{Status: "up", TimeFrom: unix(2021, 3, 1, 0, 0), TimeTo: unix(2021, 3, 10, 12, 0)},
+				{Status: "down", TimeFrom: unix(2021, 3, 10, 12, 0), TimeTo: unix(2021, 3, 10, 12, 30)},
+				{Status: "up", TimeFrom: unix(2021, 3, 10, 12, 30), TimeTo: unix(2021, 3, 31, 23, 50)},
+				{Status: "down", TimeFrom: unix(2021, 3, 31, 23, 50), TimeTo: unix(2021, 4, 1, 0, 10)},
+			},
+			2: {
+				{Status: "down", TimeFrom: unix(2021, 2, 28, 23, 0), TimeTo: unix(2021, 3, 1, 0, 5)},
+			},
+		},
+	}
+	checks := []pingdom.CheckResponse{
+		{ID: 1, Name: "web", Tags: []pingdom.CheckResponseTag{{Name: "costperminute-10"}}},
+		{ID: 2, Name: "api"},
+		{ID: 3, Name: "free"},
+	}
+
+	report, err := CostModel{Annotations: map[int]float64{2: 2}}.Impact(context.Background(), source, checks, from, to)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(source.requests), "checks without cost are not fetched")
+	assert.Equal(t, pingdom.SummaryOutageRequest{Id: 1, From: int(from.Unix()), To: int(to.Unix()), Order: "asc"}, source.requests[0])
+
+	assert.Equal(t, 3, len(report.Outages))
+	assert.Equal(t, 30*time.Minute, report.Outages[0].Duration)
+	assert.Equal(t, 300.0, report.Outages[0].Cost)
+	assert.Equal(t, 20*time.Minute, report.Outages[1].Duration)
+	// The outage of the api check started before the report period.
+	assert.Equal(t, from, report.Outages[2].Start)
+	assert.Equal(t, 10.0, report.Outages[2].Cost)
+
+	assert.Equal(t, []MonthlyCost{
+		{Month: "2021-03", CheckID: 1, CheckName: "web", Downtime: 40 * time.Minute, Cost: 400},
+		{Month: "2021-03", CheckID: 2, CheckName: "api", Downtime: 5 * time.Minute, Cost: 10},
+		{Month: "2021-04", CheckID: 1, CheckName: "web", Downtime: 10 * time.Minute, Cost: 100},
+	}, report.Monthly)
+	assert.Equal(t, 510.0, report.Total)
+}
+
+func TestImpactError(t *testing.T) {
+	source := &fakeOutageSource{err: errors.New("boom")}
+	checks := []pingdom.CheckResponse{{ID: 1, Tags: []pingdom.CheckResponseTag{{Name: "costperminute-1"}}}}
+
+	_, err := CostModel{}.Impact(context.Background(), source, checks, time.Now().Add(-time.Hour), time.Now())
+	assert.EqualError(t, err, "boom")
+}
@@ -0,0 +1,206 @@
+package reporting
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/internal/atomicfile"
+)
+
+// Deploy is a release, e.g. recorded by a deployment pipeline, which may
+// change the response time of the checks it affects.
+type Deploy struct {
+	At      time.Time `json:"at"`
+	Version string    `json:"version"`
+	// CheckIDs are the checks affected by the deploy, all of them when
+	// empty.
+	CheckIDs []int `json:"checkids,omitempty"`
+}
+
+// Affects returns whether the deploy affects the check.
+func (d Deploy) Affects(checkID int) bool {
+	if len(d.CheckIDs) == 0 {
+		return true
+	}
+	for _, id := range d.CheckIDs {
+		if id == checkID {
+			return true
+		}
+	}
+	return false
+}
+
+// DeployStore records deploys.  Implementations backed by a database or the
+// API of a deployment tool can be used instead of the ones of this package.
+type DeployStore interface {
+	Add(deploy Deploy) error
+	// Deploys returns the deploys affecting the check between from and to,
+	// oldest first.
+	Deploys(checkID int, from, to time.Time) ([]Deploy, error)
+}
+
+// MemoryDeployStore keeps the deploys in memory.  It is safe for concurrent
+// use.
+type MemoryDeployStore struct {
+	mu      sync.Mutex
+	deploys []Deploy
+}
+
+// Add implements DeployStore.
+func (s *MemoryDeployStore) Add(deploy Deploy) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deploys = append(s.deploys, deploy)
+	return nil
+}
+
+// Deploys implements DeployStore.
+func (s *MemoryDeployStore) Deploys(checkID int, from, to time.Time) ([]Deploy, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return filterDeploys(s.deploys, checkID, from, to), nil
+}
+
+// FileDeployStore saves the deploys to a JSON file, which each Add replaces
+// atomically.
+type FileDeployStore struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+// Add implements DeployStore.
+func (s *FileDeployStore) Add(deploy Deploy) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	deploys, err := s.load()
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(append(deploys, deploy), "", "  ")
+	if err != nil {
+		return err
+	}
+	return atomicfile.WriteFile(s.Path, b)
+}
+
+// Deploys implements DeployStore, a missing file holds no deploys.
+func (s *FileDeployStore) Deploys(checkID int, from, to time.Time) ([]Deploy, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	deploys, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	return filterDeploys(deploys, checkID, from, to), nil
+}
+
+func (s *FileDeployStore) load() ([]Deploy, error) {
+	b, err := ioutil.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var deploys []Deploy
+	if err := json.Unmarshal(b, &deploys); err != nil {
+		return nil, err
+	}
+	return deploys, nil
+}
+
+func filterDeploys(deploys []Deploy, checkID int, from, to time.Time) []Deploy {
+	var filtered []Deploy
+	for _, d := range deploys {
+		if d.Affects(checkID) && !d.At.Before(from) && d.At.Before(to) {
+			filtered = append(filtered, d)
+		}
+	}
+	sort.SliceStable(filtered, func(i, j int) bool { return filtered[i].At.Before(filtered[j].At) })
+	return filtered
+}
+
+// AnnotatedPoint is a point of a response time series with the deploys
+// which happened during its bucket, to draw them as markers.
+type AnnotatedPoint struct {
+	UptimePoint
+	Deploys []Deploy
+}
+
+// Annotate attaches the deploys to the points of series, whose buckets are
+// bucket long, e.g. time.Hour for an hourly series of UptimeCache.
+func Annotate(series []UptimePoint, deploys []Deploy, bucket time.Duration) []AnnotatedPoint {
+	annotated := make([]AnnotatedPoint, len(series))
+	for i, p := range series {
+		annotated[i].UptimePoint = p
+		for _, d := range deploys {
+			if !d.At.Before(p.Start) && d.At.Before(p.Start.Add(bucket)) {
+				annotated[i].Deploys = append(annotated[i].Deploys, d)
+			}
+		}
+	}
+	return annotated
+}
+
+// DeployComparison is the average response time of a check, in
+// milliseconds, during a window before and after a deploy.
+type DeployComparison struct {
+	Deploy Deploy
+	Before float64
+	After  float64
+}
+
+// Change returns the relative change of the response time after the deploy,
+// e.g. 0.25 when it is 25 percent slower, or 0 without response time before
+// the deploy.
+func (c DeployComparison) Change() float64 {
+	if c.Before == 0 {
+		return 0
+	}
+	return (c.After - c.Before) / c.Before
+}
+
+// Regressed returns whether the response time grew by more than threshold
+// after the deploy, e.g. 0.2 for 20 percent.
+func (c DeployComparison) Regressed(threshold float64) bool {
+	return c.Change() > threshold
+}
+
+// CompareDeploys compares the average response time of the points of series
+// starting during window before each deploy with the one of the points
+// starting during window after it.  The points without monitored time are
+// ignored.
+func CompareDeploys(series []UptimePoint, deploys []Deploy, window time.Duration) []DeployComparison {
+	comparisons := make([]DeployComparison, 0, len(deploys))
+	for _, d := range deploys {
+		comparisons = append(comparisons, DeployComparison{
+			Deploy: d,
+			Before: averageResponse(series, d.At.Add(-window), d.At),
+			After:  averageResponse(series, d.At, d.At.Add(window)),
+		})
+	}
+	return comparisons
+}
+
+// averageResponse returns the average response time of the points starting
+// between from and to, weighted by their monitored time.
+func averageResponse(series []UptimePoint, from, to time.Time) float64 {
+	var total, monitored float64
+	for _, p := range series {
+		if p.Start.Before(from) || !p.Start.Before(to) {
+			continue
+		}
+		weight := float64(p.Uptime + p.Downtime)
+		total += weight * float64(p.AvgResponse)
+		monitored += weight
+	}
+	if monitored == 0 {
+		return 0
+	}
+	return total / monitored
+}
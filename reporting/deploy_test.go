@@ -0,0 +1,72 @@
+package reporting
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var deployBase = time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+
+func hourly(responses ...int) []UptimePoint {
+	points := make([]UptimePoint, len(responses))
+	for i, response := range responses {
+		points[i] = UptimePoint{Start: deployBase.Add(time.Duration(i) * time.Hour), Uptime: time.Hour, AvgResponse: response}
+	}
+	return points
+}
+
+func TestDeployStores(t *testing.T) {
+	dir, err := ioutil.TempDir("", "deploys")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for _, store := range []DeployStore{&MemoryDeployStore{}, &FileDeployStore{Path: filepath.Join(dir, "deploys.json")}} {
+		deploys, err := store.Deploys(1, deployBase, deployBase.Add(time.Hour))
+		assert.NoError(t, err)
+		assert.Empty(t, deploys)
+
+		v2 := Deploy{At: deployBase.Add(2 * time.Hour), Version: "v2", CheckIDs: []int{1, 2}}
+		v1 := Deploy{At: deployBase.Add(time.Hour), Version: "v1"}
+		other := Deploy{At: deployBase.Add(90 * time.Minute), Version: "other", CheckIDs: []int{3}}
+		for _, d := range []Deploy{v2, v1, other} {
+			assert.NoError(t, store.Add(d))
+		}
+
+		deploys, err = store.Deploys(1, deployBase, deployBase.Add(3*time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, []Deploy{v1, v2}, deploys)
+		deploys, err = store.Deploys(3, deployBase, deployBase.Add(2*time.Hour))
+		assert.NoError(t, err)
+		assert.Equal(t, []Deploy{v1, other}, deploys)
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	v1 := Deploy{At: deployBase.Add(90 * time.Minute), Version: "v1"}
+	annotated := Annotate(hourly(100, 120, 130), []Deploy{v1}, time.Hour)
+	if assert.Len(t, annotated, 3) {
+		assert.Empty(t, annotated[0].Deploys)
+		assert.Equal(t, []Deploy{v1}, annotated[1].Deploys)
+		assert.Equal(t, 120, annotated[1].AvgResponse)
+		assert.Empty(t, annotated[2].Deploys)
+	}
+}
+
+func TestCompareDeploys(t *testing.T) {
+	series := hourly(100, 100, 150, 150)
+	series[3].Uptime = 0 // Not monitored
+	v1 := Deploy{At: deployBase.Add(2 * time.Hour), Version: "v1"}
+	comparisons := CompareDeploys(series, []Deploy{v1}, 2*time.Hour)
+	if assert.Len(t, comparisons, 1) {
+		assert.Equal(t, DeployComparison{Deploy: v1, Before: 100, After: 150}, comparisons[0])
+		assert.Equal(t, 0.5, comparisons[0].Change())
+		assert.True(t, comparisons[0].Regressed(0.2))
+		assert.False(t, comparisons[0].Regressed(0.5))
+	}
+	assert.Equal(t, 0.0, DeployComparison{After: 100}.Change())
+}
@@ -0,0 +1,113 @@
+package reporting
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+)
+
+// ResultSource provides the raw results of checks, it is implemented by
+// pingdom.ResultsService.
+type ResultSource interface {
+	List(ctx context.Context, request pingdom.ResultsRequest) (*pingdom.ResultsResponse, error)
+}
+
+// ExportedResult is a raw result of a check.
+type ExportedResult struct {
+	CheckID int `json:"checkid"`
+	pingdom.Result
+}
+
+// WalkResults calls fn for every raw result of the checks between from and
+// to, check by check and newest first, stopping at the first error.  Results
+// are fetched one page at a time and passed on straight away, so the memory
+// used does not depend on the length of the period or the number of checks.
+func WalkResults(ctx context.Context, source ResultSource, checkIDs []int, from, to time.Time, fn func(ExportedResult) error) error {
+	for _, id := range checkIDs {
+		if err := walkCheckResults(ctx, source, id, from, to, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StreamResults sends every raw result of the checks between from and to on
+// results, like WalkResults, and closes it when done.  A send blocks until
+// the receiver is ready, so a slow receiver slows down the export rather than
+// letting results pile up.  It returns the error of ctx if ctx is done while
+// a send is blocked.
+func StreamResults(ctx context.Context, source ResultSource, checkIDs []int, from, to time.Time, results chan<- ExportedResult) error {
+	defer close(results)
+	return WalkResults(ctx, source, checkIDs, from, to, func(result ExportedResult) error {
+		select {
+		case results <- result:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+}
+
+// ExportResults writes every raw result of the checks between from and to to
+// w, like WalkResults, as one JSON object per line.
+func ExportResults(ctx context.Context, source ResultSource, checkIDs []int, from, to time.Time, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	return WalkResults(ctx, source, checkIDs, from, to, func(result ExportedResult) error {
+		return enc.Encode(result)
+	})
+}
+
+// walkCheckResults pages through the results of one check.  Instead of an
+// offset, which Pingdom limits to pingdom.ResultMaxOffset, every page ends
+// the period of the next one at the time of its oldest result.  The results
+// of that second which were already passed on are skipped when they come
+// again.
+func walkCheckResults(ctx context.Context, source ResultSource, id int, from, to time.Time, fn func(ExportedResult) error) error {
+	end := int(to.Unix())
+	var seen map[int]bool // Probes of the results at end already passed on
+	for {
+		resp, err := source.List(ctx, pingdom.ResultsRequest{
+			Id:    id,
+			From:  int(from.Unix()),
+			To:    end,
+			Limit: pingdom.ResultMaxLimit,
+		})
+		if err != nil {
+			return err
+		}
+
+		oldest, passed := end, 0
+		next := map[int]bool{}
+		for _, result := range resp.Results {
+			if result.Time == end && seen[result.ProbeID] {
+				continue
+			}
+			if err := fn(ExportedResult{CheckID: id, Result: result}); err != nil {
+				return err
+			}
+			passed++
+			if result.Time < oldest {
+				oldest, next = result.Time, map[int]bool{}
+			}
+			if result.Time == oldest {
+				next[result.ProbeID] = true
+			}
+		}
+		if len(resp.Results) < pingdom.ResultMaxLimit {
+			return nil
+		}
+		if passed == 0 {
+			return fmt.Errorf("check %d has more than %d results at %d", id, pingdom.ResultMaxLimit, end)
+		}
+		if oldest == end {
+			for probe := range seen {
+				next[probe] = true
+			}
+		}
+		end, seen = oldest, next
+	}
+}
@@ -0,0 +1,114 @@
+package reporting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeResultSource serves results, newest first, with from and to inclusive.
+type fakeResultSource struct {
+	results  map[int][]pingdom.Result
+	requests int
+}
+
+func (f *fakeResultSource) List(ctx context.Context, request pingdom.ResultsRequest) (*pingdom.ResultsResponse, error) {
+	f.requests++
+	resp := &pingdom.ResultsResponse{}
+	for _, result := range f.results[request.Id] {
+		if result.Time < request.From || result.Time > request.To {
+			continue
+		}
+		if len(resp.Results) == request.Limit {
+			break
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	return resp, nil
+}
+
+// minutely returns results of two probes every minute until end, newest first.
+func minutely(end, n int) []pingdom.Result {
+	results := make([]pingdom.Result, 0, n)
+	for i := 0; len(results) < n; i++ {
+		results = append(results,
+			pingdom.Result{ProbeID: 1, Time: end - 60*i, Status: "up"},
+			pingdom.Result{ProbeID: 2, Time: end - 60*i, Status: "up"})
+	}
+	return results[:n]
+}
+
+func TestWalkResults(t *testing.T) {
+	from := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	source := &fakeResultSource{results: map[int][]pingdom.Result{
+		// 2 results per minute, a full page ends in the middle of a minute.
+		1: minutely(int(to.Unix()), 2501),
+		2: minutely(int(to.Unix()), 3),
+	}}
+
+	var got []ExportedResult
+	err := WalkResults(context.Background(), source, []int{1, 2}, from, to, func(result ExportedResult) error {
+		got = append(got, result)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, source.requests)
+	if assert.Len(t, got, 2504) {
+		for i, result := range source.results[1] {
+			assert.Equal(t, ExportedResult{CheckID: 1, Result: result}, got[i])
+		}
+		assert.Equal(t, ExportedResult{CheckID: 2, Result: source.results[2][0]}, got[2501])
+	}
+}
+
+func TestStreamResults(t *testing.T) {
+	to := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	source := &fakeResultSource{results: map[int][]pingdom.Result{1: minutely(int(to.Unix()), 10)}}
+
+	results := make(chan ExportedResult)
+	done := make(chan error)
+	go func() {
+		done <- StreamResults(context.Background(), source, []int{1}, to.Add(-time.Hour), to, results)
+	}()
+	n := 0
+	for range results {
+		n++
+	}
+	assert.NoError(t, <-done)
+	assert.Equal(t, 10, n)
+
+	// Nobody receives, the export stops with ctx.
+	ctx, cancel := context.WithCancel(context.Background())
+	results = make(chan ExportedResult)
+	go func() {
+		done <- StreamResults(ctx, source, []int{1}, to.Add(-time.Hour), to, results)
+	}()
+	<-results
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+}
+
+func TestExportResults(t *testing.T) {
+	to := time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC)
+	source := &fakeResultSource{results: map[int][]pingdom.Result{42: minutely(int(to.Unix()), 2)}}
+
+	var buf bytes.Buffer
+	err := ExportResults(context.Background(), source, []int{42}, to.Add(-time.Hour), to, &buf)
+	assert.NoError(t, err)
+
+	lines := bytes.Split(bytes.TrimSuffix(buf.Bytes(), []byte("\n")), []byte("\n"))
+	if assert.Len(t, lines, 2) {
+		var first map[string]interface{}
+		assert.NoError(t, json.Unmarshal(lines[0], &first))
+		assert.Equal(t, 42.0, first["checkid"])
+		assert.Equal(t, 1.0, first["probeid"])
+		assert.Equal(t, float64(to.Unix()), first["time"])
+		assert.Equal(t, "up", first["status"])
+	}
+}
@@ -0,0 +1,212 @@
+package reporting
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+)
+
+// ReportSource provides the summaries monthly reports are made of, it is
+// implemented by pingdom.CheckService.
+type ReportSource interface {
+	PerformanceSource
+	OutageSource
+}
+
+// PDFRenderer turns the HTML of a report into a PDF document, e.g. with a
+// headless browser or wkhtmltopdf. This package does not provide any, to
+// stay free of their dependencies.
+type PDFRenderer interface {
+	RenderPDF(w io.Writer, html io.Reader) error
+}
+
+// CheckSummary is the uptime, response time and outages of a check during
+// the month of a MonthlyReport.
+type CheckSummary struct {
+	CheckID     int
+	CheckName   string
+	Uptime      time.Duration
+	Downtime    time.Duration
+	Unmonitored time.Duration
+	AvgResponse int // Milliseconds, averaged over the time the check was up
+	Outages     []Outage
+}
+
+// Availability returns the percentage of monitored time the check was up.
+func (s CheckSummary) Availability() float64 {
+	return 100 * UptimePoint{Uptime: s.Uptime, Downtime: s.Downtime}.Availability()
+}
+
+// MonthlyReport is the uptime of a group of checks, e.g. those of a tag or a
+// team, during a calendar month, such as "2021-03", in a time zone such as
+// "UTC" or "Europe/Stockholm".  Its times are in that time zone.
+type MonthlyReport struct {
+	Group    string
+	Month    string
+	TimeZone string
+	Checks   []CheckSummary
+}
+
+// Availability returns the percentage of monitored time the checks of the
+// report were up, all checks taken together.
+func (r *MonthlyReport) Availability() float64 {
+	var total CheckSummary
+	for _, check := range r.Checks {
+		total.Uptime += check.Uptime
+		total.Downtime += check.Downtime
+	}
+	return total.Availability()
+}
+
+// Downtime returns the downtime of the checks of the report added up.
+func (r *MonthlyReport) Downtime() time.Duration {
+	var downtime time.Duration
+	for _, check := range r.Checks {
+		downtime += check.Downtime
+	}
+	return downtime
+}
+
+// BuildMonthlyReport fetches the daily performance and outages of the checks
+// during the month of month (UTC) and summarizes them, in the order of checks.
+func BuildMonthlyReport(ctx context.Context, source ReportSource, group string, checks []pingdom.CheckResponse, month time.Time) (*MonthlyReport, error) {
+	return BuildMonthlyReportIn(ctx, source, group, checks, month, time.UTC)
+}
+
+// BuildMonthlyReportIn is BuildMonthlyReport for the calendar month of month
+// in the location, e.g. one loaded with time.LoadLocation("Europe/Stockholm"),
+// from and to local midnight whatever the daylight saving time.  The daily
+// performance Pingdom reports may still be aggregated per UTC day.
+func BuildMonthlyReportIn(ctx context.Context, source ReportSource, group string, checks []pingdom.CheckResponse, month time.Time, loc *time.Location) (*MonthlyReport, error) {
+	month = month.In(loc)
+	from := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, loc)
+	to := from.AddDate(0, 1, 0)
+
+	report := &MonthlyReport{Group: group, Month: from.Format("2006-01"), TimeZone: loc.String(), Checks: []CheckSummary{}}
+	for _, check := range checks {
+		points, err := fetchUptime(ctx, source, check.ID, "day", from, to)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := source.SummaryOutage(ctx, pingdom.SummaryOutageRequest{
+			Id:    check.ID,
+			From:  int(from.Unix()),
+			To:    int(to.Unix()),
+			Order: "asc",
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		summary := CheckSummary{CheckID: check.ID, CheckName: check.Name, Outages: Outages(check, resp.Summary.States, 0, from, to)}
+		for i := range summary.Outages {
+			summary.Outages[i].Start = summary.Outages[i].Start.In(loc)
+			summary.Outages[i].End = summary.Outages[i].End.In(loc)
+		}
+		var weighted float64
+		for _, p := range points {
+			summary.Uptime += p.Uptime
+			summary.Downtime += p.Downtime
+			summary.Unmonitored += p.Unmonitored
+			weighted += float64(p.AvgResponse) * p.Uptime.Seconds()
+		}
+		if summary.Uptime > 0 {
+			summary.AvgResponse = int(weighted/summary.Uptime.Seconds() + 0.5)
+		}
+		report.Checks = append(report.Checks, summary)
+	}
+	return report, nil
+}
+
+// GroupByTag groups the checks by tag, a check with several tags being in
+// each of their groups and one without any in none.
+func GroupByTag(checks []pingdom.CheckResponse) map[string][]pingdom.CheckResponse {
+	groups := map[string][]pingdom.CheckResponse{}
+	for _, check := range checks {
+		for _, tag := range check.Tags {
+			groups[tag.Name] = append(groups[tag.Name], check)
+		}
+	}
+	return groups
+}
+
+// GroupByTeam groups the checks by the name of the teams alerted by them.
+func GroupByTeam(checks []pingdom.CheckResponse) map[string][]pingdom.CheckResponse {
+	groups := map[string][]pingdom.CheckResponse{}
+	for _, check := range checks {
+		for _, team := range check.Teams {
+			groups[team.Name] = append(groups[team.Name], check)
+		}
+	}
+	return groups
+}
+
+// GroupNames returns the names of the groups, sorted.
+func GroupNames(groups map[string][]pingdom.CheckResponse) []string {
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"percent": func(v float64) string { return fmt.Sprintf("%.3f%%", v) },
+	"duration": func(d time.Duration) string {
+		return d.Round(time.Second).String()
+	},
+	"datetime": func(t time.Time) string { return t.Format("2006-01-02 15:04 MST") },
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Group}} uptime report {{.Month}}</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+td.number { text-align: right; }
+</style>
+</head>
+<body>
+<h1>{{.Group}} uptime report {{.Month}}</h1>
+<p>Availability {{percent .Availability}}, downtime {{duration .Downtime}}. Times are in {{.TimeZone}}.</p>
+<table>
+<tr><th>Check</th><th>Availability</th><th>Downtime</th><th>Average response</th><th>Outages</th></tr>
+{{- range .Checks}}
+<tr><td>{{.CheckName}}</td><td class="number">{{percent .Availability}}</td><td class="number">{{duration .Downtime}}</td><td class="number">{{.AvgResponse}} ms</td><td class="number">{{len .Outages}}</td></tr>
+{{- end}}
+</table>
+{{- range .Checks}}{{if .Outages}}
+<h2>{{.CheckName}} outages</h2>
+<table>
+<tr><th>Start</th><th>End</th><th>Duration</th></tr>
+{{- range .Outages}}
+<tr><td>{{datetime .Start}}</td><td>{{datetime .End}}</td><td class="number">{{duration .Duration}}</td></tr>
+{{- end}}
+</table>
+{{- end}}{{end}}
+</body>
+</html>
+`))
+
+// WriteHTML writes the report as a standalone HTML page.
+func (r *MonthlyReport) WriteHTML(w io.Writer) error {
+	return reportTemplate.Execute(w, r)
+}
+
+// WritePDF writes the report as a PDF document rendered from its HTML page.
+func (r *MonthlyReport) WritePDF(w io.Writer, renderer PDFRenderer) error {
+	var html bytes.Buffer
+	if err := r.WriteHTML(&html); err != nil {
+		return err
+	}
+	return renderer.RenderPDF(w, &html)
+}
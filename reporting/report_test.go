@@ -0,0 +1,130 @@
+package reporting
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeReportSource struct {
+	*fakePerformanceSource
+	*fakeOutageSource
+}
+
+type fakePDFRenderer struct{}
+
+func (fakePDFRenderer) RenderPDF(w io.Writer, html io.Reader) error {
+	page, err := ioutil.ReadAll(html)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append([]byte("%PDF "), page[:15]...))
+	return err
+}
+
+func TestBuildMonthlyReport(t *testing.T) {
+	source := fakeReportSource{
+		&fakePerformanceSource{down: map[int]bool{unix(2021, 4, 10, 0, 0): true}},
+		&fakeOutageSource{states: map[int][]pingdom.SummaryOutageState{
+			1: {
+				{Status: "up", TimeFrom: unix(2021, 4, 1, 0, 0), TimeTo: unix(2021, 4, 10, 0, 0)},
+				{Status: "down", TimeFrom: unix(2021, 4, 10, 0, 0), TimeTo: unix(2021, 4, 10, 12, 0)},
+			},
+		}},
+	}
+	checks := []pingdom.CheckResponse{{ID: 1, Name: "API"}, {ID: 2, Name: "Web & <CDN>"}}
+	report, err := BuildMonthlyReport(context.Background(), source, "web", checks, time.Date(2021, 4, 16, 8, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, "web", report.Group)
+	assert.Equal(t, "2021-04", report.Month)
+	assert.Equal(t, "UTC", report.TimeZone)
+	if assert.Len(t, report.Checks, 2) {
+		api := report.Checks[0]
+		assert.Equal(t, 12*time.Hour, api.Downtime)
+		assert.Equal(t, 29*24*time.Hour+12*time.Hour, api.Uptime)
+		assert.Equal(t, 200, api.AvgResponse)
+		assert.InDelta(t, 100*(1-12.0/720), api.Availability(), 1e-9)
+		assert.Equal(t, []Outage{{
+			CheckID:   1,
+			CheckName: "API",
+			Start:     time.Date(2021, 4, 10, 0, 0, 0, 0, time.UTC),
+			End:       time.Date(2021, 4, 10, 12, 0, 0, 0, time.UTC),
+			Duration:  12 * time.Hour,
+		}}, api.Outages)
+		// The fake performance source reports the same downtime for every check.
+		assert.Equal(t, 12*time.Hour, report.Checks[1].Downtime)
+		assert.Empty(t, report.Checks[1].Outages)
+	}
+	assert.Equal(t, 24*time.Hour, report.Downtime())
+	assert.InDelta(t, 100*(1-12.0/720), report.Availability(), 1e-9)
+	assert.Equal(t, pingdom.SummaryPerformanceRequest{
+		Id: 1, From: unix(2021, 4, 1, 0, 0), To: unix(2021, 5, 1, 0, 0), Resolution: "day", IncludeUptime: true, Order: "asc",
+	}, source.fakePerformanceSource.requests[0])
+
+	var html bytes.Buffer
+	assert.NoError(t, report.WriteHTML(&html))
+	assert.Contains(t, html.String(), "<title>web uptime report 2021-04</title>")
+	assert.Contains(t, html.String(), "Times are in UTC.")
+	assert.Contains(t, html.String(), "<td>API</td><td class=\"number\">98.333%</td><td class=\"number\">12h0m0s</td><td class=\"number\">200 ms</td><td class=\"number\">1</td>")
+	assert.Contains(t, html.String(), "<td>Web &amp; &lt;CDN&gt;</td>")
+	assert.Contains(t, html.String(), "<tr><td>2021-04-10 00:00 UTC</td><td>2021-04-10 12:00 UTC</td><td class=\"number\">12h0m0s</td></tr>")
+	assert.NotContains(t, html.String(), "CDN&gt; outages")
+
+	var pdf bytes.Buffer
+	assert.NoError(t, report.WritePDF(&pdf, fakePDFRenderer{}))
+	assert.Equal(t, "%PDF <!DOCTYPE html>", pdf.String())
+
+	source.fakeOutageSource.err = errors.New("boom")
+	_, err = BuildMonthlyReport(context.Background(), source, "web", checks, time.Now())
+	assert.EqualError(t, err, "boom")
+}
+
+func TestBuildMonthlyReportIn(t *testing.T) {
+	source := fakeReportSource{
+		&fakePerformanceSource{},
+		&fakeOutageSource{states: map[int][]pingdom.SummaryOutageState{
+			1: {
+				{Status: "down", TimeFrom: unix(2021, 4, 10, 0, 0), TimeTo: unix(2021, 4, 10, 12, 0)},
+			},
+		}},
+	}
+	loc, err := time.LoadLocation("Europe/Stockholm")
+	assert.NoError(t, err)
+	// Midnight of the 1st of May in Stockholm is still April in UTC.
+	report, err := BuildMonthlyReportIn(context.Background(), source, "web", []pingdom.CheckResponse{{ID: 1, Name: "API"}}, time.Date(2021, 4, 30, 22, 30, 0, 0, time.UTC), loc)
+	assert.NoError(t, err)
+	assert.Equal(t, "2021-05", report.Month)
+	assert.Equal(t, "Europe/Stockholm", report.TimeZone)
+	assert.Equal(t, pingdom.SummaryOutageRequest{
+		Id: 1, From: unix(2021, 4, 30, 22, 0), To: unix(2021, 5, 31, 22, 0), Order: "asc",
+	}, source.fakeOutageSource.requests[0])
+
+	report, err = BuildMonthlyReportIn(context.Background(), source, "web", []pingdom.CheckResponse{{ID: 1, Name: "API"}}, time.Date(2021, 4, 16, 0, 0, 0, 0, loc), loc)
+	assert.NoError(t, err)
+	if assert.Len(t, report.Checks[0].Outages, 1) {
+		assert.Equal(t, loc, report.Checks[0].Outages[0].Start.Location())
+	}
+	var html bytes.Buffer
+	assert.NoError(t, report.WriteHTML(&html))
+	assert.Contains(t, html.String(), "Times are in Europe/Stockholm.")
+	assert.Contains(t, html.String(), "<tr><td>2021-04-10 02:00 CEST</td><td>2021-04-10 14:00 CEST</td>")
+}
+
+func TestGroupChecks(t *testing.T) {
+	checks := []pingdom.CheckResponse{
+		{ID: 1, Tags: []pingdom.CheckResponseTag{{Name: "web"}, {Name: "eu"}}, Teams: []pingdom.CheckTeamResponse{{ID: 7, Name: "SRE"}}},
+		{ID: 2, Tags: []pingdom.CheckResponseTag{{Name: "web"}}},
+		{ID: 3},
+	}
+	byTag := GroupByTag(checks)
+	assert.Equal(t, []string{"eu", "web"}, GroupNames(byTag))
+	assert.Equal(t, []pingdom.CheckResponse{checks[0], checks[1]}, byTag["web"])
+	assert.Equal(t, map[string][]pingdom.CheckResponse{"SRE": {checks[0]}}, GroupByTeam(checks))
+}
@@ -0,0 +1,249 @@
+package reporting
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+)
+
+// PerformanceSource provides the performance summaries of checks, it is
+// implemented by pingdom.CheckService.
+type PerformanceSource interface {
+	SummaryPerformance(ctx context.Context, request pingdom.SummaryPerformanceRequest) (*pingdom.SummaryPerformanceResponse, error)
+}
+
+// UptimeTier is a downsampled uptime series kept by UptimeCache: the points
+// at Resolution ("hour", "day" or "week") of the last Retention.
+type UptimeTier struct {
+	Resolution string
+	Retention  time.Duration
+}
+
+// DefaultUptimeTiers keeps hourly values for a week, daily values for 13
+// months and weekly values for 3 years.
+var DefaultUptimeTiers = []UptimeTier{
+	{Resolution: "hour", Retention: 7 * 24 * time.Hour},
+	{Resolution: "day", Retention: 396 * 24 * time.Hour},
+	{Resolution: "week", Retention: 3 * 365 * 24 * time.Hour},
+}
+
+// resolutions maps the resolutions of the summary.performance endpoint to the
+// length of their buckets and to the longest period fetched in one request.
+var resolutions = map[string]struct {
+	bucket time.Duration
+	window time.Duration
+}{
+	"hour": {bucket: time.Hour, window: 7 * 24 * time.Hour},
+	"day":  {bucket: 24 * time.Hour, window: 365 * 24 * time.Hour},
+	"week": {bucket: 7 * 24 * time.Hour, window: 5 * 365 * 24 * time.Hour},
+}
+
+// UptimePoint is the uptime of a check during one bucket of a series.
+type UptimePoint struct {
+	Start       time.Time
+	Uptime      time.Duration
+	Downtime    time.Duration
+	Unmonitored time.Duration
+	AvgResponse int
+}
+
+// Availability returns the ratio of monitored time the check was up, or 1
+// when it was not monitored at all.
+func (p UptimePoint) Availability() float64 {
+	monitored := p.Uptime + p.Downtime
+	if monitored == 0 {
+		return 1
+	}
+	return float64(p.Uptime) / float64(monitored)
+}
+
+// UptimeCache keeps downsampled uptime series of checks so that long range
+// charts do not need heavy summary calls each time. Update only fetches the
+// buckets since the previous update, the last bucket of each tier being
+// fetched again as it may have been incomplete.
+//
+// An UptimeCache is safe for concurrent use.
+type UptimeCache struct {
+	Source PerformanceSource
+	Tiers  []UptimeTier     // Defaults to DefaultUptimeTiers
+	Now    func() time.Time // Defaults to time.Now
+
+	mu     sync.RWMutex
+	series map[int]map[string][]UptimePoint
+}
+
+// Update brings the series of the check up to date.
+func (c *UptimeCache) Update(ctx context.Context, checkID int) error {
+	now := c.now()
+	for _, tier := range c.tiers() {
+		res, ok := resolutions[tier.Resolution]
+		if !ok {
+			return fmt.Errorf("invalid resolution %q", tier.Resolution)
+		}
+		oldest := now.Add(-tier.Retention)
+
+		c.mu.RLock()
+		points := c.series[checkID][tier.Resolution]
+		c.mu.RUnlock()
+
+		from := oldest
+		if len(points) > 0 {
+			from = points[len(points)-1].Start
+		}
+		var fetched []UptimePoint
+		for start := from; start.Before(now); start = start.Add(res.window) {
+			end := start.Add(res.window)
+			if end.After(now) {
+				end = now
+			}
+			chunk, err := c.fetch(ctx, checkID, tier.Resolution, start, end)
+			if err != nil {
+				return err
+			}
+			fetched = append(fetched, chunk...)
+		}
+
+		c.mu.Lock()
+		if c.series == nil {
+			c.series = map[int]map[string][]UptimePoint{}
+		}
+		if c.series[checkID] == nil {
+			c.series[checkID] = map[string][]UptimePoint{}
+		}
+		c.series[checkID][tier.Resolution] = mergePoints(c.series[checkID][tier.Resolution], fetched, oldest.Add(-res.bucket))
+		c.mu.Unlock()
+	}
+	return nil
+}
+
+// UpdateAll updates the series of all the given checks, stopping at the first
+// error.
+func (c *UptimeCache) UpdateAll(ctx context.Context, checkIDs []int) error {
+	for _, id := range checkIDs {
+		if err := c.Update(ctx, id); err != nil {
+			return fmt.Errorf("check %d: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// Series returns the cached points of the check at the given resolution
+// which start between from and to.
+func (c *UptimeCache) Series(checkID int, resolution string, from, to time.Time) []UptimePoint {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	points := []UptimePoint{}
+	for _, p := range c.series[checkID][resolution] {
+		if !p.Start.Before(from) && p.Start.Before(to) {
+			points = append(points, p)
+		}
+	}
+	return points
+}
+
+// Availability returns the availability of the check between from and to,
+// computed from the finest tier whose retention covers the period, or from
+// the tier with the longest retention when none does.
+func (c *UptimeCache) Availability(checkID int, from, to time.Time) float64 {
+	age := c.now().Sub(from)
+	var finest, longest *UptimeTier
+	for i := range c.tiers() {
+		tier := &c.tiers()[i]
+		if longest == nil || tier.Retention > longest.Retention {
+			longest = tier
+		}
+		if tier.Retention >= age && (finest == nil || resolutions[tier.Resolution].bucket < resolutions[finest.Resolution].bucket) {
+			finest = tier
+		}
+	}
+	if finest == nil {
+		finest = longest
+	}
+
+	var total UptimePoint
+	for _, p := range c.Series(checkID, finest.Resolution, from, to) {
+		total.Uptime += p.Uptime
+		total.Downtime += p.Downtime
+	}
+	return total.Availability()
+}
+
+func (c *UptimeCache) fetch(ctx context.Context, checkID int, resolution string, from, to time.Time) ([]UptimePoint, error) {
+	return fetchUptime(ctx, c.Source, checkID, resolution, from, to)
+}
+
+// fetchUptime returns the uptime series of the check between from and to at
+// the given resolution.
+func fetchUptime(ctx context.Context, source PerformanceSource, checkID int, resolution string, from, to time.Time) ([]UptimePoint, error) {
+	resp, err := source.SummaryPerformance(ctx, pingdom.SummaryPerformanceRequest{
+		Id:            checkID,
+		From:          int(from.Unix()),
+		To:            int(to.Unix()),
+		Resolution:    resolution,
+		IncludeUptime: true,
+		Order:         "asc",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var summaries []pingdom.SummaryPerformanceSummary
+	switch resolution {
+	case "hour":
+		summaries = resp.Summary.Hours
+	case "day":
+		summaries = resp.Summary.Days
+	case "week":
+		summaries = resp.Summary.Weeks
+	}
+	points := make([]UptimePoint, 0, len(summaries))
+	for _, s := range summaries {
+		points = append(points, UptimePoint{
+			Start:       time.Unix(int64(s.StartTime), 0).UTC(),
+			Uptime:      time.Duration(s.Uptime) * time.Second,
+			Downtime:    time.Duration(s.Downtime) * time.Second,
+			Unmonitored: time.Duration(s.Unmonitored) * time.Second,
+			AvgResponse: s.AvgResponse,
+		})
+	}
+	return points, nil
+}
+
+func (c *UptimeCache) tiers() []UptimeTier {
+	if len(c.Tiers) == 0 {
+		return DefaultUptimeTiers
+	}
+	return c.Tiers
+}
+
+func (c *UptimeCache) now() time.Time {
+	if c.Now == nil {
+		return time.Now()
+	}
+	return c.Now()
+}
+
+// mergePoints replaces the points of series by the fetched ones starting at
+// the same time, and drops the points starting before oldest.
+func mergePoints(series, fetched []UptimePoint, oldest time.Time) []UptimePoint {
+	byStart := make(map[int64]UptimePoint, len(series)+len(fetched))
+	for _, p := range series {
+		byStart[p.Start.Unix()] = p
+	}
+	for _, p := range fetched {
+		byStart[p.Start.Unix()] = p
+	}
+	merged := make([]UptimePoint, 0, len(byStart))
+	for _, p := range byStart {
+		if p.Start.After(oldest) {
+			merged = append(merged, p)
+		}
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Start.Before(merged[j].Start) })
+	return merged
+}
@@ -0,0 +1,112 @@
+package reporting
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakePerformanceSource returns one bucket per day, up for the whole day
+// except on the days listed in down.
+type fakePerformanceSource struct {
+	down     map[int]bool
+	requests []pingdom.SummaryPerformanceRequest
+	err      error
+}
+
+func (f *fakePerformanceSource) SummaryPerformance(ctx context.Context, request pingdom.SummaryPerformanceRequest) (*pingdom.SummaryPerformanceResponse, error) {
+	f.requests = append(f.requests, request)
+	if f.err != nil {
+		return nil, f.err
+	}
+	resp := &pingdom.SummaryPerformanceResponse{}
+	day := 24 * 60 * 60
+	for start := request.From - request.From%day; start < request.To; start += day {
+		summary := pingdom.SummaryPerformanceSummary{StartTime: start, Uptime: day, AvgResponse: 200}
+		if f.down[start] {
+			summary.Uptime, summary.Downtime = day/2, day/2
+		}
+		resp.Summary.Days = append(resp.Summary.Days, summary)
+	}
+	return resp, nil
+}
+
+func TestUptimeCache(t *testing.T) {
+	now := time.Date(2021, 3, 10, 12, 0, 0, 0, time.UTC)
+	source := &fakePerformanceSource{down: map[int]bool{unix(2021, 3, 8, 0, 0): true}}
+	cache := &UptimeCache{
+		Source: source,
+		Tiers:  []UptimeTier{{Resolution: "day", Retention: 400 * 24 * time.Hour}},
+		Now:    func() time.Time { return now },
+	}
+
+	assert.NoError(t, cache.Update(context.Background(), 1))
+	// The 400 days are fetched in two requests of at most a year.
+	if assert.Len(t, source.requests, 2) {
+		assert.Equal(t, pingdom.SummaryPerformanceRequest{
+			Id:            1,
+			From:          int(now.Add(-400 * 24 * time.Hour).Unix()),
+			To:            int(now.Add(-35 * 24 * time.Hour).Unix()),
+			Resolution:    "day",
+			IncludeUptime: true,
+			Order:         "asc",
+		}, source.requests[0])
+		assert.Equal(t, int(now.Unix()), source.requests[1].To)
+	}
+
+	series := cache.Series(1, "day", now.AddDate(0, 0, -3), now)
+	if assert.Len(t, series, 3) {
+		assert.Equal(t, time.Date(2021, 3, 8, 0, 0, 0, 0, time.UTC), series[0].Start)
+		assert.Equal(t, 12*time.Hour, series[0].Downtime)
+		assert.Equal(t, 0.5, series[0].Availability())
+		assert.Equal(t, 1.0, series[2].Availability())
+	}
+	assert.InDelta(t, 5.0/6, cache.Availability(1, time.Date(2021, 3, 8, 0, 0, 0, 0, time.UTC), now), 0.0001)
+
+	// The next update only fetches since the last, possibly incomplete, bucket.
+	now = now.Add(48 * time.Hour)
+	assert.NoError(t, cache.Update(context.Background(), 1))
+	if assert.Len(t, source.requests, 3) {
+		assert.Equal(t, unix(2021, 3, 10, 0, 0), source.requests[2].From)
+		assert.Equal(t, int(now.Unix()), source.requests[2].To)
+	}
+	assert.Len(t, cache.Series(1, "day", now.AddDate(0, 0, -3), now), 3)
+
+	// Buckets beyond the retention are dropped.
+	all := cache.Series(1, "day", time.Time{}, now)
+	assert.True(t, all[0].Start.After(now.Add(-401*24*time.Hour)))
+
+	assert.Empty(t, cache.Series(2, "day", time.Time{}, now))
+}
+
+func TestUptimeCacheAvailabilityTier(t *testing.T) {
+	now := time.Date(2021, 3, 10, 0, 0, 0, 0, time.UTC)
+	cache := &UptimeCache{
+		Tiers: []UptimeTier{
+			{Resolution: "week", Retention: 100 * 24 * time.Hour},
+			{Resolution: "day", Retention: 10 * 24 * time.Hour},
+		},
+		Now: func() time.Time { return now },
+		series: map[int]map[string][]UptimePoint{1: {
+			"day":  {{Start: now.AddDate(0, 0, -1), Uptime: time.Hour, Downtime: time.Hour}},
+			"week": {{Start: now.AddDate(0, 0, -30), Uptime: time.Hour, Downtime: 3 * time.Hour}},
+		}},
+	}
+
+	assert.Equal(t, 0.5, cache.Availability(1, now.AddDate(0, 0, -2), now))
+	assert.Equal(t, 0.25, cache.Availability(1, now.AddDate(0, 0, -50), now))
+	assert.Equal(t, 0.25, cache.Availability(1, now.AddDate(-1, 0, 0), now))
+	assert.Equal(t, 1.0, cache.Availability(2, now.AddDate(0, 0, -2), now))
+}
+
+func TestUptimeCacheErrors(t *testing.T) {
+	cache := &UptimeCache{Source: &fakePerformanceSource{err: errors.New("boom")}}
+	assert.EqualError(t, cache.UpdateAll(context.Background(), []int{1, 2}), "check 1: boom")
+
+	cache = &UptimeCache{Source: &fakePerformanceSource{}, Tiers: []UptimeTier{{Resolution: "month"}}}
+	assert.EqualError(t, cache.Update(context.Background(), 1), `invalid resolution "month"`)
+}
@@ -0,0 +1,285 @@
+// Package routing simulates the alerting of a check: it reports who would be
+// notified if the check went down, through which channel and at which
+// severity, answering the question "who gets paged if this goes down?".
+//
+// Pingdom alerts the contacts of a check directly and through its alerting
+// teams.  Each notification target of a contact (email address, phone number,
+// mobile app) has a severity, and only receives the alerts of checks of the
+// same severity level.  Paused contacts receive nothing.
+package routing
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/nordcloud/go-pingdom/pingdomext"
+)
+
+const defaultSeverity = "HIGH"
+
+// Channels through which a notification is sent.
+const (
+	ChannelEmail       = "email"
+	ChannelSMS         = "sms"
+	ChannelAPNS        = "apns"
+	ChannelAGCM        = "agcm"
+	ChannelIntegration = "integration"
+)
+
+// ContactLister lists Pingdom contacts.  It is implemented by
+// *pingdom.ContactService.
+type ContactLister interface {
+	List(ctx context.Context) ([]pingdom.Contact, error)
+}
+
+// TeamLister lists Pingdom alerting teams.  It is implemented by
+// *pingdom.TeamService.
+type TeamLister interface {
+	List(ctx context.Context) ([]pingdom.TeamResponse, error)
+}
+
+// IntegrationLister lists Pingdom integrations.  It is implemented by
+// *pingdomext.IntegrationService.
+type IntegrationLister interface {
+	List(ctx context.Context) ([]pingdomext.IntegrationGetResponse, error)
+}
+
+// Directory holds the contacts, teams and integrations of an account, which
+// alerts are routed to.
+type Directory struct {
+	Contacts     []pingdom.Contact
+	Teams        []pingdom.TeamResponse
+	Integrations []pingdomext.IntegrationGetResponse
+}
+
+// Load lists the contacts, teams and integrations of an account.  The
+// integrations are only listed when an IntegrationLister is given, as they
+// are managed through the extension client.
+func Load(ctx context.Context, contacts ContactLister, teams TeamLister, integrations IntegrationLister) (*Directory, error) {
+	d := &Directory{}
+	var err error
+	if d.Contacts, err = contacts.List(ctx); err != nil {
+		return nil, fmt.Errorf("listing contacts: %w", err)
+	}
+	if d.Teams, err = teams.List(ctx); err != nil {
+		return nil, fmt.Errorf("listing teams: %w", err)
+	}
+	if integrations != nil {
+		if d.Integrations, err = integrations.List(ctx); err != nil {
+			return nil, fmt.Errorf("listing integrations: %w", err)
+		}
+	}
+	return d, nil
+}
+
+// Notification is an alert sent to a single target.
+type Notification struct {
+	// Contact receiving the notification, unset for integrations.
+	ContactID   int
+	ContactName string
+
+	// Direct is set when the contact is alerted by the check itself, TeamIDs
+	// lists the alerting teams of the check the contact is a member of.
+	Direct  bool
+	TeamIDs []int
+
+	// IntegrationID and IntegrationName are only set for integrations.
+	IntegrationID   int
+	IntegrationName string
+
+	Channel  string
+	Target   string // e.g. the email address or phone number
+	Severity string
+}
+
+// Skip is a contact, target or integration referenced by the check which
+// would not be notified.
+type Skip struct {
+	Name   string
+	Reason string
+}
+
+// Simulation is the outcome of the alerting of a check.
+type Simulation struct {
+	CheckID   int
+	CheckName string
+	Severity  string
+
+	Notifications []Notification
+	Skipped       []Skip
+
+	// AlertAfter is the time the check needs to be down before the first
+	// alert, RepeatEvery the interval of the following ones while it stays
+	// down (0 for none), and NotifyWhenBackUp whether the recipients are
+	// notified once it is up again.
+	AlertAfter       time.Duration
+	RepeatEvery      time.Duration
+	NotifyWhenBackUp bool
+}
+
+// Contacts returns the names of the contacts which would be notified, sorted
+// and without duplicates.
+func (s *Simulation) Contacts() []string {
+	seen := map[string]bool{}
+	names := []string{}
+	for _, n := range s.Notifications {
+		if n.ContactID != 0 && !seen[n.ContactName] {
+			seen[n.ContactName] = true
+			names = append(names, n.ContactName)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Simulate reports who would be notified if the check went down.  The check
+// should be read with CheckService.Read, as listed checks do not include
+// their alerting settings.
+func Simulate(check *pingdom.CheckResponse, d *Directory) *Simulation {
+	s := &Simulation{
+		CheckID:          check.ID,
+		CheckName:        check.Name,
+		Severity:         strings.ToUpper(check.SeverityLevel),
+		NotifyWhenBackUp: check.NotifyWhenBackup,
+	}
+	if s.Severity == "" {
+		s.Severity = defaultSeverity
+	}
+	resolution := time.Duration(check.Resolution) * time.Minute
+	failures := check.SendNotificationWhenDown
+	if failures < 1 {
+		failures = 1
+	}
+	s.AlertAfter = time.Duration(failures) * resolution
+	s.RepeatEvery = time.Duration(check.NotifyAgainEvery) * resolution
+
+	// Whom the check alerts, and through which of its teams.
+	direct := map[int]bool{}
+	viaTeams := map[int][]int{}
+	var order []int
+	reach := func(id int) {
+		if !direct[id] && viaTeams[id] == nil {
+			order = append(order, id)
+		}
+	}
+	for _, id := range check.UserIds {
+		reach(id)
+		direct[id] = true
+	}
+	teams := map[int]pingdom.TeamResponse{}
+	for _, team := range d.Teams {
+		teams[team.ID] = team
+	}
+	for _, id := range teamIDs(check) {
+		team, ok := teams[id]
+		if !ok {
+			s.Skipped = append(s.Skipped, Skip{Name: fmt.Sprintf("team %d", id), Reason: "unknown team"})
+			continue
+		}
+		if len(team.Members) == 0 {
+			s.Skipped = append(s.Skipped, Skip{Name: team.Name, Reason: "team has no members"})
+		}
+		for _, member := range team.Members {
+			reach(member.ID)
+			viaTeams[member.ID] = append(viaTeams[member.ID], team.ID)
+		}
+	}
+
+	contacts := map[int]pingdom.Contact{}
+	for _, contact := range d.Contacts {
+		contacts[contact.ID] = contact
+	}
+	for _, id := range order {
+		contact, ok := contacts[id]
+		if !ok {
+			s.Skipped = append(s.Skipped, Skip{Name: fmt.Sprintf("contact %d", id), Reason: "unknown contact"})
+			continue
+		}
+		if contact.Paused {
+			s.Skipped = append(s.Skipped, Skip{Name: contact.Name, Reason: "contact is paused"})
+			continue
+		}
+		ts := targets(contact.NotificationTargets)
+		if len(ts) == 0 {
+			s.Skipped = append(s.Skipped, Skip{Name: contact.Name, Reason: "contact has no notification targets"})
+			continue
+		}
+		for _, target := range ts {
+			if !strings.EqualFold(target.Severity, s.Severity) {
+				s.Skipped = append(s.Skipped, Skip{
+					Name:   contact.Name + " " + target.Channel + " " + target.Target,
+					Reason: fmt.Sprintf("target severity is %s, the check alerts at %s", strings.ToUpper(target.Severity), s.Severity),
+				})
+				continue
+			}
+			target.ContactID = contact.ID
+			target.ContactName = contact.Name
+			target.Direct = direct[id]
+			target.TeamIDs = viaTeams[id]
+			s.Notifications = append(s.Notifications, target)
+		}
+	}
+
+	integrations := map[int]pingdomext.IntegrationGetResponse{}
+	for _, integration := range d.Integrations {
+		integrations[integration.ID] = integration
+	}
+	for _, id := range check.IntegrationIds {
+		integration, ok := integrations[id]
+		switch {
+		case !ok:
+			s.Skipped = append(s.Skipped, Skip{Name: fmt.Sprintf("integration %d", id), Reason: "unknown integration"})
+		case integration.ActivatedAt == 0:
+			s.Skipped = append(s.Skipped, Skip{Name: integration.Name, Reason: "integration is not active"})
+		default:
+			s.Notifications = append(s.Notifications, Notification{
+				IntegrationID:   integration.ID,
+				IntegrationName: integration.Name,
+				Channel:         ChannelIntegration,
+				Target:          integration.UserData["url"],
+				Severity:        s.Severity,
+			})
+		}
+	}
+	return s
+}
+
+// teamIDs returns the alerting teams of the check, which Read backfills in
+// TeamIds.
+func teamIDs(check *pingdom.CheckResponse) []int {
+	if len(check.TeamIds) > 0 {
+		return check.TeamIds
+	}
+	ids := make([]int, len(check.Teams))
+	for i, team := range check.Teams {
+		ids[i] = team.ID
+	}
+	return ids
+}
+
+// targets returns the notification targets of a contact, with their channel,
+// target and severity set.
+func targets(t pingdom.NotificationTargets) []Notification {
+	var notifications []Notification
+	for _, email := range t.Email {
+		notifications = append(notifications, Notification{Channel: ChannelEmail, Target: email.Address, Severity: email.Severity})
+	}
+	for _, sms := range t.SMS {
+		number := sms.Number
+		if sms.CountryCode != "" {
+			number = "+" + sms.CountryCode + " " + sms.Number
+		}
+		notifications = append(notifications, Notification{Channel: ChannelSMS, Target: number, Severity: sms.Severity})
+	}
+	for _, apns := range t.APNS {
+		notifications = append(notifications, Notification{Channel: ChannelAPNS, Target: apns.Name, Severity: apns.Severity})
+	}
+	for _, agcm := range t.AGCM {
+		notifications = append(notifications, Notification{Channel: ChannelAGCM, Target: agcm.AGCMID, Severity: agcm.Severity})
+	}
+	return notifications
+}
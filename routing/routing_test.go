@@ -0,0 +1,142 @@
+package routing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/nordcloud/go-pingdom/pingdomext"
+	"github.com/stretchr/testify/assert"
+)
+
+var directory = &Directory{
+	Contacts: []pingdom.Contact{
+		{
+			ID:   1,
+			Name: "Alice",
+			NotificationTargets: pingdom.NotificationTargets{
+				Email: []pingdom.EmailNotification{{Address: "alice@example.com", Severity: "HIGH"}},
+				SMS:   []pingdom.SMSNotification{{CountryCode: "46", Number: "701234567", Severity: "HIGH"}},
+			},
+		},
+		{
+			ID:   2,
+			Name: "Bob",
+			NotificationTargets: pingdom.NotificationTargets{
+				Email: []pingdom.EmailNotification{{Address: "bob@example.com", Severity: "LOW"}},
+				APNS:  []pingdom.APNSNotification{{Device: "abc", Name: "Bob's phone", Severity: "HIGH"}},
+			},
+		},
+		{
+			ID:     3,
+			Name:   "Carol",
+			Paused: true,
+			NotificationTargets: pingdom.NotificationTargets{
+				Email: []pingdom.EmailNotification{{Address: "carol@example.com", Severity: "HIGH"}},
+			},
+		},
+		{ID: 4, Name: "Dave"},
+	},
+	Teams: []pingdom.TeamResponse{
+		{ID: 10, Name: "Ops", Members: []pingdom.TeamMemberResponse{{ID: 1}, {ID: 2}, {ID: 3}}},
+		{ID: 11, Name: "Empty"},
+	},
+	Integrations: []pingdomext.IntegrationGetResponse{
+		{ID: 20, Name: "Slack", ActivatedAt: 1615819798, UserData: map[string]string{"url": "https://hooks.slack.com/services"}},
+		{ID: 21, Name: "Old webhook"},
+	},
+}
+
+func TestSimulate(t *testing.T) {
+	check := &pingdom.CheckResponse{
+		ID:                       100,
+		Name:                     "API",
+		Resolution:               5,
+		SendNotificationWhenDown: 2,
+		NotifyAgainEvery:         3,
+		NotifyWhenBackup:         true,
+		UserIds:                  []int{1, 4, 99},
+		TeamIds:                  []int{10, 11, 12},
+		IntegrationIds:           []int{20, 21, 22},
+	}
+	s := Simulate(check, directory)
+
+	assert.Equal(t, "HIGH", s.Severity)
+	assert.Equal(t, 10*time.Minute, s.AlertAfter)
+	assert.Equal(t, 15*time.Minute, s.RepeatEvery)
+	assert.True(t, s.NotifyWhenBackUp)
+	assert.Equal(t, []Notification{
+		{ContactID: 1, ContactName: "Alice", Direct: true, TeamIDs: []int{10}, Channel: ChannelEmail, Target: "alice@example.com", Severity: "HIGH"},
+		{ContactID: 1, ContactName: "Alice", Direct: true, TeamIDs: []int{10}, Channel: ChannelSMS, Target: "+46 701234567", Severity: "HIGH"},
+		{ContactID: 2, ContactName: "Bob", TeamIDs: []int{10}, Channel: ChannelAPNS, Target: "Bob's phone", Severity: "HIGH"},
+		{IntegrationID: 20, IntegrationName: "Slack", Channel: ChannelIntegration, Target: "https://hooks.slack.com/services", Severity: "HIGH"},
+	}, s.Notifications)
+	assert.Equal(t, []Skip{
+		{Name: "Empty", Reason: "team has no members"},
+		{Name: "team 12", Reason: "unknown team"},
+		{Name: "Dave", Reason: "contact has no notification targets"},
+		{Name: "contact 99", Reason: "unknown contact"},
+		{Name: "Bob email bob@example.com", Reason: "target severity is LOW, the check alerts at HIGH"},
+		{Name: "Carol", Reason: "contact is paused"},
+		{Name: "Old webhook", Reason: "integration is not active"},
+		{Name: "integration 22", Reason: "unknown integration"},
+	}, s.Skipped)
+	assert.Equal(t, []string{"Alice", "Bob"}, s.Contacts())
+}
+
+func TestSimulateLowSeverity(t *testing.T) {
+	check := &pingdom.CheckResponse{
+		ID:            100,
+		Resolution:    1,
+		SeverityLevel: "low",
+		Teams:         []pingdom.CheckTeamResponse{{ID: 10}},
+	}
+	s := Simulate(check, directory)
+
+	assert.Equal(t, "LOW", s.Severity)
+	assert.Equal(t, time.Minute, s.AlertAfter)
+	assert.Equal(t, time.Duration(0), s.RepeatEvery)
+	assert.Equal(t, []Notification{
+		{ContactID: 2, ContactName: "Bob", TeamIDs: []int{10}, Channel: ChannelEmail, Target: "bob@example.com", Severity: "LOW"},
+	}, s.Notifications)
+	assert.Equal(t, []string{"Bob"}, s.Contacts())
+}
+
+type fakeLister struct {
+	contacts     []pingdom.Contact
+	teams        []pingdom.TeamResponse
+	integrations []pingdomext.IntegrationGetResponse
+	err          error
+}
+
+type fakeContacts struct{ *fakeLister }
+
+func (f fakeContacts) List(ctx context.Context) ([]pingdom.Contact, error) { return f.contacts, nil }
+
+type fakeTeams struct{ *fakeLister }
+
+func (f fakeTeams) List(ctx context.Context) ([]pingdom.TeamResponse, error) { return f.teams, nil }
+
+type fakeIntegrations struct{ *fakeLister }
+
+func (f fakeIntegrations) List(ctx context.Context) ([]pingdomext.IntegrationGetResponse, error) {
+	return f.integrations, f.err
+}
+
+func TestLoad(t *testing.T) {
+	f := &fakeLister{contacts: directory.Contacts, teams: directory.Teams, integrations: directory.Integrations}
+
+	d, err := Load(context.Background(), fakeContacts{f}, fakeTeams{f}, fakeIntegrations{f})
+	assert.NoError(t, err)
+	assert.Equal(t, directory, d)
+
+	d, err = Load(context.Background(), fakeContacts{f}, fakeTeams{f}, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, d.Integrations)
+
+	f.err = errors.New("boom")
+	_, err = Load(context.Background(), fakeContacts{f}, fakeTeams{f}, fakeIntegrations{f})
+	assert.EqualError(t, err, "listing integrations: boom")
+}
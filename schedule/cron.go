@@ -0,0 +1,221 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron is a parsed cron expression.
+type Cron struct {
+	expr    string
+	minutes bitset
+	hours   bitset
+	days    bitset // Days of the month
+	months  bitset
+	weekday bitset // 0 is Sunday
+
+	// Whether the day of the month and day of the week fields are
+	// restricted: when both are, a day matches if either does.
+	anyDay, anyWeekday bool
+}
+
+type bitset uint64
+
+func (b bitset) has(i int) bool {
+	return b&(1<<uint(i)) != 0
+}
+
+// single returns the only value of the set, if it has exactly one.
+func (b bitset) single() (int, bool) {
+	if b == 0 || b&(b-1) != 0 {
+		return 0, false
+	}
+	for i := 0; ; i++ {
+		if b.has(i) {
+			return i, true
+		}
+	}
+}
+
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	monthNames = map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}
+	weekdayNames = map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}
+
+	minuteField  = field{name: "minute", min: 0, max: 59}
+	hourField    = field{name: "hour", min: 0, max: 23}
+	dayField     = field{name: "day of month", min: 1, max: 31}
+	monthField   = field{name: "month", min: 1, max: 12, names: monthNames}
+	weekdayField = field{name: "day of week", min: 0, max: 7, names: weekdayNames}
+
+	macros = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+// ParseCron parses a standard five fields cron expression: minute, hour, day
+// of month, month and day of week, e.g. "30 2 * * sun" for 2:30 every
+// Sunday.  Fields accept "*", values, ranges ("1-5"), lists ("1,15") and
+// steps ("*/15", "0-30/10"); months and days of the week accept their three
+// letters English names, and Sunday is either 0 or 7.  The macros @yearly,
+// @monthly, @weekly, @daily and @hourly are accepted as well.
+func ParseCron(expr string) (*Cron, error) {
+	spec := strings.TrimSpace(expr)
+	if macro, ok := macros[strings.ToLower(spec)]; ok {
+		spec = macro
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields, got %d", expr, len(fields))
+	}
+
+	c := &Cron{expr: expr}
+	var err error
+	sets := []*bitset{&c.minutes, &c.hours, &c.days, &c.months, &c.weekday}
+	for i, f := range []field{minuteField, hourField, dayField, monthField, weekdayField} {
+		if *sets[i], err = f.parse(fields[i]); err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+	}
+	if c.weekday.has(7) {
+		c.weekday = c.weekday&^(1<<7) | 1
+	}
+	c.anyDay = fields[2] == "*" || strings.HasPrefix(fields[2], "*/")
+	c.anyWeekday = fields[4] == "*" || strings.HasPrefix(fields[4], "*/")
+	return c, nil
+}
+
+// MustParseCron is like ParseCron but panics if the expression is invalid.
+func MustParseCron(expr string) *Cron {
+	c, err := ParseCron(expr)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// String returns the expression the cron was parsed from.
+func (c *Cron) String() string {
+	return c.expr
+}
+
+func (f field) parse(s string) (bitset, error) {
+	var set bitset
+	for _, part := range strings.Split(s, ",") {
+		rng, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q in %s field", part[i+1:], f.name)
+			}
+			rng, step = part[:i], n
+		}
+
+		lo, hi := f.min, f.max
+		if rng != "*" {
+			bounds := strings.SplitN(rng, "-", 2)
+			var err error
+			if lo, err = f.value(bounds[0]); err != nil {
+				return 0, err
+			}
+			hi = lo
+			if len(bounds) == 2 {
+				if hi, err = f.value(bounds[1]); err != nil {
+					return 0, err
+				}
+			} else if step > 1 {
+				hi = f.max
+			}
+			if hi < lo {
+				return 0, fmt.Errorf("invalid range %q in %s field", rng, f.name)
+			}
+		}
+		for i := lo; i <= hi; i += step {
+			set |= 1 << uint(i)
+		}
+	}
+	return set, nil
+}
+
+func (f field) value(s string) (int, error) {
+	if n, ok := f.names[strings.ToLower(s)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < f.min || n > f.max {
+		return 0, fmt.Errorf("invalid value %q in %s field, must be between %d and %d", s, f.name, f.min, f.max)
+	}
+	return n, nil
+}
+
+// matchDay reports whether the cron runs on the day.
+func (c *Cron) matchDay(t time.Time) bool {
+	if !c.months.has(int(t.Month())) {
+		return false
+	}
+	day, weekday := c.days.has(t.Day()), c.weekday.has(int(t.Weekday()))
+	switch {
+	case c.anyDay && c.anyWeekday:
+		return true
+	case c.anyDay:
+		return weekday
+	case c.anyWeekday:
+		return day
+	}
+	return day || weekday
+}
+
+// Between returns the times the cron runs at in the location, from from
+// included to until excluded, up to max times unless max is negative.  The
+// second return value reports whether there are more.
+func (c *Cron) Between(from, until time.Time, loc *time.Location, max int) ([]time.Time, bool) {
+	var times []time.Time
+	from, until = from.In(loc), until.In(loc)
+	for day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc); day.Before(until); day = day.AddDate(0, 0, 1) {
+		if !c.matchDay(day) {
+			continue
+		}
+		for hour := 0; hour < 24; hour++ {
+			if !c.hours.has(hour) {
+				continue
+			}
+			for minute := 0; minute < 60; minute++ {
+				if !c.minutes.has(minute) {
+					continue
+				}
+				t := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, loc)
+				if t.Before(from) || !t.Before(until) {
+					continue
+				}
+				// A time skipped by a daylight saving change is moved
+				// forward and may repeat the next one.
+				if n := len(times); n > 0 && !t.After(times[n-1]) {
+					continue
+				}
+				if len(times) == max {
+					return times, true
+				}
+				times = append(times, t)
+			}
+		}
+	}
+	return times, false
+}
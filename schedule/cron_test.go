@@ -0,0 +1,107 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCron(t *testing.T) {
+	tests := []struct {
+		expr  string
+		from  time.Time
+		until time.Time
+		want  []string
+	}{
+		{
+			expr:  "30 2 * * sun",
+			from:  time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+			until: time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC),
+			want:  []string{"2021-03-07T02:30:00Z", "2021-03-14T02:30:00Z"},
+		},
+		{
+			expr:  "0 */8 1,15 * *",
+			from:  time.Date(2021, 3, 1, 4, 0, 0, 0, time.UTC),
+			until: time.Date(2021, 3, 16, 0, 0, 0, 0, time.UTC),
+			want:  []string{"2021-03-01T08:00:00Z", "2021-03-01T16:00:00Z", "2021-03-15T00:00:00Z", "2021-03-15T08:00:00Z", "2021-03-15T16:00:00Z"},
+		},
+		{
+			// Both day fields restricted: either matches.
+			expr:  "0 0 1 * 7",
+			from:  time.Date(2021, 2, 26, 0, 0, 0, 0, time.UTC),
+			until: time.Date(2021, 3, 8, 0, 0, 0, 0, time.UTC),
+			want:  []string{"2021-02-28T00:00:00Z", "2021-03-01T00:00:00Z", "2021-03-07T00:00:00Z"},
+		},
+		{
+			expr:  "15 22 * jan-feb mon-fri",
+			from:  time.Date(2021, 2, 25, 0, 0, 0, 0, time.UTC),
+			until: time.Date(2021, 3, 3, 0, 0, 0, 0, time.UTC),
+			want:  []string{"2021-02-25T22:15:00Z", "2021-02-26T22:15:00Z"},
+		},
+		{
+			expr:  "@monthly",
+			from:  time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			until: time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+			want:  []string{"2021-01-01T00:00:00Z", "2021-02-01T00:00:00Z"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			c, err := ParseCron(tt.expr)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expr, c.String())
+			times, more := c.Between(tt.from, tt.until, time.UTC, -1)
+			assert.False(t, more)
+			var got []string
+			for _, t := range times {
+				got = append(got, t.Format(time.RFC3339))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	tests := map[string]string{
+		"* * * *":      `invalid cron expression "* * * *": expected 5 fields, got 4`,
+		"60 * * * *":   `invalid cron expression "60 * * * *": invalid value "60" in minute field, must be between 0 and 59`,
+		"* * 0 * *":    `invalid cron expression "* * 0 * *": invalid value "0" in day of month field, must be between 1 and 31`,
+		"* * * foo *":  `invalid cron expression "* * * foo *": invalid value "foo" in month field, must be between 1 and 12`,
+		"*/0 * * * *":  `invalid cron expression "*/0 * * * *": invalid step "0" in minute field`,
+		"* 5-1 * * *":  `invalid cron expression "* 5-1 * * *": invalid range "5-1" in hour field`,
+		"@fortnightly": `invalid cron expression "@fortnightly": expected 5 fields, got 1`,
+	}
+	for expr, want := range tests {
+		_, err := ParseCron(expr)
+		assert.EqualError(t, err, want)
+	}
+	assert.Panics(t, func() { MustParseCron("") })
+}
+
+func TestCronBetweenMax(t *testing.T) {
+	c := MustParseCron("*/10 * * * *")
+	from := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	times, more := c.Between(from, from.Add(time.Hour), time.UTC, 3)
+	assert.True(t, more)
+	assert.Len(t, times, 3)
+
+	times, more = c.Between(from, from.Add(time.Hour), time.UTC, 6)
+	assert.False(t, more)
+	assert.Len(t, times, 6)
+}
+
+func TestCronBetweenDaylightSaving(t *testing.T) {
+	stockholm, err := time.LoadLocation("Europe/Stockholm")
+	assert.NoError(t, err)
+
+	// 2:30 does not exist on March 28th 2021 in Stockholm.
+	c := MustParseCron("30 2 * * *")
+	times, _ := c.Between(time.Date(2021, 3, 27, 0, 0, 0, 0, stockholm), time.Date(2021, 3, 30, 0, 0, 0, 0, stockholm), stockholm, -1)
+	var got []string
+	for _, t := range times {
+		got = append(got, t.UTC().Format(time.RFC3339))
+	}
+	assert.Equal(t, []string{"2021-03-27T01:30:00Z", "2021-03-28T01:30:00Z", "2021-03-29T00:30:00Z"}, got)
+}
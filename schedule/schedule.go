@@ -0,0 +1,235 @@
+// Package schedule turns cron-like schedules into Pingdom maintenance
+// windows, e.g. "every Sunday from 2:00 to 4:00 Europe/Stockholm".
+//
+// The recurrence of Pingdom maintenance windows is limited: a first window
+// repeated every day, week or month until an end date, in absolute time.  A
+// schedule is translated into a single recurring window when it fits these
+// semantics, and into a series of windows otherwise, e.g. for "every weekday"
+// or when a daylight saving change moves the local time of the windows.
+package schedule
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+)
+
+// Pingdom recurrence types.
+const (
+	RecurrenceNone  = pingdom.RecurrenceNone
+	RecurrenceDay   = pingdom.RecurrenceDay
+	RecurrenceWeek  = pingdom.RecurrenceWeek
+	RecurrenceMonth = pingdom.RecurrenceMonth
+)
+
+// DefaultMaxWindows is the maximum number of windows of a series.
+const DefaultMaxWindows = 500
+
+// ErrNoWindow is returned when the schedule has no window in the period.
+var ErrNoWindow = errors.New("schedule has no window in the period")
+
+// Schedule is a maintenance schedule: windows of Duration starting at the
+// times of Cron in Location.
+type Schedule struct {
+	Cron     *Cron
+	Duration time.Duration
+
+	// Location of the times of the cron expression, defaults to UTC.
+	Location *time.Location
+
+	// MaxWindows is the maximum number of windows of a series, defaults to
+	// DefaultMaxWindows.
+	MaxWindows int
+}
+
+// Parse returns the schedule of the cron expression, duration and time zone,
+// e.g. Parse("0 2 * * sun", 2*time.Hour, "Europe/Stockholm").  An empty time
+// zone is UTC.
+func Parse(expr string, duration time.Duration, timezone string) (*Schedule, error) {
+	c, err := ParseCron(expr)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, err
+	}
+	s := &Schedule{Cron: c, Duration: duration, Location: loc}
+	if err := s.Valid(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Valid reports whether the schedule can be translated into windows.
+func (s *Schedule) Valid() error {
+	if s.Cron == nil {
+		return errors.New("missing cron expression")
+	}
+	if s.Duration < time.Minute {
+		return fmt.Errorf("invalid duration %s, must be at least a minute", s.Duration)
+	}
+	return nil
+}
+
+// Times returns the start of the windows from from to until, along with
+// whether there are more than MaxWindows of them.
+func (s *Schedule) Times(from, until time.Time) ([]time.Time, bool) {
+	max := s.MaxWindows
+	if max <= 0 {
+		max = DefaultMaxWindows
+	}
+	return s.Cron.Between(from, until, s.location(), max)
+}
+
+// Recurrence returns the recurring window starting the first window from
+// from, and repeated until until, which is equivalent to the schedule.  It
+// returns nil when the schedule can not be expressed with a Pingdom
+// recurrence.
+func (s *Schedule) Recurrence(description string, from, until time.Time) (*pingdom.MaintenanceWindow, error) {
+	if err := s.Valid(); err != nil {
+		return nil, err
+	}
+	recurrence, ok := s.recurrence()
+	if !ok {
+		return nil, nil
+	}
+	// A recurrence repeats a window at a fixed interval of absolute time,
+	// which the times of the schedule must follow for the whole period.
+	times, _ := s.Cron.Between(from, until, s.location(), -1)
+	if len(times) == 0 {
+		return nil, ErrNoWindow
+	}
+	first := times[0].UTC()
+	for i, t := range times {
+		if !t.Equal(next(first, recurrence, i)) {
+			return nil, nil
+		}
+	}
+	if err := checkOverlap(times, s.Duration); err != nil {
+		return nil, err
+	}
+
+	window := &pingdom.MaintenanceWindow{
+		Description:    description,
+		From:           first.Unix(),
+		To:             first.Add(s.Duration).Unix(),
+		RecurrenceType: recurrence,
+	}
+	if len(times) == 1 {
+		window.RecurrenceType = RecurrenceNone
+	} else {
+		window.RepeatEvery = 1
+		window.EffectiveTo = times[len(times)-1].Add(s.Duration).Unix()
+	}
+	return window, nil
+}
+
+// Windows returns one window per time of the schedule from from to until.
+// It fails when there are more than MaxWindows of them, or when windows
+// overlap.
+func (s *Schedule) Windows(description string, from, until time.Time) ([]pingdom.MaintenanceWindow, error) {
+	if err := s.Valid(); err != nil {
+		return nil, err
+	}
+	times, more := s.Times(from, until)
+	if more {
+		return nil, fmt.Errorf("schedule has more than %d windows until %s, shorten the period or raise MaxWindows", len(times), until.Format(time.RFC3339))
+	}
+	if len(times) == 0 {
+		return nil, ErrNoWindow
+	}
+
+	if err := checkOverlap(times, s.Duration); err != nil {
+		return nil, err
+	}
+	windows := make([]pingdom.MaintenanceWindow, len(times))
+	for i, t := range times {
+		windows[i] = pingdom.MaintenanceWindow{
+			Description:    description,
+			From:           t.Unix(),
+			To:             t.Add(s.Duration).Unix(),
+			RecurrenceType: RecurrenceNone,
+		}
+	}
+	return windows, nil
+}
+
+// Maintenance returns the windows of the schedule from from to until: a
+// single recurring window when the schedule can be expressed with a Pingdom
+// recurrence, one window per time otherwise.
+func (s *Schedule) Maintenance(description string, from, until time.Time) ([]pingdom.MaintenanceWindow, error) {
+	window, err := s.Recurrence(description, from, until)
+	if err != nil {
+		return nil, err
+	}
+	if window != nil {
+		return []pingdom.MaintenanceWindow{*window}, nil
+	}
+	return s.Windows(description, from, until)
+}
+
+// recurrence returns the Pingdom recurrence matching the fields of the cron
+// expression: a single time every day, every week on a single day, or every
+// month on a single day which all months have.
+func (s *Schedule) recurrence() (string, bool) {
+	c := s.Cron
+	if _, ok := c.minutes.single(); !ok {
+		return "", false
+	}
+	if _, ok := c.hours.single(); !ok {
+		return "", false
+	}
+	if c.months != fullMonths {
+		return "", false
+	}
+	switch {
+	case c.anyDay && c.anyWeekday && c.days == fullDays && c.weekday == fullWeek:
+		return RecurrenceDay, true
+	case c.anyDay && c.days == fullDays:
+		if _, ok := c.weekday.single(); ok {
+			return RecurrenceWeek, true
+		}
+	case c.anyWeekday && c.weekday == fullWeek:
+		if day, ok := c.days.single(); ok && day <= 28 {
+			return RecurrenceMonth, true
+		}
+	}
+	return "", false
+}
+
+var (
+	fullMonths = bitset(0x1ffe)     // 1-12
+	fullDays   = bitset(0xfffffffe) // 1-31
+	fullWeek   = bitset(0x7f)       // 0-6
+)
+
+// next returns the start of the i-th window of a recurrence starting at
+// first, in UTC as Pingdom repeats windows in absolute time.
+func next(first time.Time, recurrence string, i int) time.Time {
+	switch recurrence {
+	case RecurrenceWeek:
+		return first.AddDate(0, 0, 7*i)
+	case RecurrenceMonth:
+		return first.AddDate(0, i, 0)
+	}
+	return first.AddDate(0, 0, i)
+}
+
+func checkOverlap(times []time.Time, d time.Duration) error {
+	for i := 1; i < len(times); i++ {
+		if times[i].Before(times[i-1].Add(d)) {
+			return fmt.Errorf("windows of %s overlap, the duration must not exceed the interval between windows", d)
+		}
+	}
+	return nil
+}
+
+func (s *Schedule) location() *time.Location {
+	if s.Location == nil {
+		return time.UTC
+	}
+	return s.Location
+}
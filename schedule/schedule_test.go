@@ -0,0 +1,117 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	from  = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	until = time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func TestScheduleRecurrence(t *testing.T) {
+	tests := []struct {
+		expr   string
+		window *pingdom.MaintenanceWindow
+	}{
+		{
+			expr: "0 2 * * *",
+			window: &pingdom.MaintenanceWindow{
+				Description:    "nightly",
+				From:           time.Date(2021, 1, 1, 2, 0, 0, 0, time.UTC).Unix(),
+				To:             time.Date(2021, 1, 1, 3, 0, 0, 0, time.UTC).Unix(),
+				RecurrenceType: RecurrenceDay,
+				RepeatEvery:    1,
+				EffectiveTo:    time.Date(2021, 1, 31, 3, 0, 0, 0, time.UTC).Unix(),
+			},
+		},
+		{
+			expr: "30 4 * * sun",
+			window: &pingdom.MaintenanceWindow{
+				Description:    "nightly",
+				From:           time.Date(2021, 1, 3, 4, 30, 0, 0, time.UTC).Unix(),
+				To:             time.Date(2021, 1, 3, 5, 30, 0, 0, time.UTC).Unix(),
+				RecurrenceType: RecurrenceWeek,
+				RepeatEvery:    1,
+				EffectiveTo:    time.Date(2021, 1, 31, 5, 30, 0, 0, time.UTC).Unix(),
+			},
+		},
+		{
+			expr: "0 0 15 * *",
+			window: &pingdom.MaintenanceWindow{
+				Description:    "nightly",
+				From:           time.Date(2021, 1, 15, 0, 0, 0, 0, time.UTC).Unix(),
+				To:             time.Date(2021, 1, 15, 1, 0, 0, 0, time.UTC).Unix(),
+				RecurrenceType: RecurrenceNone,
+			},
+		},
+		{expr: "0 2 * * mon-fri"},
+		{expr: "0 2,14 * * *"},
+		{expr: "0 0 31 * *"},
+		{expr: "0 0 * 6 *"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			s := &Schedule{Cron: MustParseCron(tt.expr), Duration: time.Hour}
+			window, err := s.Recurrence("nightly", from, until)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.window, window)
+		})
+	}
+}
+
+func TestScheduleRecurrenceDaylightSaving(t *testing.T) {
+	s, err := Parse("0 2 * * sun", 2*time.Hour, "Europe/Stockholm")
+	assert.NoError(t, err)
+
+	// Winter only: a weekly recurrence at 1:00 UTC.
+	window, err := s.Recurrence("weekly", from, until)
+	assert.NoError(t, err)
+	assert.Equal(t, RecurrenceWeek, window.RecurrenceType)
+	assert.Equal(t, time.Date(2021, 1, 3, 1, 0, 0, 0, time.UTC).Unix(), window.From)
+
+	// Across the change to summer time the windows move an hour earlier in
+	// UTC: no recurrence, a series.
+	windows, err := s.Maintenance("weekly", time.Date(2021, 3, 14, 0, 0, 0, 0, time.UTC), time.Date(2021, 4, 5, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Len(t, windows, 4)
+	assert.Equal(t, time.Date(2021, 3, 21, 1, 0, 0, 0, time.UTC).Unix(), windows[1].From)
+	assert.Equal(t, time.Date(2021, 4, 4, 0, 0, 0, 0, time.UTC).Unix(), windows[3].From)
+	assert.Equal(t, RecurrenceNone, windows[3].RecurrenceType)
+}
+
+func TestScheduleWindows(t *testing.T) {
+	s := &Schedule{Cron: MustParseCron("0 22 * * mon-fri"), Duration: 30 * time.Minute}
+	windows, err := s.Maintenance("deploys", from, time.Date(2021, 1, 9, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Len(t, windows, 6)
+	for _, w := range windows {
+		assert.NoError(t, w.Valid())
+		assert.Equal(t, int64(30*60), w.To-w.From)
+	}
+	assert.Equal(t, time.Date(2021, 1, 1, 22, 0, 0, 0, time.UTC).Unix(), windows[0].From)
+	assert.Equal(t, time.Date(2021, 1, 8, 22, 0, 0, 0, time.UTC).Unix(), windows[5].From)
+
+	s.MaxWindows = 5
+	_, err = s.Windows("deploys", from, time.Date(2021, 1, 9, 0, 0, 0, 0, time.UTC))
+	assert.EqualError(t, err, "schedule has more than 5 windows until 2021-01-09T00:00:00Z, shorten the period or raise MaxWindows")
+}
+
+func TestScheduleErrors(t *testing.T) {
+	s := &Schedule{Cron: MustParseCron("0 * * * *"), Duration: 2 * time.Hour}
+	_, err := s.Maintenance("hourly", from, from.Add(5*time.Hour))
+	assert.EqualError(t, err, "windows of 2h0m0s overlap, the duration must not exceed the interval between windows")
+
+	s = &Schedule{Cron: MustParseCron("0 0 1 6 *"), Duration: time.Hour}
+	_, err = s.Maintenance("june", from, until)
+	assert.Equal(t, ErrNoWindow, err)
+
+	_, err = Parse("0 0 * * *", 0, "")
+	assert.EqualError(t, err, "invalid duration 0s, must be at least a minute")
+	_, err = Parse("0 0 * * *", time.Hour, "Mars/Olympus")
+	assert.Error(t, err)
+}
@@ -0,0 +1,258 @@
+// Package search implements a client side full-text search over the checks
+// of an account, ranking the checks matching every term of a query:
+//
+//	api prod
+//	"payment gateway" eu
+//
+// Terms are case insensitive and double quotes group words into a single
+// term.  A term matches the name, hostname, URL, tags or custom message of a
+// check, and scores by the field it matches, name first, and by how it
+// matches: the whole value, the start of a word, or anywhere inside it.
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+)
+
+// CheckStore lists and reads Pingdom checks.  It is implemented by
+// *pingdom.CheckService.
+type CheckStore interface {
+	List(ctx context.Context, params ...map[string]string) ([]pingdom.CheckResponse, error)
+	Read(ctx context.Context, id int) (*pingdom.CheckResponse, error)
+}
+
+// Fields of the checks searched, by decreasing weight.
+const (
+	FieldName          = "name"
+	FieldTag           = "tag"
+	FieldHostname      = "hostname"
+	FieldURL           = "url"
+	FieldCustomMessage = "custom_message"
+)
+
+var fields = []struct {
+	name   string
+	weight int
+	values func(c *pingdom.CheckResponse) []string
+}{
+	{FieldName, 10, func(c *pingdom.CheckResponse) []string { return []string{c.Name} }},
+	{FieldTag, 8, func(c *pingdom.CheckResponse) []string {
+		tags := make([]string, len(c.Tags))
+		for i, tag := range c.Tags {
+			tags[i] = tag.Name
+		}
+		return tags
+	}},
+	{FieldHostname, 6, func(c *pingdom.CheckResponse) []string { return []string{c.Hostname} }},
+	{FieldURL, 4, func(c *pingdom.CheckResponse) []string {
+		if c.Type.HTTP == nil {
+			return nil
+		}
+		return []string{c.Type.HTTP.Url}
+	}},
+	{FieldCustomMessage, 2, func(c *pingdom.CheckResponse) []string { return []string{c.CustomMessage} }},
+}
+
+// Multipliers of the weight of a field by how a term matches its value.
+const (
+	matchSubstring = 1
+	matchWord      = 2
+	matchExact     = 3
+)
+
+// Query is a parsed search query.
+type Query struct {
+	query string
+	terms []string
+}
+
+// Parse parses a search query.  It fails when the query has no term.
+func Parse(query string) (*Query, error) {
+	terms, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(terms) == 0 {
+		return nil, errors.New("empty search query")
+	}
+	return &Query{query: query, terms: terms}, nil
+}
+
+// String returns the query the Query was parsed from.
+func (q *Query) String() string {
+	return q.query
+}
+
+// Result is a check matching a query.  Fields lists the fields which matched
+// a term, by decreasing weight.
+type Result struct {
+	Check  pingdom.CheckResponse
+	Score  int
+	Fields []string
+}
+
+// Match returns the result of the check when it matches every term of the
+// query.
+func (q *Query) Match(check pingdom.CheckResponse) (Result, bool) {
+	result := Result{Check: check}
+	matched := make([]bool, len(fields))
+	for _, term := range q.terms {
+		best, bestField := 0, -1
+		for i, f := range fields {
+			for _, v := range f.values(&check) {
+				if score := f.weight * match(strings.ToLower(v), term); score > best {
+					best, bestField = score, i
+				}
+			}
+		}
+		if bestField < 0 {
+			return Result{}, false
+		}
+		result.Score += best
+		matched[bestField] = true
+	}
+	for i, f := range fields {
+		if matched[i] {
+			result.Fields = append(result.Fields, f.name)
+		}
+	}
+	return result, true
+}
+
+// Rank returns the results of the checks matching the query, best first.
+// Checks scoring the same are sorted by name, then ID.
+func (q *Query) Rank(checks []pingdom.CheckResponse) []Result {
+	results := []Result{}
+	for _, check := range checks {
+		if result, ok := q.Match(check); ok {
+			results = append(results, result)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Check.Name != b.Check.Name {
+			return a.Check.Name < b.Check.Name
+		}
+		return a.Check.ID < b.Check.ID
+	})
+	return results
+}
+
+// Searcher searches the checks of an account.
+type Searcher struct {
+	Checks CheckStore
+
+	// Details reads every check before searching it: the list of checks
+	// lacks the URL and custom message of the checks, which are then not
+	// searched.  This is a request per check.
+	Details bool
+
+	// Bulk controls the concurrency of the reads of Details.
+	Bulk pingdom.BulkConfig
+}
+
+// Search lists the checks of the account, along with their tags, and returns
+// the ones matching the query, best first.
+func (s *Searcher) Search(ctx context.Context, query string) ([]Result, error) {
+	q, err := Parse(query)
+	if err != nil {
+		return nil, err
+	}
+	checks, err := s.Checks.List(ctx, map[string]string{"include_tags": "true"})
+	if err != nil {
+		return nil, err
+	}
+	if s.Details {
+		if checks, err = s.read(ctx, checks); err != nil {
+			return nil, err
+		}
+	}
+	return q.Rank(checks), nil
+}
+
+// read returns the details of the checks, keeping the tags of the list which
+// the details of a check may lack.
+func (s *Searcher) read(ctx context.Context, checks []pingdom.CheckResponse) ([]pingdom.CheckResponse, error) {
+	detailed := make([]pingdom.CheckResponse, len(checks))
+	errs := pingdom.RunBulk(ctx, s.Bulk, len(checks), func(i int) error {
+		check, err := s.Checks.Read(ctx, checks[i].ID)
+		if err != nil {
+			return err
+		}
+		detailed[i] = *check
+		if len(detailed[i].Tags) == 0 {
+			detailed[i].Tags = checks[i].Tags
+		}
+		return nil
+	})
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("reading check %d: %v", checks[i].ID, err)
+		}
+	}
+	return detailed, nil
+}
+
+// match returns how the term matches the lower case value, or 0.
+func match(value, term string) int {
+	if value == term {
+		return matchExact
+	}
+	best := 0
+	for i := strings.Index(value, term); i >= 0; {
+		if r, _ := utf8.DecodeLastRuneInString(value[:i]); i == 0 || !isWordRune(r) {
+			return matchWord
+		}
+		best = matchSubstring
+		next := strings.Index(value[i+1:], term)
+		if next < 0 {
+			break
+		}
+		i += next + 1
+	}
+	return best
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// tokenize splits the query into lower case terms.
+func tokenize(query string) ([]string, error) {
+	var terms []string
+	query = strings.ToLower(query)
+	for {
+		query = strings.TrimLeftFunc(query, unicode.IsSpace)
+		if query == "" {
+			return terms, nil
+		}
+		if query[0] == '"' {
+			end := strings.IndexByte(query[1:], '"')
+			if end < 0 {
+				return nil, errors.New("unterminated quote in search query")
+			}
+			if term := strings.TrimSpace(query[1 : end+1]); term != "" {
+				terms = append(terms, term)
+			}
+			query = query[end+2:]
+			continue
+		}
+		end := strings.IndexFunc(query, unicode.IsSpace)
+		if end < 0 {
+			end = len(query)
+		}
+		terms = append(terms, query[:end])
+		query = query[end:]
+	}
+}
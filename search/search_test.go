@@ -0,0 +1,168 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+var checks = []pingdom.CheckResponse{
+	{
+		ID: 1, Name: "API", Hostname: "api.example.com",
+		Tags: []pingdom.CheckResponseTag{{Name: "prod"}},
+	},
+	{
+		ID: 2, Name: "API staging", Hostname: "api.staging.example.com",
+		Tags: []pingdom.CheckResponseTag{{Name: "staging"}},
+	},
+	{
+		ID: 3, Name: "Payments", Hostname: "payments.example.com", CustomMessage: "Calls the API of the bank",
+		Tags: []pingdom.CheckResponseTag{{Name: "prod"}},
+	},
+	{ID: 4, Name: "Mail", Hostname: "mx.example.com"},
+}
+
+func ids(results []Result) []int {
+	ids := make([]int, len(results))
+	for i, r := range results {
+		ids[i] = r.Check.ID
+	}
+	return ids
+}
+
+func TestQueryRank(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []int
+	}{
+		{"api", []int{1, 2, 3}},
+		{"API prod", []int{1, 3}},
+		{`"api staging"`, []int{2}},
+		{"example", []int{1, 2, 4, 3}},
+		{"mple", []int{1, 2, 4, 3}},
+		{"xample pay", []int{3}},
+		{"nothing", []int{}},
+	}
+	for _, tt := range tests {
+		q, err := Parse(tt.query)
+		if !assert.NoError(t, err, tt.query) {
+			continue
+		}
+		assert.Equal(t, tt.want, ids(q.Rank(checks)), tt.query)
+		assert.Equal(t, tt.query, q.String())
+	}
+}
+
+func TestQueryMatch(t *testing.T) {
+	q, _ := Parse("api")
+	tests := []struct {
+		check  pingdom.CheckResponse
+		score  int
+		fields []string
+	}{
+		{checks[0], 30, []string{FieldName}},
+		{checks[1], 20, []string{FieldName}},
+		{checks[2], 4, []string{FieldCustomMessage}},
+		{
+			pingdom.CheckResponse{Name: "Web", Type: pingdom.CheckResponseType{
+				HTTP: &pingdom.CheckResponseHTTPDetails{Url: "/rapid"},
+			}},
+			4, []string{FieldURL},
+		},
+	}
+	for _, tt := range tests {
+		result, ok := q.Match(tt.check)
+		assert.True(t, ok, tt.check.Name)
+		assert.Equal(t, tt.score, result.Score, tt.check.Name)
+		assert.Equal(t, tt.fields, result.Fields, tt.check.Name)
+	}
+
+	q, _ = Parse("prod payments")
+	result, ok := q.Match(checks[2])
+	assert.True(t, ok)
+	assert.Equal(t, 8*3+10*3, result.Score)
+	assert.Equal(t, []string{FieldName, FieldTag}, result.Fields)
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := Parse("  ")
+	assert.EqualError(t, err, "empty search query")
+	_, err = Parse(`api "prod`)
+	assert.EqualError(t, err, "unterminated quote in search query")
+}
+
+type fakeChecks struct {
+	params map[string]string
+	err    error
+
+	mu    sync.Mutex
+	reads []int
+}
+
+func (f *fakeChecks) List(ctx context.Context, params ...map[string]string) ([]pingdom.CheckResponse, error) {
+	f.params = params[0]
+	// The list of checks has no custom message.
+	listed := make([]pingdom.CheckResponse, len(checks))
+	for i, check := range checks {
+		listed[i] = check
+		listed[i].CustomMessage = ""
+	}
+	return listed, f.err
+}
+
+func (f *fakeChecks) Read(ctx context.Context, id int) (*pingdom.CheckResponse, error) {
+	f.mu.Lock()
+	f.reads = append(f.reads, id)
+	f.mu.Unlock()
+	check := checks[id-1]
+	check.Tags = nil
+	return &check, nil
+}
+
+func TestSearcherSearch(t *testing.T) {
+	store := &fakeChecks{}
+	s := &Searcher{Checks: store}
+	results, err := s.Search(context.Background(), "api")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, ids(results))
+	assert.Equal(t, "true", store.params["include_tags"])
+	assert.Empty(t, store.reads)
+
+	s.Details = true
+	results, err = s.Search(context.Background(), "api")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, ids(results))
+	assert.Len(t, store.reads, 4)
+	assert.Equal(t, checks[0].Tags, results[0].Check.Tags, "tags are kept from the list")
+
+	_, err = s.Search(context.Background(), "")
+	assert.EqualError(t, err, "empty search query")
+
+	store.err = errors.New("boom")
+	s.Details = false
+	_, err = s.Search(context.Background(), "api")
+	assert.EqualError(t, err, "boom")
+}
+
+func TestSearcherSearchReadError(t *testing.T) {
+	s := &Searcher{Checks: readFailure{}, Details: true}
+	_, err := s.Search(context.Background(), "api")
+	assert.EqualError(t, err, "reading check 4: boom")
+}
+
+type readFailure struct{}
+
+func (readFailure) List(ctx context.Context, params ...map[string]string) ([]pingdom.CheckResponse, error) {
+	return checks, nil
+}
+
+func (readFailure) Read(ctx context.Context, id int) (*pingdom.CheckResponse, error) {
+	if id == 4 {
+		return nil, errors.New("boom")
+	}
+	return &checks[id-1], nil
+}
@@ -0,0 +1,333 @@
+// Package server exposes the services of the pingdom client over a small REST
+// API, so that tools not written in Go can manage checks, contacts, teams and
+// maintenance windows through the same client logic: retries, timeouts,
+// validation and models.
+//
+// The server holds no credentials: each request is authenticated with the
+// Pingdom API token of the caller, passed in the Authorization header as a
+// Bearer token, and forwarded to Pingdom as is.  Responses are the JSON
+// encoding of the pingdom types, and errors have the format of the errors of
+// the Pingdom API:
+//
+//	{"error": {"statuscode": 404, "statusdesc": "Not Found", "errormessage": "..."}}
+//
+// Routes:
+//
+//	GET    /v1/checks              List checks, the query parameters are passed to Pingdom
+//	POST   /v1/checks              Create a check, e.g. {"type": "http", "check": {...}}
+//	GET    /v1/checks/{id}         Read a check
+//	PUT    /v1/checks/{id}         Update a check, with the body of POST
+//	DELETE /v1/checks/{id}         Delete a check
+//	GET    /v1/contacts[/{id}]     List or read contacts
+//	GET    /v1/teams[/{id}]        List or read teams
+//	GET    /v1/maintenance[/{id}]  List or read maintenance windows
+//	GET    /v1/probes              List probes
+package server
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/nordcloud/go-pingdom/snapshot"
+)
+
+// Server is an http.Handler serving the REST API.
+type Server struct {
+	// Config is the configuration of the clients sending the requests to
+	// Pingdom, e.g. its retry policy and timeouts.  The API token and
+	// credentials are replaced by the token of each caller.
+	Config pingdom.ClientConfig
+
+	// MaxClients is the number of clients kept, those of the tokens used
+	// least recently being dropped first, defaults to 100.
+	MaxClients int
+
+	// MaxBodySize is the size of the largest request body read, defaults
+	// to 1MB.  Larger ones are answered with 413.
+	MaxBodySize int64
+
+	mu      sync.Mutex
+	clients map[string]*list.Element
+	recent  list.List // Of *tokenClient, most recently used first
+}
+
+// tokenClient is a client kept for the token of a caller.
+type tokenClient struct {
+	token  string
+	client *pingdom.Client
+}
+
+const (
+	defaultMaxClients  = 100
+	defaultMaxBodySize = 1 << 20
+)
+
+// New returns a server sending requests to Pingdom with clients configured
+// with config.
+func New(config pingdom.ClientConfig) *Server {
+	return &Server{Config: config}
+}
+
+// statusError is an error with the HTTP status the server responds with.
+type statusError struct {
+	status int
+	err    error
+}
+
+func (e *statusError) Error() string {
+	return e.err.Error()
+}
+
+func errorf(status int, format string, args ...interface{}) error {
+	return &statusError{status: status, err: fmt.Errorf(format, args...)}
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, s.maxBodySize())
+	v, err := s.serve(r.Context(), r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func (s *Server) serve(ctx context.Context, r *http.Request) (interface{}, error) {
+	path := strings.Trim(r.URL.Path, "/")
+	parts := strings.Split(path, "/")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] != "v1" {
+		return nil, errorf(http.StatusNotFound, "unknown path /%s", path)
+	}
+	resource := parts[1]
+	id := 0
+	if len(parts) == 3 {
+		var err error
+		if id, err = strconv.Atoi(parts[2]); err != nil || id <= 0 {
+			return nil, errorf(http.StatusNotFound, "invalid ID %q", parts[2])
+		}
+	}
+
+	client, err := s.client(r)
+	if err != nil {
+		return nil, err
+	}
+	switch resource {
+	case "checks":
+		return s.checks(ctx, client, r, id)
+	case "contacts":
+		if err := allow(r, http.MethodGet); err != nil {
+			return nil, err
+		}
+		if id != 0 {
+			return client.Contacts.Read(ctx, id)
+		}
+		return client.Contacts.List(ctx)
+	case "teams":
+		if err := allow(r, http.MethodGet); err != nil {
+			return nil, err
+		}
+		if id != 0 {
+			return client.Teams.Read(ctx, id)
+		}
+		return client.Teams.List(ctx)
+	case "maintenance":
+		if err := allow(r, http.MethodGet); err != nil {
+			return nil, err
+		}
+		if id != 0 {
+			return client.Maintenances.Read(ctx, id)
+		}
+		return client.Maintenances.List(ctx, query(r))
+	case "probes":
+		if err := allow(r, http.MethodGet); err != nil {
+			return nil, err
+		}
+		if id != 0 {
+			return nil, errorf(http.StatusNotFound, "unknown path /%s", path)
+		}
+		return client.Probes.List(ctx, query(r))
+	}
+	return nil, errorf(http.StatusNotFound, "unknown path /%s", path)
+}
+
+func (s *Server) checks(ctx context.Context, client *pingdom.Client, r *http.Request, id int) (interface{}, error) {
+	if id == 0 {
+		if err := allow(r, http.MethodGet, http.MethodPost); err != nil {
+			return nil, err
+		}
+		if r.Method == http.MethodGet {
+			return client.Checks.List(ctx, query(r))
+		}
+		check, err := s.decodeCheck(r)
+		if err != nil {
+			return nil, err
+		}
+		return client.Checks.Create(ctx, check)
+	}
+
+	if err := allow(r, http.MethodGet, http.MethodPut, http.MethodDelete); err != nil {
+		return nil, err
+	}
+	switch r.Method {
+	case http.MethodPut:
+		check, err := s.decodeCheck(r)
+		if err != nil {
+			return nil, err
+		}
+		return client.Checks.Update(ctx, id, check)
+	case http.MethodDelete:
+		return client.Checks.Delete(ctx, id)
+	}
+	return client.Checks.Read(ctx, id)
+}
+
+// client returns the client authenticated with the token of the caller.
+// Clients are kept per token, so that their caches are shared by the
+// requests of a caller, up to MaxClients.
+func (s *Server) client(r *http.Request) (*pingdom.Client, error) {
+	token := r.Header.Get("Authorization")
+	if !strings.HasPrefix(token, "Bearer ") || strings.TrimSpace(token[len("Bearer "):]) == "" {
+		return nil, errorf(http.StatusUnauthorized, "missing bearer token")
+	}
+	token = strings.TrimSpace(token[len("Bearer "):])
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.clients[token]; ok {
+		s.recent.MoveToFront(e)
+		return e.Value.(*tokenClient).client, nil
+	}
+	config := s.Config
+	config.APIToken = token
+	config.Auth = nil
+	config.Username, config.Password, config.AppKey, config.AccountEmail = "", "", "", ""
+	client, err := pingdom.NewClientWithConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	if s.clients == nil {
+		s.clients = map[string]*list.Element{}
+	}
+	s.clients[token] = s.recent.PushFront(&tokenClient{token: token, client: client})
+	for s.recent.Len() > s.maxClients() {
+		oldest := s.recent.Back()
+		s.recent.Remove(oldest)
+		delete(s.clients, oldest.Value.(*tokenClient).token)
+	}
+	return client, nil
+}
+
+func (s *Server) maxClients() int {
+	if s.MaxClients <= 0 {
+		return defaultMaxClients
+	}
+	return s.MaxClients
+}
+
+func (s *Server) maxBodySize() int64 {
+	if s.MaxBodySize <= 0 {
+		return defaultMaxBodySize
+	}
+	return s.MaxBodySize
+}
+
+func allow(r *http.Request, methods ...string) error {
+	for _, method := range methods {
+		if r.Method == method {
+			return nil
+		}
+	}
+	return errorf(http.StatusMethodNotAllowed, "method %s not allowed on %s", r.Method, r.URL.Path)
+}
+
+func query(r *http.Request) map[string]string {
+	params := map[string]string{}
+	for k, v := range r.URL.Query() {
+		params[k] = v[0]
+	}
+	return params
+}
+
+// decodeCheck decodes a check in the format of the checks of snapshots, and
+// validates it.
+func (s *Server) decodeCheck(r *http.Request) (pingdom.Check, error) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		if int64(len(body)) >= s.maxBodySize() {
+			return nil, errorf(http.StatusRequestEntityTooLarge, "invalid check: larger than %d bytes", s.maxBodySize())
+		}
+		return nil, errorf(http.StatusBadRequest, "invalid check: %v", err)
+	}
+	entry := snapshot.CheckEntry{}
+	if err := json.Unmarshal(body, &entry); err != nil {
+		return nil, errorf(http.StatusBadRequest, "invalid check: %v", err)
+	}
+	if entry.Check == nil {
+		return nil, errorf(http.StatusBadRequest, "invalid check: missing check")
+	}
+	if err := entry.Check.Valid(); err != nil {
+		return nil, errorf(http.StatusBadRequest, "invalid check: %v", err)
+	}
+	return entry.Check, nil
+}
+
+// validationErrors are those with which the client rejects a request
+// before sending it.
+var validationErrors = []error{
+	pingdom.ErrMissingId, pingdom.ErrBadResolution, pingdom.ErrBadOrder, pingdom.ErrBadResultStatus,
+	pingdom.ErrBadLimit, pingdom.ErrBadOffset, pingdom.ErrMissingHost, pingdom.ErrMissingType,
+	pingdom.ErrBadActionStatus, pingdom.ErrBadActionVia, pingdom.ErrBadActionLimit,
+}
+
+// invalid reports whether err is a validation error of the client.
+func invalid(err error) bool {
+	var stepErr *pingdom.TMSStepError
+	if errors.As(err, &stepErr) {
+		return true
+	}
+	for _, target := range validationErrors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// writeError writes the error in the format of the Pingdom API.  Errors
+// returned by Pingdom keep their status, the validation errors of the client
+// are a bad request, those of a read-only client forbidden, and others are
+// reported as a bad gateway.
+func writeError(w http.ResponseWriter, err error) {
+	e := &pingdom.PingdomError{StatusCode: http.StatusBadGateway, Message: err.Error()}
+	var se *statusError
+	var pe *pingdom.PingdomError
+	switch {
+	case errors.As(err, &se):
+		e.StatusCode = se.status
+	case errors.As(err, &pe):
+		if pe.StatusCode != 0 {
+			e.StatusCode = pe.StatusCode
+		}
+		e.Message = pe.Message
+	case errors.Is(err, pingdom.ErrInsufficientScope), errors.Is(err, pingdom.ErrReadOnly):
+		e.StatusCode = http.StatusForbidden
+	case invalid(err):
+		e.StatusCode = http.StatusBadRequest
+	}
+	e.StatusDesc = http.StatusText(e.StatusCode)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(e.StatusCode)
+	json.NewEncoder(w).Encode(map[string]*pingdom.PingdomError{"error": e})
+}
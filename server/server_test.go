@@ -0,0 +1,230 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	mux      *http.ServeMux
+	upstream *httptest.Server
+	srv      *Server
+)
+
+func setup() {
+	mux = http.NewServeMux()
+	upstream = httptest.NewServer(mux)
+	srv = New(pingdom.ClientConfig{BaseURL: upstream.URL, APIToken: "server_token"})
+}
+
+func teardown() {
+	upstream.Close()
+}
+
+func do(method, path, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, req)
+	return w
+}
+
+func TestListChecks(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "Bearer caller_token", r.Header.Get("Authorization"))
+		assert.Equal(t, "web", r.URL.Query().Get("tags"))
+		fmt.Fprint(w, `{"checks":[{"id":1,"name":"Home","status":"up"}]}`)
+	})
+
+	w := do("GET", "/v1/checks?tags=web", "caller_token", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `"id":1,"name":"Home"`)
+	assert.Contains(t, w.Body.String(), `"status":"up"`)
+}
+
+func TestCreateCheck(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "example.com", r.URL.Query().Get("host"))
+		assert.Equal(t, "http", r.URL.Query().Get("type"))
+		fmt.Fprint(w, `{"check":{"id":138631,"name":"Home"}}`)
+	})
+
+	w := do("POST", "/v1/checks", "caller_token", `{"type":"http","check":{"name":"Home","hostname":"example.com","resolution":5}}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"id":138631`)
+
+	w = do("POST", "/v1/checks", "caller_token", `{"type":"http","check":{"name":"Home","resolution":5}}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"statuscode":400,"statusdesc":"Bad Request","errormessage":"invalid check:`)
+
+	w = do("POST", "/v1/checks", "caller_token", `{"type":"smtp","check":{}}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestUpdateAndDeleteCheck(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks/42", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			assert.Equal(t, "example.org", r.URL.Query().Get("host"))
+			fmt.Fprint(w, `{"message":"Modification of check was successful!"}`)
+		case "DELETE":
+			fmt.Fprint(w, `{"message":"Deletion of check was successful!"}`)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+
+	w := do("PUT", "/v1/checks/42", "caller_token", `{"type":"ping","check":{"name":"Ping","hostname":"example.org","resolution":1}}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Modification of check was successful!")
+
+	w = do("DELETE", "/v1/checks/42", "caller_token", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Deletion of check was successful!")
+}
+
+func TestPingdomErrors(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/checks/404", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"error":{"statuscode":404,"statusdesc":"Not Found","errormessage":"Check not found"}}`)
+	})
+	mux.HandleFunc("/checks/403", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error":{"statuscode":403,"statusdesc":"Forbidden","errormessage":"Read-only token"}}`)
+	})
+
+	w := do("GET", "/v1/checks/404", "caller_token", "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"error":{"statuscode":404,"statusdesc":"Not Found","errormessage":"Check not found"}}`, w.Body.String())
+
+	w = do("DELETE", "/v1/checks/403", "caller_token", "")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "Read-only token")
+}
+
+func TestClientErrors(t *testing.T) {
+	setup()
+	defer teardown()
+	srv.Config.ReadOnly = true
+
+	w := do("DELETE", "/v1/checks/42", "caller_token", "")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), `"statuscode":403,"statusdesc":"Forbidden","errormessage":"DELETE /checks/42 not sent: the client is read-only"`)
+
+	for _, err := range []error{
+		fmt.Errorf("list alerts: %w", pingdom.ErrBadActionLimit),
+		&pingdom.TMSStepError{Step: 1, Fn: "go_to", Err: errors.New("missing url")},
+	} {
+		w := httptest.NewRecorder()
+		writeError(w, err)
+		assert.Equal(t, http.StatusBadRequest, w.Code, err.Error())
+	}
+
+	w = httptest.NewRecorder()
+	writeError(w, errors.New("connection reset by peer"))
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+}
+
+func TestBodyTooLarge(t *testing.T) {
+	setup()
+	defer teardown()
+	srv.MaxBodySize = 64
+
+	w := do("POST", "/v1/checks", "caller_token", `{"type":"http","check":{"name":"`+strings.Repeat("a", 64)+`","hostname":"example.com"}}`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid check: larger than 64 bytes")
+}
+
+func TestRouting(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc("/alerting/teams/7", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"team":{"id":7,"name":"Ops"}}`)
+	})
+
+	w := do("GET", "/v1/teams/7", "caller_token", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"name":"Ops"`)
+
+	tests := []struct {
+		method, path, token string
+		status              int
+	}{
+		{"GET", "/v1/checks", "", http.StatusUnauthorized},
+		{"GET", "/v1/unknown", "caller_token", http.StatusNotFound},
+		{"GET", "/checks", "caller_token", http.StatusNotFound},
+		{"GET", "/v1/checks/abc", "caller_token", http.StatusNotFound},
+		{"DELETE", "/v1/teams/7", "caller_token", http.StatusMethodNotAllowed},
+		{"POST", "/v1/checks/7", "caller_token", http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		w := do(tt.method, tt.path, tt.token, "")
+		assert.Equal(t, tt.status, w.Code, tt.method+" "+tt.path)
+	}
+}
+
+func TestClientPerToken(t *testing.T) {
+	setup()
+	defer teardown()
+
+	req := httptest.NewRequest("GET", "/v1/checks", nil)
+	req.Header.Set("Authorization", "Bearer a")
+	a, err := srv.client(req)
+	assert.NoError(t, err)
+	again, _ := srv.client(req)
+	assert.True(t, a == again)
+	assert.Equal(t, "a", a.APIToken)
+
+	req.Header.Set("Authorization", "Bearer b")
+	b, _ := srv.client(req)
+	assert.True(t, a != b)
+}
+
+func TestClientsBounded(t *testing.T) {
+	setup()
+	defer teardown()
+	srv.MaxClients = 2
+
+	client := func(token string) *pingdom.Client {
+		req := httptest.NewRequest("GET", "/v1/checks", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		c, err := srv.client(req)
+		assert.NoError(t, err)
+		return c
+	}
+	a := client("a")
+	b := client("b")
+	assert.True(t, a == client("a"))
+
+	// The client of b, used least recently, is dropped.
+	client("c")
+	assert.Len(t, srv.clients, 2)
+	assert.True(t, a == client("a"))
+	assert.True(t, b != client("b"))
+	assert.Equal(t, 2, srv.recent.Len())
+}
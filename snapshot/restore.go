@@ -0,0 +1,495 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+)
+
+// Resources of an account.
+const (
+	ResourceContact     = "contact"
+	ResourceTeam        = "team"
+	ResourceCheck       = "check"
+	ResourceMaintenance = "maintenance"
+)
+
+// Action is the change applied to a resource by Restore.
+type Action string
+
+// Actions applied by Restore.
+const (
+	ActionCreate Action = "create"
+	ActionUpdate Action = "update"
+	ActionDelete Action = "delete"
+)
+
+// Change is a change to a resource.  ID is the ID of the resource in the
+// snapshot, or in the account for deletions; NewID is the ID of a resource
+// created again, or of a check replaced because its type changed.
+type Change struct {
+	Resource string
+	Action   Action
+	ID       int
+	Name     string
+	NewID    int
+	Err      error
+}
+
+func (c Change) String() string {
+	return fmt.Sprintf("%s %s %d (%s)", c.Action, c.Resource, c.ID, c.Name)
+}
+
+// Options of Restore.
+type Options struct {
+	// Prune deletes the resources which are not in the snapshot, i.e. the
+	// ones created after it was taken.
+	Prune bool
+
+	// DryRun returns the changes without applying them.
+	DryRun bool
+}
+
+// Diff returns the changes turning current into desired, including the
+// deletions which Restore only applies with Options.Prune.
+func Diff(current, desired *Snapshot) []Change {
+	r := newRestorer(Account{}, current, desired, Options{Prune: true, DryRun: true})
+	r.run(context.Background())
+	return r.changes
+}
+
+// Restore brings the account back to the configuration of the snapshot,
+// creating the missing resources, updating the modified ones and, with
+// Options.Prune, deleting the ones not in the snapshot.
+//
+// Resources are matched by ID or, failing that, by name (by description for
+// maintenance windows), so that restoring the same snapshot again does not
+// duplicate the resources created again by a previous restore.  References to
+// those, e.g. the teams alerted by a check, are remapped to their new IDs.
+// Errors on individual resources are recorded in the changes; the account
+// owner is never created nor deleted.
+func Restore(ctx context.Context, account Account, snapshot *Snapshot, options Options) ([]Change, error) {
+	current, err := Take(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	r := newRestorer(account, current, snapshot, options)
+	r.run(ctx)
+	return r.changes, nil
+}
+
+// idMap maps the IDs of the snapshot to the IDs of the account.
+type idMap map[int]int
+
+// remap returns the account IDs of the given snapshot IDs, sorted, and the
+// snapshot IDs which have none.
+func (m idMap) remap(ids []int) (mapped []int, missing []int) {
+	for _, id := range ids {
+		if target, ok := m[id]; ok {
+			mapped = append(mapped, target)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	sort.Ints(mapped)
+	return mapped, missing
+}
+
+// matcher matches the resources of the snapshot to the current ones, by ID
+// or by name.  Each current resource is matched at most once.
+type matcher struct {
+	byID    map[int]int
+	byName  map[string][]int
+	claimed map[int]bool
+}
+
+func newMatcher(n int, resource func(i int) (int, string)) *matcher {
+	m := &matcher{byID: map[int]int{}, byName: map[string][]int{}, claimed: map[int]bool{}}
+	for i := 0; i < n; i++ {
+		id, name := resource(i)
+		m.byID[id] = i
+		m.byName[name] = append(m.byName[name], i)
+	}
+	return m
+}
+
+// match returns the index of the current resource matching the given one.
+func (m *matcher) match(id int, name string) (int, bool) {
+	if i, ok := m.byID[id]; ok && !m.claimed[i] {
+		m.claimed[i] = true
+		return i, true
+	}
+	for _, i := range m.byName[name] {
+		if !m.claimed[i] {
+			m.claimed[i] = true
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+type restorer struct {
+	account  Account
+	current  *Snapshot
+	desired  *Snapshot
+	options  Options
+	contacts idMap
+	teams    idMap
+	checks   idMap
+	matched  map[string]*matcher
+	changes  []Change
+}
+
+func newRestorer(account Account, current, desired *Snapshot, options Options) *restorer {
+	return &restorer{
+		account:  account,
+		current:  current,
+		desired:  desired,
+		options:  options,
+		contacts: idMap{},
+		teams:    idMap{},
+		checks:   idMap{},
+		matched:  map[string]*matcher{},
+	}
+}
+
+func (r *restorer) run(ctx context.Context) {
+	r.restoreContacts(ctx)
+	r.restoreTeams(ctx)
+	r.restoreChecks(ctx)
+	r.restoreMaintenances(ctx)
+	if r.options.Prune {
+		r.prune(ctx)
+	}
+}
+
+// record applies the change unless in dry run, create returning the ID of
+// the created resource.
+func (r *restorer) record(change Change, apply func() (int, error)) int {
+	if r.options.DryRun {
+		r.changes = append(r.changes, change)
+		return change.ID
+	}
+	change.NewID, change.Err = apply()
+	r.changes = append(r.changes, change)
+	return change.NewID
+}
+
+func (r *restorer) restoreContacts(ctx context.Context) {
+	current := r.current.Contacts
+	m := newMatcher(len(current), func(i int) (int, string) { return current[i].ID, current[i].Name })
+	r.matched[ResourceContact] = m
+
+	for _, contact := range r.desired.Contacts {
+		contact := contact
+		desired := &pingdom.Contact{Name: contact.Name, NotificationTargets: contact.NotificationTargets, Paused: contact.Paused}
+		i, ok := m.match(contact.ID, contact.Name)
+		switch {
+		case !ok && contact.Owner:
+			continue
+		case !ok:
+			change := Change{Resource: ResourceContact, Action: ActionCreate, ID: contact.ID, Name: contact.Name}
+			if id := r.record(change, func() (int, error) {
+				created, err := r.account.Contacts.Create(ctx, desired)
+				if err != nil {
+					return 0, err
+				}
+				return created.ID, nil
+			}); id != 0 {
+				r.contacts[contact.ID] = id
+			}
+			continue
+		}
+
+		existing := current[i]
+		r.contacts[contact.ID] = existing.ID
+		if desired.RenderForJSONAPI() != existing.RenderForJSONAPI() {
+			change := Change{Resource: ResourceContact, Action: ActionUpdate, ID: contact.ID, Name: contact.Name}
+			r.record(change, func() (int, error) {
+				_, err := r.account.Contacts.Update(ctx, existing.ID, desired)
+				return 0, err
+			})
+		}
+	}
+}
+
+// sameIDs compares sorted lists of IDs, nil being the same as empty.
+func sameIDs(a, b []int) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func memberIDs(team pingdom.TeamResponse) []int {
+	ids := make([]int, 0, len(team.Members))
+	for _, member := range team.Members {
+		ids = append(ids, member.ID)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func (r *restorer) restoreTeams(ctx context.Context) {
+	current := r.current.Teams
+	m := newMatcher(len(current), func(i int) (int, string) { return current[i].ID, current[i].Name })
+	r.matched[ResourceTeam] = m
+
+	for _, team := range r.desired.Teams {
+		team := team
+		members, _ := r.contacts.remap(memberIDs(team))
+		desired := &pingdom.Team{Name: team.Name, MemberIDs: members}
+		i, ok := m.match(team.ID, team.Name)
+		if !ok {
+			change := Change{Resource: ResourceTeam, Action: ActionCreate, ID: team.ID, Name: team.Name}
+			if id := r.record(change, func() (int, error) {
+				created, err := r.account.Teams.Create(ctx, desired)
+				if err != nil {
+					return 0, err
+				}
+				return created.ID, nil
+			}); id != 0 {
+				r.teams[team.ID] = id
+			}
+			continue
+		}
+
+		existing := current[i]
+		r.teams[team.ID] = existing.ID
+		if desired.Name != existing.Name || !sameIDs(desired.MemberIDs, memberIDs(existing)) {
+			change := Change{Resource: ResourceTeam, Action: ActionUpdate, ID: team.ID, Name: team.Name}
+			r.record(change, func() (int, error) {
+				_, err := r.account.Teams.Update(ctx, existing.ID, desired)
+				return 0, err
+			})
+		}
+	}
+}
+
+// withReferences returns a copy of the check alerting the remapped contacts
+// and teams.
+func (r *restorer) withReferences(check pingdom.Check) pingdom.Check {
+	switch c := check.(type) {
+	case *pingdom.HttpCheck:
+		copied := *c
+		copied.UserIds, _ = r.contacts.remap(c.UserIds)
+		copied.TeamIds, _ = r.teams.remap(c.TeamIds)
+		return &copied
+	case *pingdom.HttpCustomCheck:
+		copied := *c
+		copied.UserIds, _ = r.contacts.remap(c.UserIds)
+		copied.TeamIds, _ = r.teams.remap(c.TeamIds)
+		return &copied
+	case *pingdom.PingCheck:
+		copied := *c
+		copied.UserIds, _ = r.contacts.remap(c.UserIds)
+		copied.TeamIds, _ = r.teams.remap(c.TeamIds)
+		return &copied
+	case *pingdom.TCPCheck:
+		copied := *c
+		copied.UserIds, _ = r.contacts.remap(c.UserIds)
+		copied.TeamIds, _ = r.teams.remap(c.TeamIds)
+		return &copied
+	case *pingdom.UDPCheck:
+		copied := *c
+		copied.UserIds, _ = r.contacts.remap(c.UserIds)
+		copied.TeamIds, _ = r.teams.remap(c.TeamIds)
+		return &copied
+	case *pingdom.SMTPCheck:
+		copied := *c
+		copied.UserIds, _ = r.contacts.remap(c.UserIds)
+		copied.TeamIds, _ = r.teams.remap(c.TeamIds)
+		return &copied
+	case *pingdom.POP3Check:
+		copied := *c
+		copied.UserIds, _ = r.contacts.remap(c.UserIds)
+		copied.TeamIds, _ = r.teams.remap(c.TeamIds)
+		return &copied
+	case *pingdom.IMAPCheck:
+		copied := *c
+		copied.UserIds, _ = r.contacts.remap(c.UserIds)
+		copied.TeamIds, _ = r.teams.remap(c.TeamIds)
+		return &copied
+	case *pingdom.DNSCheck:
+		copied := *c
+		copied.UserIds, _ = r.contacts.remap(c.UserIds)
+		copied.TeamIds, _ = r.teams.remap(c.TeamIds)
+		return &copied
+	}
+	return check
+}
+
+func checkName(entry CheckEntry) string {
+	return entry.Check.PutParams()["name"]
+}
+
+func (r *restorer) restoreChecks(ctx context.Context) {
+	current := r.current.Checks
+	m := newMatcher(len(current), func(i int) (int, string) { return current[i].ID, checkName(current[i]) })
+	r.matched[ResourceCheck] = m
+
+	for _, entry := range r.desired.Checks {
+		entry := entry
+		desired := r.withReferences(entry.Check)
+		name := checkName(entry)
+		i, ok := m.match(entry.ID, name)
+		if !ok {
+			change := Change{Resource: ResourceCheck, Action: ActionCreate, ID: entry.ID, Name: name}
+			if id := r.record(change, func() (int, error) {
+				created, err := r.account.Checks.Create(ctx, desired)
+				if err != nil {
+					return 0, err
+				}
+				return created.ID, nil
+			}); id != 0 {
+				r.checks[entry.ID] = id
+			}
+			continue
+		}
+
+		existing := current[i]
+		r.checks[entry.ID] = existing.ID
+		switch {
+		case existing.Type != entry.Type:
+			// The type of a check can not be changed, it is replaced.
+			change := Change{Resource: ResourceCheck, Action: ActionUpdate, ID: entry.ID, Name: name}
+			if id := r.record(change, func() (int, error) {
+				if _, err := r.account.Checks.Delete(ctx, existing.ID); err != nil {
+					return 0, err
+				}
+				created, err := r.account.Checks.Create(ctx, desired)
+				if err != nil {
+					return 0, err
+				}
+				return created.ID, nil
+			}); id != 0 {
+				r.checks[entry.ID] = id
+			}
+		case !reflect.DeepEqual(desired.PutParams(), existing.Check.PutParams()):
+			change := Change{Resource: ResourceCheck, Action: ActionUpdate, ID: entry.ID, Name: name}
+			r.record(change, func() (int, error) {
+				_, err := r.account.Checks.Update(ctx, existing.ID, desired)
+				return 0, err
+			})
+		}
+	}
+}
+
+// toWindow returns the definition of the maintenance window, covering the
+// given checks.
+func toWindow(window pingdom.MaintenanceResponse, uptimeIDs []int) *pingdom.MaintenanceWindow {
+	w := &pingdom.MaintenanceWindow{
+		Description: window.Description,
+		From:        window.From,
+		To:          window.To,
+		UptimeIDs:   joinIDs(uptimeIDs),
+		TmsIDs:      joinIDs(window.Checks.Tms),
+	}
+	if window.RecurrenceType != "" && window.RecurrenceType != "none" {
+		w.RecurrenceType = window.RecurrenceType
+		w.RepeatEvery = window.RepeatEvery
+		w.EffectiveTo = window.EffectiveTo
+	}
+	return w
+}
+
+func joinIDs(ids []int) string {
+	sorted := append([]int(nil), ids...)
+	sort.Ints(sorted)
+	s := make([]string, len(sorted))
+	for i, id := range sorted {
+		s[i] = strconv.Itoa(id)
+	}
+	return strings.Join(s, ",")
+}
+
+func (r *restorer) restoreMaintenances(ctx context.Context) {
+	current := r.current.Maintenances
+	m := newMatcher(len(current), func(i int) (int, string) { return current[i].ID, current[i].Description })
+	r.matched[ResourceMaintenance] = m
+
+	for _, window := range r.desired.Maintenances {
+		window := window
+		uptimeIDs, missing := r.checks.remap(window.Checks.Uptime)
+		desired := toWindow(window, uptimeIDs)
+		i, ok := m.match(window.ID, window.Description)
+
+		var change Change
+		var apply func() (int, error)
+		switch {
+		case !ok:
+			change = Change{Resource: ResourceMaintenance, Action: ActionCreate, ID: window.ID, Name: window.Description}
+			apply = func() (int, error) {
+				created, err := r.account.Maintenances.Create(ctx, desired)
+				if err != nil {
+					return 0, err
+				}
+				return created.ID, nil
+			}
+		case !reflect.DeepEqual(desired.PutParams(), toWindow(current[i], current[i].Checks.Uptime).PutParams()):
+			id := current[i].ID
+			change = Change{Resource: ResourceMaintenance, Action: ActionUpdate, ID: window.ID, Name: window.Description}
+			apply = func() (int, error) {
+				_, err := r.account.Maintenances.Update(ctx, id, desired)
+				return 0, err
+			}
+		default:
+			continue
+		}
+
+		if len(missing) > 0 {
+			// Applying the window without the checks which could not be
+			// restored would silently drop them from it.
+			change.Err = fmt.Errorf("checks %v could not be restored", missing)
+			r.changes = append(r.changes, change)
+			continue
+		}
+		r.record(change, apply)
+	}
+}
+
+// prune deletes the current resources which matched none of the snapshot,
+// dependent resources first.
+func (r *restorer) prune(ctx context.Context) {
+	for i, window := range r.current.Maintenances {
+		if !r.matched[ResourceMaintenance].claimed[i] {
+			id := window.ID
+			r.record(Change{Resource: ResourceMaintenance, Action: ActionDelete, ID: id, Name: window.Description}, func() (int, error) {
+				_, err := r.account.Maintenances.Delete(ctx, id)
+				return 0, err
+			})
+		}
+	}
+	for i, entry := range r.current.Checks {
+		if !r.matched[ResourceCheck].claimed[i] {
+			id := entry.ID
+			r.record(Change{Resource: ResourceCheck, Action: ActionDelete, ID: id, Name: checkName(entry)}, func() (int, error) {
+				_, err := r.account.Checks.Delete(ctx, id)
+				return 0, err
+			})
+		}
+	}
+	for i, team := range r.current.Teams {
+		if !r.matched[ResourceTeam].claimed[i] {
+			id := team.ID
+			r.record(Change{Resource: ResourceTeam, Action: ActionDelete, ID: id, Name: team.Name}, func() (int, error) {
+				_, err := r.account.Teams.Delete(ctx, id)
+				return 0, err
+			})
+		}
+	}
+	for i, contact := range r.current.Contacts {
+		if !r.matched[ResourceContact].claimed[i] && !contact.Owner {
+			id := contact.ID
+			r.record(Change{Resource: ResourceContact, Action: ActionDelete, ID: id, Name: contact.Name}, func() (int, error) {
+				_, err := r.account.Contacts.Delete(ctx, id)
+				return 0, err
+			})
+		}
+	}
+}
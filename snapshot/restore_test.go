@@ -0,0 +1,121 @@
+package snapshot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffUnchanged(t *testing.T) {
+	account := newFakeAccount()
+	s, err := Take(context.Background(), account.account())
+	assert.NoError(t, err)
+	assert.Empty(t, Diff(s, s))
+
+	changes, err := Restore(context.Background(), account.account(), s, Options{Prune: true})
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.Empty(t, account.calls)
+}
+
+func TestRestore(t *testing.T) {
+	account := newFakeAccount()
+	s, err := Take(context.Background(), account.account())
+	assert.NoError(t, err)
+
+	// Risky changes: Alice and the Ops team are deleted, the check is
+	// modified and a new check is created.
+	delete(account.contacts, 2)
+	delete(account.teams, 10)
+	web := account.checks[20]
+	web.Resolution = 1
+	web.TeamIds = nil
+	account.checks[20] = web
+	account.checks[21] = pingdom.CheckResponse{ID: 21, Name: "new", Hostname: "example.org", Type: pingdom.CheckResponseType{Name: "http"}}
+
+	current, err := Take(context.Background(), account.account())
+	assert.NoError(t, err)
+	assert.Equal(t, []Change{
+		{Resource: ResourceContact, Action: ActionCreate, ID: 2, Name: "Alice"},
+		{Resource: ResourceTeam, Action: ActionCreate, ID: 10, Name: "Ops"},
+		{Resource: ResourceCheck, Action: ActionUpdate, ID: 20, Name: "web"},
+		{Resource: ResourceCheck, Action: ActionDelete, ID: 21, Name: "new"},
+	}, Diff(current, s))
+
+	changes, err := Restore(context.Background(), account.account(), s, Options{DryRun: true})
+	assert.NoError(t, err)
+	assert.Len(t, changes, 3)
+	assert.Empty(t, account.calls)
+
+	changes, err = Restore(context.Background(), account.account(), s, Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, []Change{
+		{Resource: ResourceContact, Action: ActionCreate, ID: 2, Name: "Alice", NewID: 101},
+		{Resource: ResourceTeam, Action: ActionCreate, ID: 10, Name: "Ops", NewID: 102},
+		{Resource: ResourceCheck, Action: ActionUpdate, ID: 20, Name: "web"},
+	}, changes)
+
+	// References are remapped to the new IDs.
+	assert.Equal(t, []pingdom.TeamMemberResponse{{ID: 101}}, account.teams[102].Members)
+	assert.Equal(t, []int{102}, account.checks[20].TeamIds)
+	assert.Equal(t, 5, account.checks[20].Resolution)
+	assert.Contains(t, account.checks, 21)
+
+	changes, err = Restore(context.Background(), account.account(), s, Options{Prune: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []Change{
+		{Resource: ResourceCheck, Action: ActionDelete, ID: 21, Name: "new"},
+	}, changes)
+	assert.NotContains(t, account.checks, 21)
+}
+
+func TestRestoreRecreatesDeletedChecks(t *testing.T) {
+	account := newFakeAccount()
+	s, err := Take(context.Background(), account.account())
+	assert.NoError(t, err)
+
+	delete(account.checks, 20)
+	account.failCreate = true
+	changes, err := Restore(context.Background(), account.account(), s, Options{})
+	assert.NoError(t, err)
+	if assert.Len(t, changes, 2) {
+		assert.EqualError(t, changes[0].Err, "create failed")
+		// The window is left alone rather than losing the check.
+		assert.Equal(t, ResourceMaintenance, changes[1].Resource)
+		assert.EqualError(t, changes[1].Err, "checks [20] could not be restored")
+	}
+	assert.Empty(t, account.calls)
+
+	account.failCreate = false
+	changes, err = Restore(context.Background(), account.account(), s, Options{})
+	assert.NoError(t, err)
+	assert.Equal(t, []Change{
+		{Resource: ResourceCheck, Action: ActionCreate, ID: 20, Name: "web", NewID: 101},
+		{Resource: ResourceMaintenance, Action: ActionUpdate, ID: 30, Name: "weekly"},
+	}, changes)
+	assert.Equal(t, []int{101}, account.maintenances[30].Checks.Uptime)
+	assert.Equal(t, []string{"create check", "update maintenance"}, account.calls)
+
+	// Restoring again matches the check created again by name.
+	changes, err = Restore(context.Background(), account.account(), s, Options{Prune: true})
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestRestoreNeverDeletesOwner(t *testing.T) {
+	account := newFakeAccount()
+	s, err := Take(context.Background(), account.account())
+	assert.NoError(t, err)
+	s.Contacts = nil
+
+	changes := Diff(s, &Snapshot{})
+	for _, change := range changes {
+		assert.NotEqual(t, 1, change.ID, change)
+	}
+	current, _ := Take(context.Background(), account.account())
+	for _, change := range Diff(current, s) {
+		assert.NotEqual(t, 1, change.ID, change)
+	}
+}
@@ -0,0 +1,201 @@
+// Package snapshot saves the configuration of a Pingdom account (contacts,
+// alerting teams, checks and maintenance windows) to a versioned archive, and
+// restores it by applying only the differences with the current state.  It is
+// meant as a backup to roll back to before risky bulk changes.
+//
+// Snapshots hold the full definition of checks, including the credentials of
+// HTTP checks, and should be stored accordingly.
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+)
+
+// FormatVersion is the version of the archive format written by Write.
+const FormatVersion = 1
+
+// ContactStore manages Pingdom contacts.  It is implemented by
+// *pingdom.ContactService.
+type ContactStore interface {
+	List(ctx context.Context) ([]pingdom.Contact, error)
+	Create(ctx context.Context, contact pingdom.ContactAPI) (*pingdom.Contact, error)
+	Update(ctx context.Context, id int, contact pingdom.ContactAPI) (*pingdom.PingdomResponse, error)
+	Delete(ctx context.Context, id int) (*pingdom.PingdomResponse, error)
+}
+
+// TeamStore manages Pingdom alerting teams.  It is implemented by
+// *pingdom.TeamService.
+type TeamStore interface {
+	List(ctx context.Context) ([]pingdom.TeamResponse, error)
+	Create(ctx context.Context, team pingdom.TeamAPI) (*pingdom.TeamResponse, error)
+	Update(ctx context.Context, id int, team pingdom.TeamAPI) (*pingdom.TeamResponse, error)
+	Delete(ctx context.Context, id int) (*pingdom.TeamDeleteResponse, error)
+}
+
+// CheckStore manages Pingdom checks.  It is implemented by
+// *pingdom.CheckService.
+type CheckStore interface {
+	List(ctx context.Context, params ...map[string]string) ([]pingdom.CheckResponse, error)
+	Read(ctx context.Context, id int) (*pingdom.CheckResponse, error)
+	Create(ctx context.Context, check pingdom.Check) (*pingdom.CheckResponse, error)
+	Update(ctx context.Context, id int, check pingdom.Check) (*pingdom.PingdomResponse, error)
+	Delete(ctx context.Context, id int) (*pingdom.PingdomResponse, error)
+}
+
+// MaintenanceStore manages Pingdom maintenance windows.  It is implemented by
+// *pingdom.MaintenanceService.
+type MaintenanceStore interface {
+	List(ctx context.Context, params ...map[string]string) ([]pingdom.MaintenanceResponse, error)
+	Create(ctx context.Context, maintenance pingdom.Maintenance) (*pingdom.MaintenanceResponse, error)
+	Update(ctx context.Context, id int, maintenance pingdom.Maintenance) (*pingdom.PingdomResponse, error)
+	Delete(ctx context.Context, id int) (*pingdom.PingdomResponse, error)
+}
+
+// Account gives access to the resources of a Pingdom account.
+type Account struct {
+	Contacts     ContactStore
+	Teams        TeamStore
+	Checks       CheckStore
+	Maintenances MaintenanceStore
+}
+
+// NewAccount returns the Account accessed through the given client.
+func NewAccount(client *pingdom.Client) Account {
+	return Account{
+		Contacts:     client.Contacts,
+		Teams:        client.Teams,
+		Checks:       client.Checks,
+		Maintenances: client.Maintenances,
+	}
+}
+
+// Snapshot is the configuration of an account at a point in time.
+type Snapshot struct {
+	Version      int                           `json:"version"`
+	TakenAt      time.Time                     `json:"taken_at"`
+	Contacts     []pingdom.Contact             `json:"contacts"`
+	Teams        []pingdom.TeamResponse        `json:"teams"`
+	Checks       []CheckEntry                  `json:"checks"`
+	Maintenances []pingdom.MaintenanceResponse `json:"maintenances"`
+}
+
+// CheckEntry is the definition of a check along with its ID and type.
+type CheckEntry struct {
+	ID    int
+	Type  string
+	Check pingdom.Check
+}
+
+type checkEntryJSON struct {
+	ID    int             `json:"id"`
+	Type  string          `json:"type"`
+	Check json.RawMessage `json:"check"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (e CheckEntry) MarshalJSON() ([]byte, error) {
+	check, err := json.Marshal(e.Check)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(checkEntryJSON{ID: e.ID, Type: e.Type, Check: check})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, the check is decoded into the
+// pingdom type matching Type.
+func (e *CheckEntry) UnmarshalJSON(b []byte) error {
+	raw := checkEntryJSON{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	var check pingdom.Check
+	switch raw.Type {
+	case "http":
+		check = &pingdom.HttpCheck{}
+	case "httpcustom":
+		check = &pingdom.HttpCustomCheck{}
+	case "ping":
+		check = &pingdom.PingCheck{}
+	case "tcp":
+		check = &pingdom.TCPCheck{}
+	case "udp":
+		check = &pingdom.UDPCheck{}
+	case "smtp":
+		check = &pingdom.SMTPCheck{}
+	case "pop3":
+		check = &pingdom.POP3Check{}
+	case "imap":
+		check = &pingdom.IMAPCheck{}
+	case "dns":
+		check = &pingdom.DNSCheck{}
+	default:
+		return fmt.Errorf("check %d: unsupported check type %q", raw.ID, raw.Type)
+	}
+	if err := json.Unmarshal(raw.Check, check); err != nil {
+		return err
+	}
+	*e = CheckEntry{ID: raw.ID, Type: raw.Type, Check: check}
+	return nil
+}
+
+// Take reads the configuration of the account.  Checks of a type which can
+// not be converted to a Check are reported as an error.
+func Take(ctx context.Context, account Account) (*Snapshot, error) {
+	s := &Snapshot{Version: FormatVersion, TakenAt: time.Now().UTC()}
+
+	var err error
+	if s.Contacts, err = account.Contacts.List(ctx); err != nil {
+		return nil, fmt.Errorf("listing contacts: %w", err)
+	}
+	if s.Teams, err = account.Teams.List(ctx); err != nil {
+		return nil, fmt.Errorf("listing teams: %w", err)
+	}
+	if s.Maintenances, err = account.Maintenances.List(ctx); err != nil {
+		return nil, fmt.Errorf("listing maintenance windows: %w", err)
+	}
+
+	checks, err := account.Checks.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("listing checks: %w", err)
+	}
+	for _, summary := range checks {
+		details, err := account.Checks.Read(ctx, summary.ID)
+		if err != nil {
+			return nil, fmt.Errorf("reading check %d: %w", summary.ID, err)
+		}
+		sort.Ints(details.UserIds)
+		sort.Ints(details.TeamIds)
+		check, err := details.ToCheck()
+		if err != nil {
+			return nil, fmt.Errorf("check %d: %w", summary.ID, err)
+		}
+		s.Checks = append(s.Checks, CheckEntry{ID: summary.ID, Type: details.Type.Name, Check: check})
+	}
+	return s, nil
+}
+
+// Write writes the snapshot as JSON.
+func (s *Snapshot) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(s)
+}
+
+// Read reads a snapshot written by Write.
+func Read(r io.Reader) (*Snapshot, error) {
+	s := &Snapshot{}
+	if err := json.NewDecoder(r).Decode(s); err != nil {
+		return nil, err
+	}
+	if s.Version != FormatVersion {
+		return nil, fmt.Errorf("unsupported snapshot version %d", s.Version)
+	}
+	return s, nil
+}
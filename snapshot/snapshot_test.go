@@ -0,0 +1,280 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeAccount is an in-memory account supporting HTTP checks only.
+type fakeAccount struct {
+	nextID       int
+	contacts     map[int]pingdom.Contact
+	teams        map[int]pingdom.TeamResponse
+	checks       map[int]pingdom.CheckResponse
+	maintenances map[int]pingdom.MaintenanceResponse
+	calls        []string
+	failCreate   bool
+}
+
+func newFakeAccount() *fakeAccount {
+	f := &fakeAccount{
+		nextID:       100,
+		contacts:     map[int]pingdom.Contact{},
+		teams:        map[int]pingdom.TeamResponse{},
+		checks:       map[int]pingdom.CheckResponse{},
+		maintenances: map[int]pingdom.MaintenanceResponse{},
+	}
+	f.contacts[1] = pingdom.Contact{ID: 1, Name: "Owner", Owner: true}
+	f.contacts[2] = pingdom.Contact{ID: 2, Name: "Alice", NotificationTargets: pingdom.NotificationTargets{
+		Email: []pingdom.EmailNotification{{Address: "alice@example.com", Severity: "HIGH"}},
+	}}
+	f.teams[10] = pingdom.TeamResponse{ID: 10, Name: "Ops", Members: []pingdom.TeamMemberResponse{{ID: 2}}}
+	f.checks[20] = pingdom.CheckResponse{
+		ID:         20,
+		Name:       "web",
+		Hostname:   "example.com",
+		Resolution: 5,
+		Type:       pingdom.CheckResponseType{Name: "http", HTTP: &pingdom.CheckResponseHTTPDetails{Url: "/"}},
+		TeamIds:    []int{10},
+	}
+	f.maintenances[30] = pingdom.MaintenanceResponse{
+		ID:          30,
+		Description: "weekly",
+		From:        1000,
+		To:          2000,
+		Checks:      pingdom.MaintenanceCheckResponse{Uptime: []int{20}},
+	}
+	return f
+}
+
+func (f *fakeAccount) account() Account {
+	return Account{Contacts: fakeContacts{f}, Teams: fakeTeams{f}, Checks: fakeChecks{f}, Maintenances: fakeMaintenances{f}}
+}
+
+func (f *fakeAccount) id(call string) (int, error) {
+	if f.failCreate {
+		return 0, errors.New("create failed")
+	}
+	f.nextID++
+	f.calls = append(f.calls, call)
+	return f.nextID, nil
+}
+
+type fakeContacts struct{ *fakeAccount }
+
+func (f fakeContacts) List(ctx context.Context) ([]pingdom.Contact, error) {
+	var list []pingdom.Contact
+	for _, c := range f.contacts {
+		list = append(list, c)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list, nil
+}
+
+func (f fakeContacts) Create(ctx context.Context, contact pingdom.ContactAPI) (*pingdom.Contact, error) {
+	id, err := f.id("create contact")
+	if err != nil {
+		return nil, err
+	}
+	c := *contact.(*pingdom.Contact)
+	c.ID = id
+	f.contacts[id] = c
+	return &c, nil
+}
+
+func (f fakeContacts) Update(ctx context.Context, id int, contact pingdom.ContactAPI) (*pingdom.PingdomResponse, error) {
+	f.calls = append(f.calls, "update contact")
+	c := *contact.(*pingdom.Contact)
+	c.ID = id
+	f.contacts[id] = c
+	return &pingdom.PingdomResponse{}, nil
+}
+
+func (f fakeContacts) Delete(ctx context.Context, id int) (*pingdom.PingdomResponse, error) {
+	f.calls = append(f.calls, "delete contact")
+	delete(f.contacts, id)
+	return &pingdom.PingdomResponse{}, nil
+}
+
+type fakeTeams struct{ *fakeAccount }
+
+func toTeamResponse(id int, team pingdom.TeamAPI) pingdom.TeamResponse {
+	t := team.(*pingdom.Team)
+	resp := pingdom.TeamResponse{ID: id, Name: t.Name}
+	for _, member := range t.MemberIDs {
+		resp.Members = append(resp.Members, pingdom.TeamMemberResponse{ID: member})
+	}
+	return resp
+}
+
+func (f fakeTeams) List(ctx context.Context) ([]pingdom.TeamResponse, error) {
+	var list []pingdom.TeamResponse
+	for _, t := range f.teams {
+		list = append(list, t)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list, nil
+}
+
+func (f fakeTeams) Create(ctx context.Context, team pingdom.TeamAPI) (*pingdom.TeamResponse, error) {
+	id, err := f.id("create team")
+	if err != nil {
+		return nil, err
+	}
+	f.teams[id] = toTeamResponse(id, team)
+	resp := f.teams[id]
+	return &resp, nil
+}
+
+func (f fakeTeams) Update(ctx context.Context, id int, team pingdom.TeamAPI) (*pingdom.TeamResponse, error) {
+	f.calls = append(f.calls, "update team")
+	f.teams[id] = toTeamResponse(id, team)
+	resp := f.teams[id]
+	return &resp, nil
+}
+
+func (f fakeTeams) Delete(ctx context.Context, id int) (*pingdom.TeamDeleteResponse, error) {
+	f.calls = append(f.calls, "delete team")
+	delete(f.teams, id)
+	return &pingdom.TeamDeleteResponse{}, nil
+}
+
+type fakeChecks struct{ *fakeAccount }
+
+func toCheckResponse(id int, check pingdom.Check) pingdom.CheckResponse {
+	c := check.(*pingdom.HttpCheck)
+	return pingdom.CheckResponse{
+		ID:         id,
+		Name:       c.Name,
+		Hostname:   c.Hostname,
+		Resolution: c.Resolution,
+		Type:       pingdom.CheckResponseType{Name: "http", HTTP: &pingdom.CheckResponseHTTPDetails{Url: c.Url}},
+		TeamIds:    c.TeamIds,
+		UserIds:    c.UserIds,
+	}
+}
+
+func (f fakeChecks) List(ctx context.Context, params ...map[string]string) ([]pingdom.CheckResponse, error) {
+	var list []pingdom.CheckResponse
+	for _, c := range f.checks {
+		list = append(list, c)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list, nil
+}
+
+func (f fakeChecks) Read(ctx context.Context, id int) (*pingdom.CheckResponse, error) {
+	c := f.checks[id]
+	return &c, nil
+}
+
+func (f fakeChecks) Create(ctx context.Context, check pingdom.Check) (*pingdom.CheckResponse, error) {
+	id, err := f.id("create check")
+	if err != nil {
+		return nil, err
+	}
+	f.checks[id] = toCheckResponse(id, check)
+	return &pingdom.CheckResponse{ID: id}, nil
+}
+
+func (f fakeChecks) Update(ctx context.Context, id int, check pingdom.Check) (*pingdom.PingdomResponse, error) {
+	f.calls = append(f.calls, "update check")
+	f.checks[id] = toCheckResponse(id, check)
+	return &pingdom.PingdomResponse{}, nil
+}
+
+func (f fakeChecks) Delete(ctx context.Context, id int) (*pingdom.PingdomResponse, error) {
+	f.calls = append(f.calls, "delete check")
+	delete(f.checks, id)
+	return &pingdom.PingdomResponse{}, nil
+}
+
+type fakeMaintenances struct{ *fakeAccount }
+
+func toMaintenanceResponse(id int, maintenance pingdom.Maintenance) pingdom.MaintenanceResponse {
+	w := maintenance.(*pingdom.MaintenanceWindow)
+	resp := pingdom.MaintenanceResponse{ID: id, Description: w.Description, From: w.From, To: w.To}
+	for _, s := range strings.Split(w.UptimeIDs, ",") {
+		if checkID, err := strconv.Atoi(s); err == nil {
+			resp.Checks.Uptime = append(resp.Checks.Uptime, checkID)
+		}
+	}
+	return resp
+}
+
+func (f fakeMaintenances) List(ctx context.Context, params ...map[string]string) ([]pingdom.MaintenanceResponse, error) {
+	var list []pingdom.MaintenanceResponse
+	for _, m := range f.maintenances {
+		list = append(list, m)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list, nil
+}
+
+func (f fakeMaintenances) Create(ctx context.Context, maintenance pingdom.Maintenance) (*pingdom.MaintenanceResponse, error) {
+	id, err := f.id("create maintenance")
+	if err != nil {
+		return nil, err
+	}
+	f.maintenances[id] = toMaintenanceResponse(id, maintenance)
+	return &pingdom.MaintenanceResponse{ID: id}, nil
+}
+
+func (f fakeMaintenances) Update(ctx context.Context, id int, maintenance pingdom.Maintenance) (*pingdom.PingdomResponse, error) {
+	f.calls = append(f.calls, "update maintenance")
+	f.maintenances[id] = toMaintenanceResponse(id, maintenance)
+	return &pingdom.PingdomResponse{}, nil
+}
+
+func (f fakeMaintenances) Delete(ctx context.Context, id int) (*pingdom.PingdomResponse, error) {
+	f.calls = append(f.calls, "delete maintenance")
+	delete(f.maintenances, id)
+	return &pingdom.PingdomResponse{}, nil
+}
+
+func TestTake(t *testing.T) {
+	account := newFakeAccount()
+	s, err := Take(context.Background(), account.account())
+	assert.NoError(t, err)
+	assert.Equal(t, FormatVersion, s.Version)
+	assert.False(t, s.TakenAt.IsZero())
+	assert.Len(t, s.Contacts, 2)
+	assert.Len(t, s.Teams, 1)
+	assert.Len(t, s.Maintenances, 1)
+	if assert.Len(t, s.Checks, 1) {
+		assert.Equal(t, 20, s.Checks[0].ID)
+		assert.Equal(t, "http", s.Checks[0].Type)
+		assert.Equal(t, "example.com", s.Checks[0].Check.(*pingdom.HttpCheck).Hostname)
+	}
+
+	account.checks[21] = pingdom.CheckResponse{ID: 21, Type: pingdom.CheckResponseType{Name: "transaction"}}
+	_, err = Take(context.Background(), account.account())
+	assert.EqualError(t, err, `check 21: unsupported check type "transaction"`)
+}
+
+func TestWriteRead(t *testing.T) {
+	s, err := Take(context.Background(), newFakeAccount().account())
+	assert.NoError(t, err)
+	s.Checks = append(s.Checks, CheckEntry{ID: 21, Type: "ping", Check: &pingdom.PingCheck{Name: "ping", Hostname: "example.org"}})
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, s.Write(buf))
+	read, err := Read(buf)
+	assert.NoError(t, err)
+	assert.True(t, s.TakenAt.Equal(read.TakenAt))
+	read.TakenAt = s.TakenAt
+	assert.Equal(t, s, read)
+
+	_, err = Read(strings.NewReader(`{"version": 2}`))
+	assert.EqualError(t, err, "unsupported snapshot version 2")
+	_, err = Read(strings.NewReader(`{"version": 1, "checks": [{"id": 1, "type": "transaction", "check": {}}]}`))
+	assert.EqualError(t, err, `check 1: unsupported check type "transaction"`)
+}
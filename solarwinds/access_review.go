@@ -0,0 +1,114 @@
+package solarwinds
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+)
+
+const (
+	AccessStatusActive  = "active"
+	AccessStatusPending = "pending"
+)
+
+// AccessReview lists everyone who has, or has been invited to have, access to the organization.
+type AccessReview struct {
+	OrganizationId string              `json:"organizationId"`
+	Entries        []AccessReviewEntry `json:"entries"`
+}
+
+// AccessReviewEntry is either an active member (Status is AccessStatusActive) or a pending invitation
+// (Status is AccessStatusPending) of the organization.
+type AccessReviewEntry struct {
+	Email     string    `json:"email"`
+	FirstName string    `json:"firstName,omitempty"`
+	LastName  string    `json:"lastName,omitempty"`
+	Status    string    `json:"status"`
+	Role      string    `json:"role"`
+	Products  []Product `json:"products"`
+	LastLogin string    `json:"lastLogin,omitempty"`
+	InvitedAt string    `json:"invitedAt,omitempty"`
+}
+
+var accessReviewCSVHeader = []string{"email", "first_name", "last_name", "status", "role", "products", "last_login", "invited_at"}
+
+// AccessReview combines the active users and the pending invitations of the organization into a single report,
+// sorted by email.
+func (us *UserService) AccessReview(ctx context.Context) (*AccessReview, error) {
+	activeUsers, err := us.ActiveUserService.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	invitations, err := us.InvitationService.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	review := &AccessReview{
+		OrganizationId: activeUsers.Organization.Id,
+		Entries:        []AccessReviewEntry{},
+	}
+	for _, member := range activeUsers.Organization.Members {
+		review.Entries = append(review.Entries, AccessReviewEntry{
+			Email:     member.User.Email,
+			FirstName: member.User.FirstName,
+			LastName:  member.User.LastName,
+			Status:    AccessStatusActive,
+			Role:      member.Role,
+			Products:  member.Products,
+			LastLogin: member.User.LastLogin,
+		})
+	}
+	for _, invitation := range invitations.Organization.Invitations {
+		review.Entries = append(review.Entries, AccessReviewEntry{
+			Email:     invitation.Email,
+			Status:    AccessStatusPending,
+			Role:      invitation.Role,
+			Products:  invitation.Products,
+			InvitedAt: invitation.Date,
+		})
+	}
+	sort.SliceStable(review.Entries, func(i, j int) bool {
+		return review.Entries[i].Email < review.Entries[j].Email
+	})
+	return review, nil
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *AccessReview) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteCSV writes the report as CSV with a header row. Products are rendered as a ';' separated list of NAME:ROLE.
+func (r *AccessReview) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(accessReviewCSVHeader); err != nil {
+		return err
+	}
+	for _, entry := range r.Entries {
+		products := make([]string, 0, len(entry.Products))
+		for _, product := range entry.Products {
+			products = append(products, product.Name+":"+product.Role)
+		}
+		record := []string{
+			entry.Email,
+			entry.FirstName,
+			entry.LastName,
+			entry.Status,
+			entry.Role,
+			strings.Join(products, ";"),
+			entry.LastLogin,
+			entry.InvitedAt,
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
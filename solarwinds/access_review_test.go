@@ -0,0 +1,82 @@
+package solarwinds
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func setupAccessReview(t *testing.T) {
+	mux.HandleFunc(graphQLEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		graphQLReq := GraphQLRequest{}
+		_ = json.NewDecoder(r.Body).Decode(&graphQLReq)
+
+		switch graphQLReq.OperationName {
+		case listActiveUserOp:
+			_, _ = fmt.Fprint(w, listActiveUserResponseStr)
+		case listInvitationOp:
+			_, _ = fmt.Fprint(w, listInvitationResponseStr)
+		default:
+			t.Errorf("should not have op: %v", graphQLReq.OperationName)
+		}
+	})
+}
+
+func TestAccessReview(t *testing.T) {
+	setup()
+	defer teardown()
+	setupAccessReview(t)
+
+	review, err := client.UserService.AccessReview(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "106269109693582336", review.OrganizationId)
+	assert.Equal(t, 4, len(review.Entries))
+
+	var emails []string
+	for _, entry := range review.Entries {
+		emails = append(emails, entry.Email)
+	}
+	assert.Equal(t, []string{"0JTELJv5YA@foo.com", pendingUserEmail, "bar@nordcloud.com", activeUserEmail}, emails)
+
+	pending := review.Entries[1]
+	assert.Equal(t, AccessStatusPending, pending.Status)
+	assert.Equal(t, "2021-03-25T02:36:48Z", pending.InvitedAt)
+	assert.Empty(t, pending.LastLogin)
+
+	active := review.Entries[3]
+	assert.Equal(t, AccessStatusActive, active.Status)
+	assert.Equal(t, "IT", active.FirstName)
+	assert.Equal(t, "2021-03-23T07:17:48Z", active.LastLogin)
+	assert.Equal(t, 3, len(active.Products))
+}
+
+func TestAccessReviewExport(t *testing.T) {
+	setup()
+	defer teardown()
+	setupAccessReview(t)
+
+	review, err := client.UserService.AccessReview(context.Background())
+	assert.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, review.WriteCSV(buf))
+	records, err := csv.NewReader(buf).ReadAll()
+	assert.NoError(t, err)
+	assert.Equal(t, 5, len(records))
+	assert.Equal(t, accessReviewCSVHeader, records[0])
+	assert.Equal(t, []string{
+		activeUserEmail, "IT", "Nordcloud", AccessStatusActive, "ADMIN",
+		"APPOPTICS:NO_ACCESS;LOGGLY:NO_ACCESS;PINGDOM:ADMIN", "2021-03-23T07:17:48Z", "",
+	}, records[4])
+
+	buf.Reset()
+	assert.NoError(t, review.WriteJSON(buf))
+	decoded := AccessReview{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, *review, decoded)
+}
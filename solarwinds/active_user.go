@@ -1,18 +1,6 @@
 package solarwinds
 
-const (
-	listActiveUserOp           = "getUsersQuery"
-	listActiveUserQuery        = "query getUsersQuery {\n  user {\n    id\n    currentOrganization {\n      id\n      members {\n        user {\n          id\n          firstName\n          lastName\n          email\n          lastLogin\n          __typename\n        }\n        role\n        products {\n          name\n          access\n          role\n          __typename\n        }\n        __typename\n      }\n      __typename\n    }\n    __typename\n  }\n}\n"
-	listActiveUserResponseType = "user"
-
-	getActiveUserOp           = "getEditUserQuery"
-	getActiveUserQuery        = "query getEditUserQuery($userId: String!) {\n  user {\n    id\n    currentOrganization {\n      id\n      members(filter: {id: $userId}) {\n        id\n        user {\n          email\n          __typename\n        }\n        role\n        products {\n          name\n          role\n          access\n          __typename\n        }\n        __typename\n      }\n      __typename\n    }\n    __typename\n  }\n}\n"
-	getActiveUserResponseType = "user"
-
-	updateActiveUserOp           = "updateMemberRolesMutation"
-	updateActiveUserQuery        = "mutation updateMemberRolesMutation($userId: ID!, $role: OrganizationRole!, $products: [ProductAccessInput!]) {\n  updateMemberRoles(userId: $userId, input: {role: $role, products: $products}) {\n    code\n    success\n    message\n    __typename\n  }\n}\n"
-	updateActiveUserResponseType = "updateMemberRoles"
-)
+import "context"
 
 type UpdateActiveUserRequest struct {
 	UserId   string    `json:"userId"`
@@ -21,10 +9,6 @@ type UpdateActiveUserRequest struct {
 	Email    string    `json:"-"`
 }
 
-type getActiveUserVars struct {
-	UserId string `json:"userId"`
-}
-
 type ActiveUserList struct {
 	OwnerUserId  string                  `json:"id"`
 	Organization OrganizationWithMembers `json:"currentOrganization"`
@@ -53,56 +37,64 @@ type ActiveUserService struct {
 	client *Client
 }
 
-func (us *ActiveUserService) List() (*ActiveUserList, error) {
-	req := GraphQLRequest{
-		OperationName: listActiveUserOp,
-		Query:         listActiveUserQuery,
-		ResponseType:  listActiveUserResponseType,
-	}
-	resp, err := us.client.MakeGraphQLRequest(&req)
+func (us *ActiveUserService) List(ctx context.Context) (*ActiveUserList, error) {
+	resp, err := us.client.listActiveUser(ctx)
 	if err != nil {
 		return nil, err
 	}
-	userList := ActiveUserList{}
-	if err := Convert(&resp, &userList); err != nil {
-		return nil, err
+	userList := ActiveUserList{OwnerUserId: resp.Id}
+	if org := resp.CurrentOrganization; org != nil {
+		userList.Organization.Id = org.Id
+		for _, m := range org.Members {
+			member := OrganizationMember{
+				User: ActiveUser{
+					Id:        m.User.Id,
+					FirstName: m.User.FirstName,
+					LastName:  m.User.LastName,
+					Email:     m.User.Email,
+					LastLogin: m.User.LastLogin,
+				},
+				Role: m.Role,
+			}
+			for _, p := range m.Products {
+				member.Products = append(member.Products, Product{Name: p.Name, Role: p.Role})
+			}
+			userList.Organization.Members = append(userList.Organization.Members, member)
+		}
 	}
 	return &userList, nil
 }
 
-func (us *ActiveUserService) Get(userId string) (*ActiveUserList, error) {
-	req := GraphQLRequest{
-		OperationName: getActiveUserOp,
-		Query:         getActiveUserQuery,
-		Variables: getActiveUserVars{
-			UserId: userId,
-		},
-		ResponseType: getActiveUserResponseType,
-	}
-	resp, err := us.client.MakeGraphQLRequest(&req)
+func (us *ActiveUserService) Get(ctx context.Context, userId string) (*ActiveUserList, error) {
+	resp, err := us.client.getActiveUser(ctx, getActiveUserVars{UserId: userId})
 	if err != nil {
 		return nil, err
 	}
-	userList := ActiveUserList{}
-	if err := Convert(&resp, &userList); err != nil {
-		return nil, err
+	userList := ActiveUserList{OwnerUserId: resp.Id}
+	if org := resp.CurrentOrganization; org != nil {
+		userList.Organization.Id = org.Id
+		for _, m := range org.Members {
+			member := OrganizationMember{User: ActiveUser{Email: m.User.Email}, Role: m.Role}
+			for _, p := range m.Products {
+				member.Products = append(member.Products, Product{Name: p.Name, Role: p.Role})
+			}
+			userList.Organization.Members = append(userList.Organization.Members, member)
+		}
 	}
 	return &userList, nil
 }
 
-func (us *ActiveUserService) Update(update UpdateActiveUserRequest) error {
-	req := GraphQLRequest{
-		OperationName: updateActiveUserOp,
-		Query:         updateActiveUserQuery,
-		Variables:     update,
-		ResponseType:  updateActiveUserResponseType,
-	}
-	_, err := us.client.MakeGraphQLRequest(&req)
+func (us *ActiveUserService) Update(ctx context.Context, update UpdateActiveUserRequest) error {
+	_, err := us.client.updateActiveUser(ctx, updateActiveUserVars{
+		UserId:   update.UserId,
+		Role:     update.Role,
+		Products: productInputs(update.Products),
+	})
 	return err
 }
 
-func (us *ActiveUserService) GetByEmail(email string) (*OrganizationMember, error) {
-	activeUserList, err := us.List()
+func (us *ActiveUserService) GetByEmail(ctx context.Context, email string) (*OrganizationMember, error) {
+	activeUserList, err := us.List(ctx)
 	if err != nil {
 		return nil, err
 	}
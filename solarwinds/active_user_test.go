@@ -1,6 +1,7 @@
 package solarwinds
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/stretchr/testify/assert"
@@ -160,13 +161,13 @@ func TestListActiveUsers(t *testing.T) {
 
 		_, _ = fmt.Fprint(w, listActiveUserResponseStr)
 	})
-	userList, err := client.ActiveUserService.List()
+	userList, err := client.ActiveUserService.List(context.Background())
 	assert.NoError(t, err)
 	members := userList.Organization.Members
 	assert.Equal(t, "106586091288584192", userList.OwnerUserId)
 	assert.Equal(t, len(members), 2)
 
-	user, err := client.ActiveUserService.GetByEmail(members[1].User.Email)
+	user, err := client.ActiveUserService.GetByEmail(context.Background(), members[1].User.Email)
 	assert.NoError(t, err)
 	assert.Equal(t, members[1], *user)
 }
@@ -189,7 +190,7 @@ func TestGetActiveUser(t *testing.T) {
 		assert.Equal(t, input, actualVars)
 		_, _ = fmt.Fprint(w, getActiveUserResponseStr)
 	})
-	userList, err := client.ActiveUserService.Get("106586091288584192")
+	userList, err := client.ActiveUserService.Get(context.Background(), "106586091288584192")
 	assert.NoError(t, err)
 	members := userList.Organization.Members
 	assert.Equal(t, len(members), 1)
@@ -221,6 +222,6 @@ func TestUpdateActiveUser(t *testing.T) {
 		assert.Equal(t, update, actualVars)
 		_, _ = fmt.Fprint(w, updateActiveUserResponseStr)
 	})
-	err := client.ActiveUserService.Update(update)
+	err := client.ActiveUserService.Update(context.Background(), update)
 	assert.NoError(t, err)
 }
@@ -0,0 +1,139 @@
+package solarwinds
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// CSRFSource is the stage of the acquisition of the CSRF token which succeeded.
+type CSRFSource string
+
+// The stages of the acquisition of the CSRF token, attempted in this order.
+const (
+	// CSRFSourceMetaTag is the csrf-token meta tag of the settings page.
+	CSRFSourceMetaTag CSRFSource = "meta tag"
+	// CSRFSourceHeader is the X-CSRF-Token header of the settings page.
+	CSRFSourceHeader CSRFSource = "response header"
+	// CSRFSourceEndpoint is the dedicated token endpoint of AuthConfig.TokenPath.
+	CSRFSourceEndpoint CSRFSource = "token endpoint"
+	// CSRFSourceStatic is the static token of AuthConfig.CSRFToken.
+	CSRFSourceStatic CSRFSource = "static token"
+)
+
+// CSRFError is returned when every stage of the acquisition of the CSRF token failed. Stages lists why each stage
+// failed, in order.
+type CSRFError struct {
+	Stages []CSRFStageError
+}
+
+// CSRFStageError is the failure of a stage of the acquisition of the CSRF token.
+type CSRFStageError struct {
+	Source CSRFSource
+	Err    error
+}
+
+func (e *CSRFError) Error() string {
+	stages := make([]string, len(e.Stages))
+	for i, stage := range e.Stages {
+		stages[i] = fmt.Sprintf("%s: %v", stage.Source, stage.Err)
+	}
+	return "failed to obtain the CSRF token: " + strings.Join(stages, "; ")
+}
+
+var errStageNotConfigured = errors.New("not configured")
+
+// CSRFTokenSource returns the stage which provided the CSRF token at the last Init, empty before.
+func (c *Client) CSRFTokenSource() CSRFSource {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.csrfSource
+}
+
+// acquireCSRFToken obtains the CSRF token from the response of the settings page, falling back on the token endpoint
+// and then on the static token, so that a change of the settings page does not break the login.
+func (c *Client) acquireCSRFToken(ctx context.Context, resp *http.Response, cookies []*http.Cookie) error {
+	stages := []struct {
+		source CSRFSource
+		get    func() (string, error)
+	}{
+		{CSRFSourceMetaTag, func() (string, error) {
+			doc, err := html.Parse(resp.Body)
+			if err != nil {
+				return "", err
+			}
+			return extractCSRFToken(doc)
+		}},
+		{CSRFSourceHeader, func() (string, error) {
+			if token := resp.Header.Get(headerNameCSRFToken); token != "" {
+				return token, nil
+			}
+			return "", fmt.Errorf("no %s header", headerNameCSRFToken)
+		}},
+		{CSRFSourceEndpoint, func() (string, error) {
+			return c.fetchCSRFToken(ctx, cookies)
+		}},
+		{CSRFSourceStatic, func() (string, error) {
+			if c.auth.CSRFToken == "" {
+				return "", errStageNotConfigured
+			}
+			return c.auth.CSRFToken, nil
+		}},
+	}
+
+	csrfErr := &CSRFError{}
+	for _, stage := range stages {
+		token, err := stage.get()
+		if err == nil {
+			c.csrfToken, c.csrfSource = token, stage.source
+			return nil
+		}
+		csrfErr.Stages = append(csrfErr.Stages, CSRFStageError{Source: stage.source, Err: err})
+	}
+	return csrfErr
+}
+
+// fetchCSRFToken gets the token from the token endpoint, either from its X-CSRF-Token header or from the csrfToken
+// or token field of its JSON body.
+func (c *Client) fetchCSRFToken(ctx context.Context, cookies []*http.Cookie) (string, error) {
+	if c.auth.TokenPath == "" {
+		return "", errStageNotConfigured
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+c.auth.TokenPath, nil)
+	if err != nil {
+		return "", err
+	}
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("status %d", resp.StatusCode)
+	}
+	if token := resp.Header.Get(headerNameCSRFToken); token != "" {
+		return token, nil
+	}
+	var body struct {
+		CSRFToken string `json:"csrfToken"`
+		Token     string `json:"token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("invalid response: %v", err)
+	}
+	if body.CSRFToken != "" {
+		return body.CSRFToken, nil
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return "", errors.New("response contains no token")
+}
@@ -0,0 +1,110 @@
+package solarwinds
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const settingsWithoutTokenStr = `<!doctype html><html><head><title>Settings</title></head><body></body></html>`
+
+func TestObtainTokenFallbacks(t *testing.T) {
+	tests := []struct {
+		name     string
+		auth     AuthConfig
+		header   string
+		endpoint func(w http.ResponseWriter)
+		token    string
+		source   CSRFSource
+	}{
+		{
+			name:   "header",
+			header: "from-header",
+			auth:   AuthConfig{TokenPath: "/token", CSRFToken: "static"},
+			token:  "from-header",
+			source: CSRFSourceHeader,
+		},
+		{
+			name:     "endpoint body",
+			auth:     AuthConfig{TokenPath: "/token", CSRFToken: "static"},
+			endpoint: func(w http.ResponseWriter) { fmt.Fprint(w, `{"csrfToken":"from-endpoint"}`) },
+			token:    "from-endpoint",
+			source:   CSRFSourceEndpoint,
+		},
+		{
+			name: "endpoint header",
+			auth: AuthConfig{TokenPath: "/token"},
+			endpoint: func(w http.ResponseWriter) {
+				w.Header().Set(headerNameCSRFToken, "from-endpoint-header")
+			},
+			token:  "from-endpoint-header",
+			source: CSRFSourceEndpoint,
+		},
+		{
+			name:     "static",
+			auth:     AuthConfig{TokenPath: "/token", CSRFToken: "static"},
+			endpoint: func(w http.ResponseWriter) { w.WriteHeader(http.StatusNotFound) },
+			token:    "static",
+			source:   CSRFSourceStatic,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup()
+			defer teardown()
+			client.auth = tt.auth.withDefaults()
+			mux.HandleFunc("/settings", func(w http.ResponseWriter, r *http.Request) {
+				if tt.header != "" {
+					w.Header().Set(headerNameCSRFToken, tt.header)
+				}
+				fmt.Fprint(w, settingsWithoutTokenStr)
+			})
+			mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+				cookie, err := r.Cookie(cookieNameSwicus)
+				assert.NoError(t, err)
+				assert.Equal(t, "swicus", cookie.Value)
+				tt.endpoint(w)
+			})
+
+			err := client.obtainToken(context.Background(), &loginResult{Swicus: "swicus"})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.token, client.csrfToken)
+			assert.Equal(t, tt.source, client.CSRFTokenSource())
+		})
+	}
+}
+
+func TestObtainTokenMetaTagSource(t *testing.T) {
+	setup()
+	defer teardown()
+	client.auth = AuthConfig{CSRFToken: "static"}.withDefaults()
+	mux.HandleFunc("/settings", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerNameCSRFToken, "from-header")
+		fmt.Fprint(w, obtainTokenRespStr)
+	})
+	assert.NoError(t, client.obtainToken(context.Background(), &loginResult{}))
+	assert.Equal(t, "fbO8qrEt-qGJ3jtQctuzcbVfBD47Quy-RE_Q", client.csrfToken)
+	assert.Equal(t, CSRFSourceMetaTag, client.CSRFTokenSource())
+}
+
+func TestObtainTokenAllStagesFail(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/settings", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, settingsWithoutTokenStr)
+	})
+
+	err := client.obtainToken(context.Background(), &loginResult{})
+	assert.EqualError(t, err, "failed to obtain the CSRF token: "+
+		"meta tag: response of callback URL does not contain CSRF token; "+
+		"response header: no X-CSRF-Token header; "+
+		"token endpoint: not configured; "+
+		"static token: not configured")
+	if csrfErr, ok := err.(*CSRFError); assert.True(t, ok) {
+		assert.Len(t, csrfErr.Stages, 4)
+	}
+	assert.Equal(t, CSRFSource(""), client.CSRFTokenSource())
+}
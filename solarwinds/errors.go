@@ -1,10 +1,15 @@
 package solarwinds
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/nordcloud/go-pingdom/apierror"
+)
 
 const (
 	ErrCodeNetworkException uint32 = iota
 	ErrCodeDeleteActiveUserException
+	ErrCodeProtocolException
 )
 
 type ClientError struct {
@@ -16,6 +21,10 @@ func (c *ClientError) Error() string {
 	return fmt.Sprintf("status: %d, err: %v", c.StatusCode, c.Err)
 }
 
+func (c *ClientError) Unwrap() error {
+	return c.Err
+}
+
 func NewNetworkError(cause error) error {
 	return &ClientError{
 		StatusCode: ErrCodeNetworkException,
@@ -29,3 +38,46 @@ func NewErrorAttemptDeleteActiveUser(user string) error {
 		Err:        fmt.Errorf("deleting active user %v is not supported", user),
 	}
 }
+
+// NewProtocolError is returned when the connection to SolarWinds failed at the HTTP protocol level, which is
+// typically caused by a proxy which does not support HTTP/2.
+func NewProtocolError(cause error) error {
+	return &ClientError{
+		StatusCode: ErrCodeProtocolException,
+		Err:        fmt.Errorf("HTTP protocol negotiation failed, consider setting TransportConfig.DisableHTTP2: %w", cause),
+	}
+}
+
+// GraphQLError is returned when SolarWinds reports the failure of a GraphQL operation, either with the errors of a
+// response without data or with the message of an unsuccessful result. It implements apierror.APIError.
+type GraphQLError struct {
+	// Operation is the name of the failed operation, set by MakeGraphQLRequest.
+	Operation string
+	// Code is the code of an unsuccessful result, empty for a response without data.
+	Code    string
+	Message string
+	// Response is the redacted body of a response without data, empty for an unsuccessful result.
+	Response string
+}
+
+func (e *GraphQLError) Error() string {
+	if e.Response != "" {
+		return fmt.Sprintf("request failed with response: %s", e.Response)
+	}
+	return fmt.Sprintf("request failed with message: %v", e.Message)
+}
+
+// APIBackend returns apierror.BackendSolarWinds.
+func (e *GraphQLError) APIBackend() string {
+	return apierror.BackendSolarWinds
+}
+
+// HTTPStatus returns 0, GraphQL reporting errors in successful responses.
+func (e *GraphQLError) HTTPStatus() int {
+	return 0
+}
+
+// APIMessage returns the message of the error.
+func (e *GraphQLError) APIMessage() string {
+	return e.Message
+}
@@ -1,9 +1,12 @@
 package solarwinds
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"github.com/nordcloud/go-pingdom/apierror"
 	"github.com/stretchr/testify/assert"
+	"net/http"
 	"testing"
 )
 
@@ -26,3 +29,26 @@ func TestClientErrors(t *testing.T) {
 		}
 	}
 }
+
+func TestGraphQLError(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc(graphQLEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":{"deleteOrganizationInvitation":{"success":false,"code":"404","message":"no such invitation"}}}`)
+	})
+
+	err := client.InvitationService.Revoke(context.Background(), "foo@nordcloud.com")
+	assert.EqualError(t, err, "request failed with message: no such invitation")
+
+	apiErr, ok := apierror.As(fmt.Errorf("revoking: %w", err))
+	if assert.True(t, ok) {
+		assert.Equal(t, apierror.BackendSolarWinds, apiErr.APIBackend())
+		assert.Equal(t, 0, apiErr.HTTPStatus())
+		assert.Equal(t, "no such invitation", apiErr.APIMessage())
+	}
+	var gqlErr *GraphQLError
+	if assert.True(t, errors.As(err, &gqlErr)) {
+		assert.Equal(t, revokeInvitationOp, gqlErr.Operation)
+		assert.Equal(t, "404", gqlErr.Code)
+	}
+}
@@ -1,8 +1,11 @@
 package solarwinds
 
+// The constants of the GraphQL operations are generated from the files under graphql/.
+//go:generate go run ./internal/graphqlgen -dir graphql -out operations_gen.go
+
 import (
 	"encoding/json"
-	"fmt"
+	"github.com/nordcloud/go-pingdom/internal/redact"
 	"io"
 	"io/ioutil"
 	"log"
@@ -10,10 +13,11 @@ import (
 )
 
 type GraphQLRequest struct {
-	OperationName string      `json:"operationName"`
-	Variables     interface{} `json:"variables"`
-	Query         string      `json:"query"`
-	ResponseType  string      `json:"-"` // Not required by GraphQL schema
+	OperationName string             `json:"operationName"`
+	Variables     interface{}        `json:"variables"`
+	Query         string             `json:"query,omitempty"`
+	Extensions    *GraphQLExtensions `json:"extensions,omitempty"`
+	ResponseType  string             `json:"-"` // Not required by GraphQL schema
 }
 
 type GraphQLResponse map[string]interface{}
@@ -24,7 +28,7 @@ func NewGraphQLResponse(body io.Reader, key string) (*GraphQLResponse, error) {
 		return nil, err
 	}
 	bodyStr := string(b)
-	log.Printf("The response body is: %s", bodyStr)
+	log.Printf("The response body is: %s", redact.JSON(b))
 	root := map[string]interface{}{}
 	if err := json.NewDecoder(strings.NewReader(bodyStr)).Decode(&root); err != nil {
 		return nil, err
@@ -32,7 +36,7 @@ func NewGraphQLResponse(body io.Reader, key string) (*GraphQLResponse, error) {
 	data, ok := root["data"].(map[string]interface{})
 	if !ok {
 		body, _ := json.Marshal(root)
-		return nil, fmt.Errorf("request failed with response: %v", string(body))
+		return nil, &GraphQLError{Message: errorMessages(root), Response: string(redact.JSON(body))}
 	}
 	graphQLResp := GraphQLResponse{}
 	for k, v := range data[key].(map[string]interface{}) {
@@ -56,3 +60,24 @@ func (r GraphQLResponse) message() string {
 		return ""
 	}
 }
+
+func (r GraphQLResponse) code() string {
+	if code, ok := r["code"].(string); ok {
+		return code
+	}
+	return ""
+}
+
+// errorMessages returns the messages of the errors of a GraphQL response, separated by semicolons.
+func errorMessages(root map[string]interface{}) string {
+	errs, _ := root["errors"].([]interface{})
+	var messages []string
+	for _, e := range errs {
+		if e, ok := e.(map[string]interface{}); ok {
+			if msg, ok := e["message"].(string); ok {
+				messages = append(messages, msg)
+			}
+		}
+	}
+	return strings.Join(messages, "; ")
+}
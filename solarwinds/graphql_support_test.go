@@ -1,7 +1,10 @@
 package solarwinds
 
 import (
+	"bytes"
 	"github.com/stretchr/testify/assert"
+	"log"
+	"os"
 	"strings"
 	"testing"
 )
@@ -28,3 +31,21 @@ func TestNewGraphQLResponse(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, resp.isSuccess())
 }
+
+func TestNewGraphQLResponseRedaction(t *testing.T) {
+	logs := &bytes.Buffer{}
+	log.SetOutput(logs)
+	defer log.SetOutput(os.Stderr)
+
+	_, err := NewGraphQLResponse(strings.NewReader(`{"data":{"user":{"id":"1","apiToken":"s3cr3t"}}}`), "user")
+	assert.NoError(t, err)
+	assert.NotContains(t, logs.String(), "s3cr3t")
+	assert.Contains(t, logs.String(), `"id":"1"`)
+
+	_, err = NewGraphQLResponse(strings.NewReader(`{"errors":[{"message":"bad"}],"csrfToken":"s3cr3t"}`), "user")
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "s3cr3t")
+	if gqlErr, ok := err.(*GraphQLError); assert.True(t, ok) {
+		assert.Equal(t, "bad", gqlErr.Message)
+	}
+}
@@ -0,0 +1,153 @@
+// Command graphqlgen turns the GraphQL operation files of the solarwinds package into typed Go code sending them. For
+// every <name>.graphql file three constants are generated:
+//
+//	<name>Op           the operation name declared in the document
+//	<name>Query        the document itself, byte for byte
+//	<name>ResponseType the top level field of the operation, i.e. the key of the response data
+//
+// along with a <name> method of Client, which sends the operation with its <name>Vars, if it has variables, and
+// returns its <name>Response, the top level field of the data. The types of the variables and of the selected fields
+// are those of the schema.graphqls file of the directory of the operations, which defines the part of the schema the
+// operations use. The Go types of the nested objects are named after their path, e.g. <name>ResponseMembersUser, and
+// those of the input types after the input types.
+//
+// It is meant to be run through go generate from the solarwinds package directory.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type operation struct {
+	Name          string
+	File          string
+	Keyword       string // query or mutation
+	OperationName string
+	Query         string
+	ResponseType  string
+	Variables     []variable
+	Selections    []*selection
+}
+
+func main() {
+	dir := flag.String("dir", "graphql", "directory containing the .graphql operation files")
+	out := flag.String("out", "operations_gen.go", "file to write the generated code to")
+	pkg := flag.String("package", "solarwinds", "package name of the generated file")
+	flag.Parse()
+
+	ops, err := loadOperations(*dir)
+	if err != nil {
+		log.Fatal(err)
+	}
+	types, err := loadSchema(filepath.Join(*dir, schemaFile))
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err := generate(*pkg, ops, types)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(*out, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// schemaFile is the file of the operations directory defining the types of the schema used by the operations.
+const schemaFile = "schema.graphqls"
+
+// loadSchema reads the types of the schema in file.
+func loadSchema(file string) (schema, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	types, err := parseSchema(string(content))
+	if err == nil {
+		err = types.check()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%v: %v", file, err)
+	}
+	return types, nil
+}
+
+// loadOperations reads all the .graphql files in dir, sorted by file name so the output is stable.
+func loadOperations(dir string) ([]operation, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.graphql"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	var ops []operation
+	for _, file := range files {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		op, err := parseOperation(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("%v: %v", file, err)
+		}
+		op.File = filepath.Base(file)
+		op.Name = strings.TrimSuffix(op.File, ".graphql")
+		if !isIdentifier(op.Name) {
+			return nil, fmt.Errorf("%v: file name is not a valid Go identifier", file)
+		}
+		ops = append(ops, op)
+	}
+	return ops, nil
+}
+
+// generate renders the constants, types and functions of all the operations as gofmt'ed Go source.
+func generate(pkg string, ops []operation, types schema) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	fmt.Fprint(buf, "// Code generated by graphqlgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(buf, "package %v\n\n", pkg)
+	fmt.Fprint(buf, "import \"context\"\n\n")
+	fmt.Fprint(buf, "// Constant values used in GraphQL requests.\nconst (\n")
+	for i, op := range ops {
+		if i > 0 {
+			fmt.Fprintln(buf)
+		}
+		fmt.Fprintf(buf, "// %v\n", op.File)
+		fmt.Fprintf(buf, "%vOp = %v\n", op.Name, strconv.Quote(op.OperationName))
+		fmt.Fprintf(buf, "%vQuery = %v\n", op.Name, strconv.Quote(op.Query))
+		fmt.Fprintf(buf, "%vResponseType = %v\n", op.Name, strconv.Quote(op.ResponseType))
+	}
+	fmt.Fprint(buf, ")\n")
+
+	g := &generator{schema: types, inputs: map[string]bool{}}
+	for _, op := range ops {
+		if err := g.operation(buf, op); err != nil {
+			return nil, fmt.Errorf("%v: %v", op.File, err)
+		}
+	}
+	if err := g.inputTypes(buf); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+func readName(s string) string {
+	end := strings.IndexFunc(s, func(r rune) bool {
+		return !(r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r))
+	})
+	if end < 0 {
+		return s
+	}
+	return s[:end]
+}
+
+func isIdentifier(s string) bool {
+	return s != "" && readName(s) == s && !unicode.IsDigit(rune(s[0]))
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseOperation(t *testing.T) {
+	op, err := parseOperation("mutation deleteMutation($email: ID!, $filter: Filter = {id: 1}) {\n  deleteInvitation(email: $email) {\n    success\n  }\n}\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "deleteMutation", op.OperationName)
+	assert.Equal(t, "deleteInvitation", op.ResponseType)
+
+	op, err = parseOperation("\nquery getUsersQuery {\n  user {\n    id\n  }\n}\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "getUsersQuery", op.OperationName)
+	assert.Equal(t, "user", op.ResponseType)
+
+	_, err = parseOperation("query {\n  user {\n    id\n  }\n}\n")
+	assert.Error(t, err)
+
+	_, err = parseOperation("fragment userFields on User {\n  id\n}\n")
+	assert.Error(t, err)
+
+	_, err = parseOperation("query emptyQuery {}")
+	assert.Error(t, err)
+}
+
+func TestGenerateIsStable(t *testing.T) {
+	types, err := parseSchema("type Query {\n  b: B\n}\ntype B {\n  id: ID!\n}\n")
+	assert.NoError(t, err)
+	op, err := parseOperation("query bQuery {\n  b {\n    id\n  }\n}\n")
+	assert.NoError(t, err)
+	op.Name, op.File = "b", "b.graphql"
+
+	src, err := generate("solarwinds", []operation{op}, types)
+	assert.NoError(t, err)
+	assert.Contains(t, string(src), "bQuery        = \"query bQuery {\\n  b {\\n    id\\n  }\\n}\\n\"")
+
+	again, err := generate("solarwinds", []operation{op}, types)
+	assert.NoError(t, err)
+	assert.Equal(t, src, again)
+}
+
+// The checked in code must be what the generator produces, otherwise someone forgot to run go generate.
+func TestGeneratedCodeUpToDate(t *testing.T) {
+	ops, err := loadOperations("../../graphql")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, ops)
+	types, err := loadSchema("../../graphql/" + schemaFile)
+	assert.NoError(t, err)
+
+	src, err := generate("solarwinds", ops, types)
+	assert.NoError(t, err)
+	current, err := ioutil.ReadFile("../../operations_gen.go")
+	assert.NoError(t, err)
+	assert.Equal(t, string(current), string(src))
+}
@@ -0,0 +1,593 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// token is a lexical token of a GraphQL document: a name, a punctuator, a number or a string, all kept as written.
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenName
+	tokenPunct
+	tokenNumber
+	tokenString
+)
+
+// lex splits a GraphQL document into tokens, leaving out white space, commas and comments.
+func lex(doc string) ([]token, error) {
+	var tokens []token
+	runes := []rune(doc)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r) || r == ',' || r == '\uFEFF':
+			i++
+		case r == '#':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '.':
+			if i+2 >= len(runes) || runes[i+1] != '.' || runes[i+2] != '.' {
+				return nil, fmt.Errorf("unexpected %q", r)
+			}
+			tokens = append(tokens, token{tokenPunct, "..."})
+			i += 3
+		case strings.ContainsRune("!$&()=:@[]{}|", r):
+			tokens = append(tokens, token{tokenPunct, string(r)})
+			i++
+		case r == '_' || unicode.IsLetter(r):
+			start := i
+			for i < len(runes) && (runes[i] == '_' || unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+				i++
+			}
+			tokens = append(tokens, token{tokenName, string(runes[start:i])})
+		case r == '-' || unicode.IsDigit(r):
+			start := i
+			i++
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || strings.ContainsRune(".eE+-", runes[i])) {
+				i++
+			}
+			tokens = append(tokens, token{tokenNumber, string(runes[start:i])})
+		case r == '"':
+			end, err := stringEnd(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{tokenString, string(runes[i:end])})
+			i = end
+		default:
+			return nil, fmt.Errorf("unexpected %q", r)
+		}
+	}
+	return tokens, nil
+}
+
+// stringEnd returns the index after the string, or block string, starting at start.
+func stringEnd(runes []rune, start int) (int, error) {
+	if strings.HasPrefix(string(runes[start:]), `"""`) {
+		for i := start + 3; i+2 < len(runes); i++ {
+			if runes[i] == '\\' {
+				i++
+			} else if runes[i] == '"' && runes[i+1] == '"' && runes[i+2] == '"' {
+				return i + 3, nil
+			}
+		}
+		return 0, fmt.Errorf("unterminated block string")
+	}
+	for i := start + 1; i < len(runes) && runes[i] != '\n'; i++ {
+		if runes[i] == '\\' {
+			i++
+		} else if runes[i] == '"' {
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("unterminated string")
+}
+
+// parser reads the tokens of a document.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func newParser(doc string) (*parser, error) {
+	tokens, err := lex(doc)
+	if err != nil {
+		return nil, err
+	}
+	return &parser{tokens: tokens}, nil
+}
+
+func (p *parser) peek() token {
+	if p.pos >= len(p.tokens) {
+		return token{kind: tokenEOF}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	if p.pos < len(p.tokens) {
+		p.pos++
+	}
+	return t
+}
+
+// is reports whether the next token is the given punctuator.
+func (p *parser) is(punct string) bool {
+	t := p.peek()
+	return t.kind == tokenPunct && t.value == punct
+}
+
+// skip consumes the next token when it is the given punctuator.
+func (p *parser) skip(punct string) bool {
+	if p.is(punct) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(punct string) error {
+	if !p.skip(punct) {
+		return fmt.Errorf("expected %q, got %q", punct, p.peek().value)
+	}
+	return nil
+}
+
+func (p *parser) name() (string, error) {
+	t := p.next()
+	if t.kind != tokenName {
+		return "", fmt.Errorf("expected a name, got %q", t.value)
+	}
+	return t.value, nil
+}
+
+// skipBalanced skips the tokens from an opening punctuator up to its closing one, e.g. the arguments of a field.
+func (p *parser) skipBalanced(open, close string) error {
+	if err := p.expect(open); err != nil {
+		return err
+	}
+	for depth := 1; depth > 0; {
+		t := p.next()
+		switch {
+		case t.kind == tokenEOF:
+			return fmt.Errorf("missing %q", close)
+		case t.kind == tokenPunct && t.value == open:
+			depth++
+		case t.kind == tokenPunct && t.value == close:
+			depth--
+		}
+	}
+	return nil
+}
+
+// skipDirectives skips the directives at the position, e.g. "@include(if: $all)".
+func (p *parser) skipDirectives() error {
+	for p.skip("@") {
+		if _, err := p.name(); err != nil {
+			return err
+		}
+		if p.is("(") {
+			if err := p.skipBalanced("(", ")"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// typeRef is a type as written in a document: a named type, or a list of Elem, possibly non-null.
+type typeRef struct {
+	Name    string
+	Elem    *typeRef
+	NonNull bool
+}
+
+func (r typeRef) String() string {
+	s := r.Name
+	if r.Elem != nil {
+		s = "[" + r.Elem.String() + "]"
+	}
+	if r.NonNull {
+		s += "!"
+	}
+	return s
+}
+
+// named returns the named type of the list elements, or the type itself.
+func (r typeRef) named() string {
+	for r.Elem != nil {
+		r = *r.Elem
+	}
+	return r.Name
+}
+
+func (p *parser) typeRef() (typeRef, error) {
+	var ref typeRef
+	if p.skip("[") {
+		elem, err := p.typeRef()
+		if err != nil {
+			return ref, err
+		}
+		if err := p.expect("]"); err != nil {
+			return ref, err
+		}
+		ref.Elem = &elem
+	} else {
+		name, err := p.name()
+		if err != nil {
+			return ref, err
+		}
+		ref.Name = name
+	}
+	ref.NonNull = p.skip("!")
+	return ref, nil
+}
+
+// skipDescription skips the description of a definition of a schema.
+func (p *parser) skipDescription() {
+	if p.peek().kind == tokenString {
+		p.pos++
+	}
+}
+
+// selection is a field selected by an operation.  Key is its alias, or its name when it has none, i.e. the key of the
+// field in the response.
+type selection struct {
+	Key        string
+	Name       string
+	Selections []*selection
+}
+
+// variable is a variable defined by an operation.
+type variable struct {
+	Name string
+	Type typeRef
+}
+
+// parseOperation parses a document containing a single named query or mutation.  Fragments are not supported.
+func parseOperation(doc string) (operation, error) {
+	op := operation{Query: doc}
+	p, err := newParser(doc)
+	if err != nil {
+		return op, err
+	}
+	op.Keyword = p.peek().value
+	if p.peek().kind != tokenName || (op.Keyword != "query" && op.Keyword != "mutation") {
+		return op, fmt.Errorf("expected query or mutation, got %q", op.Keyword)
+	}
+	p.next()
+	if p.peek().kind != tokenName {
+		return op, fmt.Errorf("anonymous %v is not supported", op.Keyword)
+	}
+	op.OperationName, _ = p.name()
+	if op.Variables, err = p.variableDefinitions(); err != nil {
+		return op, fmt.Errorf("operation %v: %v", op.OperationName, err)
+	}
+	if err := p.skipDirectives(); err != nil {
+		return op, err
+	}
+	if !p.is("{") {
+		return op, fmt.Errorf("no selection set in operation %v", op.OperationName)
+	}
+	if op.Selections, err = p.selectionSet(); err != nil {
+		return op, fmt.Errorf("operation %v: %v", op.OperationName, err)
+	}
+	if len(op.Selections) == 0 {
+		return op, fmt.Errorf("empty selection set in operation %v", op.OperationName)
+	}
+	if len(op.Selections) > 1 {
+		return op, fmt.Errorf("operation %v selects %d top level fields, only one is supported", op.OperationName, len(op.Selections))
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return op, fmt.Errorf("operation %v: unexpected %q after the operation, only one operation is supported", op.OperationName, t.value)
+	}
+	op.ResponseType = op.Selections[0].Key
+	return op, nil
+}
+
+// variableDefinitions parses the variable definitions of an operation, if any.
+func (p *parser) variableDefinitions() ([]variable, error) {
+	var vars []variable
+	if !p.skip("(") {
+		return nil, nil
+	}
+	for !p.skip(")") {
+		if err := p.expect("$"); err != nil {
+			return nil, err
+		}
+		v := variable{}
+		var err error
+		if v.Name, err = p.name(); err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		if v.Type, err = p.typeRef(); err != nil {
+			return nil, err
+		}
+		if p.skip("=") {
+			if err := p.skipValue(); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.skipDirectives(); err != nil {
+			return nil, err
+		}
+		vars = append(vars, v)
+	}
+	return vars, nil
+}
+
+// skipValue skips a value, e.g. the default value of a variable.
+func (p *parser) skipValue() error {
+	switch {
+	case p.is("{"):
+		return p.skipBalanced("{", "}")
+	case p.is("["):
+		return p.skipBalanced("[", "]")
+	case p.peek().kind == tokenEOF || p.peek().kind == tokenPunct:
+		return fmt.Errorf("expected a value, got %q", p.peek().value)
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) selectionSet() ([]*selection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var selections []*selection
+	for !p.skip("}") {
+		if p.is("...") {
+			return nil, fmt.Errorf("fragments are not supported")
+		}
+		s := &selection{}
+		var err error
+		if s.Name, err = p.name(); err != nil {
+			return nil, err
+		}
+		s.Key = s.Name
+		if p.skip(":") {
+			if s.Name, err = p.name(); err != nil {
+				return nil, err
+			}
+		}
+		if p.is("(") {
+			if err := p.skipBalanced("(", ")"); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.skipDirectives(); err != nil {
+			return nil, err
+		}
+		if p.is("{") {
+			if s.Selections, err = p.selectionSet(); err != nil {
+				return nil, err
+			}
+			if len(s.Selections) == 0 {
+				return nil, fmt.Errorf("empty selection set of %v", s.Key)
+			}
+		}
+		selections = append(selections, s)
+	}
+	return selections, nil
+}
+
+// The kinds of the types of a schema.
+const (
+	kindScalar = "scalar"
+	kindEnum   = "enum"
+	kindObject = "type"
+	kindInput  = "input"
+)
+
+// schemaType is a type defined by a schema, with the fields of an object or input type in their order.
+type schemaType struct {
+	Kind   string
+	Name   string
+	Fields []schemaField
+}
+
+type schemaField struct {
+	Name string
+	Type typeRef
+}
+
+func (t *schemaType) field(name string) (schemaField, bool) {
+	for _, f := range t.Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return schemaField{}, false
+}
+
+// schema is the set of types of a schema, the built-in ones included.
+type schema map[string]*schemaType
+
+// builtinSchema defines the built-in scalars and the types of the introspection system.
+const builtinSchema = `
+scalar ID
+scalar String
+scalar Int
+scalar Float
+scalar Boolean
+
+type __Schema {
+  description: String
+  types: [__Type!]!
+  queryType: __Type!
+  mutationType: __Type
+  subscriptionType: __Type
+  directives: [__Directive!]!
+}
+
+type __Type {
+  kind: __TypeKind!
+  name: String
+  description: String
+  specifiedByURL: String
+  fields(includeDeprecated: Boolean = false): [__Field!]
+  interfaces: [__Type!]
+  possibleTypes: [__Type!]
+  enumValues(includeDeprecated: Boolean = false): [__EnumValue!]
+  inputFields(includeDeprecated: Boolean = false): [__InputValue!]
+  ofType: __Type
+}
+
+type __Field {
+  name: String!
+  description: String
+  args(includeDeprecated: Boolean = false): [__InputValue!]!
+  type: __Type!
+  isDeprecated: Boolean!
+  deprecationReason: String
+}
+
+type __InputValue {
+  name: String!
+  description: String
+  type: __Type!
+  defaultValue: String
+  isDeprecated: Boolean!
+  deprecationReason: String
+}
+
+type __EnumValue {
+  name: String!
+  description: String
+  isDeprecated: Boolean!
+  deprecationReason: String
+}
+
+type __Directive {
+  name: String!
+  description: String
+  locations: [String!]!
+  args(includeDeprecated: Boolean = false): [__InputValue!]!
+  isRepeatable: Boolean!
+}
+
+enum __TypeKind {
+  SCALAR
+  OBJECT
+  INTERFACE
+  UNION
+  ENUM
+  INPUT_OBJECT
+  LIST
+  NON_NULL
+}
+`
+
+// parseSchema parses the scalar, enum, object and input type definitions of a schema, in addition to the built-in
+// ones.  The arguments of the fields are left out, as are interfaces and unions, which are not supported.
+func parseSchema(doc string) (schema, error) {
+	s := schema{}
+	for _, d := range []string{builtinSchema, doc} {
+		if err := s.parse(d); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s schema) parse(doc string) error {
+	p, err := newParser(doc)
+	if err != nil {
+		return err
+	}
+	for p.peek().kind != tokenEOF {
+		p.skipDescription()
+		kind, err := p.name()
+		if err != nil {
+			return err
+		}
+		if kind != kindScalar && kind != kindEnum && kind != kindObject && kind != kindInput {
+			return fmt.Errorf("unsupported definition %q", kind)
+		}
+		t := &schemaType{Kind: kind}
+		if t.Name, err = p.name(); err != nil {
+			return err
+		}
+		if _, ok := s[t.Name]; ok {
+			return fmt.Errorf("type %v is defined twice", t.Name)
+		}
+		if kind == kindObject && p.peek().value == "implements" {
+			return fmt.Errorf("type %v: interfaces are not supported", t.Name)
+		}
+		if err := p.skipDirectives(); err != nil {
+			return err
+		}
+		switch kind {
+		case kindEnum:
+			err = p.skipBalanced("{", "}")
+		case kindObject, kindInput:
+			err = p.fields(t)
+		}
+		if err != nil {
+			return fmt.Errorf("type %v: %v", t.Name, err)
+		}
+		s[t.Name] = t
+	}
+	return nil
+}
+
+func (p *parser) fields(t *schemaType) error {
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for !p.skip("}") {
+		p.skipDescription()
+		f := schemaField{}
+		var err error
+		if f.Name, err = p.name(); err != nil {
+			return err
+		}
+		if p.is("(") {
+			if err := p.skipBalanced("(", ")"); err != nil {
+				return err
+			}
+		}
+		if err := p.expect(":"); err != nil {
+			return err
+		}
+		if f.Type, err = p.typeRef(); err != nil {
+			return err
+		}
+		if p.skip("=") {
+			if err := p.skipValue(); err != nil {
+				return err
+			}
+		}
+		if err := p.skipDirectives(); err != nil {
+			return err
+		}
+		t.Fields = append(t.Fields, f)
+	}
+	return nil
+}
+
+// check reports the types which are referred to but not defined.
+func (s schema) check() error {
+	for _, t := range s {
+		for _, f := range t.Fields {
+			if _, ok := s[f.Type.named()]; !ok {
+				return fmt.Errorf("field %v.%v: unknown type %v", t.Name, f.Name, f.Type.named())
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLex(t *testing.T) {
+	tokens, err := lex("query q($a: [ID!] = [\"x, y\"]) { # a comment\n  f(b: -1.5e3) @skip(if: true) { ...on T { g } }\n}")
+	assert.NoError(t, err)
+	var values []string
+	for _, tok := range tokens {
+		values = append(values, tok.value)
+	}
+	assert.Equal(t, []string{
+		"query", "q", "(", "$", "a", ":", "[", "ID", "!", "]", "=", "[", `"x, y"`, "]", ")", "{",
+		"f", "(", "b", ":", "-1.5e3", ")", "@", "skip", "(", "if", ":", "true", ")", "{", "...", "on", "T", "{", "g", "}", "}",
+		"}",
+	}, values)
+
+	tokens, err = lex(`"""a "block" string""" x`)
+	assert.NoError(t, err)
+	assert.Equal(t, []token{{tokenString, `"""a "block" string"""`}, {tokenName, "x"}}, tokens)
+
+	_, err = lex(`"unterminated`)
+	assert.EqualError(t, err, "unterminated string")
+	_, err = lex("a % b")
+	assert.EqualError(t, err, `unexpected '%'`)
+}
+
+func TestParseOperationVariablesAndSelections(t *testing.T) {
+	op, err := parseOperation("mutation m($id: ID!, $tags: [String!] = [\"a\"]) {\n  update(id: $id) {\n    ok: success\n    item { id }\n  }\n}\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "mutation", op.Keyword)
+	assert.Equal(t, []variable{
+		{Name: "id", Type: typeRef{Name: "ID", NonNull: true}},
+		{Name: "tags", Type: typeRef{Elem: &typeRef{Name: "String", NonNull: true}}},
+	}, op.Variables)
+	assert.Equal(t, "update", op.ResponseType)
+	assert.Equal(t, []*selection{
+		{Key: "ok", Name: "success"},
+		{Key: "item", Name: "item", Selections: []*selection{{Key: "id", Name: "id"}}},
+	}, op.Selections[0].Selections)
+
+	_, err = parseOperation("query q {\n  a { id }\n  b { id }\n}\n")
+	assert.EqualError(t, err, "operation q selects 2 top level fields, only one is supported")
+
+	_, err = parseOperation("query q {\n  a { ...fields }\n}\n")
+	assert.EqualError(t, err, "operation q: fragments are not supported")
+
+	_, err = parseOperation("query q { a { id } }\nquery r { a { id } }\n")
+	assert.Error(t, err)
+}
+
+func TestParseSchema(t *testing.T) {
+	types, err := parseSchema(`
+"""The root."""
+type Query {
+  "The user."
+  user(id: ID = "1"): User @deprecated
+}
+
+type User {
+  id: ID!
+  friends: [User!]!
+}
+
+enum Role { ADMIN MEMBER }
+
+input Filter {
+  role: Role = ADMIN
+}
+`)
+	assert.NoError(t, err)
+	assert.NoError(t, types.check())
+	assert.Equal(t, &schemaType{Kind: kindObject, Name: "Query", Fields: []schemaField{{Name: "user", Type: typeRef{Name: "User"}}}}, types["Query"])
+	assert.Equal(t, "[User!]!", types["User"].Fields[1].Type.String())
+	assert.Equal(t, kindEnum, types["Role"].Kind)
+	assert.Equal(t, kindInput, types["Filter"].Kind)
+	assert.Equal(t, kindObject, types["__Schema"].Kind)
+	assert.Equal(t, kindScalar, types["Boolean"].Kind)
+
+	types, err = parseSchema("type Query {\n  user: Missing\n}\n")
+	assert.NoError(t, err)
+	assert.EqualError(t, types.check(), "field Query.user: unknown type Missing")
+
+	_, err = parseSchema("type ID {\n  id: ID\n}\n")
+	assert.EqualError(t, err, "type ID is defined twice")
+
+	_, err = parseSchema("interface Node {\n  id: ID!\n}\n")
+	assert.EqualError(t, err, `unsupported definition "interface"`)
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// generator renders the functions of the operations and the Go types of their variables and responses.
+type generator struct {
+	schema schema
+	inputs map[string]bool // Input types to render, by GraphQL name
+}
+
+// goStruct is a struct type to render.
+type goStruct struct {
+	Name   string
+	Doc    string
+	Fields []goField
+}
+
+type goField struct {
+	Name string
+	Type string
+	Key  string
+}
+
+func (s *goStruct) render(buf *bytes.Buffer) {
+	fmt.Fprintf(buf, "\n// %v\ntype %v struct {\n", s.Doc, s.Name)
+	for _, f := range s.Fields {
+		fmt.Fprintf(buf, "%v %v `json:%v`\n", f.Name, f.Type, strconv.Quote(f.Key))
+	}
+	fmt.Fprint(buf, "}\n")
+}
+
+// operation renders the function sending the operation, the type of its variables and those of its response.
+func (g *generator) operation(buf *bytes.Buffer, op operation) error {
+	rootName := "Query"
+	if op.Keyword == "mutation" {
+		rootName = "Mutation"
+	}
+	root, ok := g.schema[rootName]
+	if !ok || root.Kind != kindObject {
+		return fmt.Errorf("the schema has no %v type", rootName)
+	}
+	top := op.Selections[0]
+	field, err := g.field(root, top.Name)
+	if err != nil {
+		return err
+	}
+	// The response of MakeGraphQLRequest is the object of the top level field.
+	if t := g.schema[field.Type.named()]; field.Type.Elem != nil || t == nil || t.Kind != kindObject {
+		return fmt.Errorf("top level field %v must be an object, not %v", top.Name, field.Type)
+	}
+
+	var structs []*goStruct
+	response := op.Name + "Response"
+	doc := fmt.Sprintf("%v is the %v field of the data of %v.", response, top.Key, op.OperationName)
+	if err := g.object(&structs, g.schema[field.Type.named()], response, doc, op, top.Selections); err != nil {
+		return err
+	}
+
+	var vars *goStruct
+	if len(op.Variables) > 0 {
+		vars = &goStruct{Name: op.Name + "Vars", Doc: fmt.Sprintf("%vVars are the variables of %v.", op.Name, op.OperationName)}
+		for _, v := range op.Variables {
+			typ, err := g.inputType(v.Type, false)
+			if err != nil {
+				return fmt.Errorf("variable $%v: %v", v.Name, err)
+			}
+			vars.Fields = append(vars.Fields, goField{Name: exportName(v.Name), Type: typ, Key: v.Name})
+		}
+	}
+
+	fmt.Fprintf(buf, "\n// %v sends the %v %v.\n", op.Name, op.OperationName, op.Keyword)
+	if vars != nil {
+		fmt.Fprintf(buf, "func (c *Client) %v(ctx context.Context, vars %v) (*%v, error) {\n", op.Name, vars.Name, response)
+	} else {
+		fmt.Fprintf(buf, "func (c *Client) %v(ctx context.Context) (*%v, error) {\n", op.Name, response)
+	}
+	fmt.Fprint(buf, "req := GraphQLRequest{\n")
+	fmt.Fprintf(buf, "OperationName: %vOp,\n", op.Name)
+	fmt.Fprintf(buf, "Query: %vQuery,\n", op.Name)
+	if vars != nil {
+		fmt.Fprint(buf, "Variables: vars,\n")
+	}
+	fmt.Fprintf(buf, "ResponseType: %vResponseType,\n", op.Name)
+	fmt.Fprint(buf, "}\n")
+	fmt.Fprint(buf, "resp, err := c.MakeGraphQLRequest(ctx, &req)\nif err != nil {\nreturn nil, err\n}\n")
+	fmt.Fprintf(buf, "data := %v{}\n", response)
+	fmt.Fprint(buf, "if err := Convert(resp, &data); err != nil {\nreturn nil, err\n}\nreturn &data, nil\n}\n")
+
+	if vars != nil {
+		vars.render(buf)
+	}
+	for _, s := range structs {
+		s.render(buf)
+	}
+	return nil
+}
+
+// field returns the field of an object type, including the meta fields of the introspection system.
+func (g *generator) field(t *schemaType, name string) (schemaField, error) {
+	if t.Name == "Query" {
+		switch name {
+		case "__schema":
+			return schemaField{Name: name, Type: typeRef{Name: "__Schema", NonNull: true}}, nil
+		case "__type":
+			return schemaField{Name: name, Type: typeRef{Name: "__Type"}}, nil
+		}
+	}
+	f, ok := t.field(name)
+	if !ok {
+		return f, fmt.Errorf("type %v has no field %v", t.Name, name)
+	}
+	return f, nil
+}
+
+// object adds the struct of a selection of an object type, and those of the objects it selects, to structs.
+func (g *generator) object(structs *[]*goStruct, t *schemaType, name, doc string, op operation, selections []*selection) error {
+	s := &goStruct{Name: name, Doc: doc}
+	*structs = append(*structs, s)
+	keys := map[string]bool{}
+	for _, sel := range selections {
+		if sel.Name == "__typename" {
+			continue
+		}
+		if keys[sel.Key] {
+			return fmt.Errorf("%v is selected twice in %v", sel.Key, t.Name)
+		}
+		keys[sel.Key] = true
+		f, err := g.field(t, sel.Name)
+		if err != nil {
+			return err
+		}
+		if ft, ok := g.schema[f.Type.named()]; !ok {
+			return fmt.Errorf("unknown type %v", f.Type.named())
+		} else if ft.Kind != kindObject && len(sel.Selections) > 0 {
+			return fmt.Errorf("%v of type %v cannot have a selection set", sel.Key, f.Type)
+		} else if ft.Kind == kindObject && len(sel.Selections) == 0 {
+			return fmt.Errorf("%v of type %v needs a selection set", sel.Key, f.Type)
+		}
+		typ, err := g.outputType(structs, f.Type, name+exportName(sel.Key), op, sel, false)
+		if err != nil {
+			return err
+		}
+		s.Fields = append(s.Fields, goField{Name: exportName(sel.Key), Type: typ, Key: sel.Key})
+	}
+	return nil
+}
+
+// outputType returns the Go type of a selected field, which object has checked.  Nullable objects are pointers, unless in a list.
+func (g *generator) outputType(structs *[]*goStruct, ref typeRef, name string, op operation, sel *selection, inList bool) (string, error) {
+	if ref.Elem != nil {
+		elem, err := g.outputType(structs, *ref.Elem, name, op, sel, true)
+		return "[]" + elem, err
+	}
+	t := g.schema[ref.Name]
+	if t.Kind != kindObject {
+		return scalarType(t), nil
+	}
+	doc := fmt.Sprintf("%v is the %v %v selected by %v.", name, sel.Key, t.Name, op.OperationName)
+	if err := g.object(structs, t, name, doc, op, sel.Selections); err != nil {
+		return "", err
+	}
+	if ref.NonNull || inList {
+		return name, nil
+	}
+	return "*" + name, nil
+}
+
+// inputType returns the Go type of a variable or of a field of an input type.  Nullable input objects are pointers,
+// unless in a list.
+func (g *generator) inputType(ref typeRef, inList bool) (string, error) {
+	if ref.Elem != nil {
+		elem, err := g.inputType(*ref.Elem, true)
+		return "[]" + elem, err
+	}
+	t, ok := g.schema[ref.Name]
+	if !ok {
+		return "", fmt.Errorf("unknown type %v", ref.Name)
+	}
+	switch t.Kind {
+	case kindObject:
+		return "", fmt.Errorf("type %v is not an input type", t.Name)
+	case kindInput:
+		g.inputs[t.Name] = true
+		if ref.NonNull || inList {
+			return inputTypeName(t.Name), nil
+		}
+		return "*" + inputTypeName(t.Name), nil
+	}
+	return scalarType(t), nil
+}
+
+// inputTypes renders the input types used by the operations, and those they refer to, sorted by name.
+func (g *generator) inputTypes(buf *bytes.Buffer) error {
+	rendered := map[string]bool{}
+	for {
+		var names []string
+		for name := range g.inputs {
+			if !rendered[name] {
+				names = append(names, name)
+			}
+		}
+		if len(names) == 0 {
+			return nil
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			rendered[name] = true
+			s := &goStruct{Name: inputTypeName(name), Doc: fmt.Sprintf("%v is the %v input type.", inputTypeName(name), name)}
+			for _, f := range g.schema[name].Fields {
+				typ, err := g.inputType(f.Type, false)
+				if err != nil {
+					return fmt.Errorf("field %v.%v: %v", name, f.Name, err)
+				}
+				s.Fields = append(s.Fields, goField{Name: exportName(f.Name), Type: typ, Key: f.Name})
+			}
+			s.render(buf)
+		}
+	}
+}
+
+// scalarType returns the Go type of a scalar or an enum.  Enums and custom scalars, e.g. dates, are strings.
+func scalarType(t *schemaType) string {
+	switch t.Name {
+	case "Int":
+		return "int"
+	case "Float":
+		return "float64"
+	case "Boolean":
+		return "bool"
+	}
+	return "string"
+}
+
+// exportName returns the name of the Go field of a GraphQL field, e.g. "FirstName" for "firstName".
+func exportName(name string) string {
+	name = strings.TrimLeft(name, "_")
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// inputTypeName returns the name of the Go type of an input type, which is not exported, e.g. "productAccessInput".
+func inputTypeName(name string) string {
+	name = strings.TrimLeft(name, "_")
+	return strings.ToLower(name[:1]) + name[1:]
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testSchema = `
+type Query {
+  user: User
+}
+
+type Mutation {
+  rename(input: RenameInput!): RenameResponse
+}
+
+type User {
+  id: ID!
+  age: Int
+  score: Float
+  admin: Boolean!
+  manager: User
+  teams: [Team!]!
+}
+
+type Team {
+  name: String!
+}
+
+type RenameResponse {
+  success: Boolean!
+}
+
+input RenameInput {
+  id: ID!
+  name: NameInput
+  aliases: [NameInput!]
+}
+
+input NameInput {
+  first: String!
+}
+`
+
+func generateTest(t *testing.T, doc string) (string, error) {
+	types, err := parseSchema(testSchema)
+	assert.NoError(t, err)
+	op, err := parseOperation(doc)
+	assert.NoError(t, err)
+	op.Name, op.File = "test", "test.graphql"
+	src, err := generate("solarwinds", []operation{op}, types)
+	return string(src), err
+}
+
+func TestGenerateTypes(t *testing.T) {
+	src, err := generateTest(t, "query getUser {\n  user {\n    id\n    age\n    score\n    boss: manager {\n      id\n      __typename\n    }\n    teams {\n      name\n    }\n  }\n}\n")
+	assert.NoError(t, err)
+	assert.Contains(t, src, "func (c *Client) test(ctx context.Context) (*testResponse, error) {")
+	assert.Contains(t, src, "// testResponse is the user field of the data of getUser.\ntype testResponse struct {\n"+
+		"\tId    string              `json:\"id\"`\n"+
+		"\tAge   int                 `json:\"age\"`\n"+
+		"\tScore float64             `json:\"score\"`\n"+
+		"\tBoss  *testResponseBoss   `json:\"boss\"`\n"+
+		"\tTeams []testResponseTeams `json:\"teams\"`\n"+
+		"}")
+	assert.Contains(t, src, "// testResponseBoss is the boss User selected by getUser.\ntype testResponseBoss struct {\n\tId string `json:\"id\"`\n}")
+	assert.Contains(t, src, "type testResponseTeams struct {\n\tName string `json:\"name\"`\n}")
+}
+
+func TestGenerateVariables(t *testing.T) {
+	src, err := generateTest(t, "mutation renameUser($input: RenameInput!) {\n  rename(input: $input) {\n    success\n  }\n}\n")
+	assert.NoError(t, err)
+	assert.Contains(t, src, "func (c *Client) test(ctx context.Context, vars testVars) (*testResponse, error) {")
+	assert.Contains(t, src, "Variables:     vars,")
+	assert.Contains(t, src, "type testVars struct {\n\tInput renameInput `json:\"input\"`\n}")
+	assert.Contains(t, src, "// renameInput is the RenameInput input type.\ntype renameInput struct {\n"+
+		"\tId      string      `json:\"id\"`\n"+
+		"\tName    *nameInput  `json:\"name\"`\n"+
+		"\tAliases []nameInput `json:\"aliases\"`\n"+
+		"}")
+	assert.Contains(t, src, "type nameInput struct {\n\tFirst string `json:\"first\"`\n}")
+}
+
+func TestGenerateErrors(t *testing.T) {
+	for doc, msg := range map[string]string{
+		"query q {\n  user {\n    name\n  }\n}\n":                    "test.graphql: type User has no field name",
+		"query q {\n  user {\n    teams\n  }\n}\n":                   "test.graphql: teams of type [Team!]! needs a selection set",
+		"query q {\n  user {\n    id {\n      x\n    }\n  }\n}\n":    "test.graphql: id of type ID! cannot have a selection set",
+		"query q {\n  user {\n    id\n    id\n  }\n}\n":              "test.graphql: id is selected twice in User",
+		"query q($u: User) {\n  user {\n    id\n  }\n}\n":            "test.graphql: variable $u: type User is not an input type",
+		"mutation m {\n  user {\n    id\n  }\n}\n":                   "test.graphql: type Mutation has no field user",
+		"query q($x: Unknown) {\n  user {\n    id\n  }\n}\n":         "test.graphql: variable $x: unknown type Unknown",
+		"mutation m {\n  rename(input: {}) {\n    success\n  }\n}\n": "",
+	} {
+		_, err := generateTest(t, doc)
+		if msg == "" {
+			assert.NoError(t, err)
+		} else {
+			assert.EqualError(t, err, msg, doc)
+		}
+	}
+}
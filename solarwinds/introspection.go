@@ -0,0 +1,170 @@
+package solarwinds
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// The kinds of the types of a GraphQL schema.
+const (
+	TypeKindScalar      = "SCALAR"
+	TypeKindObject      = "OBJECT"
+	TypeKindInterface   = "INTERFACE"
+	TypeKindUnion       = "UNION"
+	TypeKindEnum        = "ENUM"
+	TypeKindInputObject = "INPUT_OBJECT"
+	TypeKindList        = "LIST"
+	TypeKindNonNull     = "NON_NULL"
+)
+
+// Schema is the GraphQL schema of the SolarWinds API, as told by Introspect.
+type Schema struct {
+	QueryType    *TypeRef     `json:"queryType"`
+	MutationType *TypeRef     `json:"mutationType"`
+	Types        []SchemaType `json:"types"`
+}
+
+// SchemaType is a named type of a schema.  Objects and interfaces have Fields,
+// input objects InputFields and enums EnumValues.
+type SchemaType struct {
+	Kind        string            `json:"kind"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Fields      []SchemaField     `json:"fields"`
+	InputFields []InputValue      `json:"inputFields"`
+	EnumValues  []SchemaEnumValue `json:"enumValues"`
+}
+
+// SchemaField is a field of an object or interface, e.g. a query or a mutation
+// of the schema.
+type SchemaField struct {
+	Name        string       `json:"name"`
+	Description string       `json:"description"`
+	Args        []InputValue `json:"args"`
+	Type        TypeRef      `json:"type"`
+}
+
+// InputValue is an argument of a field or a field of an input object.
+type InputValue struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	Type        TypeRef `json:"type"`
+}
+
+// SchemaEnumValue is a value of an enum.
+type SchemaEnumValue struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// TypeRef refers to a type, wrapped by the lists and non-null types of OfType.
+type TypeRef struct {
+	Kind   string   `json:"kind"`
+	Name   string   `json:"name"`
+	OfType *TypeRef `json:"ofType"`
+}
+
+// String returns the type as written in GraphQL, e.g. "[ProductAccessInput!]".
+func (r TypeRef) String() string {
+	switch {
+	case r.Kind == TypeKindNonNull && r.OfType != nil:
+		return r.OfType.String() + "!"
+	case r.Kind == TypeKindList && r.OfType != nil:
+		return "[" + r.OfType.String() + "]"
+	}
+	return r.Name
+}
+
+// NamedType returns the name of the type without its lists and non-null types,
+// e.g. "ProductAccessInput".
+func (r TypeRef) NamedType() string {
+	for r.OfType != nil {
+		r = *r.OfType
+	}
+	return r.Name
+}
+
+// String returns the signature of the field, e.g.
+// "resendOrganizationInvitation(email: ID!): ResendOrganizationInvitationResponse".
+func (f SchemaField) String() string {
+	var b strings.Builder
+	b.WriteString(f.Name)
+	if len(f.Args) > 0 {
+		args := make([]string, len(f.Args))
+		for i, arg := range f.Args {
+			args[i] = arg.Name + ": " + arg.Type.String()
+		}
+		b.WriteString("(" + strings.Join(args, ", ") + ")")
+	}
+	b.WriteString(": " + f.Type.String())
+	return b.String()
+}
+
+// Type returns the type of the given name, or nil when the schema has none.
+func (s *Schema) Type(name string) *SchemaType {
+	for i := range s.Types {
+		if s.Types[i].Name == name {
+			return &s.Types[i]
+		}
+	}
+	return nil
+}
+
+// Queries returns the fields of the query type, sorted by name.
+func (s *Schema) Queries() []SchemaField {
+	return s.rootFields(s.QueryType)
+}
+
+// Mutations returns the fields of the mutation type, sorted by name, or none
+// when the schema has no mutations.
+func (s *Schema) Mutations() []SchemaField {
+	return s.rootFields(s.MutationType)
+}
+
+// TypeNames returns the names of the types of the schema, sorted, leaving out
+// the types of the introspection system, i.e. those starting with "__".
+func (s *Schema) TypeNames() []string {
+	var names []string
+	for _, t := range s.Types {
+		if !strings.HasPrefix(t.Name, "__") {
+			names = append(names, t.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (s *Schema) rootFields(root *TypeRef) []SchemaField {
+	if root == nil {
+		return nil
+	}
+	t := s.Type(root.Name)
+	if t == nil {
+		return nil
+	}
+	fields := make([]SchemaField, len(t.Fields))
+	copy(fields, t.Fields)
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Name < fields[j].Name
+	})
+	return fields
+}
+
+// Introspect runs an introspection query and returns the schema of the API,
+// e.g. to find out which queries and mutations exist before wrapping them.
+// Servers may turn off introspection, in which case a GraphQLError is
+// returned.
+func (c *Client) Introspect(ctx context.Context) (*Schema, error) {
+	resp, err := c.introspect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Schema has the shape of the generated response, whose types of the
+	// nested type references differ at each level.
+	schema := Schema{}
+	if err := Convert(resp, &schema); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
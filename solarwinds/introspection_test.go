@@ -0,0 +1,141 @@
+package solarwinds
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+const introspectResponseStr = `
+{
+  "data": {
+    "__schema": {
+      "queryType": {"name": "Query"},
+      "mutationType": {"name": "Mutation"},
+      "types": [
+        {
+          "kind": "OBJECT",
+          "name": "Query",
+          "fields": [
+            {
+              "name": "user",
+              "args": [],
+              "type": {"kind": "OBJECT", "name": "AuthenticatedUser", "ofType": null}
+            }
+          ]
+        },
+        {
+          "kind": "OBJECT",
+          "name": "Mutation",
+          "fields": [
+            {
+              "name": "updateMemberRoles",
+              "args": [
+                {
+                  "name": "userId",
+                  "type": {"kind": "NON_NULL", "name": null, "ofType": {"kind": "SCALAR", "name": "ID", "ofType": null}}
+                },
+                {
+                  "name": "input",
+                  "type": {"kind": "NON_NULL", "name": null, "ofType": {"kind": "INPUT_OBJECT", "name": "UpdateMemberRolesInput", "ofType": null}}
+                }
+              ],
+              "type": {"kind": "OBJECT", "name": "UpdateMemberRolesResponse", "ofType": null}
+            },
+            {
+              "name": "deleteOrganizationInvitation",
+              "args": [
+                {
+                  "name": "email",
+                  "type": {"kind": "NON_NULL", "name": null, "ofType": {"kind": "SCALAR", "name": "ID", "ofType": null}}
+                }
+              ],
+              "type": {"kind": "OBJECT", "name": "DeleteOrganizationInvitationResponse", "ofType": null}
+            }
+          ]
+        },
+        {
+          "kind": "INPUT_OBJECT",
+          "name": "UpdateMemberRolesInput",
+          "inputFields": [
+            {
+              "name": "products",
+              "type": {"kind": "LIST", "name": null, "ofType": {"kind": "NON_NULL", "name": null, "ofType": {"kind": "INPUT_OBJECT", "name": "ProductAccessInput", "ofType": null}}}
+            }
+          ]
+        },
+        {
+          "kind": "ENUM",
+          "name": "OrganizationRole",
+          "enumValues": [{"name": "ADMIN"}, {"name": "MEMBER"}]
+        },
+        {
+          "kind": "OBJECT",
+          "name": "__Schema",
+          "fields": []
+        }
+      ]
+    }
+  }
+}
+`
+
+func TestIntrospect(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(graphQLEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		graphQLReq := GraphQLRequest{}
+		_ = json.NewDecoder(r.Body).Decode(&graphQLReq)
+		assert.Equal(t, introspectOp, graphQLReq.OperationName)
+		assert.Equal(t, introspectQuery, graphQLReq.Query)
+
+		_, _ = fmt.Fprint(w, introspectResponseStr)
+	})
+	schema, err := client.Introspect(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Mutation", "OrganizationRole", "Query", "UpdateMemberRolesInput"}, schema.TypeNames())
+
+	queries := schema.Queries()
+	assert.Len(t, queries, 1)
+	assert.Equal(t, "user: AuthenticatedUser", queries[0].String())
+
+	mutations := schema.Mutations()
+	assert.Len(t, mutations, 2)
+	assert.Equal(t, "deleteOrganizationInvitation(email: ID!): DeleteOrganizationInvitationResponse", mutations[0].String())
+	assert.Equal(t, "updateMemberRoles(userId: ID!, input: UpdateMemberRolesInput!): UpdateMemberRolesResponse", mutations[1].String())
+
+	input := schema.Type(mutations[1].Args[1].Type.NamedType())
+	assert.NotNil(t, input)
+	assert.Equal(t, TypeKindInputObject, input.Kind)
+	assert.Equal(t, "[ProductAccessInput!]", input.InputFields[0].Type.String())
+	assert.Equal(t, "ProductAccessInput", input.InputFields[0].Type.NamedType())
+	assert.Len(t, schema.Type("OrganizationRole").EnumValues, 2)
+	assert.Nil(t, schema.Type("Missing"))
+}
+
+func TestIntrospectWithoutMutations(t *testing.T) {
+	schema := Schema{
+		QueryType: &TypeRef{Name: "Query"},
+		Types:     []SchemaType{{Kind: TypeKindObject, Name: "Query"}},
+	}
+	assert.Empty(t, schema.Queries())
+	assert.Nil(t, schema.Mutations())
+}
+
+func TestIntrospectDisabled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(graphQLEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"errors": [{"message": "GraphQL introspection is not allowed"}], "data": null}`)
+	})
+	_, err := client.Introspect(context.Background())
+	gqlErr, ok := err.(*GraphQLError)
+	assert.True(t, ok)
+	assert.Equal(t, introspectOp, gqlErr.Operation)
+	assert.Equal(t, "GraphQL introspection is not allowed", gqlErr.Message)
+}
@@ -1,28 +1,12 @@
 package solarwinds
 
-// Constant values used in GraphQL requests.
-const (
-	inviteUserOp           = "createOrganizationAdminMutation"
-	inviteUserQuery        = "mutation createOrganizationAdminMutation($input: CreateOrganizationInvitationInput!) {\n  createOrganizationInvitation(input: $input) {\n    success\n    code\n    message\n    invitation {\n      email\n      role\n      __typename\n    }\n    __typename\n  }\n}\n"
-	inviteUserResponseType = "createOrganizationInvitation"
-
-	revokeInvitationOp           = "deleteOrganizationInvitationMutation"
-	revokeInvitationQuery        = "mutation deleteOrganizationInvitationMutation($email: ID!) {\n  deleteOrganizationInvitation(email: $email) {\n    success\n    code\n    message\n    __typename\n  }\n}\n"
-	revokeInvitationResponseType = "deleteOrganizationInvitation"
-
-	resendInvitationOp           = "resendOrganizationInvitationMutation"
-	resendInvitationQuery        = "mutation resendOrganizationInvitationMutation($email: ID!) {\n  resendOrganizationInvitation(email: $email) {\n    success\n    code\n    message\n    __typename\n  }\n}\n"
-	resendInvitationResponseType = "resendOrganizationInvitation"
-
-	listInvitationOp           = "getInvitationsQuery"
-	listInvitationQuery        = "query getInvitationsQuery {\n  user {\n    id\n    currentOrganization {\n      id\n      invitations {\n        email\n        role\n        date\n        products {\n          name\n          role\n          access\n          __typename\n        }\n        __typename\n      }\n      __typename\n    }\n    __typename\n  }\n}\n"
-	listInvitationResponseType = "user"
-)
+import "context"
 
 type Invitation struct {
 	Email    string    `json:"email"`
 	Role     string    `json:"role"`
 	Products []Product `json:"products"`
+	Date     string    `json:"date,omitempty"` // Only returned when listing invitations, not sent when inviting
 }
 
 type Product struct {
@@ -44,70 +28,54 @@ type InvitationService struct {
 	client *Client
 }
 
-type inviteUserVars struct {
-	Input Invitation `json:"input"`
-}
-
-type revokeInvitationVars struct {
-	Email string `json:"email"`
-}
-
-type resendInvitationVars struct {
-	Email string `json:"email"`
-}
-
-func (is *InvitationService) Create(user Invitation) error {
-	req := GraphQLRequest{
-		OperationName: inviteUserOp,
-		Query:         inviteUserQuery,
-		Variables: inviteUserVars{
-			Input: user,
+func (is *InvitationService) Create(ctx context.Context, user Invitation) error {
+	_, err := is.client.inviteUser(ctx, inviteUserVars{
+		Input: createOrganizationInvitationInput{
+			Email:    user.Email,
+			Role:     user.Role,
+			Products: productInputs(user.Products),
 		},
-		ResponseType: inviteUserResponseType,
-	}
-	_, err := is.client.MakeGraphQLRequest(&req)
+	})
 	return err
 }
 
-func (is *InvitationService) Revoke(email string) error {
-	req := GraphQLRequest{
-		OperationName: revokeInvitationOp,
-		Query:         revokeInvitationQuery,
-		Variables: revokeInvitationVars{
-			Email: email,
-		},
-		ResponseType: revokeInvitationResponseType,
-	}
-	_, err := is.client.MakeGraphQLRequest(&req)
+func (is *InvitationService) Revoke(ctx context.Context, email string) error {
+	_, err := is.client.revokeInvitation(ctx, revokeInvitationVars{Email: email})
 	return err
 }
 
-func (is *InvitationService) Resend(email string) error {
-	req := GraphQLRequest{
-		OperationName: resendInvitationOp,
-		Query:         resendInvitationQuery,
-		Variables: resendInvitationVars{
-			Email: email,
-		},
-		ResponseType: resendInvitationResponseType,
-	}
-	_, err := is.client.MakeGraphQLRequest(&req)
+func (is *InvitationService) Resend(ctx context.Context, email string) error {
+	_, err := is.client.resendInvitation(ctx, resendInvitationVars{Email: email})
 	return err
 }
 
-func (is *InvitationService) List() (*InvitationList, error) {
-	req := GraphQLRequest{
-		OperationName: listInvitationOp,
-		Query:         listInvitationQuery,
-		ResponseType:  listInvitationResponseType,
-	}
-	resp, err := is.client.MakeGraphQLRequest(&req)
+func (is *InvitationService) List(ctx context.Context) (*InvitationList, error) {
+	resp, err := is.client.listInvitation(ctx)
 	if err != nil {
 		return nil, err
 	}
-	invitationList := InvitationList{}
-	if err := Convert(&resp, &invitationList); err != nil {
-		return nil, err
+	invitationList := InvitationList{OwnerUserId: resp.Id}
+	if org := resp.CurrentOrganization; org != nil {
+		invitationList.Organization.Id = org.Id
+		for _, i := range org.Invitations {
+			invitation := Invitation{Email: i.Email, Role: i.Role, Date: i.Date}
+			for _, p := range i.Products {
+				invitation.Products = append(invitation.Products, Product{Name: p.Name, Role: p.Role})
+			}
+			invitationList.Organization.Invitations = append(invitationList.Organization.Invitations, invitation)
+		}
 	}
 	return &invitationList, nil
 }
+
+// productInputs returns the products as the input of a mutation.
+func productInputs(products []Product) []productAccessInput {
+	if products == nil {
+		return nil
+	}
+	inputs := make([]productAccessInput, len(products))
+	for i, p := range products {
+		inputs[i] = productAccessInput{Name: p.Name, Role: p.Role}
+	}
+	return inputs
+}
@@ -0,0 +1,81 @@
+package solarwinds
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+const (
+	InvitationActionRevoke = "revoke"
+	InvitationActionResend = "resend"
+)
+
+// InvitationExpiryPolicy decides what happens to invitations which have been pending for longer than MaxAge.
+type InvitationExpiryPolicy struct {
+	MaxAge time.Duration
+	Action string           // Either InvitationActionRevoke or InvitationActionResend
+	Now    func() time.Time // Defaults to time.Now, mainly overridden in tests
+}
+
+// ReconciledInvitation records what has been done to a single expired invitation.
+type ReconciledInvitation struct {
+	Email  string
+	Age    time.Duration
+	Action string
+	Err    error // Set if the action failed, the other invitations are still processed
+}
+
+func (p InvitationExpiryPolicy) validate() error {
+	if p.MaxAge <= 0 {
+		return fmt.Errorf("max age of the invitation expiry policy must be positive, got %v", p.MaxAge)
+	}
+	if p.Action != InvitationActionRevoke && p.Action != InvitationActionResend {
+		return fmt.Errorf("invalid invitation expiry action: %q", p.Action)
+	}
+	return nil
+}
+
+// ReconcileInvitations lists all pending invitations and revokes or resends, depending on the policy, every
+// invitation which is older than the max age of the policy. Invitations without a parsable date are left untouched.
+// An error is returned only when the policy is invalid or the invitations can not be listed, failures of individual
+// invitations are reported in the returned results.
+func (is *InvitationService) ReconcileInvitations(ctx context.Context, policy InvitationExpiryPolicy) ([]ReconciledInvitation, error) {
+	if err := policy.validate(); err != nil {
+		return nil, err
+	}
+	now := time.Now
+	if policy.Now != nil {
+		now = policy.Now
+	}
+
+	invitationList, err := is.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	results := []ReconciledInvitation{}
+	for _, invitation := range invitationList.Organization.Invitations {
+		invitedAt, err := time.Parse(time.RFC3339, invitation.Date)
+		if err != nil {
+			log.Printf("Skipping invitation of %v with unknown date %q", invitation.Email, invitation.Date)
+			continue
+		}
+		age := now().Sub(invitedAt)
+		if age <= policy.MaxAge {
+			continue
+		}
+		result := ReconciledInvitation{
+			Email:  invitation.Email,
+			Age:    age,
+			Action: policy.Action,
+		}
+		if policy.Action == InvitationActionRevoke {
+			result.Err = is.Revoke(ctx, invitation.Email)
+		} else {
+			result.Err = is.Resend(ctx, invitation.Email)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
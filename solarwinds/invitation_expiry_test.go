@@ -0,0 +1,109 @@
+package solarwinds
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestReconcileInvitations(t *testing.T) {
+	// The invitations in the mock response are sent at 2021-03-25T02:36:48Z and 2021-03-25T02:37:25Z.
+	now := func() time.Time {
+		return time.Date(2021, 3, 25, 2, 37, 0, 0, time.UTC)
+	}
+
+	t.Run("revokes expired invitations", func(t *testing.T) {
+		setup()
+		defer teardown()
+
+		var revoked []string
+		mux.HandleFunc(graphQLEndpoint, func(w http.ResponseWriter, r *http.Request) {
+			graphQLReq := GraphQLRequest{}
+			_ = json.NewDecoder(r.Body).Decode(&graphQLReq)
+
+			switch graphQLReq.OperationName {
+			case listInvitationOp:
+				_, _ = fmt.Fprint(w, listInvitationResponseStr)
+			case revokeInvitationOp:
+				actualVars := revokeInvitationVars{}
+				_ = Convert(&graphQLReq.Variables, &actualVars)
+				revoked = append(revoked, actualVars.Email)
+				_, _ = fmt.Fprint(w, revokePendingInvitationResponseStr)
+			default:
+				t.Errorf("should not have op: %v", graphQLReq.OperationName)
+			}
+		})
+
+		results, err := client.InvitationService.ReconcileInvitations(context.Background(), InvitationExpiryPolicy{
+			MaxAge: time.Second,
+			Action: InvitationActionRevoke,
+			Now:    now,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{pendingUserEmail}, revoked)
+		assert.Equal(t, []ReconciledInvitation{
+			{
+				Email:  pendingUserEmail,
+				Age:    12 * time.Second,
+				Action: InvitationActionRevoke,
+			},
+		}, results)
+	})
+
+	t.Run("resends expired invitations", func(t *testing.T) {
+		setup()
+		defer teardown()
+
+		var resent []string
+		mux.HandleFunc(graphQLEndpoint, func(w http.ResponseWriter, r *http.Request) {
+			graphQLReq := GraphQLRequest{}
+			_ = json.NewDecoder(r.Body).Decode(&graphQLReq)
+
+			switch graphQLReq.OperationName {
+			case listInvitationOp:
+				_, _ = fmt.Fprint(w, listInvitationResponseStr)
+			case resendInvitationOp:
+				actualVars := resendInvitationVars{}
+				_ = Convert(&graphQLReq.Variables, &actualVars)
+				resent = append(resent, actualVars.Email)
+				_, _ = fmt.Fprint(w, resendInvitationResponseStr)
+			default:
+				t.Errorf("should not have op: %v", graphQLReq.OperationName)
+			}
+		})
+
+		results, err := client.InvitationService.ReconcileInvitations(context.Background(), InvitationExpiryPolicy{
+			MaxAge: 24 * time.Hour,
+			Action: InvitationActionResend,
+			Now: func() time.Time {
+				return now().Add(48 * time.Hour)
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{pendingUserEmail, "0JTELJv5YA@foo.com"}, resent)
+		assert.Equal(t, 2, len(results))
+		for _, result := range results {
+			assert.NoError(t, result.Err)
+		}
+	})
+
+	t.Run("rejects invalid policy", func(t *testing.T) {
+		setup()
+		defer teardown()
+
+		_, err := client.InvitationService.ReconcileInvitations(context.Background(), InvitationExpiryPolicy{
+			MaxAge: time.Hour,
+			Action: "delete",
+		})
+		assert.Error(t, err)
+
+		_, err = client.InvitationService.ReconcileInvitations(context.Background(), InvitationExpiryPolicy{
+			Action: InvitationActionRevoke,
+		})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,88 @@
+package solarwinds
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+const (
+	InvitationStatusAccepted = "accepted"
+	InvitationStatusPending  = "pending"
+	InvitationStatusExpired  = "expired"
+	InvitationStatusRevoked  = "revoked"
+)
+
+// SentInvitation is an invitation as recorded by whoever sent it, since SolarWinds forgets invitations once they are
+// accepted or revoked.
+type SentInvitation struct {
+	Email  string
+	SentAt time.Time
+}
+
+// TrackedInvitation is the outcome of a sent invitation. The status is InvitationStatusAccepted when the email belongs
+// to an active member, InvitationStatusPending or InvitationStatusExpired when the invitation is still pending,
+// depending on its age, and InvitationStatusRevoked when it is neither, i.e. it was revoked or expired elsewhere.
+type TrackedInvitation struct {
+	Email     string
+	Status    string
+	SentAt    time.Time
+	InvitedAt time.Time // Date of the pending invitation, zero unless pending or expired
+	LastLogin time.Time // Last login of the member, zero unless accepted and logged in
+}
+
+// InvitationFunnel is the outcome of sent invitations, in the order they were given, with the number of invitations
+// of each status.
+type InvitationFunnel struct {
+	Invitations []TrackedInvitation
+	Counts      map[string]int
+}
+
+// TrackInvitations correlates the sent invitations with the active members and the pending invitations of the
+// organization, e.g. for onboarding metrics. Pending invitations sent more than maxAge ago are expired; with a
+// non-positive maxAge they never are. now defaults to time.Now when nil. Emails are compared case-insensitively.
+func (us *UserService) TrackInvitations(ctx context.Context, sent []SentInvitation, maxAge time.Duration, now func() time.Time) (*InvitationFunnel, error) {
+	if now == nil {
+		now = time.Now
+	}
+	activeUsers, err := us.ActiveUserService.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	invitations, err := us.InvitationService.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	members := map[string]ActiveUser{}
+	for _, member := range activeUsers.Organization.Members {
+		members[strings.ToLower(member.User.Email)] = member.User
+	}
+	pending := map[string]Invitation{}
+	for _, invitation := range invitations.Organization.Invitations {
+		pending[strings.ToLower(invitation.Email)] = invitation
+	}
+
+	funnel := &InvitationFunnel{Counts: map[string]int{}}
+	for _, s := range sent {
+		tracked := TrackedInvitation{Email: s.Email, SentAt: s.SentAt, Status: InvitationStatusRevoked}
+		email := strings.ToLower(s.Email)
+		if member, ok := members[email]; ok {
+			tracked.Status = InvitationStatusAccepted
+			tracked.LastLogin, _ = time.Parse(time.RFC3339, member.LastLogin)
+		} else if invitation, ok := pending[email]; ok {
+			tracked.Status = InvitationStatusPending
+			tracked.InvitedAt, _ = time.Parse(time.RFC3339, invitation.Date)
+			invitedAt := tracked.InvitedAt
+			if invitedAt.IsZero() {
+				invitedAt = s.SentAt
+			}
+			if maxAge > 0 && now().Sub(invitedAt) > maxAge {
+				tracked.Status = InvitationStatusExpired
+			}
+		}
+		funnel.Invitations = append(funnel.Invitations, tracked)
+		funnel.Counts[tracked.Status]++
+	}
+	return funnel, nil
+}
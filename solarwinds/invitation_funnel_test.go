@@ -0,0 +1,75 @@
+package solarwinds
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTrackInvitations(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(graphQLEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		graphQLReq := GraphQLRequest{}
+		_ = json.NewDecoder(r.Body).Decode(&graphQLReq)
+
+		switch graphQLReq.OperationName {
+		case listActiveUserOp:
+			_, _ = fmt.Fprint(w, listActiveUserResponseStr)
+		case listInvitationOp:
+			_, _ = fmt.Fprint(w, listInvitationResponseStr)
+		default:
+			t.Errorf("should not have op: %v", graphQLReq.OperationName)
+		}
+	})
+
+	// The invitations in the mock response are sent at 2021-03-25T02:36:48Z and 2021-03-25T02:37:25Z.
+	now := func() time.Time {
+		return time.Date(2021, 3, 25, 2, 37, 30, 0, time.UTC)
+	}
+	sentAt := time.Date(2021, 3, 20, 0, 0, 0, 0, time.UTC)
+	sent := []SentInvitation{
+		{Email: "FOO@nordcloud.com", SentAt: sentAt},
+		{Email: pendingUserEmail, SentAt: sentAt},
+		{Email: "0JTELJv5YA@foo.com", SentAt: sentAt},
+		{Email: nonExistUserEmail, SentAt: sentAt},
+	}
+	funnel, err := client.UserService.TrackInvitations(context.Background(), sent, 30*time.Second, now)
+	assert.NoError(t, err)
+	assert.Equal(t, []TrackedInvitation{
+		{
+			Email:     "FOO@nordcloud.com",
+			Status:    InvitationStatusAccepted,
+			SentAt:    sentAt,
+			LastLogin: time.Date(2021, 3, 23, 7, 17, 48, 0, time.UTC),
+		},
+		{
+			Email:     pendingUserEmail,
+			Status:    InvitationStatusExpired,
+			SentAt:    sentAt,
+			InvitedAt: time.Date(2021, 3, 25, 2, 36, 48, 0, time.UTC),
+		},
+		{
+			Email:     "0JTELJv5YA@foo.com",
+			Status:    InvitationStatusPending,
+			SentAt:    sentAt,
+			InvitedAt: time.Date(2021, 3, 25, 2, 37, 25, 0, time.UTC),
+		},
+		{
+			Email:  nonExistUserEmail,
+			Status: InvitationStatusRevoked,
+			SentAt: sentAt,
+		},
+	}, funnel.Invitations)
+	assert.Equal(t, map[string]int{
+		InvitationStatusAccepted: 1,
+		InvitationStatusPending:  1,
+		InvitationStatusExpired:  1,
+		InvitationStatusRevoked:  1,
+	}, funnel.Counts)
+}
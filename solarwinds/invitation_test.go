@@ -1,6 +1,7 @@
 package solarwinds
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/stretchr/testify/assert"
@@ -115,7 +116,11 @@ func TestInviteUser(t *testing.T) {
 		},
 	}
 	input := inviteUserVars{
-		Input: invitation,
+		Input: createOrganizationInvitationInput{
+			Email:    invitation.Email,
+			Role:     invitation.Role,
+			Products: []productAccessInput{{Name: "AppOptics", Role: "Admin"}, {Name: "Loggly", Role: "User"}},
+		},
 	}
 	mux.HandleFunc(graphQLEndpoint, func(w http.ResponseWriter, r *http.Request) {
 		defer r.Body.Close()
@@ -129,7 +134,7 @@ func TestInviteUser(t *testing.T) {
 
 		_, _ = fmt.Fprint(w, inviteUserResponseStr)
 	})
-	err := client.InvitationService.Create(invitation)
+	err := client.InvitationService.Create(context.Background(), invitation)
 	assert.NoError(t, err)
 }
 
@@ -153,7 +158,7 @@ func TestRevokePendingInvitation(t *testing.T) {
 
 		_, _ = fmt.Fprint(w, revokePendingInvitationResponseStr)
 	})
-	err := client.InvitationService.Revoke(email)
+	err := client.InvitationService.Revoke(context.Background(), email)
 	assert.NoError(t, err)
 }
 
@@ -176,7 +181,7 @@ func TestResendInvitation(t *testing.T) {
 
 		_, _ = fmt.Fprint(w, resendInvitationResponseStr)
 	})
-	err := client.InvitationService.Resend(email)
+	err := client.InvitationService.Resend(context.Background(), email)
 	assert.NoError(t, err)
 }
 
@@ -192,8 +197,23 @@ func TestListInvitation(t *testing.T) {
 
 		_, _ = fmt.Fprint(w, listInvitationResponseStr)
 	})
-	invitationList, err := client.InvitationService.List()
+	invitationList, err := client.InvitationService.List(context.Background())
 	assert.NoError(t, err)
 	invitations := invitationList.Organization.Invitations
 	assert.Equal(t, len(invitations), 2)
 }
+
+func TestInvitationJSONRoundTrip(t *testing.T) {
+	want := Invitation{
+		Email:    "foo@example.com",
+		Role:     "MEMBER",
+		Products: []Product{{Name: "APPOPTICS", Role: "ADMIN"}, {Name: "PINGDOM", Role: "MEMBER"}},
+		Date:     "2021-01-01T00:00:00Z",
+	}
+	b, err := json.Marshal(want)
+	assert.NoError(t, err)
+
+	var got Invitation
+	assert.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,502 @@
+// Code generated by graphqlgen. DO NOT EDIT.
+
+package solarwinds
+
+import "context"
+
+// Constant values used in GraphQL requests.
+const (
+	// getActiveUser.graphql
+	getActiveUserOp           = "getEditUserQuery"
+	getActiveUserQuery        = "query getEditUserQuery($userId: String!) {\n  user {\n    id\n    currentOrganization {\n      id\n      members(filter: {id: $userId}) {\n        id\n        user {\n          email\n          __typename\n        }\n        role\n        products {\n          name\n          role\n          access\n          __typename\n        }\n        __typename\n      }\n      __typename\n    }\n    __typename\n  }\n}\n"
+	getActiveUserResponseType = "user"
+
+	// introspect.graphql
+	introspectOp           = "IntrospectionQuery"
+	introspectQuery        = "query IntrospectionQuery {\n  __schema {\n    queryType {\n      name\n    }\n    mutationType {\n      name\n    }\n    types {\n      kind\n      name\n      description\n      fields {\n        name\n        description\n        args {\n          name\n          description\n          type {\n            kind\n            name\n            ofType {\n              kind\n              name\n              ofType {\n                kind\n                name\n                ofType {\n                  kind\n                  name\n                }\n              }\n            }\n          }\n        }\n        type {\n          kind\n          name\n          ofType {\n            kind\n            name\n            ofType {\n              kind\n              name\n              ofType {\n                kind\n                name\n              }\n            }\n          }\n        }\n      }\n      inputFields {\n        name\n        description\n        type {\n          kind\n          name\n          ofType {\n            kind\n            name\n            ofType {\n              kind\n              name\n              ofType {\n                kind\n                name\n              }\n            }\n          }\n        }\n      }\n      enumValues {\n        name\n        description\n      }\n    }\n  }\n}\n"
+	introspectResponseType = "__schema"
+
+	// inviteUser.graphql
+	inviteUserOp           = "createOrganizationAdminMutation"
+	inviteUserQuery        = "mutation createOrganizationAdminMutation($input: CreateOrganizationInvitationInput!) {\n  createOrganizationInvitation(input: $input) {\n    success\n    code\n    message\n    invitation {\n      email\n      role\n      __typename\n    }\n    __typename\n  }\n}\n"
+	inviteUserResponseType = "createOrganizationInvitation"
+
+	// listActiveUser.graphql
+	listActiveUserOp           = "getUsersQuery"
+	listActiveUserQuery        = "query getUsersQuery {\n  user {\n    id\n    currentOrganization {\n      id\n      members {\n        user {\n          id\n          firstName\n          lastName\n          email\n          lastLogin\n          __typename\n        }\n        role\n        products {\n          name\n          access\n          role\n          __typename\n        }\n        __typename\n      }\n      __typename\n    }\n    __typename\n  }\n}\n"
+	listActiveUserResponseType = "user"
+
+	// listInvitation.graphql
+	listInvitationOp           = "getInvitationsQuery"
+	listInvitationQuery        = "query getInvitationsQuery {\n  user {\n    id\n    currentOrganization {\n      id\n      invitations {\n        email\n        role\n        date\n        products {\n          name\n          role\n          access\n          __typename\n        }\n        __typename\n      }\n      __typename\n    }\n    __typename\n  }\n}\n"
+	listInvitationResponseType = "user"
+
+	// resendInvitation.graphql
+	resendInvitationOp           = "resendOrganizationInvitationMutation"
+	resendInvitationQuery        = "mutation resendOrganizationInvitationMutation($email: ID!) {\n  resendOrganizationInvitation(email: $email) {\n    success\n    code\n    message\n    __typename\n  }\n}\n"
+	resendInvitationResponseType = "resendOrganizationInvitation"
+
+	// revokeInvitation.graphql
+	revokeInvitationOp           = "deleteOrganizationInvitationMutation"
+	revokeInvitationQuery        = "mutation deleteOrganizationInvitationMutation($email: ID!) {\n  deleteOrganizationInvitation(email: $email) {\n    success\n    code\n    message\n    __typename\n  }\n}\n"
+	revokeInvitationResponseType = "deleteOrganizationInvitation"
+
+	// updateActiveUser.graphql
+	updateActiveUserOp           = "updateMemberRolesMutation"
+	updateActiveUserQuery        = "mutation updateMemberRolesMutation($userId: ID!, $role: OrganizationRole!, $products: [ProductAccessInput!]) {\n  updateMemberRoles(userId: $userId, input: {role: $role, products: $products}) {\n    code\n    success\n    message\n    __typename\n  }\n}\n"
+	updateActiveUserResponseType = "updateMemberRoles"
+)
+
+// getActiveUser sends the getEditUserQuery query.
+func (c *Client) getActiveUser(ctx context.Context, vars getActiveUserVars) (*getActiveUserResponse, error) {
+	req := GraphQLRequest{
+		OperationName: getActiveUserOp,
+		Query:         getActiveUserQuery,
+		Variables:     vars,
+		ResponseType:  getActiveUserResponseType,
+	}
+	resp, err := c.MakeGraphQLRequest(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	data := getActiveUserResponse{}
+	if err := Convert(resp, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// getActiveUserVars are the variables of getEditUserQuery.
+type getActiveUserVars struct {
+	UserId string `json:"userId"`
+}
+
+// getActiveUserResponse is the user field of the data of getEditUserQuery.
+type getActiveUserResponse struct {
+	Id                  string                                    `json:"id"`
+	CurrentOrganization *getActiveUserResponseCurrentOrganization `json:"currentOrganization"`
+}
+
+// getActiveUserResponseCurrentOrganization is the currentOrganization Organization selected by getEditUserQuery.
+type getActiveUserResponseCurrentOrganization struct {
+	Id      string                                            `json:"id"`
+	Members []getActiveUserResponseCurrentOrganizationMembers `json:"members"`
+}
+
+// getActiveUserResponseCurrentOrganizationMembers is the members OrganizationMember selected by getEditUserQuery.
+type getActiveUserResponseCurrentOrganizationMembers struct {
+	Id       string                                                    `json:"id"`
+	User     getActiveUserResponseCurrentOrganizationMembersUser       `json:"user"`
+	Role     string                                                    `json:"role"`
+	Products []getActiveUserResponseCurrentOrganizationMembersProducts `json:"products"`
+}
+
+// getActiveUserResponseCurrentOrganizationMembersUser is the user User selected by getEditUserQuery.
+type getActiveUserResponseCurrentOrganizationMembersUser struct {
+	Email string `json:"email"`
+}
+
+// getActiveUserResponseCurrentOrganizationMembersProducts is the products ProductAccess selected by getEditUserQuery.
+type getActiveUserResponseCurrentOrganizationMembersProducts struct {
+	Name   string `json:"name"`
+	Role   string `json:"role"`
+	Access bool   `json:"access"`
+}
+
+// introspect sends the IntrospectionQuery query.
+func (c *Client) introspect(ctx context.Context) (*introspectResponse, error) {
+	req := GraphQLRequest{
+		OperationName: introspectOp,
+		Query:         introspectQuery,
+		ResponseType:  introspectResponseType,
+	}
+	resp, err := c.MakeGraphQLRequest(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	data := introspectResponse{}
+	if err := Convert(resp, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// introspectResponse is the __schema field of the data of IntrospectionQuery.
+type introspectResponse struct {
+	QueryType    introspectResponseQueryType     `json:"queryType"`
+	MutationType *introspectResponseMutationType `json:"mutationType"`
+	Types        []introspectResponseTypes       `json:"types"`
+}
+
+// introspectResponseQueryType is the queryType __Type selected by IntrospectionQuery.
+type introspectResponseQueryType struct {
+	Name string `json:"name"`
+}
+
+// introspectResponseMutationType is the mutationType __Type selected by IntrospectionQuery.
+type introspectResponseMutationType struct {
+	Name string `json:"name"`
+}
+
+// introspectResponseTypes is the types __Type selected by IntrospectionQuery.
+type introspectResponseTypes struct {
+	Kind        string                               `json:"kind"`
+	Name        string                               `json:"name"`
+	Description string                               `json:"description"`
+	Fields      []introspectResponseTypesFields      `json:"fields"`
+	InputFields []introspectResponseTypesInputFields `json:"inputFields"`
+	EnumValues  []introspectResponseTypesEnumValues  `json:"enumValues"`
+}
+
+// introspectResponseTypesFields is the fields __Field selected by IntrospectionQuery.
+type introspectResponseTypesFields struct {
+	Name        string                              `json:"name"`
+	Description string                              `json:"description"`
+	Args        []introspectResponseTypesFieldsArgs `json:"args"`
+	Type        introspectResponseTypesFieldsType   `json:"type"`
+}
+
+// introspectResponseTypesFieldsArgs is the args __InputValue selected by IntrospectionQuery.
+type introspectResponseTypesFieldsArgs struct {
+	Name        string                                `json:"name"`
+	Description string                                `json:"description"`
+	Type        introspectResponseTypesFieldsArgsType `json:"type"`
+}
+
+// introspectResponseTypesFieldsArgsType is the type __Type selected by IntrospectionQuery.
+type introspectResponseTypesFieldsArgsType struct {
+	Kind   string                                       `json:"kind"`
+	Name   string                                       `json:"name"`
+	OfType *introspectResponseTypesFieldsArgsTypeOfType `json:"ofType"`
+}
+
+// introspectResponseTypesFieldsArgsTypeOfType is the ofType __Type selected by IntrospectionQuery.
+type introspectResponseTypesFieldsArgsTypeOfType struct {
+	Kind   string                                             `json:"kind"`
+	Name   string                                             `json:"name"`
+	OfType *introspectResponseTypesFieldsArgsTypeOfTypeOfType `json:"ofType"`
+}
+
+// introspectResponseTypesFieldsArgsTypeOfTypeOfType is the ofType __Type selected by IntrospectionQuery.
+type introspectResponseTypesFieldsArgsTypeOfTypeOfType struct {
+	Kind   string                                                   `json:"kind"`
+	Name   string                                                   `json:"name"`
+	OfType *introspectResponseTypesFieldsArgsTypeOfTypeOfTypeOfType `json:"ofType"`
+}
+
+// introspectResponseTypesFieldsArgsTypeOfTypeOfTypeOfType is the ofType __Type selected by IntrospectionQuery.
+type introspectResponseTypesFieldsArgsTypeOfTypeOfTypeOfType struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// introspectResponseTypesFieldsType is the type __Type selected by IntrospectionQuery.
+type introspectResponseTypesFieldsType struct {
+	Kind   string                                   `json:"kind"`
+	Name   string                                   `json:"name"`
+	OfType *introspectResponseTypesFieldsTypeOfType `json:"ofType"`
+}
+
+// introspectResponseTypesFieldsTypeOfType is the ofType __Type selected by IntrospectionQuery.
+type introspectResponseTypesFieldsTypeOfType struct {
+	Kind   string                                         `json:"kind"`
+	Name   string                                         `json:"name"`
+	OfType *introspectResponseTypesFieldsTypeOfTypeOfType `json:"ofType"`
+}
+
+// introspectResponseTypesFieldsTypeOfTypeOfType is the ofType __Type selected by IntrospectionQuery.
+type introspectResponseTypesFieldsTypeOfTypeOfType struct {
+	Kind   string                                               `json:"kind"`
+	Name   string                                               `json:"name"`
+	OfType *introspectResponseTypesFieldsTypeOfTypeOfTypeOfType `json:"ofType"`
+}
+
+// introspectResponseTypesFieldsTypeOfTypeOfTypeOfType is the ofType __Type selected by IntrospectionQuery.
+type introspectResponseTypesFieldsTypeOfTypeOfTypeOfType struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// introspectResponseTypesInputFields is the inputFields __InputValue selected by IntrospectionQuery.
+type introspectResponseTypesInputFields struct {
+	Name        string                                 `json:"name"`
+	Description string                                 `json:"description"`
+	Type        introspectResponseTypesInputFieldsType `json:"type"`
+}
+
+// introspectResponseTypesInputFieldsType is the type __Type selected by IntrospectionQuery.
+type introspectResponseTypesInputFieldsType struct {
+	Kind   string                                        `json:"kind"`
+	Name   string                                        `json:"name"`
+	OfType *introspectResponseTypesInputFieldsTypeOfType `json:"ofType"`
+}
+
+// introspectResponseTypesInputFieldsTypeOfType is the ofType __Type selected by IntrospectionQuery.
+type introspectResponseTypesInputFieldsTypeOfType struct {
+	Kind   string                                              `json:"kind"`
+	Name   string                                              `json:"name"`
+	OfType *introspectResponseTypesInputFieldsTypeOfTypeOfType `json:"ofType"`
+}
+
+// introspectResponseTypesInputFieldsTypeOfTypeOfType is the ofType __Type selected by IntrospectionQuery.
+type introspectResponseTypesInputFieldsTypeOfTypeOfType struct {
+	Kind   string                                                    `json:"kind"`
+	Name   string                                                    `json:"name"`
+	OfType *introspectResponseTypesInputFieldsTypeOfTypeOfTypeOfType `json:"ofType"`
+}
+
+// introspectResponseTypesInputFieldsTypeOfTypeOfTypeOfType is the ofType __Type selected by IntrospectionQuery.
+type introspectResponseTypesInputFieldsTypeOfTypeOfTypeOfType struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// introspectResponseTypesEnumValues is the enumValues __EnumValue selected by IntrospectionQuery.
+type introspectResponseTypesEnumValues struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// inviteUser sends the createOrganizationAdminMutation mutation.
+func (c *Client) inviteUser(ctx context.Context, vars inviteUserVars) (*inviteUserResponse, error) {
+	req := GraphQLRequest{
+		OperationName: inviteUserOp,
+		Query:         inviteUserQuery,
+		Variables:     vars,
+		ResponseType:  inviteUserResponseType,
+	}
+	resp, err := c.MakeGraphQLRequest(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	data := inviteUserResponse{}
+	if err := Convert(resp, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// inviteUserVars are the variables of createOrganizationAdminMutation.
+type inviteUserVars struct {
+	Input createOrganizationInvitationInput `json:"input"`
+}
+
+// inviteUserResponse is the createOrganizationInvitation field of the data of createOrganizationAdminMutation.
+type inviteUserResponse struct {
+	Success    bool                          `json:"success"`
+	Code       string                        `json:"code"`
+	Message    string                        `json:"message"`
+	Invitation *inviteUserResponseInvitation `json:"invitation"`
+}
+
+// inviteUserResponseInvitation is the invitation OrganizationInvitation selected by createOrganizationAdminMutation.
+type inviteUserResponseInvitation struct {
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+// listActiveUser sends the getUsersQuery query.
+func (c *Client) listActiveUser(ctx context.Context) (*listActiveUserResponse, error) {
+	req := GraphQLRequest{
+		OperationName: listActiveUserOp,
+		Query:         listActiveUserQuery,
+		ResponseType:  listActiveUserResponseType,
+	}
+	resp, err := c.MakeGraphQLRequest(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	data := listActiveUserResponse{}
+	if err := Convert(resp, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// listActiveUserResponse is the user field of the data of getUsersQuery.
+type listActiveUserResponse struct {
+	Id                  string                                     `json:"id"`
+	CurrentOrganization *listActiveUserResponseCurrentOrganization `json:"currentOrganization"`
+}
+
+// listActiveUserResponseCurrentOrganization is the currentOrganization Organization selected by getUsersQuery.
+type listActiveUserResponseCurrentOrganization struct {
+	Id      string                                             `json:"id"`
+	Members []listActiveUserResponseCurrentOrganizationMembers `json:"members"`
+}
+
+// listActiveUserResponseCurrentOrganizationMembers is the members OrganizationMember selected by getUsersQuery.
+type listActiveUserResponseCurrentOrganizationMembers struct {
+	User     listActiveUserResponseCurrentOrganizationMembersUser       `json:"user"`
+	Role     string                                                     `json:"role"`
+	Products []listActiveUserResponseCurrentOrganizationMembersProducts `json:"products"`
+}
+
+// listActiveUserResponseCurrentOrganizationMembersUser is the user User selected by getUsersQuery.
+type listActiveUserResponseCurrentOrganizationMembersUser struct {
+	Id        string `json:"id"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+	Email     string `json:"email"`
+	LastLogin string `json:"lastLogin"`
+}
+
+// listActiveUserResponseCurrentOrganizationMembersProducts is the products ProductAccess selected by getUsersQuery.
+type listActiveUserResponseCurrentOrganizationMembersProducts struct {
+	Name   string `json:"name"`
+	Access bool   `json:"access"`
+	Role   string `json:"role"`
+}
+
+// listInvitation sends the getInvitationsQuery query.
+func (c *Client) listInvitation(ctx context.Context) (*listInvitationResponse, error) {
+	req := GraphQLRequest{
+		OperationName: listInvitationOp,
+		Query:         listInvitationQuery,
+		ResponseType:  listInvitationResponseType,
+	}
+	resp, err := c.MakeGraphQLRequest(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	data := listInvitationResponse{}
+	if err := Convert(resp, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// listInvitationResponse is the user field of the data of getInvitationsQuery.
+type listInvitationResponse struct {
+	Id                  string                                     `json:"id"`
+	CurrentOrganization *listInvitationResponseCurrentOrganization `json:"currentOrganization"`
+}
+
+// listInvitationResponseCurrentOrganization is the currentOrganization Organization selected by getInvitationsQuery.
+type listInvitationResponseCurrentOrganization struct {
+	Id          string                                                 `json:"id"`
+	Invitations []listInvitationResponseCurrentOrganizationInvitations `json:"invitations"`
+}
+
+// listInvitationResponseCurrentOrganizationInvitations is the invitations OrganizationInvitation selected by getInvitationsQuery.
+type listInvitationResponseCurrentOrganizationInvitations struct {
+	Email    string                                                         `json:"email"`
+	Role     string                                                         `json:"role"`
+	Date     string                                                         `json:"date"`
+	Products []listInvitationResponseCurrentOrganizationInvitationsProducts `json:"products"`
+}
+
+// listInvitationResponseCurrentOrganizationInvitationsProducts is the products ProductAccess selected by getInvitationsQuery.
+type listInvitationResponseCurrentOrganizationInvitationsProducts struct {
+	Name   string `json:"name"`
+	Role   string `json:"role"`
+	Access bool   `json:"access"`
+}
+
+// resendInvitation sends the resendOrganizationInvitationMutation mutation.
+func (c *Client) resendInvitation(ctx context.Context, vars resendInvitationVars) (*resendInvitationResponse, error) {
+	req := GraphQLRequest{
+		OperationName: resendInvitationOp,
+		Query:         resendInvitationQuery,
+		Variables:     vars,
+		ResponseType:  resendInvitationResponseType,
+	}
+	resp, err := c.MakeGraphQLRequest(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	data := resendInvitationResponse{}
+	if err := Convert(resp, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// resendInvitationVars are the variables of resendOrganizationInvitationMutation.
+type resendInvitationVars struct {
+	Email string `json:"email"`
+}
+
+// resendInvitationResponse is the resendOrganizationInvitation field of the data of resendOrganizationInvitationMutation.
+type resendInvitationResponse struct {
+	Success bool   `json:"success"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// revokeInvitation sends the deleteOrganizationInvitationMutation mutation.
+func (c *Client) revokeInvitation(ctx context.Context, vars revokeInvitationVars) (*revokeInvitationResponse, error) {
+	req := GraphQLRequest{
+		OperationName: revokeInvitationOp,
+		Query:         revokeInvitationQuery,
+		Variables:     vars,
+		ResponseType:  revokeInvitationResponseType,
+	}
+	resp, err := c.MakeGraphQLRequest(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	data := revokeInvitationResponse{}
+	if err := Convert(resp, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// revokeInvitationVars are the variables of deleteOrganizationInvitationMutation.
+type revokeInvitationVars struct {
+	Email string `json:"email"`
+}
+
+// revokeInvitationResponse is the deleteOrganizationInvitation field of the data of deleteOrganizationInvitationMutation.
+type revokeInvitationResponse struct {
+	Success bool   `json:"success"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// updateActiveUser sends the updateMemberRolesMutation mutation.
+func (c *Client) updateActiveUser(ctx context.Context, vars updateActiveUserVars) (*updateActiveUserResponse, error) {
+	req := GraphQLRequest{
+		OperationName: updateActiveUserOp,
+		Query:         updateActiveUserQuery,
+		Variables:     vars,
+		ResponseType:  updateActiveUserResponseType,
+	}
+	resp, err := c.MakeGraphQLRequest(ctx, &req)
+	if err != nil {
+		return nil, err
+	}
+	data := updateActiveUserResponse{}
+	if err := Convert(resp, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// updateActiveUserVars are the variables of updateMemberRolesMutation.
+type updateActiveUserVars struct {
+	UserId   string               `json:"userId"`
+	Role     string               `json:"role"`
+	Products []productAccessInput `json:"products"`
+}
+
+// updateActiveUserResponse is the updateMemberRoles field of the data of updateMemberRolesMutation.
+type updateActiveUserResponse struct {
+	Code    string `json:"code"`
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// createOrganizationInvitationInput is the CreateOrganizationInvitationInput input type.
+type createOrganizationInvitationInput struct {
+	Email    string               `json:"email"`
+	Role     string               `json:"role"`
+	Products []productAccessInput `json:"products"`
+}
+
+// productAccessInput is the ProductAccessInput input type.
+type productAccessInput struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
@@ -0,0 +1,124 @@
+package solarwinds
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"sync"
+)
+
+// Error messages of the automatic persisted queries protocol, as sent by Apollo compatible servers.
+const (
+	persistedQueryNotFound     = "PersistedQueryNotFound"
+	persistedQueryNotSupported = "PersistedQueryNotSupported"
+)
+
+// GraphQLExtensions are the protocol extensions sent along with a GraphQL request.
+type GraphQLExtensions struct {
+	PersistedQuery *PersistedQuery `json:"persistedQuery,omitempty"`
+}
+
+// PersistedQuery identifies the query of a request by its hash, see ClientConfig.PersistedQueries.
+type PersistedQuery struct {
+	Version    int    `json:"version"`
+	Sha256Hash string `json:"sha256Hash"`
+}
+
+// persistedQueries tracks whether the endpoint supports persisted queries. Support is assumed until the server
+// says otherwise.
+type persistedQueries struct {
+	mu          sync.Mutex
+	unsupported bool
+}
+
+func (p *persistedQueries) enabled() bool {
+	if p == nil {
+		return false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return !p.unsupported
+}
+
+func (p *persistedQueries) disable() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.unsupported = true
+}
+
+// NewPersistedQuery returns the persisted query identifying the given query text.
+func NewPersistedQuery(query string) *PersistedQuery {
+	sum := sha256.Sum256([]byte(query))
+	return &PersistedQuery{Version: 1, Sha256Hash: hex.EncodeToString(sum[:])}
+}
+
+// postGraphQL sends the request and returns the raw response body. When persisted queries are enabled, the hash of
+// the query is sent first, the full text only being sent when the server does not know the hash yet, which
+// registers it, or does not support persisted queries at all, in which case they are disabled for the client.
+func (c *Client) postGraphQL(ctx context.Context, graphQLRequest *GraphQLRequest) ([]byte, error) {
+	if !c.persistedQueries.enabled() || graphQLRequest.Query == "" {
+		return c.sendGraphQL(ctx, graphQLRequest)
+	}
+
+	hashed := *graphQLRequest
+	hashed.Query = ""
+	hashed.Extensions = &GraphQLExtensions{PersistedQuery: NewPersistedQuery(graphQLRequest.Query)}
+	body, err := c.sendGraphQL(ctx, &hashed)
+	if err != nil {
+		return nil, err
+	}
+	switch persistedQueryError(body) {
+	case persistedQueryNotFound:
+		full := hashed
+		full.Query = graphQLRequest.Query
+		return c.sendGraphQL(ctx, &full)
+	case persistedQueryNotSupported:
+		c.persistedQueries.disable()
+		return c.sendGraphQL(ctx, graphQLRequest)
+	}
+	return body, nil
+}
+
+func (c *Client) sendGraphQL(ctx context.Context, graphQLRequest *GraphQLRequest) ([]byte, error) {
+	body, err := ToJsonNoEscape(graphQLRequest)
+	if err != nil {
+		return nil, err
+	}
+	req, err := c.NewRequest("POST", graphQLEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return ioutil.ReadAll(resp.Body)
+}
+
+// persistedQueryError returns the persisted query error reported in the response body, if any.
+func persistedQueryError(body []byte) string {
+	root := struct {
+		Errors []struct {
+			Message    string `json:"message"`
+			Extensions struct {
+				Code string `json:"code"`
+			} `json:"extensions"`
+		} `json:"errors"`
+	}{}
+	if err := json.Unmarshal(body, &root); err != nil {
+		return ""
+	}
+	for _, e := range root.Errors {
+		switch {
+		case e.Message == persistedQueryNotFound || e.Extensions.Code == "PERSISTED_QUERY_NOT_FOUND":
+			return persistedQueryNotFound
+		case e.Message == persistedQueryNotSupported || e.Extensions.Code == "PERSISTED_QUERY_NOT_SUPPORTED":
+			return persistedQueryNotSupported
+		}
+	}
+	return ""
+}
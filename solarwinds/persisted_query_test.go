@@ -0,0 +1,103 @@
+package solarwinds
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+const persistedQueryResponse = `{"data":{"user":{"id":"1"}}}`
+
+func TestPersistedQueries(t *testing.T) {
+	setup()
+	defer teardown()
+	client.persistedQueries = &persistedQueries{}
+
+	query := "query getUser { user { id } }"
+	known := map[string]bool{}
+	var sent []GraphQLRequest
+	mux.HandleFunc(graphQLEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		req := GraphQLRequest{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		sent = append(sent, req)
+		hash := req.Extensions.PersistedQuery.Sha256Hash
+		if req.Query != "" {
+			known[hash] = true
+		}
+		if !known[hash] {
+			_, _ = fmt.Fprint(w, `{"errors":[{"message":"PersistedQueryNotFound","extensions":{"code":"PERSISTED_QUERY_NOT_FOUND"}}]}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, persistedQueryResponse)
+	})
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.MakeGraphQLRequest(context.Background(), &GraphQLRequest{OperationName: "getUser", Query: query, ResponseType: "user"})
+		assert.NoError(t, err)
+		assert.Equal(t, "1", (*resp)["id"])
+	}
+
+	if assert.Len(t, sent, 3) {
+		assert.Equal(t, "", sent[0].Query)
+		assert.Equal(t, NewPersistedQuery(query), sent[0].Extensions.PersistedQuery)
+		assert.Equal(t, 1, sent[0].Extensions.PersistedQuery.Version)
+		assert.Equal(t, query, sent[1].Query)
+		assert.Equal(t, NewPersistedQuery(query), sent[1].Extensions.PersistedQuery)
+		assert.Equal(t, "", sent[2].Query)
+	}
+}
+
+func TestPersistedQueriesNotSupported(t *testing.T) {
+	setup()
+	defer teardown()
+	client.persistedQueries = &persistedQueries{}
+
+	var sent []GraphQLRequest
+	mux.HandleFunc(graphQLEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		req := GraphQLRequest{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		sent = append(sent, req)
+		if req.Query == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprint(w, `{"errors":[{"message":"PersistedQueryNotSupported"}]}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, persistedQueryResponse)
+	})
+
+	for i := 0; i < 2; i++ {
+		_, err := client.MakeGraphQLRequest(context.Background(), &GraphQLRequest{OperationName: "getUser", Query: "query getUser { user { id } }", ResponseType: "user"})
+		assert.NoError(t, err)
+	}
+
+	// The second request is sent in full right away.
+	if assert.Len(t, sent, 3) {
+		assert.Nil(t, sent[1].Extensions)
+		assert.Nil(t, sent[2].Extensions)
+		assert.NotEmpty(t, sent[2].Query)
+	}
+	assert.False(t, client.persistedQueries.enabled())
+}
+
+func TestPersistedQueriesDisabled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	mux.HandleFunc(graphQLEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		req := GraphQLRequest{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Nil(t, req.Extensions)
+		assert.NotEmpty(t, req.Query)
+		_, _ = fmt.Fprint(w, persistedQueryResponse)
+	})
+
+	_, err := client.MakeGraphQLRequest(context.Background(), &GraphQLRequest{OperationName: "getUser", Query: "query getUser { user { id } }", ResponseType: "user"})
+	assert.NoError(t, err)
+
+	c, err := NewClient(ClientConfig{PersistedQueries: true})
+	assert.NoError(t, err)
+	assert.True(t, c.persistedQueries.enabled())
+}
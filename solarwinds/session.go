@@ -0,0 +1,136 @@
+package solarwinds
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRefreshMargin is how long before the expiry of the session the refresher logs in again.
+	DefaultRefreshMargin = 5 * time.Minute
+	// DefaultRefreshInterval is the interval of the refresher when the cookies of the session do not expire.
+	DefaultRefreshInterval = time.Hour
+)
+
+// refreshRetryInterval is the shortest interval between the logins of the refresher, which retries failed ones.
+var refreshRetryInterval = time.Minute
+
+// refresher is the background goroutine of Start, which logs in again shortly before the session expires.
+type refresher struct {
+	margin    time.Duration
+	onRefresh func(error)
+
+	mu   sync.Mutex
+	stop context.CancelFunc
+	done chan struct{}
+}
+
+// SessionExpiry returns when the session started by Init expires, from the Max-Age or Expires attribute of its
+// cookies, and whether it is known.
+func (c *Client) SessionExpiry() (time.Time, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.sessionExpires, !c.sessionExpires.IsZero()
+}
+
+// Start starts a goroutine which logs in again shortly before the session expires, so that long-lived processes do
+// not fail on an expired session in the middle of an operation. It logs in at once when Init has not been called.
+// It logs in at most once a minute, retrying failed logins until the session is refreshed. Start does nothing when
+// the refresher is already running.
+func (c *Client) Start() {
+	r := &c.refresher
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stop != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	r.stop, r.done = cancel, make(chan struct{})
+	go c.refresh(ctx, r.done)
+}
+
+// Stop stops the goroutine of Start, cancelling a login in progress, and waits for it to return.
+func (c *Client) Stop() {
+	r := &c.refresher
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stop == nil {
+		return
+	}
+	r.stop()
+	<-r.done
+	r.stop, r.done = nil, nil
+}
+
+func (c *Client) refresh(ctx context.Context, done chan struct{}) {
+	defer close(done)
+	loggedIn := false
+	for ctx.Err() == nil {
+		wait := c.untilRefresh()
+		if loggedIn && wait < refreshRetryInterval {
+			wait = refreshRetryInterval
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+		case <-timer.C:
+			err := c.Init(ctx)
+			loggedIn = true
+			if c.refresher.onRefresh != nil && ctx.Err() == nil {
+				c.refresher.onRefresh(err)
+			}
+		}
+	}
+}
+
+// untilRefresh returns the time until the next login of the refresher.
+func (c *Client) untilRefresh() time.Duration {
+	c.mu.RLock()
+	expires, started := c.sessionExpires, c.csrfToken != ""
+	c.mu.RUnlock()
+	if !started {
+		return 0
+	}
+	if expires.IsZero() {
+		return DefaultRefreshInterval
+	}
+	margin := c.refresher.margin
+	if margin <= 0 {
+		margin = DefaultRefreshMargin
+	}
+	if wait := time.Until(expires.Add(-margin)); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// expireAt records the expiry of a cookie of the session, which expires with its first cookie.
+func (c *Client) expireAt(t time.Time) {
+	if t.IsZero() {
+		return
+	}
+	if c.sessionExpires.IsZero() || t.Before(c.sessionExpires) {
+		c.sessionExpires = t
+	}
+}
+
+// cookieExpiry returns the expiry of the cookie set by the response, Max-Age taking precedence over Expires, or the
+// zero time for a cookie without expiry.
+func cookieExpiry(resp *http.Response, name string) time.Time {
+	if resp == nil {
+		return time.Time{}
+	}
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name != name {
+			continue
+		}
+		if cookie.MaxAge > 0 {
+			return time.Now().Add(time.Duration(cookie.MaxAge) * time.Second)
+		}
+		return cookie.Expires
+	}
+	return time.Time{}
+}
@@ -0,0 +1,104 @@
+package solarwinds
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// setupSession serves the login flow with a swicus cookie of the max age, returning the number of logins.
+func setupSession(maxAge int) *int32 {
+	setup()
+	var logins int32
+	mux.HandleFunc("/v1/login", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&logins, 1)
+		w.Header().Add(headerNameSetCookie, fmt.Sprintf("%s=swicus-%d; Path=/; Max-Age=%d", cookieNameSwicus, n, maxAge))
+		fmt.Fprint(w, `{"RedirectUrl": "/callback"}`)
+	})
+	mux.HandleFunc("/common/login", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add(headerNameSetCookie, cookieNameSwiSettings+"=settings; Path=/; Max-Age=3600")
+		http.Redirect(w, r, "/foo", http.StatusFound)
+	})
+	mux.HandleFunc("/settings", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, obtainTokenRespStr)
+	})
+	return &logins
+}
+
+func TestSessionExpiry(t *testing.T) {
+	setupSession(1209600)
+	defer teardown()
+
+	_, known := client.SessionExpiry()
+	assert.False(t, known)
+
+	before := time.Now()
+	assert.NoError(t, client.Init(context.Background()))
+	expires, known := client.SessionExpiry()
+	assert.True(t, known)
+	// The swi-settings cookie expires first.
+	assert.WithinDuration(t, before.Add(time.Hour), expires, 5*time.Second)
+}
+
+func TestSessionRefresher(t *testing.T) {
+	logins := setupSession(1)
+	defer teardown()
+	defer func(interval time.Duration) { refreshRetryInterval = interval }(refreshRetryInterval)
+	refreshRetryInterval = 10 * time.Millisecond
+
+	refreshed := make(chan error, 10)
+	client.refresher.margin = 900 * time.Millisecond
+	client.refresher.onRefresh = func(err error) { refreshed <- err }
+
+	// Without a session, the refresher logs in at once.
+	client.Start()
+	client.Start()
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-refreshed:
+			assert.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("session not refreshed")
+		}
+	}
+	client.Stop()
+	client.Stop()
+
+	n := atomic.LoadInt32(logins)
+	assert.True(t, n >= 2)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, n, atomic.LoadInt32(logins), "no login after Stop")
+}
+
+func TestSessionRefresherRetries(t *testing.T) {
+	setup()
+	defer teardown()
+	defer func(interval time.Duration) { refreshRetryInterval = interval }(refreshRetryInterval)
+	refreshRetryInterval = 10 * time.Millisecond
+
+	var logins int32
+	mux.HandleFunc("/v1/login", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&logins, 1)
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	refreshed := make(chan error, 10)
+	client.refresher.onRefresh = func(err error) { refreshed <- err }
+
+	client.Start()
+	defer client.Stop()
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-refreshed:
+			assert.EqualError(t, err, "visit callback failed, status 401")
+		case <-time.After(5 * time.Second):
+			t.Fatal("login not retried")
+		}
+	}
+	_, known := client.SessionExpiry()
+	assert.False(t, known)
+}
@@ -2,6 +2,7 @@ package solarwinds
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,6 +11,8 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"sync"
+	"time"
 )
 
 const (
@@ -24,8 +27,19 @@ const (
 	EnvSolarwindsOrganizationId = "SOLARWINDS_ORG_ID"
 )
 
+// Defaults of AuthConfig, the values of the production environment.
+const (
+	DefaultOAuthClientID    = "adminpanel"
+	DefaultOAuthRedirectURI = "https://my.solarwinds.cloud/common/auth/callback"
+	DefaultOAuthScope       = "openid swicus"
+	DefaultLoginPath        = "/v1/login"
+	DefaultLoginPagePath    = "/common/login"
+	DefaultSettingsPath     = "/settings"
+)
+
 type Client struct {
 	csrfToken         string
+	csrfSource        CSRFSource
 	swiSettings       string
 	email             string
 	password          string
@@ -35,6 +49,13 @@ type Client struct {
 	InvitationService *InvitationService
 	ActiveUserService *ActiveUserService
 	UserService       *UserService
+	persistedQueries  *persistedQueries
+	auth              AuthConfig
+
+	// mu guards the session, which the refresher replaces.
+	mu             sync.RWMutex
+	sessionExpires time.Time
+	refresher      refresher
 }
 
 type ClientConfig struct {
@@ -42,6 +63,59 @@ type ClientConfig struct {
 	Password       string
 	OrganizationId string
 	BaseURL        string // For UT
+	HTTPClient     *http.Client
+	Transport      *TransportConfig // Ignored if HTTPClient is set
+	// PersistedQueries sends the hash of the queries instead of their text, as the web UI does. The full text is
+	// sent when the server does not know a hash yet, or does not support persisted queries.
+	PersistedQueries bool
+	// Auth overrides the OAuth parameters and paths of the login, to target staging or mock environments.
+	Auth AuthConfig
+	// RefreshMargin is how long before the expiry of the session the refresher started by Start logs in again,
+	// defaults to DefaultRefreshMargin.
+	RefreshMargin time.Duration
+	// OnRefresh is called after each login of the refresher, with its error if it failed. It is not called for a
+	// login cancelled by Stop.
+	OnRefresh func(error)
+}
+
+// AuthConfig holds the OAuth parameters and the paths of the login flow, relative to the base URL. Empty fields
+// keep the values of the production environment, so that only what differs in a staging or mock environment needs
+// to be set.
+type AuthConfig struct {
+	ClientID      string // Defaults to DefaultOAuthClientID
+	RedirectURI   string // Defaults to DefaultOAuthRedirectURI
+	Scope         string // Defaults to DefaultOAuthScope
+	LoginPath     string // Receives the credentials, defaults to DefaultLoginPath
+	LoginPagePath string // Sets the swi-settings cookie, defaults to DefaultLoginPagePath
+	SettingsPath  string // Holds the CSRF token, defaults to DefaultSettingsPath
+
+	// TokenPath is a dedicated endpoint returning the CSRF token, tried when the settings page does not provide it.
+	TokenPath string
+	// CSRFToken is a static CSRF token, the last resort when neither the settings page nor the token endpoint
+	// provide one.
+	CSRFToken string
+}
+
+func (ac AuthConfig) withDefaults() AuthConfig {
+	if ac.ClientID == "" {
+		ac.ClientID = DefaultOAuthClientID
+	}
+	if ac.RedirectURI == "" {
+		ac.RedirectURI = DefaultOAuthRedirectURI
+	}
+	if ac.Scope == "" {
+		ac.Scope = DefaultOAuthScope
+	}
+	if ac.LoginPath == "" {
+		ac.LoginPath = DefaultLoginPath
+	}
+	if ac.LoginPagePath == "" {
+		ac.LoginPagePath = DefaultLoginPagePath
+	}
+	if ac.SettingsPath == "" {
+		ac.SettingsPath = DefaultSettingsPath
+	}
+	return ac
 }
 
 type loginPayload struct {
@@ -83,13 +157,26 @@ func NewClient(config ClientConfig) (*Client, error) {
 		organizationId = os.Getenv(EnvSolarwindsOrganizationId)
 	}
 
+	httpClient, err := newHTTPClient(config)
+	if err != nil {
+		return nil, err
+	}
+
 	c := &Client{
 		email:          username,
 		password:       password,
 		organizationId: organizationId,
 		baseURL:        baseURLToUse.String(),
+		auth:           config.Auth.withDefaults(),
+		refresher: refresher{
+			margin:    config.RefreshMargin,
+			onRefresh: config.OnRefresh,
+		},
+	}
+	c.client = httpClient
+	if config.PersistedQueries {
+		c.persistedQueries = &persistedQueries{}
 	}
-	c.client = http.DefaultClient
 	c.InvitationService = &InvitationService{client: c}
 	c.ActiveUserService = &ActiveUserService{client: c}
 	c.UserService = &UserService{
@@ -99,15 +186,33 @@ func NewClient(config ClientConfig) (*Client, error) {
 	return c, nil
 }
 
-func (c *Client) Init() error {
-	auth, err := c.login()
+// Init logs in and starts a new session, replacing the current one once the login succeeded.
+func (c *Client) Init(ctx context.Context) error {
+	fresh := &Client{
+		email:          c.email,
+		password:       c.password,
+		organizationId: c.organizationId,
+		client:         c.client,
+		baseURL:        c.baseURL,
+		auth:           c.auth,
+	}
+	auth, err := fresh.login(ctx)
 	if err != nil {
 		return err
 	}
-	if err := c.obtainSwiSettings(); err != nil {
+	if err := fresh.obtainSwiSettings(ctx); err != nil {
 		return err
 	}
-	return c.obtainToken(auth)
+	if err := fresh.obtainToken(ctx, auth); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.csrfToken, c.csrfSource = fresh.csrfToken, fresh.csrfSource
+	c.swiSettings = fresh.swiSettings
+	c.sessionExpires = fresh.sessionExpires
+	return nil
 }
 
 func (c *Client) NewRequest(method string, rsc string, params io.Reader) (*http.Request, error) {
@@ -121,6 +226,8 @@ func (c *Client) NewRequest(method string, rsc string, params io.Reader) (*http.
 		return nil, err
 	}
 	req.Header.Add("Content-Type", "application/json")
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	req.AddCookie(&http.Cookie{
 		Name:  cookieNameSwiSettings,
 		Value: c.swiSettings,
@@ -129,38 +236,36 @@ func (c *Client) NewRequest(method string, rsc string, params io.Reader) (*http.
 	return req, err
 }
 
-func (c *Client) MakeGraphQLRequest(graphQLRequest *GraphQLRequest) (*GraphQLResponse, error) {
-	body, err := ToJsonNoEscape(graphQLRequest)
-	if err != nil {
-		return nil, err
-	}
-	req, err := c.NewRequest("POST", graphQLEndpoint, bytes.NewReader(body))
+func (c *Client) MakeGraphQLRequest(ctx context.Context, graphQLRequest *GraphQLRequest) (*GraphQLResponse, error) {
+	body, err := c.postGraphQL(ctx, graphQLRequest)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, err
+	graphQLResp, err := NewGraphQLResponse(bytes.NewReader(body), graphQLRequest.ResponseType)
+	if gqlErr, ok := err.(*GraphQLError); ok {
+		gqlErr.Operation = graphQLRequest.OperationName
 	}
-	defer resp.Body.Close()
-	graphQLResp, err := NewGraphQLResponse(resp.Body, graphQLRequest.ResponseType)
 	if err != nil {
 		return nil, err
 	}
 	if !graphQLResp.isSuccess() {
-		return nil, fmt.Errorf("request failed with message: %v", graphQLResp.message())
+		return nil, &GraphQLError{
+			Operation: graphQLRequest.OperationName,
+			Code:      graphQLResp.code(),
+			Message:   graphQLResp.message(),
+		}
 	}
 	return graphQLResp, err
 }
 
 // login provides user credentials and gets a 'swicus' value in return. This value serves
 // as a proof that one has been authenticated.
-func (c *Client) login() (*loginResult, error) {
+func (c *Client) login(ctx context.Context) (*loginResult, error) {
 	params := map[string]string{
 		"response_type": "code",
-		"scope":         "openid swicus",
-		"client_id":     "adminpanel",
-		"redirect_uri":  "https://my.solarwinds.cloud/common/auth/callback",
+		"scope":         c.auth.Scope,
+		"client_id":     c.auth.ClientID,
+		"redirect_uri":  c.auth.RedirectURI,
 		"state":         RandString(10),
 	}
 	paramsToUse := url.Values{}
@@ -176,12 +281,12 @@ func (c *Client) login() (*loginResult, error) {
 	if err != nil {
 		return nil, err
 	}
-	req, err := http.NewRequest("POST", c.baseURL+"/v1/login", bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+c.auth.LoginPath, bytes.NewReader(body))
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Set("content-type", "application/json")
-	resp, err := c.client.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
@@ -199,45 +304,51 @@ func (c *Client) login() (*loginResult, error) {
 	} else {
 		result.Swicus = swicus
 	}
+	c.expireAt(cookieExpiry(resp, cookieNameSwicus))
 	return result, nil
 }
 
 // obtainSwiSettings is used to retrieve 'swi-settings' cookie. The value is contained
 // in a redirect response. This step does not depend on any previous steps.
-func (c *Client) obtainSwiSettings() error {
-	resp, err := http.Get(c.baseURL + "/common/login")
+func (c *Client) obtainSwiSettings(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+c.auth.LoginPagePath, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(req)
 	if err != nil {
 		return err
 	}
+	defer resp.Body.Close()
 	swiSettings, err := retrieveCookie(resp.Request.Response, cookieNameSwiSettings)
 	if err != nil {
 		return err
 	}
 	c.swiSettings = swiSettings
+	c.expireAt(cookieExpiry(resp.Request.Response, cookieNameSwiSettings))
 	return nil
 }
 
 // obtainToken uses the 'swicus' and 'swi-settings' to obtain a CSRF token.
-func (c *Client) obtainToken(auth *loginResult) error {
+func (c *Client) obtainToken(ctx context.Context, auth *loginResult) error {
 	var url string
 	if c.organizationId != "" {
-		url = fmt.Sprintf("%s/%s/%s/users", c.baseURL, "settings", c.organizationId)
+		url = fmt.Sprintf("%s%s/%s/users", c.baseURL, c.auth.SettingsPath, c.organizationId)
 	} else {
-		url = c.baseURL + "/settings"
+		url = c.baseURL + c.auth.SettingsPath
 	}
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return err
 	}
-	req.AddCookie(&http.Cookie{
-		Name:  cookieNameSwicus,
-		Value: auth.Swicus,
-	})
-	req.AddCookie(&http.Cookie{
-		Name:  cookieNameSwiSettings,
-		Value: c.swiSettings,
-	})
-	resp, err := c.client.Do(req)
+	cookies := []*http.Cookie{
+		{Name: cookieNameSwicus, Value: auth.Swicus},
+		{Name: cookieNameSwiSettings, Value: c.swiSettings},
+	}
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	resp, err := c.do(req)
 	if err != nil {
 		return err
 	}
@@ -245,44 +356,34 @@ func (c *Client) obtainToken(auth *loginResult) error {
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("visit callback URL failed, status %d", resp.StatusCode)
 	}
-	doc, err := html.Parse(resp.Body)
-	if err != nil {
-		return err
-	}
-	if token, err := extractCSRFToken(doc); err != nil {
-		return err
-	} else {
-		c.csrfToken = token
-	}
-	return nil
+	return c.acquireCSRFToken(ctx, resp, cookies)
 }
 
-func extractCSRFToken(start *html.Node) (string, error) {
+// extractCSRFToken returns the content of the csrf-token meta tag of the document.
+func extractCSRFToken(doc *html.Node) (string, error) {
 	var token string
-	var head *html.Node
-	if first := start.FirstChild; first.Type == html.DoctypeNode {
-		head = first.NextSibling.FirstChild
-	} else {
-		head = first.FirstChild
-	}
-outer:
-	for c := head.FirstChild; c != nil; c = c.NextSibling {
-		if c.Type == html.ElementNode && c.Data == "meta" && len(c.Attr) == 2 {
-			for _, attr := range c.Attr {
-				if attr.Key == "name" && attr.Val != "csrf-token" {
-					continue outer
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "meta" {
+			var name, content string
+			for _, attr := range n.Attr {
+				switch attr.Key {
+				case "name":
+					name = attr.Val
+				case "content":
+					content = attr.Val
 				}
 			}
-			for _, attr := range c.Attr {
-				if attr.Key == "content" {
-					token = attr.Val
-				}
-			}
-			if token != "" {
-				break
+			if name == "csrf-token" && content != "" {
+				token = content
+				return
 			}
 		}
+		for c := n.FirstChild; c != nil && token == ""; c = c.NextSibling {
+			walk(c)
+		}
 	}
+	walk(doc)
 	if token == "" {
 		return "", errors.New("response of callback URL does not contain CSRF token")
 	}
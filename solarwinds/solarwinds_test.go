@@ -1,14 +1,19 @@
 package solarwinds
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/net/html"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"strings"
 	"testing"
+	"time"
 )
 
 var (
@@ -126,7 +131,7 @@ func TestLogin(t *testing.T) {
 			state)
 		_, _ = fmt.Fprint(w, body)
 	})
-	result, err := client.login()
+	result, err := client.login(context.Background())
 	assert.NoError(t, err)
 	assert.Equal(t, swicus, result.Swicus)
 }
@@ -139,7 +144,7 @@ func TestObtainSwiSettings(t *testing.T) {
 		w.Header().Add(headerNameSetCookie, fmt.Sprintf("%v=%v", cookieNameSwiSettings, swiSettings)+"; Path=/; Expires=Tue, 06 Apr 2021 11:14:34 GMT; HttpOnly; Secure; SameSite=None")
 		http.Redirect(w, r, "/foo", http.StatusFound)
 	})
-	err := client.obtainSwiSettings()
+	err := client.obtainSwiSettings(context.Background())
 	assert.NoError(t, err)
 	assert.Equal(t, swiSettings, client.swiSettings)
 }
@@ -191,7 +196,7 @@ func TestObtainTokenWithoutOrgId(t *testing.T) {
 		}
 		fmt.Fprint(w, obtainTokenRespStr)
 	})
-	err := client.obtainToken(&loginResult{
+	err := client.obtainToken(context.Background(), &loginResult{
 		RedirectURL: server.URL + "/settings",
 	})
 	assert.NoError(t, err)
@@ -210,9 +215,75 @@ func TestObtainTokenWithOrgId(t *testing.T) {
 		fmt.Fprint(w, obtainTokenRespStr)
 	})
 	client.organizationId = "123"
-	err := client.obtainToken(&loginResult{
+	err := client.obtainToken(context.Background(), &loginResult{
 		RedirectURL: server.URL + "/settings",
 	})
 	assert.NoError(t, err)
 	assert.Equal(t, tokenStr, client.csrfToken)
 }
+
+func TestInitWithAuthConfig(t *testing.T) {
+	setup()
+	defer teardown()
+	client.auth = AuthConfig{
+		ClientID:      "staging-panel",
+		RedirectURI:   server.URL + "/callback",
+		LoginPath:     "/mock/login",
+		LoginPagePath: "/mock/login-page",
+		SettingsPath:  "/mock/settings",
+	}.withDefaults()
+	client.organizationId = "123"
+
+	mux.HandleFunc("/mock/login", func(w http.ResponseWriter, r *http.Request) {
+		var payload loginPayload
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		params, err := url.ParseQuery(payload.LoginQueryParams)
+		assert.NoError(t, err)
+		assert.Equal(t, "staging-panel", params.Get("client_id"))
+		assert.Equal(t, server.URL+"/callback", params.Get("redirect_uri"))
+		assert.Equal(t, DefaultOAuthScope, params.Get("scope"))
+		w.Header().Add(headerNameSetCookie, cookieNameSwicus+"=swicus; Path=/")
+		fmt.Fprint(w, `{"RedirectUrl": "/callback"}`)
+	})
+	mux.HandleFunc("/mock/login-page", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add(headerNameSetCookie, cookieNameSwiSettings+"=settings; Path=/")
+		http.Redirect(w, r, "/foo", http.StatusFound)
+	})
+	mux.HandleFunc("/mock/settings/123/users", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, obtainTokenRespStr)
+	})
+	assert.NoError(t, client.Init(context.Background()))
+	assert.Equal(t, "settings", client.swiSettings)
+	assert.Equal(t, "fbO8qrEt-qGJ3jtQctuzcbVfBD47Quy-RE_Q", client.csrfToken)
+}
+
+func TestInitContextDeadline(t *testing.T) {
+	setup()
+	defer teardown()
+	release := make(chan struct{})
+	defer close(release)
+	mux.HandleFunc("/v1/login", func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := client.Init(ctx)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, "", client.csrfToken)
+}
+
+func TestMakeGraphQLRequestCancelled(t *testing.T) {
+	setup()
+	defer teardown()
+	requests := 0
+	mux.HandleFunc(graphQLEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.MakeGraphQLRequest(ctx, &GraphQLRequest{OperationName: listActiveUserOp, Query: listActiveUserQuery})
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, 0, requests)
+}
@@ -0,0 +1,137 @@
+package solarwinds
+
+import (
+	"crypto/tls"
+	"errors"
+	"github.com/nordcloud/go-pingdom/internal/redact"
+	"github.com/nordcloud/go-pingdom/internal/transport"
+	"golang.org/x/net/http2"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Timeouts limits the time spent in each phase of a request, a request
+// exceeding one of them fails with a *TimeoutError naming the phase.
+type Timeouts = transport.Timeouts
+
+// TimeoutError is returned, possibly wrapped, when a request times out.
+type TimeoutError = transport.TimeoutError
+
+// TLSConfig sets the minimum TLS version and optionally pins the public keys or certificates of the SolarWinds
+// endpoints, a handshake with a server matching no pin fails with ErrCertificateNotPinned.
+type TLSConfig = transport.TLSConfig
+
+// ErrCertificateNotPinned is wrapped by the errors of the requests to a server whose certificate chain matches none
+// of the pins of the TLSConfig.
+var ErrCertificateNotPinned = transport.ErrCertificateNotPinned
+
+// ProxyConfig routes the requests to SolarWinds through an authenticated proxy, instead of the one of the
+// environment.
+type ProxyConfig = transport.ProxyConfig
+
+// The authentication schemes of ProxyConfig.Auth.
+const (
+	ProxyAuthBasic = transport.ProxyAuthBasic
+	ProxyAuthNTLM  = transport.ProxyAuthNTLM
+)
+
+// TransportConfig tunes the connections made to SolarWinds. Some corporate proxies do not handle HTTP/2 with the
+// SolarWinds endpoints well, in which case DisableHTTP2 should be set.
+type TransportConfig struct {
+	DisableHTTP2 bool
+	// ReadIdleTimeout is the interval after which an idle HTTP/2 connection is health checked with a ping frame.
+	// Zero disables the health check.
+	ReadIdleTimeout time.Duration
+	// PingTimeout is how long to wait for the answer to a health check ping before the connection is closed.
+	// Defaults to 15 seconds.
+	PingTimeout time.Duration
+	Timeouts    Timeouts
+	// TLS applies to the connections made with HTTP/1.1 and HTTP/2 alike.
+	TLS *TLSConfig
+	// Proxy is used for the HTTP/2 connections as well, they are tunnelled with CONNECT.
+	Proxy *ProxyConfig
+}
+
+// newHTTPClient picks the HTTP client according to the configuration, http.DefaultClient is used if neither an
+// HTTP client nor a transport configuration is given.
+func newHTTPClient(config ClientConfig) (*http.Client, error) {
+	if config.HTTPClient != nil {
+		return config.HTTPClient, nil
+	}
+	if config.Transport == nil {
+		return http.DefaultClient, nil
+	}
+	rt, err := newTransport(*config.Transport)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Transport: rt}, nil
+}
+
+// newTransport builds the transport shared with the other clients of this module and configures HTTP/2 on it.
+// The round tripper is only wrapped to enforce the timeouts when any is set.
+func newTransport(config TransportConfig) (http.RoundTripper, error) {
+	t := transport.NewTransport(config.Timeouts)
+	if config.TLS != nil {
+		// Before configuring HTTP/2, which adds its protocol to the TLS configuration.
+		if err := config.TLS.Apply(t); err != nil {
+			return nil, err
+		}
+	}
+	if config.Proxy != nil {
+		if err := config.Proxy.Apply(t); err != nil {
+			return nil, err
+		}
+	}
+	if config.DisableHTTP2 {
+		// A non-nil empty map stops net/http from upgrading TLS connections to HTTP/2.
+		t.ForceAttemptHTTP2 = false
+		t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	} else {
+		h2, err := http2.ConfigureTransports(t)
+		if err != nil {
+			return nil, err
+		}
+		h2.ReadIdleTimeout = config.ReadIdleTimeout
+		h2.PingTimeout = config.PingTimeout
+	}
+	if config.Timeouts == (Timeouts{}) {
+		return t, nil
+	}
+	return transport.WithTimeouts(t, config.Timeouts), nil
+}
+
+// do sends the request, errors caused by the HTTP protocol rather than by the network are singled out. Credentials
+// and session cookies are redacted from the errors.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	resp, err := c.client.Do(req)
+	if err == nil {
+		return resp, nil
+	}
+	err = c.secrets().Error(err)
+	if isProtocolError(err) {
+		return nil, NewProtocolError(err)
+	}
+	return nil, err
+}
+
+// secrets are the values which must never appear in errors and logs.
+func (c *Client) secrets() redact.Secrets {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return redact.Secrets{c.password, c.csrfToken, c.swiSettings}
+}
+
+func isProtocolError(err error) bool {
+	var goAway http2.GoAwayError
+	var stream http2.StreamError
+	var conn http2.ConnectionError
+	var record tls.RecordHeaderError
+	if errors.As(err, &goAway) || errors.As(err, &stream) || errors.As(err, &conn) || errors.As(err, &record) {
+		return true
+	}
+	// The HTTP/2 implementation bundled in net/http does not export its error types.
+	msg := err.Error()
+	return strings.Contains(msg, "http2:") || strings.Contains(msg, "malformed HTTP")
+}
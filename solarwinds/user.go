@@ -1,6 +1,7 @@
 package solarwinds
 
 import (
+	"context"
 	"fmt"
 	"log"
 )
@@ -13,26 +14,33 @@ type UserService struct {
 }
 
 // Create will create a new invitation for the user. It is not possible to add user without going through invitation.
-func (us *UserService) Create(user User) error {
-	return us.InvitationService.Create(user)
+func (us *UserService) Create(ctx context.Context, user User) error {
+	return us.InvitationService.Create(ctx, user)
 }
 
 // Update will first try to update an active user with the given email. If no such user exist, will see if there
 // is an invitation with the email, if yes, will revoke the invitation and send a new one. Otherwise, error is returned.
-func (us *UserService) Update(update User) error {
-	activeUser, _ := us.ActiveUserService.GetByEmail(update.Email)
+//
+// Only the products whose role changes are sent for an active user, the products missing from the update keep their
+// current role, and nothing is sent when neither the role nor any product changes.
+func (us *UserService) Update(ctx context.Context, update User) error {
+	activeUser, _ := us.ActiveUserService.GetByEmail(ctx, update.Email)
 	if activeUser != nil {
+		products := ChangedProducts(activeUser.Products, update.Products)
+		if update.Role == activeUser.Role && len(products) == 0 {
+			return nil
+		}
 		activeUserUpdate := UpdateActiveUserRequest{
 			UserId:   activeUser.User.Id,
 			Role:     update.Role,
-			Products: update.Products,
+			Products: products,
 		}
-		return us.ActiveUserService.Update(activeUserUpdate)
+		return us.ActiveUserService.Update(ctx, activeUserUpdate)
 	}
 
 	log.Printf("Will revoke the invitation and send a new one for user: %v", update.Email)
 	invitationService := us.InvitationService
-	invitationList, err := invitationService.List()
+	invitationList, err := invitationService.List(ctx)
 	if err != nil {
 		return err
 	}
@@ -40,7 +48,7 @@ func (us *UserService) Update(update User) error {
 	for _, invitation := range invitationList.Organization.Invitations {
 		if invitation.Email == update.Email {
 			invitationFound = true
-			if err = invitationService.Revoke(update.Email); err != nil {
+			if err = invitationService.Revoke(ctx, update.Email); err != nil {
 				return err
 			}
 		}
@@ -48,7 +56,7 @@ func (us *UserService) Update(update User) error {
 	if !invitationFound {
 		return fmt.Errorf("there is no invitation with email: %v", update.Email)
 	}
-	if err = invitationService.Create(Invitation{
+	if err = invitationService.Create(ctx, Invitation{
 		Email:    update.Email,
 		Role:     update.Role,
 		Products: update.Products,
@@ -59,12 +67,12 @@ func (us *UserService) Update(update User) error {
 }
 
 // Delete will only be effective if it is an invitation. There is no way to delete an active user in Pingdom.
-func (us *UserService) Delete(email string) error {
-	activeUser, _ := us.ActiveUserService.GetByEmail(email)
+func (us *UserService) Delete(ctx context.Context, email string) error {
+	activeUser, _ := us.ActiveUserService.GetByEmail(ctx, email)
 	if activeUser != nil {
 		return NewErrorAttemptDeleteActiveUser(email)
 	}
-	err := us.InvitationService.Revoke(email)
+	err := us.InvitationService.Revoke(ctx, email)
 	if err != nil {
 		return NewNetworkError(err)
 	}
@@ -72,8 +80,8 @@ func (us *UserService) Delete(email string) error {
 }
 
 // Retrieve return the user information, either it is an invitation or an active user.
-func (us *UserService) Retrieve(email string) (*User, error) {
-	activeUser, err := us.ActiveUserService.GetByEmail(email)
+func (us *UserService) Retrieve(ctx context.Context, email string) (*User, error) {
+	activeUser, err := us.ActiveUserService.GetByEmail(ctx, email)
 	if err != nil {
 		return nil, err
 	}
@@ -87,7 +95,7 @@ func (us *UserService) Retrieve(email string) (*User, error) {
 	}
 
 	log.Printf("user %v is not found in active user list, will look up in invitations", email)
-	invitationList, err := us.InvitationService.List()
+	invitationList, err := us.InvitationService.List(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -101,3 +109,19 @@ func (us *UserService) Retrieve(email string) (*User, error) {
 	}
 	return targetInvitation, nil
 }
+
+// ChangedProducts returns the products of desired whose role differs from the one in current, or which are not in
+// current, in the order of desired.
+func ChangedProducts(current, desired []Product) []Product {
+	roles := make(map[string]string, len(current))
+	for _, product := range current {
+		roles[product.Name] = product.Role
+	}
+	var changed []Product
+	for _, product := range desired {
+		if role, ok := roles[product.Name]; !ok || role != product.Role {
+			changed = append(changed, product)
+		}
+	}
+	return changed
+}
@@ -1,6 +1,7 @@
 package solarwinds
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/stretchr/testify/assert"
@@ -36,15 +37,15 @@ func TestRetrieveUser(t *testing.T) {
 		_, _ = fmt.Fprint(w, responseStr)
 	})
 	userService := client.UserService
-	user, err := userService.Retrieve(activeUserEmail)
+	user, err := userService.Retrieve(context.Background(), activeUserEmail)
 	assert.NoError(t, err)
 	assert.NotNil(t, user)
 
-	user, err = userService.Retrieve(nonExistUserEmail)
+	user, err = userService.Retrieve(context.Background(), nonExistUserEmail)
 	assert.NoError(t, err)
 	assert.Nil(t, user)
 
-	user, err = userService.Retrieve(pendingUserEmail)
+	user, err = userService.Retrieve(context.Background(), pendingUserEmail)
 	assert.NoError(t, err)
 	assert.NotNil(t, user)
 }
@@ -68,7 +69,11 @@ func TestCreateUser(t *testing.T) {
 		},
 	}
 	input := inviteUserVars{
-		Input: invitation,
+		Input: createOrganizationInvitationInput{
+			Email:    invitation.Email,
+			Role:     invitation.Role,
+			Products: []productAccessInput{{Name: "AppOptics", Role: "Admin"}, {Name: "Loggly", Role: "User"}},
+		},
 	}
 	mux.HandleFunc(graphQLEndpoint, func(w http.ResponseWriter, r *http.Request) {
 		graphQLReq := GraphQLRequest{}
@@ -81,7 +86,7 @@ func TestCreateUser(t *testing.T) {
 
 		_, _ = fmt.Fprint(w, inviteUserResponseStr)
 	})
-	err := client.UserService.Create(invitation)
+	err := client.UserService.Create(context.Background(), invitation)
 	assert.NoError(t, err)
 }
 
@@ -96,8 +101,13 @@ func TestUpdateUser(t *testing.T) {
 				Name: "APPOPTICS",
 				Role: "MEMBER",
 			},
+			{
+				Name: "LOGGLY",
+				Role: "NO_ACCESS",
+			},
 		},
 	}
+	updates := 0
 	mux.HandleFunc(graphQLEndpoint, func(w http.ResponseWriter, r *http.Request) {
 		graphQLReq := GraphQLRequest{}
 		_ = json.NewDecoder(r.Body).Decode(&graphQLReq)
@@ -110,6 +120,8 @@ func TestUpdateUser(t *testing.T) {
 			actualVars := UpdateActiveUserRequest{}
 			_ = Convert(&graphQLReq.Variables, &actualVars)
 			assert.Equal(t, activeUserId, actualVars.UserId)
+			assert.Equal(t, []Product{{Name: "APPOPTICS", Role: "MEMBER"}}, actualVars.Products)
+			updates++
 			_, _ = fmt.Fprint(w, updateActiveUserResponseStr)
 		case revokeInvitationOp:
 			_, _ = fmt.Fprint(w, revokePendingInvitationResponseStr)
@@ -124,18 +136,32 @@ func TestUpdateUser(t *testing.T) {
 
 	userService := client.UserService
 	update.Email = activeUserEmail
-	err := userService.Update(update)
+	err := userService.Update(context.Background(), update)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, updates)
+
+	unchanged := User{Email: activeUserEmail, Role: "ADMIN", Products: []Product{{Name: "LOGGLY", Role: "NO_ACCESS"}}}
+	err = userService.Update(context.Background(), unchanged)
 	assert.NoError(t, err)
+	assert.Equal(t, 1, updates)
 
 	update.Email = nonExistUserEmail
-	err = userService.Update(update)
+	err = userService.Update(context.Background(), update)
 	assert.Error(t, err)
 
 	update.Email = pendingUserEmail
-	err = userService.Update(update)
+	err = userService.Update(context.Background(), update)
 	assert.NoError(t, err)
 }
 
+func TestChangedProducts(t *testing.T) {
+	current := []Product{{Name: "APPOPTICS", Role: "NO_ACCESS"}, {Name: "PINGDOM", Role: "ADMIN"}}
+	assert.Nil(t, ChangedProducts(current, nil))
+	assert.Nil(t, ChangedProducts(current, []Product{{Name: "PINGDOM", Role: "ADMIN"}}))
+	assert.Equal(t, []Product{{Name: "LOGGLY", Role: "MEMBER"}, {Name: "PINGDOM", Role: "MEMBER"}},
+		ChangedProducts(current, []Product{{Name: "LOGGLY", Role: "MEMBER"}, {Name: "PINGDOM", Role: "MEMBER"}}))
+}
+
 func TestDeleteUser(t *testing.T) {
 	setup()
 	defer teardown()
@@ -173,13 +199,39 @@ func TestDeleteUser(t *testing.T) {
 	})
 
 	userService := client.UserService
-	err := userService.Delete(pendingUserEmail)
+	err := userService.Delete(context.Background(), pendingUserEmail)
 	assert.NoError(t, err)
 
-	err = userService.Delete(activeUserEmail)
+	err = userService.Delete(context.Background(), activeUserEmail)
 	assert.Error(t, err)
 	assert.Equal(t, ErrCodeDeleteActiveUserException, err.(*ClientError).StatusCode)
 
-	err = userService.Delete(nonExistUserEmail)
+	err = userService.Delete(context.Background(), nonExistUserEmail)
 	assert.Error(t, err)
 }
+
+func TestUserJSONRoundTrip(t *testing.T) {
+	want := User{
+		Email:    "foo@example.com",
+		Role:     "MEMBER",
+		Products: []Product{{Name: "PINGDOM", Role: "ADMIN"}},
+	}
+	b, err := json.Marshal(want)
+	assert.NoError(t, err)
+
+	var got User
+	assert.NoError(t, json.Unmarshal(b, &got))
+	assert.Equal(t, want, got)
+
+	member := OrganizationMember{
+		User:     ActiveUser{Id: "1", FirstName: "Foo", LastName: "Bar", Email: "foo@example.com", LastLogin: "2021-01-01T00:00:00Z"},
+		Role:     "ADMIN",
+		Products: []Product{{Name: "PINGDOM", Role: "ADMIN"}},
+	}
+	b, err = json.Marshal(member)
+	assert.NoError(t, err)
+
+	var gotMember OrganizationMember
+	assert.NoError(t, json.Unmarshal(b, &gotMember))
+	assert.Equal(t, member, gotMember)
+}
@@ -0,0 +1,155 @@
+// Package templates renders check definitions containing placeholders, such as
+// {{env}} or {{region}}, into concrete checks for each environment.
+//
+// A template is a regular check value, e.g. a *pingdom.HttpCheck, whose string
+// fields (and request header names) may contain placeholders:
+//
+//	library := &templates.Library{
+//		Templates: []pingdom.Check{
+//			&pingdom.HttpCheck{Name: "api-{{env}}", Hostname: "api.{{env}}.example.com", Resolution: 5},
+//		},
+//		Environments: map[string]templates.Vars{
+//			"staging":    {"region": "eu"},
+//			"production": {"region": "us"},
+//		},
+//	}
+//	checks, err := library.Render("staging")
+package templates
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+)
+
+// EnvVar is the placeholder set to the name of the environment, unless the
+// environment defines it itself.
+const EnvVar = "env"
+
+var placeholder = regexp.MustCompile(`{{\s*([A-Za-z0-9_.-]+)\s*}}`)
+
+// Vars are the values of the placeholders.
+type Vars map[string]string
+
+// Library is a set of check templates together with the variables of each
+// environment they are rendered for.
+type Library struct {
+	Templates    []pingdom.Check
+	Environments map[string]Vars
+}
+
+// Render renders every template of the library for the environment.
+func (l *Library) Render(env string) ([]pingdom.Check, error) {
+	envVars, ok := l.Environments[env]
+	if !ok {
+		return nil, fmt.Errorf("unknown environment %q", env)
+	}
+	vars := Vars{EnvVar: env}
+	for k, v := range envVars {
+		vars[k] = v
+	}
+
+	checks := make([]pingdom.Check, 0, len(l.Templates))
+	for i, tmpl := range l.Templates {
+		check, err := Render(tmpl, vars)
+		if err != nil {
+			return nil, fmt.Errorf("template %d for environment %q: %v", i, env, err)
+		}
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+// RenderAll renders every template of the library for every environment.
+func (l *Library) RenderAll() (map[string][]pingdom.Check, error) {
+	rendered := make(map[string][]pingdom.Check, len(l.Environments))
+	for env := range l.Environments {
+		checks, err := l.Render(env)
+		if err != nil {
+			return nil, err
+		}
+		rendered[env] = checks
+	}
+	return rendered, nil
+}
+
+// Render returns a copy of the template, which must be a pointer to a check
+// struct, with all placeholders substituted. It fails if a placeholder has no
+// value or if the rendered check is not valid.
+func Render(tmpl pingdom.Check, vars Vars) (pingdom.Check, error) {
+	v := reflect.ValueOf(tmpl)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("template must be a pointer to a check struct, got %T", tmpl)
+	}
+
+	raw, err := json.Marshal(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	var doc interface{}
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, err
+	}
+
+	missing := map[string]bool{}
+	doc = substitute(doc, vars, missing)
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("no value for placeholders: %v", strings.Join(names, ", "))
+	}
+
+	raw, err = json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	rendered := reflect.New(v.Elem().Type()).Interface().(pingdom.Check)
+	if err := json.Unmarshal(raw, rendered); err != nil {
+		return nil, err
+	}
+	if err := rendered.Valid(); err != nil {
+		return nil, err
+	}
+	return rendered, nil
+}
+
+// substitute replaces the placeholders in all the strings and object keys of
+// a decoded JSON document, recording the placeholders without value.
+func substitute(doc interface{}, vars Vars, missing map[string]bool) interface{} {
+	switch value := doc.(type) {
+	case string:
+		return expand(value, vars, missing)
+	case []interface{}:
+		for i := range value {
+			value[i] = substitute(value[i], vars, missing)
+		}
+		return value
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(value))
+		for k, elem := range value {
+			result[expand(k, vars, missing)] = substitute(elem, vars, missing)
+		}
+		return result
+	}
+	return doc
+}
+
+func expand(s string, vars Vars, missing map[string]bool) string {
+	return placeholder.ReplaceAllStringFunc(s, func(match string) string {
+		name := placeholder.FindStringSubmatch(match)[1]
+		value, ok := vars[name]
+		if !ok {
+			missing[name] = true
+			return match
+		}
+		return value
+	})
+}
@@ -0,0 +1,76 @@
+package templates
+
+import (
+	"testing"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	verify := true
+	tmpl := &pingdom.HttpCheck{
+		Name:       "api-{{env}}",
+		Hostname:   "api.{{ env }}.{{region}}.example.com",
+		Resolution: 5,
+		Url:        "/health",
+		RequestHeaders: map[string]string{
+			"X-{{env}}": "{{region}}",
+		},
+		Tags:              "{{env}},api",
+		VerifyCertificate: &verify,
+	}
+
+	check, err := Render(tmpl, Vars{"env": "staging", "region": "eu"})
+	assert.NoError(t, err)
+	assert.Equal(t, &pingdom.HttpCheck{
+		Name:       "api-staging",
+		Hostname:   "api.staging.eu.example.com",
+		Resolution: 5,
+		Url:        "/health",
+		RequestHeaders: map[string]string{
+			"X-staging": "eu",
+		},
+		Tags:              "staging,api",
+		VerifyCertificate: &verify,
+	}, check)
+
+	// The template itself is left untouched.
+	assert.Equal(t, "api-{{env}}", tmpl.Name)
+}
+
+func TestRenderErrors(t *testing.T) {
+	_, err := Render(&pingdom.PingCheck{Name: "{{env}}-{{region}}", Hostname: "{{zone}}", Resolution: 5}, Vars{"env": "dev"})
+	assert.EqualError(t, err, "no value for placeholders: region, zone")
+
+	_, err = Render(&pingdom.PingCheck{Name: "{{env}}", Resolution: 5}, Vars{"env": "dev"})
+	assert.Error(t, err, "rendered check must be valid")
+}
+
+func TestLibrary(t *testing.T) {
+	library := &Library{
+		Templates: []pingdom.Check{
+			&pingdom.HttpCheck{Name: "web-{{env}}", Hostname: "{{env}}.example.com", Resolution: 5},
+			&pingdom.TCPCheck{Name: "db-{{env}}", Hostname: "db.{{region}}.example.com", Port: 5432, Resolution: 1},
+		},
+		Environments: map[string]Vars{
+			"staging":    {"region": "eu"},
+			"production": {"region": "us", "env": "prod"},
+		},
+	}
+
+	checks, err := library.Render("staging")
+	assert.NoError(t, err)
+	assert.Equal(t, []pingdom.Check{
+		&pingdom.HttpCheck{Name: "web-staging", Hostname: "staging.example.com", Resolution: 5},
+		&pingdom.TCPCheck{Name: "db-staging", Hostname: "db.eu.example.com", Port: 5432, Resolution: 1},
+	}, checks)
+
+	all, err := library.RenderAll()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(all))
+	assert.Equal(t, "web-prod", all["production"][0].(*pingdom.HttpCheck).Name)
+
+	_, err = library.Render("qa")
+	assert.Error(t, err)
+}
@@ -0,0 +1,109 @@
+package watcher
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+)
+
+const defaultHalfLife = time.Hour
+
+// Observer is given the checks of every poll of a Watcher.
+type Observer interface {
+	Observe(checks []pingdom.CheckResponse, at time.Time)
+}
+
+// Estimator is an Observer which keeps an exponentially smoothed availability
+// of every check, between 0 and 1, from the statuses of the polls: a cheap
+// "current reliability" for dashboards which needs no summary requests.
+//
+// Each status weighs as much as the time since the previous poll, and a
+// status weighs half as much once HalfLife has passed, so irregular polls do
+// not skew the estimate.  "up" and "unconfirmed_down" count as available,
+// "down" as unavailable, and the other statuses, e.g. "paused", leave the
+// estimate as is.
+type Estimator struct {
+	// HalfLife defaults to an hour.
+	HalfLife time.Duration
+
+	mu        sync.Mutex
+	estimates map[int]*estimate
+}
+
+type estimate struct {
+	value float64
+	at    time.Time
+	known bool // Whether value holds a status yet
+}
+
+// Observe updates the estimates with the statuses of the checks at a poll.
+func (e *Estimator) Observe(checks []pingdom.CheckResponse, at time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.estimates == nil {
+		e.estimates = make(map[int]*estimate)
+	}
+	for _, check := range checks {
+		est, ok := e.estimates[check.ID]
+		if !ok {
+			est = &estimate{at: at}
+			e.estimates[check.ID] = est
+		}
+		sample, counted := availability(check.Status)
+		switch {
+		case !counted:
+		case !est.known:
+			est.value, est.known = sample, true
+		case at.After(est.at):
+			weight := 1 - math.Exp2(-float64(at.Sub(est.at))/float64(e.halfLife()))
+			est.value += weight * (sample - est.value)
+		}
+		if at.After(est.at) {
+			est.at = at
+		}
+	}
+}
+
+// Availability returns the estimated availability of a check, and false
+// when no poll has reported a counted status of the check yet.
+func (e *Estimator) Availability(checkID int) (float64, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if est, ok := e.estimates[checkID]; ok && est.known {
+		return est.value, true
+	}
+	return 0, false
+}
+
+// Availabilities returns the estimated availabilities of all the checks with
+// an estimate, by check ID.
+func (e *Estimator) Availabilities() map[int]float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	availabilities := make(map[int]float64, len(e.estimates))
+	for id, est := range e.estimates {
+		if est.known {
+			availabilities[id] = est.value
+		}
+	}
+	return availabilities
+}
+
+func (e *Estimator) halfLife() time.Duration {
+	if e.HalfLife > 0 {
+		return e.HalfLife
+	}
+	return defaultHalfLife
+}
+
+func availability(status string) (float64, bool) {
+	switch pingdom.CheckStatus(status) {
+	case pingdom.CheckStatusUp, pingdom.CheckStatusUnconfirmedDown:
+		return 1, true
+	case pingdom.CheckStatusDown:
+		return 0, true
+	}
+	return 0, false
+}
@@ -0,0 +1,60 @@
+package watcher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimator(t *testing.T) {
+	e := &Estimator{HalfLife: 10 * time.Minute}
+	status := func(statuses ...string) []pingdom.CheckResponse {
+		var checks []pingdom.CheckResponse
+		for i, status := range statuses {
+			checks = append(checks, pingdom.CheckResponse{ID: i + 1, Status: status})
+		}
+		return checks
+	}
+
+	e.Observe(status("up", "paused"), now)
+	a, ok := e.Availability(1)
+	assert.True(t, ok)
+	assert.Equal(t, 1.0, a)
+	_, ok = e.Availability(2)
+	assert.False(t, ok)
+
+	// Down for a half life halves the estimate.
+	e.Observe(status("down", "down"), now.Add(10*time.Minute))
+	a, _ = e.Availability(1)
+	assert.InDelta(t, 0.5, a, 1e-9)
+	a, _ = e.Availability(2)
+	assert.Equal(t, 0.0, a)
+
+	// Two polls weigh as much as one over the same time.
+	e.Observe(status("up", "up"), now.Add(15*time.Minute))
+	e.Observe(status("up", "up"), now.Add(20*time.Minute))
+	a, _ = e.Availability(1)
+	assert.InDelta(t, 0.75, a, 1e-9)
+
+	// Statuses which are not counted leave the estimate, but not the time.
+	e.Observe(status("unknown", "up"), now.Add(30*time.Minute))
+	e.Observe(status("unconfirmed_down", "up"), now.Add(40*time.Minute))
+	a, _ = e.Availability(1)
+	assert.InDelta(t, 0.875, a, 1e-9)
+
+	assert.Len(t, e.Availabilities(), 2)
+}
+
+func TestWatcherObserver(t *testing.T) {
+	checks := &fakeChecks{}
+	e := &Estimator{}
+	w := &Watcher{Checks: checks, Observer: e, Now: func() time.Time { return now }}
+
+	checks.set("up", "down")
+	_, err := w.Poll(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]float64{1: 1, 2: 0}, e.Availabilities())
+}
@@ -0,0 +1,84 @@
+package watcher
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/nordcloud/go-pingdom/internal/atomicfile"
+)
+
+// StateStore persists the last known statuses of checks, by check ID.
+// Implementations backed by a database or a key-value store can be used to
+// share the state of watchers running on ephemeral hosts.
+type StateStore interface {
+	// Load returns the saved statuses, or nil when nothing was saved yet.
+	Load() (map[int]string, error)
+	Save(statuses map[int]string) error
+}
+
+// MemoryStore keeps the statuses in memory, e.g. to share them between the
+// watchers created by a long running process.  It is safe for concurrent
+// use.
+type MemoryStore struct {
+	mu       sync.Mutex
+	statuses map[int]string
+}
+
+// Load implements StateStore.
+func (s *MemoryStore) Load() (map[int]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return copyStatuses(s.statuses), nil
+}
+
+// Save implements StateStore.
+func (s *MemoryStore) Save(statuses map[int]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statuses = copyStatuses(statuses)
+	return nil
+}
+
+// FileStore saves the statuses to a JSON file.  The file is replaced
+// atomically, so that a crash while saving does not corrupt it.
+type FileStore struct {
+	Path string
+}
+
+// Load implements StateStore, a missing file holds no statuses.
+func (s *FileStore) Load() (map[int]string, error) {
+	b, err := ioutil.ReadFile(s.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	statuses := map[int]string{}
+	if err := json.Unmarshal(b, &statuses); err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// Save implements StateStore.
+func (s *FileStore) Save(statuses map[int]string) error {
+	b, err := json.MarshalIndent(statuses, "", "  ")
+	if err != nil {
+		return err
+	}
+	return atomicfile.WriteFile(s.Path, b)
+}
+
+func copyStatuses(statuses map[int]string) map[int]string {
+	if statuses == nil {
+		return nil
+	}
+	c := make(map[int]string, len(statuses))
+	for id, status := range statuses {
+		c[id] = status
+	}
+	return c
+}
@@ -0,0 +1,46 @@
+package watcher
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "watcher")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	store := &FileStore{Path: filepath.Join(dir, "state.json")}
+
+	statuses, err := store.Load()
+	assert.NoError(t, err)
+	assert.Nil(t, statuses)
+
+	assert.NoError(t, store.Save(map[int]string{1: "up", 2: "down"}))
+	assert.NoError(t, store.Save(map[int]string{1: "up", 3: "paused"}))
+	statuses, err = store.Load()
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]string{1: "up", 3: "paused"}, statuses)
+
+	files, _ := ioutil.ReadDir(dir)
+	assert.Len(t, files, 1)
+
+	assert.NoError(t, ioutil.WriteFile(store.Path, []byte("{"), 0600))
+	_, err = store.Load()
+	assert.Error(t, err)
+}
+
+func TestMemoryStore(t *testing.T) {
+	store := &MemoryStore{}
+	statuses, _ := store.Load()
+	assert.Nil(t, statuses)
+
+	saved := map[int]string{1: "up"}
+	store.Save(saved)
+	saved[1] = "down"
+	statuses, _ = store.Load()
+	assert.Equal(t, map[int]string{1: "up"}, statuses)
+}
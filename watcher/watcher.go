@@ -0,0 +1,165 @@
+// Package watcher polls the statuses of checks and reports their changes, e.g.
+// to forward them to a chat channel or an incident tool without configuring a
+// webhook integration.
+//
+// The last known statuses can be persisted in a StateStore, so that a
+// restarted watcher neither reports every check as changed nor misses the
+// transitions which happened while it was not running: the first poll after
+// a restart compares the statuses with the saved ones.
+//
+// An Estimator observing the polls keeps a smoothed availability of every
+// check.
+package watcher
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+)
+
+const defaultInterval = time.Minute
+
+// CheckLister lists Pingdom checks.  It is implemented by
+// *pingdom.CheckService.
+type CheckLister interface {
+	List(ctx context.Context, params ...map[string]string) ([]pingdom.CheckResponse, error)
+}
+
+// Event is a change of the status of a check, e.g. from "up" to "down".
+type Event struct {
+	CheckID   int
+	CheckName string
+	From      string
+	To        string
+	At        time.Time // Time of the poll which detected the change
+}
+
+// Watcher polls the statuses of checks.
+type Watcher struct {
+	Checks CheckLister
+
+	// Params are passed to CheckLister.List, e.g. to only watch the checks
+	// with some tags.
+	Params map[string]string
+
+	// Store persists the last known statuses.  Without store, the first
+	// poll only records the statuses.
+	Store StateStore
+
+	// Observer, if any, is given the checks of every poll, e.g. an
+	// Estimator.
+	Observer Observer
+
+	// Interval between polls in Run, defaults to a minute.
+	Interval time.Duration
+
+	// Now defaults to time.Now.
+	Now func() time.Time
+
+	statuses map[int]string
+}
+
+// Poll lists the checks and returns the changes of their statuses since the
+// previous poll, sorted by check ID.  Checks which were not known at the
+// previous poll, including all the checks at the first poll without saved
+// state, are recorded without event.  The statuses are saved to the Store
+// when they changed.
+func (w *Watcher) Poll(ctx context.Context) ([]Event, error) {
+	if w.statuses == nil && w.Store != nil {
+		statuses, err := w.Store.Load()
+		if err != nil {
+			return nil, err
+		}
+		w.statuses = statuses
+	}
+
+	checks, err := w.Checks.List(ctx, w.params())
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(checks, func(i, j int) bool { return checks[i].ID < checks[j].ID })
+
+	now := w.now()
+	if w.Observer != nil {
+		w.Observer.Observe(checks, now)
+	}
+	statuses := make(map[int]string, len(checks))
+	var events []Event
+	for _, check := range checks {
+		statuses[check.ID] = check.Status
+		if previous, ok := w.statuses[check.ID]; ok && previous != check.Status {
+			events = append(events, Event{
+				CheckID:   check.ID,
+				CheckName: check.Name,
+				From:      previous,
+				To:        check.Status,
+				At:        now,
+			})
+		}
+	}
+
+	changed := w.statuses == nil || !equal(w.statuses, statuses)
+	w.statuses = statuses
+	if changed && w.Store != nil {
+		if err := w.Store.Save(statuses); err != nil {
+			return events, err
+		}
+	}
+	return events, nil
+}
+
+// Run polls the checks every Interval and calls handle with each change,
+// until the context is done.  Errors of polls are passed to onError, if any,
+// and do not stop the watcher.
+func (w *Watcher) Run(ctx context.Context, handle func(Event), onError func(error)) error {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for ctx.Err() == nil {
+		events, err := w.Poll(ctx)
+		for _, event := range events {
+			handle(event)
+		}
+		if err != nil && onError != nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-ticker.C:
+		}
+	}
+	return ctx.Err()
+}
+
+func (w *Watcher) params() map[string]string {
+	if w.Params == nil {
+		return map[string]string{}
+	}
+	return w.Params
+}
+
+func (w *Watcher) now() time.Time {
+	if w.Now != nil {
+		return w.Now()
+	}
+	return time.Now()
+}
+
+func equal(a, b map[int]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for id, status := range a {
+		if other, ok := b[id]; !ok || other != status {
+			return false
+		}
+	}
+	return true
+}
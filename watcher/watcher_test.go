@@ -0,0 +1,144 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nordcloud/go-pingdom/pingdom"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeChecks struct {
+	checks []pingdom.CheckResponse
+	err    error
+	params map[string]string
+}
+
+func (f *fakeChecks) List(ctx context.Context, params ...map[string]string) ([]pingdom.CheckResponse, error) {
+	f.params = params[0]
+	return f.checks, f.err
+}
+
+func (f *fakeChecks) set(statuses ...string) {
+	f.checks = nil
+	for i, status := range statuses {
+		f.checks = append(f.checks, pingdom.CheckResponse{ID: i + 1, Name: "check", Status: status})
+	}
+}
+
+var now = time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func TestWatcherPoll(t *testing.T) {
+	checks := &fakeChecks{}
+	w := &Watcher{Checks: checks, Params: map[string]string{"tags": "web"}, Now: func() time.Time { return now }}
+
+	checks.set("up", "up")
+	events, err := w.Poll(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, events)
+	assert.Equal(t, map[string]string{"tags": "web"}, checks.params)
+
+	checks.set("down", "up", "unknown")
+	events, err = w.Poll(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []Event{{CheckID: 1, CheckName: "check", From: "up", To: "down", At: now}}, events)
+
+	checks.err = errors.New("boom")
+	_, err = w.Poll(context.Background())
+	assert.EqualError(t, err, "boom")
+
+	checks.err = nil
+	checks.set("up", "up", "up")
+	events, err = w.Poll(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []Event{
+		{CheckID: 1, CheckName: "check", From: "down", To: "up", At: now},
+		{CheckID: 3, CheckName: "check", From: "unknown", To: "up", At: now},
+	}, events)
+}
+
+func TestWatcherRestart(t *testing.T) {
+	checks := &fakeChecks{}
+	store := &MemoryStore{}
+
+	checks.set("up", "up")
+	_, err := (&Watcher{Checks: checks, Store: store}).Poll(context.Background())
+	assert.NoError(t, err)
+	saved, _ := store.Load()
+	assert.Equal(t, map[int]string{1: "up", 2: "up"}, saved)
+
+	// Check 2 went down while no watcher was running.
+	checks.set("up", "down")
+	events, err := (&Watcher{Checks: checks, Store: store}).Poll(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, 2, events[0].CheckID)
+	assert.Equal(t, "down", events[0].To)
+}
+
+type failingStore struct {
+	MemoryStore
+	saves int
+	err   error
+}
+
+func (s *failingStore) Save(statuses map[int]string) error {
+	s.saves++
+	if s.err != nil {
+		return s.err
+	}
+	return s.MemoryStore.Save(statuses)
+}
+
+func TestWatcherSavesChanges(t *testing.T) {
+	checks := &fakeChecks{}
+	store := &failingStore{}
+	w := &Watcher{Checks: checks, Store: store}
+
+	checks.set("up")
+	w.Poll(context.Background())
+	w.Poll(context.Background())
+	assert.Equal(t, 1, store.saves)
+
+	store.err = errors.New("disk full")
+	checks.set("down")
+	events, err := w.Poll(context.Background())
+	assert.EqualError(t, err, "disk full")
+	assert.Len(t, events, 1)
+}
+
+type scriptedChecks struct {
+	polls int
+}
+
+func (s *scriptedChecks) List(ctx context.Context, params ...map[string]string) ([]pingdom.CheckResponse, error) {
+	s.polls++
+	switch s.polls {
+	case 1:
+		return []pingdom.CheckResponse{{ID: 1, Status: "up"}}, nil
+	case 2:
+		return nil, errors.New("boom")
+	}
+	return []pingdom.CheckResponse{{ID: 1, Status: "down"}}, nil
+}
+
+func TestWatcherRun(t *testing.T) {
+	checks := &scriptedChecks{}
+	w := &Watcher{Checks: checks, Interval: time.Millisecond}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var events []Event
+	var errs []error
+	err := w.Run(ctx, func(e Event) {
+		events = append(events, e)
+		cancel()
+	}, func(err error) {
+		errs = append(errs, err)
+	})
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 3, checks.polls)
+	assert.Len(t, events, 1)
+	assert.Equal(t, []error{errors.New("boom")}, errs)
+}